@@ -0,0 +1,296 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/proto"
+
+	pb "github.com/sairamkiran9/asset-telemetry-monitor/gen/go/proto/asset"
+	"github.com/sairamkiran9/asset-telemetry-monitor/internal/assetio"
+	"github.com/sairamkiran9/asset-telemetry-monitor/internal/auth"
+	"github.com/sairamkiran9/asset-telemetry-monitor/internal/certs"
+)
+
+const (
+	// Records sent per ImportAssets call, the most the registry accepts
+	batchRecords = 10000
+	// Bytes sent per ImportAssets call, under gRPC's default 4 MiB limit
+	batchBytes = 3 << 20
+)
+
+const usage = `asset-cli imports assets into the asset registry and exports them from it.
+
+Usage:
+  asset-cli import [flags] FILE    upsert the assets in a CSV or JSON Lines file
+  asset-cli export [flags] [FILE]  write every asset to a file, or stdout
+
+FILE may be - for stdin. Run asset-cli COMMAND -h for the flags of a command.
+`
+
+// asset-cli bulk imports and exports assets through ImportAssets and
+// ExportAssets, reading and writing CSV or JSON Lines.
+func main() {
+	if len(os.Args) < 2 {
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+
+	var err error
+	switch os.Args[1] {
+	case "import":
+		err = runImport(os.Args[2:])
+	case "export":
+		err = runExport(os.Args[2:])
+	case "-h", "-help", "--help", "help":
+		fmt.Print(usage)
+		return
+	default:
+		fmt.Fprintf(os.Stderr, "asset-cli: unknown command %q\n\n%s", os.Args[1], usage)
+		os.Exit(2)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "asset-cli: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// client holds the flags every command has for reaching the registry.
+type client struct {
+	addr    string
+	token   string
+	tenant  string
+	key     string
+	timeout time.Duration
+	tls     certs.Config
+}
+
+func newFlagSet(name string) (*flag.FlagSet, *client) {
+	fs := flag.NewFlagSet(name, flag.ExitOnError)
+	c := &client{tls: certs.DefaultConfig()}
+	fs.StringVar(&c.addr, "addr", "localhost:50051", "address of the asset registry")
+	fs.StringVar(&c.token, "token", os.Getenv("AUTH_TOKEN"), "bearer token to call the registry with")
+	fs.StringVar(&c.tenant, "tenant", "", "tenant to act for, sent as "+auth.TenantHeader)
+	fs.StringVar(&c.key, "external-id-key", "external_id", "metadata key holding external IDs")
+	fs.DurationVar(&c.timeout, "timeout", time.Minute, "timeout for the whole command")
+	fs.StringVar(&c.tls.CertFile, "tls-cert-file", os.Getenv("TLS_CERT_FILE"), "PEM client certificate; empty dials in plaintext")
+	fs.StringVar(&c.tls.KeyFile, "tls-key-file", os.Getenv("TLS_KEY_FILE"), "PEM private key of -tls-cert-file")
+	fs.StringVar(&c.tls.CAFile, "tls-ca-file", os.Getenv("TLS_CA_FILE"), "PEM CA bundle the server is verified against")
+	return fs, c
+}
+
+// dial connects to the registry and returns a context for calls to it.
+func (c *client) dial() (pb.AssetRegistryClient, context.Context, func(), error) {
+	tlsCerts, err := certs.New(c.tls)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to load TLS certificates: %w", err)
+	}
+	opts := append(auth.DialOptions(c.token), grpc.WithTransportCredentials(tlsCerts.Credentials()))
+	conn, err := grpc.NewClient(c.addr, opts...)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to connect to %s: %w", c.addr, err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	if c.tenant != "" {
+		ctx = metadata.AppendToOutgoingContext(ctx, auth.TenantHeader, c.tenant)
+	}
+	return pb.NewAssetRegistryClient(conn), ctx, func() { cancel(); conn.Close() }, nil
+}
+
+// format returns the format named by the -format flag, else that of the
+// file's extension, else def.
+func format(flagValue, path string, def assetio.Format) (assetio.Format, error) {
+	if flagValue != "" {
+		return assetio.ParseFormat(flagValue)
+	}
+	if f, err := assetio.ParseFormat(path); err == nil {
+		return f, nil
+	}
+	return def, nil
+}
+
+func runImport(args []string) error {
+	fs, c := newFlagSet("import")
+	formatFlag := fs.String("format", "", "csv or jsonl; defaults to the file's extension, else csv")
+	mapFlag := fs.String("map", "", `comma-separated column=field mappings, e.g. "Asset Tag=external_id,Area=metadata.floor_area,Notes=-"`)
+	dryRun := fs.Bool("dry-run", false, "validate every row and report what would change, changing nothing")
+	fs.Parse(args)
+	if fs.NArg() != 1 {
+		return errors.New("import needs exactly one FILE")
+	}
+	path := fs.Arg(0)
+
+	f, err := format(*formatFlag, path, assetio.CSV)
+	if err != nil {
+		return err
+	}
+	mapping, err := assetio.ParseMapping(*mapFlag)
+	if err != nil {
+		return err
+	}
+	in := os.Stdin
+	if path != "-" {
+		if in, err = os.Open(path); err != nil {
+			return err
+		}
+		defer in.Close()
+	}
+	rows, err := assetio.Read(in, f, mapping)
+	if err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+
+	// Rows that can't be read are reported with the registry's verdict on the
+	// rest; only a dry run goes ahead without them
+	var records []*pb.AssetRecord
+	var lines []int
+	unreadable := 0
+	for _, row := range rows {
+		if row.Err != nil {
+			fmt.Printf("line %d: %v\n", row.Line, row.Err)
+			unreadable++
+			continue
+		}
+		records = append(records, row.Record)
+		lines = append(lines, row.Line)
+	}
+	if unreadable > 0 && !*dryRun {
+		return fmt.Errorf("%d rows can't be read; fix them, or check the rest with -dry-run", unreadable)
+	}
+
+	registry, ctx, done, err := c.dial()
+	if err != nil {
+		return err
+	}
+	defer done()
+
+	// Files are sent in batches, in order, so parents are committed before
+	// later batches name them. Dry runs commit nothing, so later batches are
+	// told what earlier ones would create.
+	total := &pb.ImportAssetsResponse{}
+	var planned []string
+	for start := 0; start < len(records); {
+		req := &pb.ImportAssetsRequest{DryRun: *dryRun, ExternalIdKey: c.key, PlannedExternalIds: planned}
+		end := batchEnd(records, start, proto.Size(req))
+		req.Records = records[start:end]
+		resp, err := registry.ImportAssets(ctx, req)
+		if err != nil {
+			if start > 0 && !*dryRun {
+				return fmt.Errorf("lines %d-%d: %w; lines before %d were imported", lines[start], lines[end-1], err, lines[start])
+			}
+			return fmt.Errorf("lines %d-%d: %w", lines[start], lines[end-1], err)
+		}
+
+		for i, result := range resp.Results {
+			line := lines[start+i]
+			switch result.Action {
+			case pb.ImportAction_FAILED:
+				fmt.Printf("line %d: %s: %s\n", line, result.ExternalId, result.Error)
+			case pb.ImportAction_CREATE, pb.ImportAction_UPDATE:
+				if result.AssetId == "" {
+					fmt.Printf("line %d: %s: %s\n", line, result.ExternalId, result.Action)
+				} else {
+					fmt.Printf("line %d: %s: %s %s\n", line, result.ExternalId, result.Action, result.AssetId)
+				}
+			}
+			if *dryRun && result.Action == pb.ImportAction_CREATE {
+				planned = append(planned, result.ExternalId)
+			}
+		}
+		total.Created += resp.Created
+		total.Updated += resp.Updated
+		total.Unchanged += resp.Unchanged
+		total.Failed += resp.Failed
+		start = end
+	}
+
+	verb := "Imported"
+	if *dryRun {
+		verb = "Dry run"
+	}
+	fmt.Printf("%s: %d created, %d updated, %d unchanged, %d failed, %d unreadable\n", verb, total.Created, total.Updated, total.Unchanged, total.Failed, unreadable)
+	if total.Failed > 0 || unreadable > 0 {
+		return fmt.Errorf("%d rows failed", int(total.Failed)+unreadable)
+	}
+	return nil
+}
+
+// batchEnd returns where the batch of records from start ends, for a request
+// already size bytes long. Batches hold at least one record.
+func batchEnd(records []*pb.AssetRecord, start, size int) int {
+	end := start
+	for end < len(records) && end-start < batchRecords {
+		size += protowire.SizeTag(1) + protowire.SizeBytes(proto.Size(records[end]))
+		if size > batchBytes && end > start {
+			break
+		}
+		end++
+	}
+	return end
+}
+
+func runExport(args []string) error {
+	fs, c := newFlagSet("export")
+	formatFlag := fs.String("format", "", "csv or jsonl; defaults to the file's extension, else jsonl")
+	assetType := fs.String("type", "", "only export assets of this type")
+	fs.Parse(args)
+	if fs.NArg() > 1 {
+		return errors.New("export takes at most one FILE")
+	}
+	path := fs.Arg(0)
+
+	f, err := format(*formatFlag, path, assetio.JSONLines)
+	if err != nil {
+		return err
+	}
+	out := os.Stdout
+	if path != "" && path != "-" {
+		if out, err = os.Create(path); err != nil {
+			return err
+		}
+		defer out.Close()
+	}
+
+	registry, ctx, done, err := c.dial()
+	if err != nil {
+		return err
+	}
+	defer done()
+	stream, err := registry.ExportAssets(ctx, &pb.ExportAssetsRequest{Type: *assetType})
+	if err != nil {
+		return err
+	}
+
+	w := assetio.NewWriter(out, f, c.key)
+	exported := 0
+	for {
+		asset, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		if err := w.Write(asset); err != nil {
+			return err
+		}
+		exported++
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stderr, "Exported %d assets\n", exported)
+	if out != os.Stdout {
+		return out.Close()
+	}
+	return nil
+}
@@ -0,0 +1,97 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/sairamkiran9/asset-telemetry-monitor/internal/certs"
+)
+
+// dev-certs generates a local CA and a certificate per service, so the
+// services can run with mutual TLS on one machine or in docker compose. An
+// existing CA in the output directory is reused, so certificates can be
+// reissued without redistributing ca.pem.
+func main() {
+	dir := flag.String("dir", "certs", "directory to write certificates to")
+	names := flag.String("services", "asset-registry,telemetry,asset-monitoring,monitoring,alerting,client", "comma separated certificates to issue")
+	hosts := flag.String("hosts", "localhost,127.0.0.1,::1", "comma separated names and IPs added to every certificate besides its own name")
+	validity := flag.Duration("validity", 90*24*time.Hour, "validity of issued certificates")
+	flag.Parse()
+
+	if err := run(*dir, split(*names), split(*hosts), *validity); err != nil {
+		fmt.Fprintf(os.Stderr, "dev-certs: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func split(list string) []string {
+	var items []string
+	for _, item := range strings.Split(list, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+func run(dir string, names, hosts []string, validity time.Duration) error {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+
+	caCert, caKey := filepath.Join(dir, "ca.pem"), filepath.Join(dir, "ca-key.pem")
+	ca, err := certs.LoadCA(caCert, caKey)
+	switch {
+	case err == nil:
+		fmt.Printf("Using existing CA %s\n", caCert)
+	case os.IsNotExist(err):
+		ca, err = certs.NewCA("asset-telemetry-monitor dev CA", 10*365*24*time.Hour)
+		if err != nil {
+			return err
+		}
+		keyPEM, err := ca.KeyPEM()
+		if err != nil {
+			return err
+		}
+		if err := write(caCert, ca.CertPEM(), 0644); err != nil {
+			return err
+		}
+		if err := write(caKey, keyPEM, 0600); err != nil {
+			return err
+		}
+	default:
+		return err
+	}
+
+	for _, name := range names {
+		certPEM, keyPEM, err := ca.Issue(name, append([]string{name}, hosts...), validity)
+		if err != nil {
+			return fmt.Errorf("failed to issue %s: %w", name, err)
+		}
+		if err := write(filepath.Join(dir, name+".pem"), certPEM, 0644); err != nil {
+			return err
+		}
+		if err := write(filepath.Join(dir, name+"-key.pem"), keyPEM, 0600); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// write replaces path atomically, so a service reloading its certificates
+// never sees a partly written file.
+func write(path string, data []byte, perm os.FileMode) error {
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, perm); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return err
+	}
+	fmt.Printf("Wrote %s\n", path)
+	return nil
+}
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"google.golang.org/grpc"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+
+	"github.com/sairamkiran9/asset-telemetry-monitor/internal/certs"
+)
+
+// health-probe queries a grpc.health.v1.Health service and exits 0 when the
+// service reports SERVING. It is used by container health checks.
+func main() {
+	addr := flag.String("addr", "localhost:50051", "address of the gRPC server")
+	service := flag.String("service", "", "service to check; empty checks liveness")
+	timeout := flag.Duration("timeout", 2*time.Second, "timeout for the check")
+
+	// TLS defaults to the settings of the service the probe runs beside
+	tlsCfg := certs.DefaultConfig()
+	flag.StringVar(&tlsCfg.CertFile, "tls-cert-file", os.Getenv("TLS_CERT_FILE"), "PEM client certificate; empty dials in plaintext")
+	flag.StringVar(&tlsCfg.KeyFile, "tls-key-file", os.Getenv("TLS_KEY_FILE"), "PEM private key of -tls-cert-file")
+	flag.StringVar(&tlsCfg.CAFile, "tls-ca-file", os.Getenv("TLS_CA_FILE"), "PEM CA bundle the server is verified against")
+	flag.Parse()
+
+	tlsCerts, err := certs.New(tlsCfg)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to load TLS certificates: %v\n", err)
+		os.Exit(1)
+	}
+
+	conn, err := grpc.NewClient(*addr, grpc.WithTransportCredentials(tlsCerts.Credentials()))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to connect to %s: %v\n", *addr, err)
+		os.Exit(1)
+	}
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), *timeout)
+	defer cancel()
+
+	resp, err := healthpb.NewHealthClient(conn).Check(ctx, &healthpb.HealthCheckRequest{Service: *service})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Health check failed: %v\n", err)
+		os.Exit(1)
+	}
+
+	fmt.Println(resp.Status)
+	if resp.Status != healthpb.HealthCheckResponse_SERVING {
+		os.Exit(1)
+	}
+}
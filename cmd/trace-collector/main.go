@@ -0,0 +1,175 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/sairamkiran9/asset-telemetry-monitor/internal/tracing"
+)
+
+// trace-collector is a stand-in for an OpenTelemetry Collector for local
+// testing. It accepts OTLP/HTTP JSON exports on /v1/traces, prints each span,
+// optionally appends the raw exports to a file, and serves the most recent
+// traces as indented span trees on /traces/{trace_id}.
+func main() {
+	addr := flag.String("addr", ":4318", "address to listen on")
+	file := flag.String("file", "", "append received exports to this file as JSON lines")
+	keep := flag.Int("keep", 1000, "number of recent traces to keep in memory")
+	flag.Parse()
+
+	c := &collector{keep: *keep, traces: make(map[string][]span)}
+	if *file != "" {
+		f, err := os.OpenFile(*file, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+		if err != nil {
+			log.Fatalf("Failed to open %s: %v", *file, err)
+		}
+		defer f.Close()
+		c.out = f
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /v1/traces", c.handleExport)
+	mux.HandleFunc("GET /traces", c.handleList)
+	mux.HandleFunc("GET /traces/{id}", c.handleTrace)
+
+	log.Printf("Trace collector listening on %s", *addr)
+	if err := http.ListenAndServe(*addr, mux); err != nil {
+		log.Fatalf("Failed to serve: %v", err)
+	}
+}
+
+type span struct {
+	service string
+	tracing.OTLPSpan
+}
+
+func (s span) durationMs() float64 {
+	start, _ := strconv.ParseInt(s.StartTimeUnixNano, 10, 64)
+	end, _ := strconv.ParseInt(s.EndTimeUnixNano, 10, 64)
+	return float64(end-start) / 1e6
+}
+
+func (s span) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s %s %.3fms", s.service, s.Name, s.durationMs())
+	for _, a := range s.Attributes {
+		fmt.Fprintf(&b, " %s=%s", a.Key, a.Value)
+	}
+	if s.Status.Code == int(tracing.StatusError) {
+		fmt.Fprintf(&b, " error=%q", s.Status.Message)
+	}
+	return b.String()
+}
+
+type collector struct {
+	mu     sync.Mutex
+	out    *os.File
+	keep   int
+	order  []string
+	traces map[string][]span
+}
+
+func (c *collector) handleExport(w http.ResponseWriter, r *http.Request) {
+	var req tracing.OTLPTraces
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "invalid OTLP JSON: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.out != nil {
+		if err := json.NewEncoder(c.out).Encode(req); err != nil {
+			log.Printf("Failed to write export: %v", err)
+		}
+	}
+
+	for _, rs := range req.ResourceSpans {
+		service := ""
+		for _, a := range rs.Resource.Attributes {
+			if a.Key == tracing.AttrServiceName {
+				service = a.Value.String()
+			}
+		}
+		for _, ss := range rs.ScopeSpans {
+			for _, s := range ss.Spans {
+				c.add(span{service: service, OTLPSpan: s})
+			}
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Write([]byte("{}"))
+}
+
+// add stores s and prints it, evicting the oldest trace beyond the limit.
+func (c *collector) add(s span) {
+	log.Printf("trace=%s span=%s parent=%s %s", s.TraceID, s.SpanID, s.ParentSpanID, s)
+
+	if _, ok := c.traces[s.TraceID]; !ok {
+		c.order = append(c.order, s.TraceID)
+		if len(c.order) > c.keep {
+			delete(c.traces, c.order[0])
+			c.order = c.order[1:]
+		}
+	}
+	c.traces[s.TraceID] = append(c.traces[s.TraceID], s)
+}
+
+func (c *collector) handleList(w http.ResponseWriter, r *http.Request) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for i := len(c.order) - 1; i >= 0; i-- {
+		id := c.order[i]
+		fmt.Fprintf(w, "%s %d spans\n", id, len(c.traces[id]))
+	}
+}
+
+func (c *collector) handleTrace(w http.ResponseWriter, r *http.Request) {
+	c.mu.Lock()
+	spans := append([]span(nil), c.traces[r.PathValue("id")]...)
+	c.mu.Unlock()
+
+	if len(spans) == 0 {
+		http.Error(w, "trace not found", http.StatusNotFound)
+		return
+	}
+
+	sort.Slice(spans, func(i, j int) bool {
+		return spans[i].StartTimeUnixNano < spans[j].StartTimeUnixNano
+	})
+	children := make(map[string][]span)
+	ids := make(map[string]bool)
+	for _, s := range spans {
+		ids[s.SpanID] = true
+	}
+	var roots []span
+	for _, s := range spans {
+		if s.ParentSpanID == "" || !ids[s.ParentSpanID] {
+			roots = append(roots, s)
+		} else {
+			children[s.ParentSpanID] = append(children[s.ParentSpanID], s)
+		}
+	}
+
+	var print func(s span, depth int)
+	print = func(s span, depth int) {
+		fmt.Fprintf(w, "%s%s\n", strings.Repeat("  ", depth), s)
+		for _, child := range children[s.SpanID] {
+			print(child, depth+1)
+		}
+	}
+	for _, root := range roots {
+		print(root, 0)
+	}
+}
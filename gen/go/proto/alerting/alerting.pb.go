@@ -0,0 +1,1780 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.10
+// 	protoc        v6.30.2
+// source: proto/alerting/alerting.proto
+
+package alerting
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type RuleKind int32
+
+const (
+	RuleKind_RULE_KIND_UNKNOWN RuleKind = 0
+	RuleKind_METRIC_THRESHOLD  RuleKind = 1 // Latest telemetry value compared against a threshold
+	RuleKind_ASSET_STATUS      RuleKind = 2 // Asset status reported by asset-monitoring
+)
+
+// Enum value maps for RuleKind.
+var (
+	RuleKind_name = map[int32]string{
+		0: "RULE_KIND_UNKNOWN",
+		1: "METRIC_THRESHOLD",
+		2: "ASSET_STATUS",
+	}
+	RuleKind_value = map[string]int32{
+		"RULE_KIND_UNKNOWN": 0,
+		"METRIC_THRESHOLD":  1,
+		"ASSET_STATUS":      2,
+	}
+)
+
+func (x RuleKind) Enum() *RuleKind {
+	p := new(RuleKind)
+	*p = x
+	return p
+}
+
+func (x RuleKind) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (RuleKind) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_alerting_alerting_proto_enumTypes[0].Descriptor()
+}
+
+func (RuleKind) Type() protoreflect.EnumType {
+	return &file_proto_alerting_alerting_proto_enumTypes[0]
+}
+
+func (x RuleKind) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use RuleKind.Descriptor instead.
+func (RuleKind) EnumDescriptor() ([]byte, []int) {
+	return file_proto_alerting_alerting_proto_rawDescGZIP(), []int{0}
+}
+
+type Comparison int32
+
+const (
+	Comparison_COMPARISON_UNKNOWN    Comparison = 0
+	Comparison_GREATER_THAN          Comparison = 1
+	Comparison_GREATER_THAN_OR_EQUAL Comparison = 2
+	Comparison_LESS_THAN             Comparison = 3
+	Comparison_LESS_THAN_OR_EQUAL    Comparison = 4
+	Comparison_EQUAL                 Comparison = 5
+	Comparison_NOT_EQUAL             Comparison = 6
+)
+
+// Enum value maps for Comparison.
+var (
+	Comparison_name = map[int32]string{
+		0: "COMPARISON_UNKNOWN",
+		1: "GREATER_THAN",
+		2: "GREATER_THAN_OR_EQUAL",
+		3: "LESS_THAN",
+		4: "LESS_THAN_OR_EQUAL",
+		5: "EQUAL",
+		6: "NOT_EQUAL",
+	}
+	Comparison_value = map[string]int32{
+		"COMPARISON_UNKNOWN":    0,
+		"GREATER_THAN":          1,
+		"GREATER_THAN_OR_EQUAL": 2,
+		"LESS_THAN":             3,
+		"LESS_THAN_OR_EQUAL":    4,
+		"EQUAL":                 5,
+		"NOT_EQUAL":             6,
+	}
+)
+
+func (x Comparison) Enum() *Comparison {
+	p := new(Comparison)
+	*p = x
+	return p
+}
+
+func (x Comparison) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Comparison) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_alerting_alerting_proto_enumTypes[1].Descriptor()
+}
+
+func (Comparison) Type() protoreflect.EnumType {
+	return &file_proto_alerting_alerting_proto_enumTypes[1]
+}
+
+func (x Comparison) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Comparison.Descriptor instead.
+func (Comparison) EnumDescriptor() ([]byte, []int) {
+	return file_proto_alerting_alerting_proto_rawDescGZIP(), []int{1}
+}
+
+type Severity int32
+
+const (
+	Severity_SEVERITY_UNKNOWN Severity = 0
+	Severity_INFO             Severity = 1
+	Severity_WARNING          Severity = 2
+	Severity_CRITICAL         Severity = 3
+)
+
+// Enum value maps for Severity.
+var (
+	Severity_name = map[int32]string{
+		0: "SEVERITY_UNKNOWN",
+		1: "INFO",
+		2: "WARNING",
+		3: "CRITICAL",
+	}
+	Severity_value = map[string]int32{
+		"SEVERITY_UNKNOWN": 0,
+		"INFO":             1,
+		"WARNING":          2,
+		"CRITICAL":         3,
+	}
+)
+
+func (x Severity) Enum() *Severity {
+	p := new(Severity)
+	*p = x
+	return p
+}
+
+func (x Severity) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Severity) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_alerting_alerting_proto_enumTypes[2].Descriptor()
+}
+
+func (Severity) Type() protoreflect.EnumType {
+	return &file_proto_alerting_alerting_proto_enumTypes[2]
+}
+
+func (x Severity) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Severity.Descriptor instead.
+func (Severity) EnumDescriptor() ([]byte, []int) {
+	return file_proto_alerting_alerting_proto_rawDescGZIP(), []int{2}
+}
+
+type AlertState int32
+
+const (
+	AlertState_ALERT_STATE_UNKNOWN AlertState = 0
+	AlertState_PENDING             AlertState = 1 // Condition true, waiting for the rule's for duration
+	AlertState_FIRING              AlertState = 2
+	AlertState_ACKNOWLEDGED        AlertState = 3 // Firing, but someone is on it
+	AlertState_RESOLVED            AlertState = 4
+	AlertState_SILENCED            AlertState = 5 // Firing, but notifications are suppressed
+)
+
+// Enum value maps for AlertState.
+var (
+	AlertState_name = map[int32]string{
+		0: "ALERT_STATE_UNKNOWN",
+		1: "PENDING",
+		2: "FIRING",
+		3: "ACKNOWLEDGED",
+		4: "RESOLVED",
+		5: "SILENCED",
+	}
+	AlertState_value = map[string]int32{
+		"ALERT_STATE_UNKNOWN": 0,
+		"PENDING":             1,
+		"FIRING":              2,
+		"ACKNOWLEDGED":        3,
+		"RESOLVED":            4,
+		"SILENCED":            5,
+	}
+)
+
+func (x AlertState) Enum() *AlertState {
+	p := new(AlertState)
+	*p = x
+	return p
+}
+
+func (x AlertState) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (AlertState) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_alerting_alerting_proto_enumTypes[3].Descriptor()
+}
+
+func (AlertState) Type() protoreflect.EnumType {
+	return &file_proto_alerting_alerting_proto_enumTypes[3]
+}
+
+func (x AlertState) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use AlertState.Descriptor instead.
+func (AlertState) EnumDescriptor() ([]byte, []int) {
+	return file_proto_alerting_alerting_proto_rawDescGZIP(), []int{3}
+}
+
+type AlertRule struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Kind          RuleKind               `protobuf:"varint,3,opt,name=kind,proto3,enum=alerting.RuleKind" json:"kind,omitempty"`
+	AssetId       string                 `protobuf:"bytes,4,opt,name=asset_id,json=assetId,proto3" json:"asset_id,omitempty"`                  // Empty matches every asset
+	MetricName    string                 `protobuf:"bytes,5,opt,name=metric_name,json=metricName,proto3" json:"metric_name,omitempty"`         // METRIC_THRESHOLD only
+	Comparison    Comparison             `protobuf:"varint,6,opt,name=comparison,proto3,enum=alerting.Comparison" json:"comparison,omitempty"` // METRIC_THRESHOLD only
+	Threshold     float64                `protobuf:"fixed64,7,opt,name=threshold,proto3" json:"threshold,omitempty"`                           // METRIC_THRESHOLD only
+	Status        string                 `protobuf:"bytes,8,opt,name=status,proto3" json:"status,omitempty"`                                   // ASSET_STATUS only, e.g. "OFFLINE"
+	ForSeconds    int32                  `protobuf:"varint,9,opt,name=for_seconds,json=forSeconds,proto3" json:"for_seconds,omitempty"`        // How long the condition must hold before firing
+	Severity      Severity               `protobuf:"varint,10,opt,name=severity,proto3,enum=alerting.Severity" json:"severity,omitempty"`
+	Labels        map[string]string      `protobuf:"bytes,11,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	GroupBy       []string               `protobuf:"bytes,12,rep,name=group_by,json=groupBy,proto3" json:"group_by,omitempty"` // Label names used to group notifications
+	Tenant        string                 `protobuf:"bytes,13,opt,name=tenant,proto3" json:"tenant,omitempty"`                  // Owning tenant, set from the caller's credentials
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AlertRule) Reset() {
+	*x = AlertRule{}
+	mi := &file_proto_alerting_alerting_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AlertRule) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AlertRule) ProtoMessage() {}
+
+func (x *AlertRule) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_alerting_alerting_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AlertRule.ProtoReflect.Descriptor instead.
+func (*AlertRule) Descriptor() ([]byte, []int) {
+	return file_proto_alerting_alerting_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *AlertRule) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *AlertRule) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *AlertRule) GetKind() RuleKind {
+	if x != nil {
+		return x.Kind
+	}
+	return RuleKind_RULE_KIND_UNKNOWN
+}
+
+func (x *AlertRule) GetAssetId() string {
+	if x != nil {
+		return x.AssetId
+	}
+	return ""
+}
+
+func (x *AlertRule) GetMetricName() string {
+	if x != nil {
+		return x.MetricName
+	}
+	return ""
+}
+
+func (x *AlertRule) GetComparison() Comparison {
+	if x != nil {
+		return x.Comparison
+	}
+	return Comparison_COMPARISON_UNKNOWN
+}
+
+func (x *AlertRule) GetThreshold() float64 {
+	if x != nil {
+		return x.Threshold
+	}
+	return 0
+}
+
+func (x *AlertRule) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *AlertRule) GetForSeconds() int32 {
+	if x != nil {
+		return x.ForSeconds
+	}
+	return 0
+}
+
+func (x *AlertRule) GetSeverity() Severity {
+	if x != nil {
+		return x.Severity
+	}
+	return Severity_SEVERITY_UNKNOWN
+}
+
+func (x *AlertRule) GetLabels() map[string]string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+func (x *AlertRule) GetGroupBy() []string {
+	if x != nil {
+		return x.GroupBy
+	}
+	return nil
+}
+
+func (x *AlertRule) GetTenant() string {
+	if x != nil {
+		return x.Tenant
+	}
+	return ""
+}
+
+type Alert struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Id             string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	RuleId         string                 `protobuf:"bytes,2,opt,name=rule_id,json=ruleId,proto3" json:"rule_id,omitempty"`
+	RuleName       string                 `protobuf:"bytes,3,opt,name=rule_name,json=ruleName,proto3" json:"rule_name,omitempty"`
+	AssetId        string                 `protobuf:"bytes,4,opt,name=asset_id,json=assetId,proto3" json:"asset_id,omitempty"`
+	State          AlertState             `protobuf:"varint,5,opt,name=state,proto3,enum=alerting.AlertState" json:"state,omitempty"`
+	Severity       Severity               `protobuf:"varint,6,opt,name=severity,proto3,enum=alerting.Severity" json:"severity,omitempty"`
+	Message        string                 `protobuf:"bytes,7,opt,name=message,proto3" json:"message,omitempty"`
+	Value          float64                `protobuf:"fixed64,8,opt,name=value,proto3" json:"value,omitempty"`
+	Labels         map[string]string      `protobuf:"bytes,9,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Fingerprint    string                 `protobuf:"bytes,10,opt,name=fingerprint,proto3" json:"fingerprint,omitempty"`
+	GroupKey       string                 `protobuf:"bytes,11,opt,name=group_key,json=groupKey,proto3" json:"group_key,omitempty"`
+	StartedAt      *timestamppb.Timestamp `protobuf:"bytes,12,opt,name=started_at,json=startedAt,proto3" json:"started_at,omitempty"`
+	FiredAt        *timestamppb.Timestamp `protobuf:"bytes,13,opt,name=fired_at,json=firedAt,proto3" json:"fired_at,omitempty"`
+	AcknowledgedAt *timestamppb.Timestamp `protobuf:"bytes,14,opt,name=acknowledged_at,json=acknowledgedAt,proto3" json:"acknowledged_at,omitempty"`
+	ResolvedAt     *timestamppb.Timestamp `protobuf:"bytes,15,opt,name=resolved_at,json=resolvedAt,proto3" json:"resolved_at,omitempty"`
+	AcknowledgedBy string                 `protobuf:"bytes,16,opt,name=acknowledged_by,json=acknowledgedBy,proto3" json:"acknowledged_by,omitempty"`
+	Comment        string                 `protobuf:"bytes,17,opt,name=comment,proto3" json:"comment,omitempty"`
+	SilencedBy     string                 `protobuf:"bytes,18,opt,name=silenced_by,json=silencedBy,proto3" json:"silenced_by,omitempty"` // Silence ID, or "maintenance" while the asset is under maintenance
+	Tenant         string                 `protobuf:"bytes,19,opt,name=tenant,proto3" json:"tenant,omitempty"`                           // Tenant of the asset, set by the service
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *Alert) Reset() {
+	*x = Alert{}
+	mi := &file_proto_alerting_alerting_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Alert) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Alert) ProtoMessage() {}
+
+func (x *Alert) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_alerting_alerting_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Alert.ProtoReflect.Descriptor instead.
+func (*Alert) Descriptor() ([]byte, []int) {
+	return file_proto_alerting_alerting_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Alert) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Alert) GetRuleId() string {
+	if x != nil {
+		return x.RuleId
+	}
+	return ""
+}
+
+func (x *Alert) GetRuleName() string {
+	if x != nil {
+		return x.RuleName
+	}
+	return ""
+}
+
+func (x *Alert) GetAssetId() string {
+	if x != nil {
+		return x.AssetId
+	}
+	return ""
+}
+
+func (x *Alert) GetState() AlertState {
+	if x != nil {
+		return x.State
+	}
+	return AlertState_ALERT_STATE_UNKNOWN
+}
+
+func (x *Alert) GetSeverity() Severity {
+	if x != nil {
+		return x.Severity
+	}
+	return Severity_SEVERITY_UNKNOWN
+}
+
+func (x *Alert) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *Alert) GetValue() float64 {
+	if x != nil {
+		return x.Value
+	}
+	return 0
+}
+
+func (x *Alert) GetLabels() map[string]string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+func (x *Alert) GetFingerprint() string {
+	if x != nil {
+		return x.Fingerprint
+	}
+	return ""
+}
+
+func (x *Alert) GetGroupKey() string {
+	if x != nil {
+		return x.GroupKey
+	}
+	return ""
+}
+
+func (x *Alert) GetStartedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartedAt
+	}
+	return nil
+}
+
+func (x *Alert) GetFiredAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.FiredAt
+	}
+	return nil
+}
+
+func (x *Alert) GetAcknowledgedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.AcknowledgedAt
+	}
+	return nil
+}
+
+func (x *Alert) GetResolvedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ResolvedAt
+	}
+	return nil
+}
+
+func (x *Alert) GetAcknowledgedBy() string {
+	if x != nil {
+		return x.AcknowledgedBy
+	}
+	return ""
+}
+
+func (x *Alert) GetComment() string {
+	if x != nil {
+		return x.Comment
+	}
+	return ""
+}
+
+func (x *Alert) GetSilencedBy() string {
+	if x != nil {
+		return x.SilencedBy
+	}
+	return ""
+}
+
+func (x *Alert) GetTenant() string {
+	if x != nil {
+		return x.Tenant
+	}
+	return ""
+}
+
+type Silence struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Fingerprint   string                 `protobuf:"bytes,2,opt,name=fingerprint,proto3" json:"fingerprint,omitempty"`                                                                     // Set when silencing a single alert
+	Matchers      map[string]string      `protobuf:"bytes,3,rep,name=matchers,proto3" json:"matchers,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // Alert labels that must all match
+	Owner         string                 `protobuf:"bytes,4,opt,name=owner,proto3" json:"owner,omitempty"`
+	Comment       string                 `protobuf:"bytes,5,opt,name=comment,proto3" json:"comment,omitempty"`
+	StartsAt      *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=starts_at,json=startsAt,proto3" json:"starts_at,omitempty"`
+	EndsAt        *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=ends_at,json=endsAt,proto3" json:"ends_at,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	Tenant        string                 `protobuf:"bytes,9,opt,name=tenant,proto3" json:"tenant,omitempty"` // Owning tenant, set from the caller's credentials; only its alerts are silenced
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Silence) Reset() {
+	*x = Silence{}
+	mi := &file_proto_alerting_alerting_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Silence) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Silence) ProtoMessage() {}
+
+func (x *Silence) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_alerting_alerting_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Silence.ProtoReflect.Descriptor instead.
+func (*Silence) Descriptor() ([]byte, []int) {
+	return file_proto_alerting_alerting_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Silence) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Silence) GetFingerprint() string {
+	if x != nil {
+		return x.Fingerprint
+	}
+	return ""
+}
+
+func (x *Silence) GetMatchers() map[string]string {
+	if x != nil {
+		return x.Matchers
+	}
+	return nil
+}
+
+func (x *Silence) GetOwner() string {
+	if x != nil {
+		return x.Owner
+	}
+	return ""
+}
+
+func (x *Silence) GetComment() string {
+	if x != nil {
+		return x.Comment
+	}
+	return ""
+}
+
+func (x *Silence) GetStartsAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartsAt
+	}
+	return nil
+}
+
+func (x *Silence) GetEndsAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EndsAt
+	}
+	return nil
+}
+
+func (x *Silence) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Silence) GetTenant() string {
+	if x != nil {
+		return x.Tenant
+	}
+	return ""
+}
+
+type CreateRuleRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Rule          *AlertRule             `protobuf:"bytes,1,opt,name=rule,proto3" json:"rule,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateRuleRequest) Reset() {
+	*x = CreateRuleRequest{}
+	mi := &file_proto_alerting_alerting_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateRuleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateRuleRequest) ProtoMessage() {}
+
+func (x *CreateRuleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_alerting_alerting_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateRuleRequest.ProtoReflect.Descriptor instead.
+func (*CreateRuleRequest) Descriptor() ([]byte, []int) {
+	return file_proto_alerting_alerting_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *CreateRuleRequest) GetRule() *AlertRule {
+	if x != nil {
+		return x.Rule
+	}
+	return nil
+}
+
+type CreateRuleResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Rule          *AlertRule             `protobuf:"bytes,1,opt,name=rule,proto3" json:"rule,omitempty"`
+	Success       bool                   `protobuf:"varint,2,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateRuleResponse) Reset() {
+	*x = CreateRuleResponse{}
+	mi := &file_proto_alerting_alerting_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateRuleResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateRuleResponse) ProtoMessage() {}
+
+func (x *CreateRuleResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_alerting_alerting_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateRuleResponse.ProtoReflect.Descriptor instead.
+func (*CreateRuleResponse) Descriptor() ([]byte, []int) {
+	return file_proto_alerting_alerting_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *CreateRuleResponse) GetRule() *AlertRule {
+	if x != nil {
+		return x.Rule
+	}
+	return nil
+}
+
+func (x *CreateRuleResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *CreateRuleResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type ListRulesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListRulesRequest) Reset() {
+	*x = ListRulesRequest{}
+	mi := &file_proto_alerting_alerting_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListRulesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRulesRequest) ProtoMessage() {}
+
+func (x *ListRulesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_alerting_alerting_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRulesRequest.ProtoReflect.Descriptor instead.
+func (*ListRulesRequest) Descriptor() ([]byte, []int) {
+	return file_proto_alerting_alerting_proto_rawDescGZIP(), []int{5}
+}
+
+type ListRulesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Rules         []*AlertRule           `protobuf:"bytes,1,rep,name=rules,proto3" json:"rules,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListRulesResponse) Reset() {
+	*x = ListRulesResponse{}
+	mi := &file_proto_alerting_alerting_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListRulesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRulesResponse) ProtoMessage() {}
+
+func (x *ListRulesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_alerting_alerting_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRulesResponse.ProtoReflect.Descriptor instead.
+func (*ListRulesResponse) Descriptor() ([]byte, []int) {
+	return file_proto_alerting_alerting_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ListRulesResponse) GetRules() []*AlertRule {
+	if x != nil {
+		return x.Rules
+	}
+	return nil
+}
+
+type DeleteRuleRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteRuleRequest) Reset() {
+	*x = DeleteRuleRequest{}
+	mi := &file_proto_alerting_alerting_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteRuleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteRuleRequest) ProtoMessage() {}
+
+func (x *DeleteRuleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_alerting_alerting_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteRuleRequest.ProtoReflect.Descriptor instead.
+func (*DeleteRuleRequest) Descriptor() ([]byte, []int) {
+	return file_proto_alerting_alerting_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *DeleteRuleRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type DeleteRuleResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteRuleResponse) Reset() {
+	*x = DeleteRuleResponse{}
+	mi := &file_proto_alerting_alerting_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteRuleResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteRuleResponse) ProtoMessage() {}
+
+func (x *DeleteRuleResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_alerting_alerting_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteRuleResponse.ProtoReflect.Descriptor instead.
+func (*DeleteRuleResponse) Descriptor() ([]byte, []int) {
+	return file_proto_alerting_alerting_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *DeleteRuleResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *DeleteRuleResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type ListAlertsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AssetId       string                 `protobuf:"bytes,1,opt,name=asset_id,json=assetId,proto3" json:"asset_id,omitempty"`                 // Optional filter
+	States        []AlertState           `protobuf:"varint,2,rep,packed,name=states,proto3,enum=alerting.AlertState" json:"states,omitempty"` // Optional filter, default all
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAlertsRequest) Reset() {
+	*x = ListAlertsRequest{}
+	mi := &file_proto_alerting_alerting_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAlertsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAlertsRequest) ProtoMessage() {}
+
+func (x *ListAlertsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_alerting_alerting_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAlertsRequest.ProtoReflect.Descriptor instead.
+func (*ListAlertsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_alerting_alerting_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ListAlertsRequest) GetAssetId() string {
+	if x != nil {
+		return x.AssetId
+	}
+	return ""
+}
+
+func (x *ListAlertsRequest) GetStates() []AlertState {
+	if x != nil {
+		return x.States
+	}
+	return nil
+}
+
+type ListAlertsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Alerts        []*Alert               `protobuf:"bytes,1,rep,name=alerts,proto3" json:"alerts,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAlertsResponse) Reset() {
+	*x = ListAlertsResponse{}
+	mi := &file_proto_alerting_alerting_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAlertsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAlertsResponse) ProtoMessage() {}
+
+func (x *ListAlertsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_alerting_alerting_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAlertsResponse.ProtoReflect.Descriptor instead.
+func (*ListAlertsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_alerting_alerting_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *ListAlertsResponse) GetAlerts() []*Alert {
+	if x != nil {
+		return x.Alerts
+	}
+	return nil
+}
+
+type AcknowledgeAlertRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AlertId       string                 `protobuf:"bytes,1,opt,name=alert_id,json=alertId,proto3" json:"alert_id,omitempty"`
+	Owner         string                 `protobuf:"bytes,2,opt,name=owner,proto3" json:"owner,omitempty"` // Required without authentication; otherwise the caller's subject is recorded
+	Comment       string                 `protobuf:"bytes,3,opt,name=comment,proto3" json:"comment,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AcknowledgeAlertRequest) Reset() {
+	*x = AcknowledgeAlertRequest{}
+	mi := &file_proto_alerting_alerting_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AcknowledgeAlertRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AcknowledgeAlertRequest) ProtoMessage() {}
+
+func (x *AcknowledgeAlertRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_alerting_alerting_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AcknowledgeAlertRequest.ProtoReflect.Descriptor instead.
+func (*AcknowledgeAlertRequest) Descriptor() ([]byte, []int) {
+	return file_proto_alerting_alerting_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *AcknowledgeAlertRequest) GetAlertId() string {
+	if x != nil {
+		return x.AlertId
+	}
+	return ""
+}
+
+func (x *AcknowledgeAlertRequest) GetOwner() string {
+	if x != nil {
+		return x.Owner
+	}
+	return ""
+}
+
+func (x *AcknowledgeAlertRequest) GetComment() string {
+	if x != nil {
+		return x.Comment
+	}
+	return ""
+}
+
+type AcknowledgeAlertResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Alert         *Alert                 `protobuf:"bytes,1,opt,name=alert,proto3" json:"alert,omitempty"`
+	Success       bool                   `protobuf:"varint,2,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AcknowledgeAlertResponse) Reset() {
+	*x = AcknowledgeAlertResponse{}
+	mi := &file_proto_alerting_alerting_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AcknowledgeAlertResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AcknowledgeAlertResponse) ProtoMessage() {}
+
+func (x *AcknowledgeAlertResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_alerting_alerting_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AcknowledgeAlertResponse.ProtoReflect.Descriptor instead.
+func (*AcknowledgeAlertResponse) Descriptor() ([]byte, []int) {
+	return file_proto_alerting_alerting_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *AcknowledgeAlertResponse) GetAlert() *Alert {
+	if x != nil {
+		return x.Alert
+	}
+	return nil
+}
+
+func (x *AcknowledgeAlertResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *AcknowledgeAlertResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type CreateSilenceRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	AlertId         string                 `protobuf:"bytes,1,opt,name=alert_id,json=alertId,proto3" json:"alert_id,omitempty"` // Either alert_id or matchers is required
+	Matchers        map[string]string      `protobuf:"bytes,2,rep,name=matchers,proto3" json:"matchers,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Owner           string                 `protobuf:"bytes,3,opt,name=owner,proto3" json:"owner,omitempty"` // Required without authentication; otherwise the caller's subject is recorded
+	Comment         string                 `protobuf:"bytes,4,opt,name=comment,proto3" json:"comment,omitempty"`
+	StartsAt        *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=starts_at,json=startsAt,proto3" json:"starts_at,omitempty"` // Optional: default now
+	DurationSeconds int32                  `protobuf:"varint,6,opt,name=duration_seconds,json=durationSeconds,proto3" json:"duration_seconds,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *CreateSilenceRequest) Reset() {
+	*x = CreateSilenceRequest{}
+	mi := &file_proto_alerting_alerting_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateSilenceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateSilenceRequest) ProtoMessage() {}
+
+func (x *CreateSilenceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_alerting_alerting_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateSilenceRequest.ProtoReflect.Descriptor instead.
+func (*CreateSilenceRequest) Descriptor() ([]byte, []int) {
+	return file_proto_alerting_alerting_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *CreateSilenceRequest) GetAlertId() string {
+	if x != nil {
+		return x.AlertId
+	}
+	return ""
+}
+
+func (x *CreateSilenceRequest) GetMatchers() map[string]string {
+	if x != nil {
+		return x.Matchers
+	}
+	return nil
+}
+
+func (x *CreateSilenceRequest) GetOwner() string {
+	if x != nil {
+		return x.Owner
+	}
+	return ""
+}
+
+func (x *CreateSilenceRequest) GetComment() string {
+	if x != nil {
+		return x.Comment
+	}
+	return ""
+}
+
+func (x *CreateSilenceRequest) GetStartsAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartsAt
+	}
+	return nil
+}
+
+func (x *CreateSilenceRequest) GetDurationSeconds() int32 {
+	if x != nil {
+		return x.DurationSeconds
+	}
+	return 0
+}
+
+type CreateSilenceResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Silence       *Silence               `protobuf:"bytes,1,opt,name=silence,proto3" json:"silence,omitempty"`
+	Success       bool                   `protobuf:"varint,2,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateSilenceResponse) Reset() {
+	*x = CreateSilenceResponse{}
+	mi := &file_proto_alerting_alerting_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateSilenceResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateSilenceResponse) ProtoMessage() {}
+
+func (x *CreateSilenceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_alerting_alerting_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateSilenceResponse.ProtoReflect.Descriptor instead.
+func (*CreateSilenceResponse) Descriptor() ([]byte, []int) {
+	return file_proto_alerting_alerting_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *CreateSilenceResponse) GetSilence() *Silence {
+	if x != nil {
+		return x.Silence
+	}
+	return nil
+}
+
+func (x *CreateSilenceResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *CreateSilenceResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type ListSilencesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ActiveOnly    bool                   `protobuf:"varint,1,opt,name=active_only,json=activeOnly,proto3" json:"active_only,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSilencesRequest) Reset() {
+	*x = ListSilencesRequest{}
+	mi := &file_proto_alerting_alerting_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSilencesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSilencesRequest) ProtoMessage() {}
+
+func (x *ListSilencesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_alerting_alerting_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSilencesRequest.ProtoReflect.Descriptor instead.
+func (*ListSilencesRequest) Descriptor() ([]byte, []int) {
+	return file_proto_alerting_alerting_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *ListSilencesRequest) GetActiveOnly() bool {
+	if x != nil {
+		return x.ActiveOnly
+	}
+	return false
+}
+
+type ListSilencesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Silences      []*Silence             `protobuf:"bytes,1,rep,name=silences,proto3" json:"silences,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSilencesResponse) Reset() {
+	*x = ListSilencesResponse{}
+	mi := &file_proto_alerting_alerting_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSilencesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSilencesResponse) ProtoMessage() {}
+
+func (x *ListSilencesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_alerting_alerting_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSilencesResponse.ProtoReflect.Descriptor instead.
+func (*ListSilencesResponse) Descriptor() ([]byte, []int) {
+	return file_proto_alerting_alerting_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *ListSilencesResponse) GetSilences() []*Silence {
+	if x != nil {
+		return x.Silences
+	}
+	return nil
+}
+
+type ExpireSilenceRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExpireSilenceRequest) Reset() {
+	*x = ExpireSilenceRequest{}
+	mi := &file_proto_alerting_alerting_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExpireSilenceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExpireSilenceRequest) ProtoMessage() {}
+
+func (x *ExpireSilenceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_alerting_alerting_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExpireSilenceRequest.ProtoReflect.Descriptor instead.
+func (*ExpireSilenceRequest) Descriptor() ([]byte, []int) {
+	return file_proto_alerting_alerting_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *ExpireSilenceRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type ExpireSilenceResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExpireSilenceResponse) Reset() {
+	*x = ExpireSilenceResponse{}
+	mi := &file_proto_alerting_alerting_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExpireSilenceResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExpireSilenceResponse) ProtoMessage() {}
+
+func (x *ExpireSilenceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_alerting_alerting_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExpireSilenceResponse.ProtoReflect.Descriptor instead.
+func (*ExpireSilenceResponse) Descriptor() ([]byte, []int) {
+	return file_proto_alerting_alerting_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *ExpireSilenceResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *ExpireSilenceResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+var File_proto_alerting_alerting_proto protoreflect.FileDescriptor
+
+const file_proto_alerting_alerting_proto_rawDesc = "" +
+	"\n" +
+	"\x1dproto/alerting/alerting.proto\x12\balerting\x1a\x1fgoogle/protobuf/timestamp.proto\"\xf7\x03\n" +
+	"\tAlertRule\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12&\n" +
+	"\x04kind\x18\x03 \x01(\x0e2\x12.alerting.RuleKindR\x04kind\x12\x19\n" +
+	"\basset_id\x18\x04 \x01(\tR\aassetId\x12\x1f\n" +
+	"\vmetric_name\x18\x05 \x01(\tR\n" +
+	"metricName\x124\n" +
+	"\n" +
+	"comparison\x18\x06 \x01(\x0e2\x14.alerting.ComparisonR\n" +
+	"comparison\x12\x1c\n" +
+	"\tthreshold\x18\a \x01(\x01R\tthreshold\x12\x16\n" +
+	"\x06status\x18\b \x01(\tR\x06status\x12\x1f\n" +
+	"\vfor_seconds\x18\t \x01(\x05R\n" +
+	"forSeconds\x12.\n" +
+	"\bseverity\x18\n" +
+	" \x01(\x0e2\x12.alerting.SeverityR\bseverity\x127\n" +
+	"\x06labels\x18\v \x03(\v2\x1f.alerting.AlertRule.LabelsEntryR\x06labels\x12\x19\n" +
+	"\bgroup_by\x18\f \x03(\tR\agroupBy\x12\x16\n" +
+	"\x06tenant\x18\r \x01(\tR\x06tenant\x1a9\n" +
+	"\vLabelsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\x93\x06\n" +
+	"\x05Alert\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
+	"\arule_id\x18\x02 \x01(\tR\x06ruleId\x12\x1b\n" +
+	"\trule_name\x18\x03 \x01(\tR\bruleName\x12\x19\n" +
+	"\basset_id\x18\x04 \x01(\tR\aassetId\x12*\n" +
+	"\x05state\x18\x05 \x01(\x0e2\x14.alerting.AlertStateR\x05state\x12.\n" +
+	"\bseverity\x18\x06 \x01(\x0e2\x12.alerting.SeverityR\bseverity\x12\x18\n" +
+	"\amessage\x18\a \x01(\tR\amessage\x12\x14\n" +
+	"\x05value\x18\b \x01(\x01R\x05value\x123\n" +
+	"\x06labels\x18\t \x03(\v2\x1b.alerting.Alert.LabelsEntryR\x06labels\x12 \n" +
+	"\vfingerprint\x18\n" +
+	" \x01(\tR\vfingerprint\x12\x1b\n" +
+	"\tgroup_key\x18\v \x01(\tR\bgroupKey\x129\n" +
+	"\n" +
+	"started_at\x18\f \x01(\v2\x1a.google.protobuf.TimestampR\tstartedAt\x125\n" +
+	"\bfired_at\x18\r \x01(\v2\x1a.google.protobuf.TimestampR\afiredAt\x12C\n" +
+	"\x0facknowledged_at\x18\x0e \x01(\v2\x1a.google.protobuf.TimestampR\x0eacknowledgedAt\x12;\n" +
+	"\vresolved_at\x18\x0f \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"resolvedAt\x12'\n" +
+	"\x0facknowledged_by\x18\x10 \x01(\tR\x0eacknowledgedBy\x12\x18\n" +
+	"\acomment\x18\x11 \x01(\tR\acomment\x12\x1f\n" +
+	"\vsilenced_by\x18\x12 \x01(\tR\n" +
+	"silencedBy\x12\x16\n" +
+	"\x06tenant\x18\x13 \x01(\tR\x06tenant\x1a9\n" +
+	"\vLabelsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xa6\x03\n" +
+	"\aSilence\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12 \n" +
+	"\vfingerprint\x18\x02 \x01(\tR\vfingerprint\x12;\n" +
+	"\bmatchers\x18\x03 \x03(\v2\x1f.alerting.Silence.MatchersEntryR\bmatchers\x12\x14\n" +
+	"\x05owner\x18\x04 \x01(\tR\x05owner\x12\x18\n" +
+	"\acomment\x18\x05 \x01(\tR\acomment\x127\n" +
+	"\tstarts_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\bstartsAt\x123\n" +
+	"\aends_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\x06endsAt\x129\n" +
+	"\n" +
+	"created_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12\x16\n" +
+	"\x06tenant\x18\t \x01(\tR\x06tenant\x1a;\n" +
+	"\rMatchersEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"<\n" +
+	"\x11CreateRuleRequest\x12'\n" +
+	"\x04rule\x18\x01 \x01(\v2\x13.alerting.AlertRuleR\x04rule\"q\n" +
+	"\x12CreateRuleResponse\x12'\n" +
+	"\x04rule\x18\x01 \x01(\v2\x13.alerting.AlertRuleR\x04rule\x12\x18\n" +
+	"\asuccess\x18\x02 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x03 \x01(\tR\amessage\"\x12\n" +
+	"\x10ListRulesRequest\">\n" +
+	"\x11ListRulesResponse\x12)\n" +
+	"\x05rules\x18\x01 \x03(\v2\x13.alerting.AlertRuleR\x05rules\"#\n" +
+	"\x11DeleteRuleRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"H\n" +
+	"\x12DeleteRuleResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\"\\\n" +
+	"\x11ListAlertsRequest\x12\x19\n" +
+	"\basset_id\x18\x01 \x01(\tR\aassetId\x12,\n" +
+	"\x06states\x18\x02 \x03(\x0e2\x14.alerting.AlertStateR\x06states\"=\n" +
+	"\x12ListAlertsResponse\x12'\n" +
+	"\x06alerts\x18\x01 \x03(\v2\x0f.alerting.AlertR\x06alerts\"d\n" +
+	"\x17AcknowledgeAlertRequest\x12\x19\n" +
+	"\balert_id\x18\x01 \x01(\tR\aalertId\x12\x14\n" +
+	"\x05owner\x18\x02 \x01(\tR\x05owner\x12\x18\n" +
+	"\acomment\x18\x03 \x01(\tR\acomment\"u\n" +
+	"\x18AcknowledgeAlertResponse\x12%\n" +
+	"\x05alert\x18\x01 \x01(\v2\x0f.alerting.AlertR\x05alert\x12\x18\n" +
+	"\asuccess\x18\x02 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x03 \x01(\tR\amessage\"\xcc\x02\n" +
+	"\x14CreateSilenceRequest\x12\x19\n" +
+	"\balert_id\x18\x01 \x01(\tR\aalertId\x12H\n" +
+	"\bmatchers\x18\x02 \x03(\v2,.alerting.CreateSilenceRequest.MatchersEntryR\bmatchers\x12\x14\n" +
+	"\x05owner\x18\x03 \x01(\tR\x05owner\x12\x18\n" +
+	"\acomment\x18\x04 \x01(\tR\acomment\x127\n" +
+	"\tstarts_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\bstartsAt\x12)\n" +
+	"\x10duration_seconds\x18\x06 \x01(\x05R\x0fdurationSeconds\x1a;\n" +
+	"\rMatchersEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"x\n" +
+	"\x15CreateSilenceResponse\x12+\n" +
+	"\asilence\x18\x01 \x01(\v2\x11.alerting.SilenceR\asilence\x12\x18\n" +
+	"\asuccess\x18\x02 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x03 \x01(\tR\amessage\"6\n" +
+	"\x13ListSilencesRequest\x12\x1f\n" +
+	"\vactive_only\x18\x01 \x01(\bR\n" +
+	"activeOnly\"E\n" +
+	"\x14ListSilencesResponse\x12-\n" +
+	"\bsilences\x18\x01 \x03(\v2\x11.alerting.SilenceR\bsilences\"&\n" +
+	"\x14ExpireSilenceRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"K\n" +
+	"\x15ExpireSilenceResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage*I\n" +
+	"\bRuleKind\x12\x15\n" +
+	"\x11RULE_KIND_UNKNOWN\x10\x00\x12\x14\n" +
+	"\x10METRIC_THRESHOLD\x10\x01\x12\x10\n" +
+	"\fASSET_STATUS\x10\x02*\x92\x01\n" +
+	"\n" +
+	"Comparison\x12\x16\n" +
+	"\x12COMPARISON_UNKNOWN\x10\x00\x12\x10\n" +
+	"\fGREATER_THAN\x10\x01\x12\x19\n" +
+	"\x15GREATER_THAN_OR_EQUAL\x10\x02\x12\r\n" +
+	"\tLESS_THAN\x10\x03\x12\x16\n" +
+	"\x12LESS_THAN_OR_EQUAL\x10\x04\x12\t\n" +
+	"\x05EQUAL\x10\x05\x12\r\n" +
+	"\tNOT_EQUAL\x10\x06*E\n" +
+	"\bSeverity\x12\x14\n" +
+	"\x10SEVERITY_UNKNOWN\x10\x00\x12\b\n" +
+	"\x04INFO\x10\x01\x12\v\n" +
+	"\aWARNING\x10\x02\x12\f\n" +
+	"\bCRITICAL\x10\x03*l\n" +
+	"\n" +
+	"AlertState\x12\x17\n" +
+	"\x13ALERT_STATE_UNKNOWN\x10\x00\x12\v\n" +
+	"\aPENDING\x10\x01\x12\n" +
+	"\n" +
+	"\x06FIRING\x10\x02\x12\x10\n" +
+	"\fACKNOWLEDGED\x10\x03\x12\f\n" +
+	"\bRESOLVED\x10\x04\x12\f\n" +
+	"\bSILENCED\x10\x052\x80\x05\n" +
+	"\x0fAlertingService\x12G\n" +
+	"\n" +
+	"CreateRule\x12\x1b.alerting.CreateRuleRequest\x1a\x1c.alerting.CreateRuleResponse\x12D\n" +
+	"\tListRules\x12\x1a.alerting.ListRulesRequest\x1a\x1b.alerting.ListRulesResponse\x12G\n" +
+	"\n" +
+	"DeleteRule\x12\x1b.alerting.DeleteRuleRequest\x1a\x1c.alerting.DeleteRuleResponse\x12G\n" +
+	"\n" +
+	"ListAlerts\x12\x1b.alerting.ListAlertsRequest\x1a\x1c.alerting.ListAlertsResponse\x12Y\n" +
+	"\x10AcknowledgeAlert\x12!.alerting.AcknowledgeAlertRequest\x1a\".alerting.AcknowledgeAlertResponse\x12P\n" +
+	"\rCreateSilence\x12\x1e.alerting.CreateSilenceRequest\x1a\x1f.alerting.CreateSilenceResponse\x12M\n" +
+	"\fListSilences\x12\x1d.alerting.ListSilencesRequest\x1a\x1e.alerting.ListSilencesResponse\x12P\n" +
+	"\rExpireSilence\x12\x1e.alerting.ExpireSilenceRequest\x1a\x1f.alerting.ExpireSilenceResponseBAZ?github.com/sairamkiran9/asset-telemetry-monitor/gen/go/alertingb\x06proto3"
+
+var (
+	file_proto_alerting_alerting_proto_rawDescOnce sync.Once
+	file_proto_alerting_alerting_proto_rawDescData []byte
+)
+
+func file_proto_alerting_alerting_proto_rawDescGZIP() []byte {
+	file_proto_alerting_alerting_proto_rawDescOnce.Do(func() {
+		file_proto_alerting_alerting_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_proto_alerting_alerting_proto_rawDesc), len(file_proto_alerting_alerting_proto_rawDesc)))
+	})
+	return file_proto_alerting_alerting_proto_rawDescData
+}
+
+var file_proto_alerting_alerting_proto_enumTypes = make([]protoimpl.EnumInfo, 4)
+var file_proto_alerting_alerting_proto_msgTypes = make([]protoimpl.MessageInfo, 23)
+var file_proto_alerting_alerting_proto_goTypes = []any{
+	(RuleKind)(0),                    // 0: alerting.RuleKind
+	(Comparison)(0),                  // 1: alerting.Comparison
+	(Severity)(0),                    // 2: alerting.Severity
+	(AlertState)(0),                  // 3: alerting.AlertState
+	(*AlertRule)(nil),                // 4: alerting.AlertRule
+	(*Alert)(nil),                    // 5: alerting.Alert
+	(*Silence)(nil),                  // 6: alerting.Silence
+	(*CreateRuleRequest)(nil),        // 7: alerting.CreateRuleRequest
+	(*CreateRuleResponse)(nil),       // 8: alerting.CreateRuleResponse
+	(*ListRulesRequest)(nil),         // 9: alerting.ListRulesRequest
+	(*ListRulesResponse)(nil),        // 10: alerting.ListRulesResponse
+	(*DeleteRuleRequest)(nil),        // 11: alerting.DeleteRuleRequest
+	(*DeleteRuleResponse)(nil),       // 12: alerting.DeleteRuleResponse
+	(*ListAlertsRequest)(nil),        // 13: alerting.ListAlertsRequest
+	(*ListAlertsResponse)(nil),       // 14: alerting.ListAlertsResponse
+	(*AcknowledgeAlertRequest)(nil),  // 15: alerting.AcknowledgeAlertRequest
+	(*AcknowledgeAlertResponse)(nil), // 16: alerting.AcknowledgeAlertResponse
+	(*CreateSilenceRequest)(nil),     // 17: alerting.CreateSilenceRequest
+	(*CreateSilenceResponse)(nil),    // 18: alerting.CreateSilenceResponse
+	(*ListSilencesRequest)(nil),      // 19: alerting.ListSilencesRequest
+	(*ListSilencesResponse)(nil),     // 20: alerting.ListSilencesResponse
+	(*ExpireSilenceRequest)(nil),     // 21: alerting.ExpireSilenceRequest
+	(*ExpireSilenceResponse)(nil),    // 22: alerting.ExpireSilenceResponse
+	nil,                              // 23: alerting.AlertRule.LabelsEntry
+	nil,                              // 24: alerting.Alert.LabelsEntry
+	nil,                              // 25: alerting.Silence.MatchersEntry
+	nil,                              // 26: alerting.CreateSilenceRequest.MatchersEntry
+	(*timestamppb.Timestamp)(nil),    // 27: google.protobuf.Timestamp
+}
+var file_proto_alerting_alerting_proto_depIdxs = []int32{
+	0,  // 0: alerting.AlertRule.kind:type_name -> alerting.RuleKind
+	1,  // 1: alerting.AlertRule.comparison:type_name -> alerting.Comparison
+	2,  // 2: alerting.AlertRule.severity:type_name -> alerting.Severity
+	23, // 3: alerting.AlertRule.labels:type_name -> alerting.AlertRule.LabelsEntry
+	3,  // 4: alerting.Alert.state:type_name -> alerting.AlertState
+	2,  // 5: alerting.Alert.severity:type_name -> alerting.Severity
+	24, // 6: alerting.Alert.labels:type_name -> alerting.Alert.LabelsEntry
+	27, // 7: alerting.Alert.started_at:type_name -> google.protobuf.Timestamp
+	27, // 8: alerting.Alert.fired_at:type_name -> google.protobuf.Timestamp
+	27, // 9: alerting.Alert.acknowledged_at:type_name -> google.protobuf.Timestamp
+	27, // 10: alerting.Alert.resolved_at:type_name -> google.protobuf.Timestamp
+	25, // 11: alerting.Silence.matchers:type_name -> alerting.Silence.MatchersEntry
+	27, // 12: alerting.Silence.starts_at:type_name -> google.protobuf.Timestamp
+	27, // 13: alerting.Silence.ends_at:type_name -> google.protobuf.Timestamp
+	27, // 14: alerting.Silence.created_at:type_name -> google.protobuf.Timestamp
+	4,  // 15: alerting.CreateRuleRequest.rule:type_name -> alerting.AlertRule
+	4,  // 16: alerting.CreateRuleResponse.rule:type_name -> alerting.AlertRule
+	4,  // 17: alerting.ListRulesResponse.rules:type_name -> alerting.AlertRule
+	3,  // 18: alerting.ListAlertsRequest.states:type_name -> alerting.AlertState
+	5,  // 19: alerting.ListAlertsResponse.alerts:type_name -> alerting.Alert
+	5,  // 20: alerting.AcknowledgeAlertResponse.alert:type_name -> alerting.Alert
+	26, // 21: alerting.CreateSilenceRequest.matchers:type_name -> alerting.CreateSilenceRequest.MatchersEntry
+	27, // 22: alerting.CreateSilenceRequest.starts_at:type_name -> google.protobuf.Timestamp
+	6,  // 23: alerting.CreateSilenceResponse.silence:type_name -> alerting.Silence
+	6,  // 24: alerting.ListSilencesResponse.silences:type_name -> alerting.Silence
+	7,  // 25: alerting.AlertingService.CreateRule:input_type -> alerting.CreateRuleRequest
+	9,  // 26: alerting.AlertingService.ListRules:input_type -> alerting.ListRulesRequest
+	11, // 27: alerting.AlertingService.DeleteRule:input_type -> alerting.DeleteRuleRequest
+	13, // 28: alerting.AlertingService.ListAlerts:input_type -> alerting.ListAlertsRequest
+	15, // 29: alerting.AlertingService.AcknowledgeAlert:input_type -> alerting.AcknowledgeAlertRequest
+	17, // 30: alerting.AlertingService.CreateSilence:input_type -> alerting.CreateSilenceRequest
+	19, // 31: alerting.AlertingService.ListSilences:input_type -> alerting.ListSilencesRequest
+	21, // 32: alerting.AlertingService.ExpireSilence:input_type -> alerting.ExpireSilenceRequest
+	8,  // 33: alerting.AlertingService.CreateRule:output_type -> alerting.CreateRuleResponse
+	10, // 34: alerting.AlertingService.ListRules:output_type -> alerting.ListRulesResponse
+	12, // 35: alerting.AlertingService.DeleteRule:output_type -> alerting.DeleteRuleResponse
+	14, // 36: alerting.AlertingService.ListAlerts:output_type -> alerting.ListAlertsResponse
+	16, // 37: alerting.AlertingService.AcknowledgeAlert:output_type -> alerting.AcknowledgeAlertResponse
+	18, // 38: alerting.AlertingService.CreateSilence:output_type -> alerting.CreateSilenceResponse
+	20, // 39: alerting.AlertingService.ListSilences:output_type -> alerting.ListSilencesResponse
+	22, // 40: alerting.AlertingService.ExpireSilence:output_type -> alerting.ExpireSilenceResponse
+	33, // [33:41] is the sub-list for method output_type
+	25, // [25:33] is the sub-list for method input_type
+	25, // [25:25] is the sub-list for extension type_name
+	25, // [25:25] is the sub-list for extension extendee
+	0,  // [0:25] is the sub-list for field type_name
+}
+
+func init() { file_proto_alerting_alerting_proto_init() }
+func file_proto_alerting_alerting_proto_init() {
+	if File_proto_alerting_alerting_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_alerting_alerting_proto_rawDesc), len(file_proto_alerting_alerting_proto_rawDesc)),
+			NumEnums:      4,
+			NumMessages:   23,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_proto_alerting_alerting_proto_goTypes,
+		DependencyIndexes: file_proto_alerting_alerting_proto_depIdxs,
+		EnumInfos:         file_proto_alerting_alerting_proto_enumTypes,
+		MessageInfos:      file_proto_alerting_alerting_proto_msgTypes,
+	}.Build()
+	File_proto_alerting_alerting_proto = out.File
+	file_proto_alerting_alerting_proto_goTypes = nil
+	file_proto_alerting_alerting_proto_depIdxs = nil
+}
@@ -22,16 +22,298 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+type ConnectionType int32
+
+const (
+	ConnectionType_CONNECTION_UNKNOWN ConnectionType = 0
+	ConnectionType_POWER              ConnectionType = 1 // Electrical supply, e.g. feeder to panel
+	ConnectionType_CHILLED_WATER      ConnectionType = 2
+	ConnectionType_HOT_WATER          ConnectionType = 3
+	ConnectionType_STEAM_SUPPLY       ConnectionType = 4
+	ConnectionType_GAS                ConnectionType = 5
+	ConnectionType_AIR                ConnectionType = 6 // Supply air, e.g. AHU to VAV box
+)
+
+// Enum value maps for ConnectionType.
+var (
+	ConnectionType_name = map[int32]string{
+		0: "CONNECTION_UNKNOWN",
+		1: "POWER",
+		2: "CHILLED_WATER",
+		3: "HOT_WATER",
+		4: "STEAM_SUPPLY",
+		5: "GAS",
+		6: "AIR",
+	}
+	ConnectionType_value = map[string]int32{
+		"CONNECTION_UNKNOWN": 0,
+		"POWER":              1,
+		"CHILLED_WATER":      2,
+		"HOT_WATER":          3,
+		"STEAM_SUPPLY":       4,
+		"GAS":                5,
+		"AIR":                6,
+	}
+)
+
+func (x ConnectionType) Enum() *ConnectionType {
+	p := new(ConnectionType)
+	*p = x
+	return p
+}
+
+func (x ConnectionType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ConnectionType) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_asset_asset_proto_enumTypes[0].Descriptor()
+}
+
+func (ConnectionType) Type() protoreflect.EnumType {
+	return &file_proto_asset_asset_proto_enumTypes[0]
+}
+
+func (x ConnectionType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ConnectionType.Descriptor instead.
+func (ConnectionType) EnumDescriptor() ([]byte, []int) {
+	return file_proto_asset_asset_proto_rawDescGZIP(), []int{0}
+}
+
+type AssetEventType int32
+
+const (
+	AssetEventType_ASSET_EVENT_UNKNOWN AssetEventType = 0
+	AssetEventType_CREATED             AssetEventType = 1
+	AssetEventType_UPDATED             AssetEventType = 2
+	AssetEventType_DELETED             AssetEventType = 3
+	AssetEventType_SYNCED              AssetEventType = 4 // Every current asset, or missed change, has been sent
+)
+
+// Enum value maps for AssetEventType.
+var (
+	AssetEventType_name = map[int32]string{
+		0: "ASSET_EVENT_UNKNOWN",
+		1: "CREATED",
+		2: "UPDATED",
+		3: "DELETED",
+		4: "SYNCED",
+	}
+	AssetEventType_value = map[string]int32{
+		"ASSET_EVENT_UNKNOWN": 0,
+		"CREATED":             1,
+		"UPDATED":             2,
+		"DELETED":             3,
+		"SYNCED":              4,
+	}
+)
+
+func (x AssetEventType) Enum() *AssetEventType {
+	p := new(AssetEventType)
+	*p = x
+	return p
+}
+
+func (x AssetEventType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (AssetEventType) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_asset_asset_proto_enumTypes[1].Descriptor()
+}
+
+func (AssetEventType) Type() protoreflect.EnumType {
+	return &file_proto_asset_asset_proto_enumTypes[1]
+}
+
+func (x AssetEventType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use AssetEventType.Descriptor instead.
+func (AssetEventType) EnumDescriptor() ([]byte, []int) {
+	return file_proto_asset_asset_proto_rawDescGZIP(), []int{1}
+}
+
+type Direction int32
+
+const (
+	Direction_DOWNSTREAM Direction = 0
+	Direction_UPSTREAM   Direction = 1
+	Direction_BOTH       Direction = 2 // The asset's whole connected component
+)
+
+// Enum value maps for Direction.
+var (
+	Direction_name = map[int32]string{
+		0: "DOWNSTREAM",
+		1: "UPSTREAM",
+		2: "BOTH",
+	}
+	Direction_value = map[string]int32{
+		"DOWNSTREAM": 0,
+		"UPSTREAM":   1,
+		"BOTH":       2,
+	}
+)
+
+func (x Direction) Enum() *Direction {
+	p := new(Direction)
+	*p = x
+	return p
+}
+
+func (x Direction) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Direction) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_asset_asset_proto_enumTypes[2].Descriptor()
+}
+
+func (Direction) Type() protoreflect.EnumType {
+	return &file_proto_asset_asset_proto_enumTypes[2]
+}
+
+func (x Direction) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Direction.Descriptor instead.
+func (Direction) EnumDescriptor() ([]byte, []int) {
+	return file_proto_asset_asset_proto_rawDescGZIP(), []int{2}
+}
+
+type FieldKind int32
+
+const (
+	FieldKind_FIELD_KIND_UNKNOWN FieldKind = 0
+	FieldKind_STRING             FieldKind = 1
+	FieldKind_NUMBER             FieldKind = 2
+	FieldKind_INTEGER            FieldKind = 3
+	FieldKind_BOOLEAN            FieldKind = 4
+	FieldKind_ENUM               FieldKind = 5 // One of the field's values
+)
+
+// Enum value maps for FieldKind.
+var (
+	FieldKind_name = map[int32]string{
+		0: "FIELD_KIND_UNKNOWN",
+		1: "STRING",
+		2: "NUMBER",
+		3: "INTEGER",
+		4: "BOOLEAN",
+		5: "ENUM",
+	}
+	FieldKind_value = map[string]int32{
+		"FIELD_KIND_UNKNOWN": 0,
+		"STRING":             1,
+		"NUMBER":             2,
+		"INTEGER":            3,
+		"BOOLEAN":            4,
+		"ENUM":               5,
+	}
+)
+
+func (x FieldKind) Enum() *FieldKind {
+	p := new(FieldKind)
+	*p = x
+	return p
+}
+
+func (x FieldKind) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (FieldKind) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_asset_asset_proto_enumTypes[3].Descriptor()
+}
+
+func (FieldKind) Type() protoreflect.EnumType {
+	return &file_proto_asset_asset_proto_enumTypes[3]
+}
+
+func (x FieldKind) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use FieldKind.Descriptor instead.
+func (FieldKind) EnumDescriptor() ([]byte, []int) {
+	return file_proto_asset_asset_proto_rawDescGZIP(), []int{3}
+}
+
+type ImportAction int32
+
+const (
+	ImportAction_IMPORT_ACTION_UNKNOWN ImportAction = 0
+	ImportAction_CREATE                ImportAction = 1
+	ImportAction_UPDATE                ImportAction = 2
+	ImportAction_UNCHANGED             ImportAction = 3
+	ImportAction_FAILED                ImportAction = 4
+)
+
+// Enum value maps for ImportAction.
+var (
+	ImportAction_name = map[int32]string{
+		0: "IMPORT_ACTION_UNKNOWN",
+		1: "CREATE",
+		2: "UPDATE",
+		3: "UNCHANGED",
+		4: "FAILED",
+	}
+	ImportAction_value = map[string]int32{
+		"IMPORT_ACTION_UNKNOWN": 0,
+		"CREATE":                1,
+		"UPDATE":                2,
+		"UNCHANGED":             3,
+		"FAILED":                4,
+	}
+)
+
+func (x ImportAction) Enum() *ImportAction {
+	p := new(ImportAction)
+	*p = x
+	return p
+}
+
+func (x ImportAction) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ImportAction) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_asset_asset_proto_enumTypes[4].Descriptor()
+}
+
+func (ImportAction) Type() protoreflect.EnumType {
+	return &file_proto_asset_asset_proto_enumTypes[4]
+}
+
+func (x ImportAction) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ImportAction.Descriptor instead.
+func (ImportAction) EnumDescriptor() ([]byte, []int) {
+	return file_proto_asset_asset_proto_rawDescGZIP(), []int{4}
+}
+
 type Asset struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	Type          string                 `protobuf:"bytes,3,opt,name=type,proto3" json:"type,omitempty"`
-	Description   string                 `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
-	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
-	Metadata      map[string]string      `protobuf:"bytes,6,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Id              string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name            string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Type            string                 `protobuf:"bytes,3,opt,name=type,proto3" json:"type,omitempty"` // One of the registry's asset types, by its canonical name
+	Description     string                 `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
+	CreatedAt       *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	Metadata        map[string]string      `protobuf:"bytes,6,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Tenant          string                 `protobuf:"bytes,7,opt,name=tenant,proto3" json:"tenant,omitempty"`                                           // Owning tenant, set from the caller's credentials
+	ResourceVersion uint64                 `protobuf:"varint,8,opt,name=resource_version,json=resourceVersion,proto3" json:"resource_version,omitempty"` // Registry version at the asset's last change
+	ParentId        string                 `protobuf:"bytes,9,opt,name=parent_id,json=parentId,proto3" json:"parent_id,omitempty"`
+	Connections     []*Connection          `protobuf:"bytes,10,rep,name=connections,proto3" json:"connections,omitempty"` // Outgoing, from this asset
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
 }
 
 func (x *Asset) Reset() {
@@ -106,19 +388,109 @@ func (x *Asset) GetMetadata() map[string]string {
 	return nil
 }
 
+func (x *Asset) GetTenant() string {
+	if x != nil {
+		return x.Tenant
+	}
+	return ""
+}
+
+func (x *Asset) GetResourceVersion() uint64 {
+	if x != nil {
+		return x.ResourceVersion
+	}
+	return 0
+}
+
+func (x *Asset) GetParentId() string {
+	if x != nil {
+		return x.ParentId
+	}
+	return ""
+}
+
+func (x *Asset) GetConnections() []*Connection {
+	if x != nil {
+		return x.Connections
+	}
+	return nil
+}
+
+// A directed connection: from_id supplies to_id.
+type Connection struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FromId        string                 `protobuf:"bytes,1,opt,name=from_id,json=fromId,proto3" json:"from_id,omitempty"`
+	ToId          string                 `protobuf:"bytes,2,opt,name=to_id,json=toId,proto3" json:"to_id,omitempty"`
+	Type          ConnectionType         `protobuf:"varint,3,opt,name=type,proto3,enum=asset.ConnectionType" json:"type,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Connection) Reset() {
+	*x = Connection{}
+	mi := &file_proto_asset_asset_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Connection) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Connection) ProtoMessage() {}
+
+func (x *Connection) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_asset_asset_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Connection.ProtoReflect.Descriptor instead.
+func (*Connection) Descriptor() ([]byte, []int) {
+	return file_proto_asset_asset_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Connection) GetFromId() string {
+	if x != nil {
+		return x.FromId
+	}
+	return ""
+}
+
+func (x *Connection) GetToId() string {
+	if x != nil {
+		return x.ToId
+	}
+	return ""
+}
+
+func (x *Connection) GetType() ConnectionType {
+	if x != nil {
+		return x.Type
+	}
+	return ConnectionType_CONNECTION_UNKNOWN
+}
+
 type RegisterAssetRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
 	Type          string                 `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
 	Description   string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
 	Metadata      map[string]string      `protobuf:"bytes,4,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	ParentId      string                 `protobuf:"bytes,5,opt,name=parent_id,json=parentId,proto3" json:"parent_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *RegisterAssetRequest) Reset() {
 	*x = RegisterAssetRequest{}
-	mi := &file_proto_asset_asset_proto_msgTypes[1]
+	mi := &file_proto_asset_asset_proto_msgTypes[2]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -130,7 +502,7 @@ func (x *RegisterAssetRequest) String() string {
 func (*RegisterAssetRequest) ProtoMessage() {}
 
 func (x *RegisterAssetRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_asset_asset_proto_msgTypes[1]
+	mi := &file_proto_asset_asset_proto_msgTypes[2]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -143,7 +515,7 @@ func (x *RegisterAssetRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RegisterAssetRequest.ProtoReflect.Descriptor instead.
 func (*RegisterAssetRequest) Descriptor() ([]byte, []int) {
-	return file_proto_asset_asset_proto_rawDescGZIP(), []int{1}
+	return file_proto_asset_asset_proto_rawDescGZIP(), []int{2}
 }
 
 func (x *RegisterAssetRequest) GetName() string {
@@ -174,6 +546,13 @@ func (x *RegisterAssetRequest) GetMetadata() map[string]string {
 	return nil
 }
 
+func (x *RegisterAssetRequest) GetParentId() string {
+	if x != nil {
+		return x.ParentId
+	}
+	return ""
+}
+
 type RegisterAssetResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Asset         *Asset                 `protobuf:"bytes,1,opt,name=asset,proto3" json:"asset,omitempty"`
@@ -185,7 +564,7 @@ type RegisterAssetResponse struct {
 
 func (x *RegisterAssetResponse) Reset() {
 	*x = RegisterAssetResponse{}
-	mi := &file_proto_asset_asset_proto_msgTypes[2]
+	mi := &file_proto_asset_asset_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -197,7 +576,7 @@ func (x *RegisterAssetResponse) String() string {
 func (*RegisterAssetResponse) ProtoMessage() {}
 
 func (x *RegisterAssetResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_asset_asset_proto_msgTypes[2]
+	mi := &file_proto_asset_asset_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -210,7 +589,7 @@ func (x *RegisterAssetResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RegisterAssetResponse.ProtoReflect.Descriptor instead.
 func (*RegisterAssetResponse) Descriptor() ([]byte, []int) {
-	return file_proto_asset_asset_proto_rawDescGZIP(), []int{2}
+	return file_proto_asset_asset_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *RegisterAssetResponse) GetAsset() *Asset {
@@ -243,7 +622,7 @@ type GetAssetRequest struct {
 
 func (x *GetAssetRequest) Reset() {
 	*x = GetAssetRequest{}
-	mi := &file_proto_asset_asset_proto_msgTypes[3]
+	mi := &file_proto_asset_asset_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -255,7 +634,7 @@ func (x *GetAssetRequest) String() string {
 func (*GetAssetRequest) ProtoMessage() {}
 
 func (x *GetAssetRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_asset_asset_proto_msgTypes[3]
+	mi := &file_proto_asset_asset_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -268,7 +647,7 @@ func (x *GetAssetRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetAssetRequest.ProtoReflect.Descriptor instead.
 func (*GetAssetRequest) Descriptor() ([]byte, []int) {
-	return file_proto_asset_asset_proto_rawDescGZIP(), []int{3}
+	return file_proto_asset_asset_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *GetAssetRequest) GetId() string {
@@ -288,7 +667,7 @@ type GetAssetResponse struct {
 
 func (x *GetAssetResponse) Reset() {
 	*x = GetAssetResponse{}
-	mi := &file_proto_asset_asset_proto_msgTypes[4]
+	mi := &file_proto_asset_asset_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -300,7 +679,7 @@ func (x *GetAssetResponse) String() string {
 func (*GetAssetResponse) ProtoMessage() {}
 
 func (x *GetAssetResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_asset_asset_proto_msgTypes[4]
+	mi := &file_proto_asset_asset_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -313,7 +692,7 @@ func (x *GetAssetResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetAssetResponse.ProtoReflect.Descriptor instead.
 func (*GetAssetResponse) Descriptor() ([]byte, []int) {
-	return file_proto_asset_asset_proto_rawDescGZIP(), []int{4}
+	return file_proto_asset_asset_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *GetAssetResponse) GetAsset() *Asset {
@@ -340,7 +719,7 @@ type ListAssetsRequest struct {
 
 func (x *ListAssetsRequest) Reset() {
 	*x = ListAssetsRequest{}
-	mi := &file_proto_asset_asset_proto_msgTypes[5]
+	mi := &file_proto_asset_asset_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -352,7 +731,7 @@ func (x *ListAssetsRequest) String() string {
 func (*ListAssetsRequest) ProtoMessage() {}
 
 func (x *ListAssetsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_asset_asset_proto_msgTypes[5]
+	mi := &file_proto_asset_asset_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -365,7 +744,7 @@ func (x *ListAssetsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListAssetsRequest.ProtoReflect.Descriptor instead.
 func (*ListAssetsRequest) Descriptor() ([]byte, []int) {
-	return file_proto_asset_asset_proto_rawDescGZIP(), []int{5}
+	return file_proto_asset_asset_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *ListAssetsRequest) GetPageSize() int32 {
@@ -383,16 +762,17 @@ func (x *ListAssetsRequest) GetPageToken() string {
 }
 
 type ListAssetsResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Assets        []*Asset               `protobuf:"bytes,1,rep,name=assets,proto3" json:"assets,omitempty"`
-	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Assets          []*Asset               `protobuf:"bytes,1,rep,name=assets,proto3" json:"assets,omitempty"`
+	NextPageToken   string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	ResourceVersion uint64                 `protobuf:"varint,3,opt,name=resource_version,json=resourceVersion,proto3" json:"resource_version,omitempty"` // Registry version the list reflects
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
 }
 
 func (x *ListAssetsResponse) Reset() {
 	*x = ListAssetsResponse{}
-	mi := &file_proto_asset_asset_proto_msgTypes[6]
+	mi := &file_proto_asset_asset_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -404,7 +784,7 @@ func (x *ListAssetsResponse) String() string {
 func (*ListAssetsResponse) ProtoMessage() {}
 
 func (x *ListAssetsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_asset_asset_proto_msgTypes[6]
+	mi := &file_proto_asset_asset_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -417,7 +797,7 @@ func (x *ListAssetsResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListAssetsResponse.ProtoReflect.Descriptor instead.
 func (*ListAssetsResponse) Descriptor() ([]byte, []int) {
-	return file_proto_asset_asset_proto_rawDescGZIP(), []int{6}
+	return file_proto_asset_asset_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *ListAssetsResponse) GetAssets() []*Asset {
@@ -434,51 +814,1825 @@ func (x *ListAssetsResponse) GetNextPageToken() string {
 	return ""
 }
 
-var File_proto_asset_asset_proto protoreflect.FileDescriptor
+func (x *ListAssetsResponse) GetResourceVersion() uint64 {
+	if x != nil {
+		return x.ResourceVersion
+	}
+	return 0
+}
 
-const file_proto_asset_asset_proto_rawDesc = "" +
-	"\n" +
-	"\x17proto/asset/asset.proto\x12\x05asset\x1a\x1fgoogle/protobuf/timestamp.proto\"\x91\x02\n" +
-	"\x05Asset\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
-	"\x04name\x18\x02 \x01(\tR\x04name\x12\x12\n" +
-	"\x04type\x18\x03 \x01(\tR\x04type\x12 \n" +
-	"\vdescription\x18\x04 \x01(\tR\vdescription\x129\n" +
-	"\n" +
-	"created_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x126\n" +
-	"\bmetadata\x18\x06 \x03(\v2\x1a.asset.Asset.MetadataEntryR\bmetadata\x1a;\n" +
-	"\rMetadataEntry\x12\x10\n" +
-	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
-	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xe4\x01\n" +
-	"\x14RegisterAssetRequest\x12\x12\n" +
-	"\x04name\x18\x01 \x01(\tR\x04name\x12\x12\n" +
-	"\x04type\x18\x02 \x01(\tR\x04type\x12 \n" +
-	"\vdescription\x18\x03 \x01(\tR\vdescription\x12E\n" +
-	"\bmetadata\x18\x04 \x03(\v2).asset.RegisterAssetRequest.MetadataEntryR\bmetadata\x1a;\n" +
-	"\rMetadataEntry\x12\x10\n" +
-	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
-	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"o\n" +
-	"\x15RegisterAssetResponse\x12\"\n" +
-	"\x05asset\x18\x01 \x01(\v2\f.asset.AssetR\x05asset\x12\x18\n" +
-	"\asuccess\x18\x02 \x01(\bR\asuccess\x12\x18\n" +
-	"\amessage\x18\x03 \x01(\tR\amessage\"!\n" +
-	"\x0fGetAssetRequest\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\"L\n" +
-	"\x10GetAssetResponse\x12\"\n" +
-	"\x05asset\x18\x01 \x01(\v2\f.asset.AssetR\x05asset\x12\x14\n" +
-	"\x05found\x18\x02 \x01(\bR\x05found\"O\n" +
-	"\x11ListAssetsRequest\x12\x1b\n" +
-	"\tpage_size\x18\x01 \x01(\x05R\bpageSize\x12\x1d\n" +
-	"\n" +
-	"page_token\x18\x02 \x01(\tR\tpageToken\"b\n" +
+// Empty fields keep their current values; a non-empty metadata map replaces
+// the asset's metadata.
+type UpdateAssetRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Type          string                 `protobuf:"bytes,3,opt,name=type,proto3" json:"type,omitempty"`
+	Description   string                 `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
+	Metadata      map[string]string      `protobuf:"bytes,5,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateAssetRequest) Reset() {
+	*x = UpdateAssetRequest{}
+	mi := &file_proto_asset_asset_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateAssetRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateAssetRequest) ProtoMessage() {}
+
+func (x *UpdateAssetRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_asset_asset_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateAssetRequest.ProtoReflect.Descriptor instead.
+func (*UpdateAssetRequest) Descriptor() ([]byte, []int) {
+	return file_proto_asset_asset_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *UpdateAssetRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *UpdateAssetRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *UpdateAssetRequest) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *UpdateAssetRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *UpdateAssetRequest) GetMetadata() map[string]string {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+type UpdateAssetResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Asset         *Asset                 `protobuf:"bytes,1,opt,name=asset,proto3" json:"asset,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateAssetResponse) Reset() {
+	*x = UpdateAssetResponse{}
+	mi := &file_proto_asset_asset_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateAssetResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateAssetResponse) ProtoMessage() {}
+
+func (x *UpdateAssetResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_asset_asset_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateAssetResponse.ProtoReflect.Descriptor instead.
+func (*UpdateAssetResponse) Descriptor() ([]byte, []int) {
+	return file_proto_asset_asset_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *UpdateAssetResponse) GetAsset() *Asset {
+	if x != nil {
+		return x.Asset
+	}
+	return nil
+}
+
+type DeleteAssetRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteAssetRequest) Reset() {
+	*x = DeleteAssetRequest{}
+	mi := &file_proto_asset_asset_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteAssetRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteAssetRequest) ProtoMessage() {}
+
+func (x *DeleteAssetRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_asset_asset_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteAssetRequest.ProtoReflect.Descriptor instead.
+func (*DeleteAssetRequest) Descriptor() ([]byte, []int) {
+	return file_proto_asset_asset_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *DeleteAssetRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type DeleteAssetResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Asset         *Asset                 `protobuf:"bytes,1,opt,name=asset,proto3" json:"asset,omitempty"` // The asset as it was before deletion
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteAssetResponse) Reset() {
+	*x = DeleteAssetResponse{}
+	mi := &file_proto_asset_asset_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteAssetResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteAssetResponse) ProtoMessage() {}
+
+func (x *DeleteAssetResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_asset_asset_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteAssetResponse.ProtoReflect.Descriptor instead.
+func (*DeleteAssetResponse) Descriptor() ([]byte, []int) {
+	return file_proto_asset_asset_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *DeleteAssetResponse) GetAsset() *Asset {
+	if x != nil {
+		return x.Asset
+	}
+	return nil
+}
+
+type WatchAssetsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Resume after this registry version; 0 starts with every current asset
+	ResourceVersion uint64 `protobuf:"varint,1,opt,name=resource_version,json=resourceVersion,proto3" json:"resource_version,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *WatchAssetsRequest) Reset() {
+	*x = WatchAssetsRequest{}
+	mi := &file_proto_asset_asset_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchAssetsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchAssetsRequest) ProtoMessage() {}
+
+func (x *WatchAssetsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_asset_asset_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchAssetsRequest.ProtoReflect.Descriptor instead.
+func (*WatchAssetsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_asset_asset_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *WatchAssetsRequest) GetResourceVersion() uint64 {
+	if x != nil {
+		return x.ResourceVersion
+	}
+	return 0
+}
+
+type AssetEvent struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Type            AssetEventType         `protobuf:"varint,1,opt,name=type,proto3,enum=asset.AssetEventType" json:"type,omitempty"`
+	Asset           *Asset                 `protobuf:"bytes,2,opt,name=asset,proto3" json:"asset,omitempty"` // Unset for SYNCED
+	ResourceVersion uint64                 `protobuf:"varint,3,opt,name=resource_version,json=resourceVersion,proto3" json:"resource_version,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *AssetEvent) Reset() {
+	*x = AssetEvent{}
+	mi := &file_proto_asset_asset_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AssetEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AssetEvent) ProtoMessage() {}
+
+func (x *AssetEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_asset_asset_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AssetEvent.ProtoReflect.Descriptor instead.
+func (*AssetEvent) Descriptor() ([]byte, []int) {
+	return file_proto_asset_asset_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *AssetEvent) GetType() AssetEventType {
+	if x != nil {
+		return x.Type
+	}
+	return AssetEventType_ASSET_EVENT_UNKNOWN
+}
+
+func (x *AssetEvent) GetAsset() *Asset {
+	if x != nil {
+		return x.Asset
+	}
+	return nil
+}
+
+func (x *AssetEvent) GetResourceVersion() uint64 {
+	if x != nil {
+		return x.ResourceVersion
+	}
+	return 0
+}
+
+type SetParentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AssetId       string                 `protobuf:"bytes,1,opt,name=asset_id,json=assetId,proto3" json:"asset_id,omitempty"`
+	ParentId      string                 `protobuf:"bytes,2,opt,name=parent_id,json=parentId,proto3" json:"parent_id,omitempty"` // Empty makes the asset a root
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetParentRequest) Reset() {
+	*x = SetParentRequest{}
+	mi := &file_proto_asset_asset_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetParentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetParentRequest) ProtoMessage() {}
+
+func (x *SetParentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_asset_asset_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetParentRequest.ProtoReflect.Descriptor instead.
+func (*SetParentRequest) Descriptor() ([]byte, []int) {
+	return file_proto_asset_asset_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *SetParentRequest) GetAssetId() string {
+	if x != nil {
+		return x.AssetId
+	}
+	return ""
+}
+
+func (x *SetParentRequest) GetParentId() string {
+	if x != nil {
+		return x.ParentId
+	}
+	return ""
+}
+
+type SetParentResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Asset         *Asset                 `protobuf:"bytes,1,opt,name=asset,proto3" json:"asset,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetParentResponse) Reset() {
+	*x = SetParentResponse{}
+	mi := &file_proto_asset_asset_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetParentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetParentResponse) ProtoMessage() {}
+
+func (x *SetParentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_asset_asset_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetParentResponse.ProtoReflect.Descriptor instead.
+func (*SetParentResponse) Descriptor() ([]byte, []int) {
+	return file_proto_asset_asset_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *SetParentResponse) GetAsset() *Asset {
+	if x != nil {
+		return x.Asset
+	}
+	return nil
+}
+
+type ConnectAssetsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FromId        string                 `protobuf:"bytes,1,opt,name=from_id,json=fromId,proto3" json:"from_id,omitempty"`
+	ToId          string                 `protobuf:"bytes,2,opt,name=to_id,json=toId,proto3" json:"to_id,omitempty"`
+	Type          ConnectionType         `protobuf:"varint,3,opt,name=type,proto3,enum=asset.ConnectionType" json:"type,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConnectAssetsRequest) Reset() {
+	*x = ConnectAssetsRequest{}
+	mi := &file_proto_asset_asset_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConnectAssetsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConnectAssetsRequest) ProtoMessage() {}
+
+func (x *ConnectAssetsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_asset_asset_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConnectAssetsRequest.ProtoReflect.Descriptor instead.
+func (*ConnectAssetsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_asset_asset_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *ConnectAssetsRequest) GetFromId() string {
+	if x != nil {
+		return x.FromId
+	}
+	return ""
+}
+
+func (x *ConnectAssetsRequest) GetToId() string {
+	if x != nil {
+		return x.ToId
+	}
+	return ""
+}
+
+func (x *ConnectAssetsRequest) GetType() ConnectionType {
+	if x != nil {
+		return x.Type
+	}
+	return ConnectionType_CONNECTION_UNKNOWN
+}
+
+type ConnectAssetsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Asset         *Asset                 `protobuf:"bytes,1,opt,name=asset,proto3" json:"asset,omitempty"` // The upstream asset, carrying the connection
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConnectAssetsResponse) Reset() {
+	*x = ConnectAssetsResponse{}
+	mi := &file_proto_asset_asset_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConnectAssetsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConnectAssetsResponse) ProtoMessage() {}
+
+func (x *ConnectAssetsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_asset_asset_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConnectAssetsResponse.ProtoReflect.Descriptor instead.
+func (*ConnectAssetsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_asset_asset_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *ConnectAssetsResponse) GetAsset() *Asset {
+	if x != nil {
+		return x.Asset
+	}
+	return nil
+}
+
+type DisconnectAssetsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FromId        string                 `protobuf:"bytes,1,opt,name=from_id,json=fromId,proto3" json:"from_id,omitempty"`
+	ToId          string                 `protobuf:"bytes,2,opt,name=to_id,json=toId,proto3" json:"to_id,omitempty"`
+	Type          ConnectionType         `protobuf:"varint,3,opt,name=type,proto3,enum=asset.ConnectionType" json:"type,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DisconnectAssetsRequest) Reset() {
+	*x = DisconnectAssetsRequest{}
+	mi := &file_proto_asset_asset_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DisconnectAssetsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DisconnectAssetsRequest) ProtoMessage() {}
+
+func (x *DisconnectAssetsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_asset_asset_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DisconnectAssetsRequest.ProtoReflect.Descriptor instead.
+func (*DisconnectAssetsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_asset_asset_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *DisconnectAssetsRequest) GetFromId() string {
+	if x != nil {
+		return x.FromId
+	}
+	return ""
+}
+
+func (x *DisconnectAssetsRequest) GetToId() string {
+	if x != nil {
+		return x.ToId
+	}
+	return ""
+}
+
+func (x *DisconnectAssetsRequest) GetType() ConnectionType {
+	if x != nil {
+		return x.Type
+	}
+	return ConnectionType_CONNECTION_UNKNOWN
+}
+
+type DisconnectAssetsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Asset         *Asset                 `protobuf:"bytes,1,opt,name=asset,proto3" json:"asset,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DisconnectAssetsResponse) Reset() {
+	*x = DisconnectAssetsResponse{}
+	mi := &file_proto_asset_asset_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DisconnectAssetsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DisconnectAssetsResponse) ProtoMessage() {}
+
+func (x *DisconnectAssetsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_asset_asset_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DisconnectAssetsResponse.ProtoReflect.Descriptor instead.
+func (*DisconnectAssetsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_asset_asset_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *DisconnectAssetsResponse) GetAsset() *Asset {
+	if x != nil {
+		return x.Asset
+	}
+	return nil
+}
+
+type GetSubtreeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AssetId       string                 `protobuf:"bytes,1,opt,name=asset_id,json=assetId,proto3" json:"asset_id,omitempty"`
+	MaxDepth      int32                  `protobuf:"varint,2,opt,name=max_depth,json=maxDepth,proto3" json:"max_depth,omitempty"` // Levels below the asset; 0 is unlimited
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetSubtreeRequest) Reset() {
+	*x = GetSubtreeRequest{}
+	mi := &file_proto_asset_asset_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetSubtreeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSubtreeRequest) ProtoMessage() {}
+
+func (x *GetSubtreeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_asset_asset_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSubtreeRequest.ProtoReflect.Descriptor instead.
+func (*GetSubtreeRequest) Descriptor() ([]byte, []int) {
+	return file_proto_asset_asset_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *GetSubtreeRequest) GetAssetId() string {
+	if x != nil {
+		return x.AssetId
+	}
+	return ""
+}
+
+func (x *GetSubtreeRequest) GetMaxDepth() int32 {
+	if x != nil {
+		return x.MaxDepth
+	}
+	return 0
+}
+
+type GetSubtreeResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Assets        []*Asset               `protobuf:"bytes,1,rep,name=assets,proto3" json:"assets,omitempty"` // The asset first, then descendants depth-first
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetSubtreeResponse) Reset() {
+	*x = GetSubtreeResponse{}
+	mi := &file_proto_asset_asset_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetSubtreeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSubtreeResponse) ProtoMessage() {}
+
+func (x *GetSubtreeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_asset_asset_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSubtreeResponse.ProtoReflect.Descriptor instead.
+func (*GetSubtreeResponse) Descriptor() ([]byte, []int) {
+	return file_proto_asset_asset_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *GetSubtreeResponse) GetAssets() []*Asset {
+	if x != nil {
+		return x.Assets
+	}
+	return nil
+}
+
+type GetAncestorsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AssetId       string                 `protobuf:"bytes,1,opt,name=asset_id,json=assetId,proto3" json:"asset_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAncestorsRequest) Reset() {
+	*x = GetAncestorsRequest{}
+	mi := &file_proto_asset_asset_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAncestorsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAncestorsRequest) ProtoMessage() {}
+
+func (x *GetAncestorsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_asset_asset_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAncestorsRequest.ProtoReflect.Descriptor instead.
+func (*GetAncestorsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_asset_asset_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *GetAncestorsRequest) GetAssetId() string {
+	if x != nil {
+		return x.AssetId
+	}
+	return ""
+}
+
+type GetAncestorsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Ancestors     []*Asset               `protobuf:"bytes,1,rep,name=ancestors,proto3" json:"ancestors,omitempty"` // Parent first, root last
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAncestorsResponse) Reset() {
+	*x = GetAncestorsResponse{}
+	mi := &file_proto_asset_asset_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAncestorsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAncestorsResponse) ProtoMessage() {}
+
+func (x *GetAncestorsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_asset_asset_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAncestorsResponse.ProtoReflect.Descriptor instead.
+func (*GetAncestorsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_asset_asset_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *GetAncestorsResponse) GetAncestors() []*Asset {
+	if x != nil {
+		return x.Ancestors
+	}
+	return nil
+}
+
+type GetConnectedRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	AssetId         string                 `protobuf:"bytes,1,opt,name=asset_id,json=assetId,proto3" json:"asset_id,omitempty"`
+	Direction       Direction              `protobuf:"varint,2,opt,name=direction,proto3,enum=asset.Direction" json:"direction,omitempty"`
+	ConnectionTypes []ConnectionType       `protobuf:"varint,3,rep,packed,name=connection_types,json=connectionTypes,proto3,enum=asset.ConnectionType" json:"connection_types,omitempty"` // Connections to follow; empty follows all
+	AssetType       string                 `protobuf:"bytes,4,opt,name=asset_type,json=assetType,proto3" json:"asset_type,omitempty"`                                                     // Only return assets of this type
+	MaxDepth        int32                  `protobuf:"varint,5,opt,name=max_depth,json=maxDepth,proto3" json:"max_depth,omitempty"`                                                       // Connections to follow from the asset; 0 is unlimited
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *GetConnectedRequest) Reset() {
+	*x = GetConnectedRequest{}
+	mi := &file_proto_asset_asset_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetConnectedRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetConnectedRequest) ProtoMessage() {}
+
+func (x *GetConnectedRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_asset_asset_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetConnectedRequest.ProtoReflect.Descriptor instead.
+func (*GetConnectedRequest) Descriptor() ([]byte, []int) {
+	return file_proto_asset_asset_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *GetConnectedRequest) GetAssetId() string {
+	if x != nil {
+		return x.AssetId
+	}
+	return ""
+}
+
+func (x *GetConnectedRequest) GetDirection() Direction {
+	if x != nil {
+		return x.Direction
+	}
+	return Direction_DOWNSTREAM
+}
+
+func (x *GetConnectedRequest) GetConnectionTypes() []ConnectionType {
+	if x != nil {
+		return x.ConnectionTypes
+	}
+	return nil
+}
+
+func (x *GetConnectedRequest) GetAssetType() string {
+	if x != nil {
+		return x.AssetType
+	}
+	return ""
+}
+
+func (x *GetConnectedRequest) GetMaxDepth() int32 {
+	if x != nil {
+		return x.MaxDepth
+	}
+	return 0
+}
+
+type GetConnectedResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Assets        []*Asset               `protobuf:"bytes,1,rep,name=assets,proto3" json:"assets,omitempty"`           // Reachable assets, nearest first, excluding the asset itself
+	Connections   []*Connection          `protobuf:"bytes,2,rep,name=connections,proto3" json:"connections,omitempty"` // Connections followed
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetConnectedResponse) Reset() {
+	*x = GetConnectedResponse{}
+	mi := &file_proto_asset_asset_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetConnectedResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetConnectedResponse) ProtoMessage() {}
+
+func (x *GetConnectedResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_asset_asset_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetConnectedResponse.ProtoReflect.Descriptor instead.
+func (*GetConnectedResponse) Descriptor() ([]byte, []int) {
+	return file_proto_asset_asset_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *GetConnectedResponse) GetAssets() []*Asset {
+	if x != nil {
+		return x.Assets
+	}
+	return nil
+}
+
+func (x *GetConnectedResponse) GetConnections() []*Connection {
+	if x != nil {
+		return x.Connections
+	}
+	return nil
+}
+
+// A metadata entry an asset type expects. Metadata values are strings; the
+// kind says how they're parsed.
+type MetadataField struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Kind          FieldKind              `protobuf:"varint,2,opt,name=kind,proto3,enum=asset.FieldKind" json:"kind,omitempty"`
+	Required      bool                   `protobuf:"varint,3,opt,name=required,proto3" json:"required,omitempty"`
+	Values        []string               `protobuf:"bytes,4,rep,name=values,proto3" json:"values,omitempty"` // Allowed values of an ENUM field
+	Unit          string                 `protobuf:"bytes,5,opt,name=unit,proto3" json:"unit,omitempty"`     // Unit numeric values are given in, e.g. kW
+	Description   string                 `protobuf:"bytes,6,opt,name=description,proto3" json:"description,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MetadataField) Reset() {
+	*x = MetadataField{}
+	mi := &file_proto_asset_asset_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MetadataField) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MetadataField) ProtoMessage() {}
+
+func (x *MetadataField) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_asset_asset_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MetadataField.ProtoReflect.Descriptor instead.
+func (*MetadataField) Descriptor() ([]byte, []int) {
+	return file_proto_asset_asset_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *MetadataField) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *MetadataField) GetKind() FieldKind {
+	if x != nil {
+		return x.Kind
+	}
+	return FieldKind_FIELD_KIND_UNKNOWN
+}
+
+func (x *MetadataField) GetRequired() bool {
+	if x != nil {
+		return x.Required
+	}
+	return false
+}
+
+func (x *MetadataField) GetValues() []string {
+	if x != nil {
+		return x.Values
+	}
+	return nil
+}
+
+func (x *MetadataField) GetUnit() string {
+	if x != nil {
+		return x.Unit
+	}
+	return ""
+}
+
+func (x *MetadataField) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+type AssetTypeSchema struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"` // Canonical name, stored as Asset.type
+	Description   string                 `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	Aliases       []string               `protobuf:"bytes,3,rep,name=aliases,proto3" json:"aliases,omitempty"` // Other names accepted for the type
+	Fields        []*MetadataField       `protobuf:"bytes,4,rep,name=fields,proto3" json:"fields,omitempty"`
+	Channels      []*ReadingChannel      `protobuf:"bytes,5,rep,name=channels,proto3" json:"channels,omitempty"` // Readings assets of the type report
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AssetTypeSchema) Reset() {
+	*x = AssetTypeSchema{}
+	mi := &file_proto_asset_asset_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AssetTypeSchema) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AssetTypeSchema) ProtoMessage() {}
+
+func (x *AssetTypeSchema) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_asset_asset_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AssetTypeSchema.ProtoReflect.Descriptor instead.
+func (*AssetTypeSchema) Descriptor() ([]byte, []int) {
+	return file_proto_asset_asset_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *AssetTypeSchema) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *AssetTypeSchema) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *AssetTypeSchema) GetAliases() []string {
+	if x != nil {
+		return x.Aliases
+	}
+	return nil
+}
+
+func (x *AssetTypeSchema) GetFields() []*MetadataField {
+	if x != nil {
+		return x.Fields
+	}
+	return nil
+}
+
+func (x *AssetTypeSchema) GetChannels() []*ReadingChannel {
+	if x != nil {
+		return x.Channels
+	}
+	return nil
+}
+
+// A named reading an asset type reports, such as a boiler's flow_temp.
+// Simulated readings are drawn from min to max.
+type ReadingChannel struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Unit          string                 `protobuf:"bytes,2,opt,name=unit,proto3" json:"unit,omitempty"`
+	Min           float64                `protobuf:"fixed64,3,opt,name=min,proto3" json:"min,omitempty"`
+	Max           float64                `protobuf:"fixed64,4,opt,name=max,proto3" json:"max,omitempty"`
+	Description   string                 `protobuf:"bytes,5,opt,name=description,proto3" json:"description,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReadingChannel) Reset() {
+	*x = ReadingChannel{}
+	mi := &file_proto_asset_asset_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReadingChannel) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReadingChannel) ProtoMessage() {}
+
+func (x *ReadingChannel) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_asset_asset_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReadingChannel.ProtoReflect.Descriptor instead.
+func (*ReadingChannel) Descriptor() ([]byte, []int) {
+	return file_proto_asset_asset_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *ReadingChannel) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ReadingChannel) GetUnit() string {
+	if x != nil {
+		return x.Unit
+	}
+	return ""
+}
+
+func (x *ReadingChannel) GetMin() float64 {
+	if x != nil {
+		return x.Min
+	}
+	return 0
+}
+
+func (x *ReadingChannel) GetMax() float64 {
+	if x != nil {
+		return x.Max
+	}
+	return 0
+}
+
+func (x *ReadingChannel) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+type ListAssetTypesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAssetTypesRequest) Reset() {
+	*x = ListAssetTypesRequest{}
+	mi := &file_proto_asset_asset_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAssetTypesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAssetTypesRequest) ProtoMessage() {}
+
+func (x *ListAssetTypesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_asset_asset_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAssetTypesRequest.ProtoReflect.Descriptor instead.
+func (*ListAssetTypesRequest) Descriptor() ([]byte, []int) {
+	return file_proto_asset_asset_proto_rawDescGZIP(), []int{29}
+}
+
+type ListAssetTypesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Types         []*AssetTypeSchema     `protobuf:"bytes,1,rep,name=types,proto3" json:"types,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAssetTypesResponse) Reset() {
+	*x = ListAssetTypesResponse{}
+	mi := &file_proto_asset_asset_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAssetTypesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAssetTypesResponse) ProtoMessage() {}
+
+func (x *ListAssetTypesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_asset_asset_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAssetTypesResponse.ProtoReflect.Descriptor instead.
+func (*ListAssetTypesResponse) Descriptor() ([]byte, []int) {
+	return file_proto_asset_asset_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *ListAssetTypesResponse) GetTypes() []*AssetTypeSchema {
+	if x != nil {
+		return x.Types
+	}
+	return nil
+}
+
+// An asset to import, identified by an ID from the system it comes from,
+// such as an asset tag.
+type AssetRecord struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	ExternalId       string                 `protobuf:"bytes,1,opt,name=external_id,json=externalId,proto3" json:"external_id,omitempty"`
+	Name             string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Type             string                 `protobuf:"bytes,3,opt,name=type,proto3" json:"type,omitempty"`                                                                                   // Empty keeps an existing asset's type
+	Description      string                 `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`                                                                     // Empty keeps an existing asset's description
+	Metadata         map[string]string      `protobuf:"bytes,5,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // Replaces an existing asset's metadata
+	ParentExternalId string                 `protobuf:"bytes,6,opt,name=parent_external_id,json=parentExternalId,proto3" json:"parent_external_id,omitempty"`                                 // Empty keeps an existing asset's parent
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *AssetRecord) Reset() {
+	*x = AssetRecord{}
+	mi := &file_proto_asset_asset_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AssetRecord) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AssetRecord) ProtoMessage() {}
+
+func (x *AssetRecord) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_asset_asset_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AssetRecord.ProtoReflect.Descriptor instead.
+func (*AssetRecord) Descriptor() ([]byte, []int) {
+	return file_proto_asset_asset_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *AssetRecord) GetExternalId() string {
+	if x != nil {
+		return x.ExternalId
+	}
+	return ""
+}
+
+func (x *AssetRecord) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *AssetRecord) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *AssetRecord) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *AssetRecord) GetMetadata() map[string]string {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+func (x *AssetRecord) GetParentExternalId() string {
+	if x != nil {
+		return x.ParentExternalId
+	}
+	return ""
+}
+
+type ImportAssetsRequest struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	Records            []*AssetRecord         `protobuf:"bytes,1,rep,name=records,proto3" json:"records,omitempty"`                                                   // Applied in order; parents must come before their children
+	DryRun             bool                   `protobuf:"varint,2,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"`                                      // Validate and report what would change without changing anything
+	ExternalIdKey      string                 `protobuf:"bytes,3,opt,name=external_id_key,json=externalIdKey,proto3" json:"external_id_key,omitempty"`                // Metadata key holding external IDs, default "external_id"
+	PlannedExternalIds []string               `protobuf:"bytes,4,rep,name=planned_external_ids,json=plannedExternalIds,proto3" json:"planned_external_ids,omitempty"` // Dry runs: external IDs earlier batches of the same dry run would create
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *ImportAssetsRequest) Reset() {
+	*x = ImportAssetsRequest{}
+	mi := &file_proto_asset_asset_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportAssetsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportAssetsRequest) ProtoMessage() {}
+
+func (x *ImportAssetsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_asset_asset_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportAssetsRequest.ProtoReflect.Descriptor instead.
+func (*ImportAssetsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_asset_asset_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *ImportAssetsRequest) GetRecords() []*AssetRecord {
+	if x != nil {
+		return x.Records
+	}
+	return nil
+}
+
+func (x *ImportAssetsRequest) GetDryRun() bool {
+	if x != nil {
+		return x.DryRun
+	}
+	return false
+}
+
+func (x *ImportAssetsRequest) GetExternalIdKey() string {
+	if x != nil {
+		return x.ExternalIdKey
+	}
+	return ""
+}
+
+func (x *ImportAssetsRequest) GetPlannedExternalIds() []string {
+	if x != nil {
+		return x.PlannedExternalIds
+	}
+	return nil
+}
+
+// The outcome of one record, in the order of the request's records.
+type ImportResult struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ExternalId    string                 `protobuf:"bytes,1,opt,name=external_id,json=externalId,proto3" json:"external_id,omitempty"`
+	Action        ImportAction           `protobuf:"varint,2,opt,name=action,proto3,enum=asset.ImportAction" json:"action,omitempty"`
+	AssetId       string                 `protobuf:"bytes,3,opt,name=asset_id,json=assetId,proto3" json:"asset_id,omitempty"` // Empty for assets a dry run would create
+	Error         string                 `protobuf:"bytes,4,opt,name=error,proto3" json:"error,omitempty"`                    // Why the record failed
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportResult) Reset() {
+	*x = ImportResult{}
+	mi := &file_proto_asset_asset_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportResult) ProtoMessage() {}
+
+func (x *ImportResult) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_asset_asset_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportResult.ProtoReflect.Descriptor instead.
+func (*ImportResult) Descriptor() ([]byte, []int) {
+	return file_proto_asset_asset_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *ImportResult) GetExternalId() string {
+	if x != nil {
+		return x.ExternalId
+	}
+	return ""
+}
+
+func (x *ImportResult) GetAction() ImportAction {
+	if x != nil {
+		return x.Action
+	}
+	return ImportAction_IMPORT_ACTION_UNKNOWN
+}
+
+func (x *ImportResult) GetAssetId() string {
+	if x != nil {
+		return x.AssetId
+	}
+	return ""
+}
+
+func (x *ImportResult) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+type ImportAssetsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Results       []*ImportResult        `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+	Created       int32                  `protobuf:"varint,2,opt,name=created,proto3" json:"created,omitempty"`
+	Updated       int32                  `protobuf:"varint,3,opt,name=updated,proto3" json:"updated,omitempty"`
+	Unchanged     int32                  `protobuf:"varint,4,opt,name=unchanged,proto3" json:"unchanged,omitempty"`
+	Failed        int32                  `protobuf:"varint,5,opt,name=failed,proto3" json:"failed,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportAssetsResponse) Reset() {
+	*x = ImportAssetsResponse{}
+	mi := &file_proto_asset_asset_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportAssetsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportAssetsResponse) ProtoMessage() {}
+
+func (x *ImportAssetsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_asset_asset_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportAssetsResponse.ProtoReflect.Descriptor instead.
+func (*ImportAssetsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_asset_asset_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *ImportAssetsResponse) GetResults() []*ImportResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+func (x *ImportAssetsResponse) GetCreated() int32 {
+	if x != nil {
+		return x.Created
+	}
+	return 0
+}
+
+func (x *ImportAssetsResponse) GetUpdated() int32 {
+	if x != nil {
+		return x.Updated
+	}
+	return 0
+}
+
+func (x *ImportAssetsResponse) GetUnchanged() int32 {
+	if x != nil {
+		return x.Unchanged
+	}
+	return 0
+}
+
+func (x *ImportAssetsResponse) GetFailed() int32 {
+	if x != nil {
+		return x.Failed
+	}
+	return 0
+}
+
+type ExportAssetsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Type          string                 `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"` // Optional: only assets of this type
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportAssetsRequest) Reset() {
+	*x = ExportAssetsRequest{}
+	mi := &file_proto_asset_asset_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportAssetsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportAssetsRequest) ProtoMessage() {}
+
+func (x *ExportAssetsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_asset_asset_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportAssetsRequest.ProtoReflect.Descriptor instead.
+func (*ExportAssetsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_asset_asset_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *ExportAssetsRequest) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+var File_proto_asset_asset_proto protoreflect.FileDescriptor
+
+const file_proto_asset_asset_proto_rawDesc = "" +
+	"\n" +
+	"\x17proto/asset/asset.proto\x12\x05asset\x1a\x1fgoogle/protobuf/timestamp.proto\"\xa6\x03\n" +
+	"\x05Asset\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x12\n" +
+	"\x04type\x18\x03 \x01(\tR\x04type\x12 \n" +
+	"\vdescription\x18\x04 \x01(\tR\vdescription\x129\n" +
+	"\n" +
+	"created_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x126\n" +
+	"\bmetadata\x18\x06 \x03(\v2\x1a.asset.Asset.MetadataEntryR\bmetadata\x12\x16\n" +
+	"\x06tenant\x18\a \x01(\tR\x06tenant\x12)\n" +
+	"\x10resource_version\x18\b \x01(\x04R\x0fresourceVersion\x12\x1b\n" +
+	"\tparent_id\x18\t \x01(\tR\bparentId\x123\n" +
+	"\vconnections\x18\n" +
+	" \x03(\v2\x11.asset.ConnectionR\vconnections\x1a;\n" +
+	"\rMetadataEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"e\n" +
+	"\n" +
+	"Connection\x12\x17\n" +
+	"\afrom_id\x18\x01 \x01(\tR\x06fromId\x12\x13\n" +
+	"\x05to_id\x18\x02 \x01(\tR\x04toId\x12)\n" +
+	"\x04type\x18\x03 \x01(\x0e2\x15.asset.ConnectionTypeR\x04type\"\x81\x02\n" +
+	"\x14RegisterAssetRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x12\n" +
+	"\x04type\x18\x02 \x01(\tR\x04type\x12 \n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\x12E\n" +
+	"\bmetadata\x18\x04 \x03(\v2).asset.RegisterAssetRequest.MetadataEntryR\bmetadata\x12\x1b\n" +
+	"\tparent_id\x18\x05 \x01(\tR\bparentId\x1a;\n" +
+	"\rMetadataEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"o\n" +
+	"\x15RegisterAssetResponse\x12\"\n" +
+	"\x05asset\x18\x01 \x01(\v2\f.asset.AssetR\x05asset\x12\x18\n" +
+	"\asuccess\x18\x02 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x03 \x01(\tR\amessage\"!\n" +
+	"\x0fGetAssetRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"L\n" +
+	"\x10GetAssetResponse\x12\"\n" +
+	"\x05asset\x18\x01 \x01(\v2\f.asset.AssetR\x05asset\x12\x14\n" +
+	"\x05found\x18\x02 \x01(\bR\x05found\"O\n" +
+	"\x11ListAssetsRequest\x12\x1b\n" +
+	"\tpage_size\x18\x01 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x02 \x01(\tR\tpageToken\"\x8d\x01\n" +
 	"\x12ListAssetsResponse\x12$\n" +
 	"\x06assets\x18\x01 \x03(\v2\f.asset.AssetR\x06assets\x12&\n" +
-	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken2\xdb\x01\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\x12)\n" +
+	"\x10resource_version\x18\x03 \x01(\x04R\x0fresourceVersion\"\xf0\x01\n" +
+	"\x12UpdateAssetRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x12\n" +
+	"\x04type\x18\x03 \x01(\tR\x04type\x12 \n" +
+	"\vdescription\x18\x04 \x01(\tR\vdescription\x12C\n" +
+	"\bmetadata\x18\x05 \x03(\v2'.asset.UpdateAssetRequest.MetadataEntryR\bmetadata\x1a;\n" +
+	"\rMetadataEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"9\n" +
+	"\x13UpdateAssetResponse\x12\"\n" +
+	"\x05asset\x18\x01 \x01(\v2\f.asset.AssetR\x05asset\"$\n" +
+	"\x12DeleteAssetRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"9\n" +
+	"\x13DeleteAssetResponse\x12\"\n" +
+	"\x05asset\x18\x01 \x01(\v2\f.asset.AssetR\x05asset\"?\n" +
+	"\x12WatchAssetsRequest\x12)\n" +
+	"\x10resource_version\x18\x01 \x01(\x04R\x0fresourceVersion\"\x86\x01\n" +
+	"\n" +
+	"AssetEvent\x12)\n" +
+	"\x04type\x18\x01 \x01(\x0e2\x15.asset.AssetEventTypeR\x04type\x12\"\n" +
+	"\x05asset\x18\x02 \x01(\v2\f.asset.AssetR\x05asset\x12)\n" +
+	"\x10resource_version\x18\x03 \x01(\x04R\x0fresourceVersion\"J\n" +
+	"\x10SetParentRequest\x12\x19\n" +
+	"\basset_id\x18\x01 \x01(\tR\aassetId\x12\x1b\n" +
+	"\tparent_id\x18\x02 \x01(\tR\bparentId\"7\n" +
+	"\x11SetParentResponse\x12\"\n" +
+	"\x05asset\x18\x01 \x01(\v2\f.asset.AssetR\x05asset\"o\n" +
+	"\x14ConnectAssetsRequest\x12\x17\n" +
+	"\afrom_id\x18\x01 \x01(\tR\x06fromId\x12\x13\n" +
+	"\x05to_id\x18\x02 \x01(\tR\x04toId\x12)\n" +
+	"\x04type\x18\x03 \x01(\x0e2\x15.asset.ConnectionTypeR\x04type\";\n" +
+	"\x15ConnectAssetsResponse\x12\"\n" +
+	"\x05asset\x18\x01 \x01(\v2\f.asset.AssetR\x05asset\"r\n" +
+	"\x17DisconnectAssetsRequest\x12\x17\n" +
+	"\afrom_id\x18\x01 \x01(\tR\x06fromId\x12\x13\n" +
+	"\x05to_id\x18\x02 \x01(\tR\x04toId\x12)\n" +
+	"\x04type\x18\x03 \x01(\x0e2\x15.asset.ConnectionTypeR\x04type\">\n" +
+	"\x18DisconnectAssetsResponse\x12\"\n" +
+	"\x05asset\x18\x01 \x01(\v2\f.asset.AssetR\x05asset\"K\n" +
+	"\x11GetSubtreeRequest\x12\x19\n" +
+	"\basset_id\x18\x01 \x01(\tR\aassetId\x12\x1b\n" +
+	"\tmax_depth\x18\x02 \x01(\x05R\bmaxDepth\":\n" +
+	"\x12GetSubtreeResponse\x12$\n" +
+	"\x06assets\x18\x01 \x03(\v2\f.asset.AssetR\x06assets\"0\n" +
+	"\x13GetAncestorsRequest\x12\x19\n" +
+	"\basset_id\x18\x01 \x01(\tR\aassetId\"B\n" +
+	"\x14GetAncestorsResponse\x12*\n" +
+	"\tancestors\x18\x01 \x03(\v2\f.asset.AssetR\tancestors\"\xde\x01\n" +
+	"\x13GetConnectedRequest\x12\x19\n" +
+	"\basset_id\x18\x01 \x01(\tR\aassetId\x12.\n" +
+	"\tdirection\x18\x02 \x01(\x0e2\x10.asset.DirectionR\tdirection\x12@\n" +
+	"\x10connection_types\x18\x03 \x03(\x0e2\x15.asset.ConnectionTypeR\x0fconnectionTypes\x12\x1d\n" +
+	"\n" +
+	"asset_type\x18\x04 \x01(\tR\tassetType\x12\x1b\n" +
+	"\tmax_depth\x18\x05 \x01(\x05R\bmaxDepth\"q\n" +
+	"\x14GetConnectedResponse\x12$\n" +
+	"\x06assets\x18\x01 \x03(\v2\f.asset.AssetR\x06assets\x123\n" +
+	"\vconnections\x18\x02 \x03(\v2\x11.asset.ConnectionR\vconnections\"\xb3\x01\n" +
+	"\rMetadataField\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12$\n" +
+	"\x04kind\x18\x02 \x01(\x0e2\x10.asset.FieldKindR\x04kind\x12\x1a\n" +
+	"\brequired\x18\x03 \x01(\bR\brequired\x12\x16\n" +
+	"\x06values\x18\x04 \x03(\tR\x06values\x12\x12\n" +
+	"\x04unit\x18\x05 \x01(\tR\x04unit\x12 \n" +
+	"\vdescription\x18\x06 \x01(\tR\vdescription\"\xc2\x01\n" +
+	"\x0fAssetTypeSchema\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12 \n" +
+	"\vdescription\x18\x02 \x01(\tR\vdescription\x12\x18\n" +
+	"\aaliases\x18\x03 \x03(\tR\aaliases\x12,\n" +
+	"\x06fields\x18\x04 \x03(\v2\x14.asset.MetadataFieldR\x06fields\x121\n" +
+	"\bchannels\x18\x05 \x03(\v2\x15.asset.ReadingChannelR\bchannels\"~\n" +
+	"\x0eReadingChannel\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x12\n" +
+	"\x04unit\x18\x02 \x01(\tR\x04unit\x12\x10\n" +
+	"\x03min\x18\x03 \x01(\x01R\x03min\x12\x10\n" +
+	"\x03max\x18\x04 \x01(\x01R\x03max\x12 \n" +
+	"\vdescription\x18\x05 \x01(\tR\vdescription\"\x17\n" +
+	"\x15ListAssetTypesRequest\"F\n" +
+	"\x16ListAssetTypesResponse\x12,\n" +
+	"\x05types\x18\x01 \x03(\v2\x16.asset.AssetTypeSchemaR\x05types\"\xa1\x02\n" +
+	"\vAssetRecord\x12\x1f\n" +
+	"\vexternal_id\x18\x01 \x01(\tR\n" +
+	"externalId\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x12\n" +
+	"\x04type\x18\x03 \x01(\tR\x04type\x12 \n" +
+	"\vdescription\x18\x04 \x01(\tR\vdescription\x12<\n" +
+	"\bmetadata\x18\x05 \x03(\v2 .asset.AssetRecord.MetadataEntryR\bmetadata\x12,\n" +
+	"\x12parent_external_id\x18\x06 \x01(\tR\x10parentExternalId\x1a;\n" +
+	"\rMetadataEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xb6\x01\n" +
+	"\x13ImportAssetsRequest\x12,\n" +
+	"\arecords\x18\x01 \x03(\v2\x12.asset.AssetRecordR\arecords\x12\x17\n" +
+	"\adry_run\x18\x02 \x01(\bR\x06dryRun\x12&\n" +
+	"\x0fexternal_id_key\x18\x03 \x01(\tR\rexternalIdKey\x120\n" +
+	"\x14planned_external_ids\x18\x04 \x03(\tR\x12plannedExternalIds\"\x8d\x01\n" +
+	"\fImportResult\x12\x1f\n" +
+	"\vexternal_id\x18\x01 \x01(\tR\n" +
+	"externalId\x12+\n" +
+	"\x06action\x18\x02 \x01(\x0e2\x13.asset.ImportActionR\x06action\x12\x19\n" +
+	"\basset_id\x18\x03 \x01(\tR\aassetId\x12\x14\n" +
+	"\x05error\x18\x04 \x01(\tR\x05error\"\xaf\x01\n" +
+	"\x14ImportAssetsResponse\x12-\n" +
+	"\aresults\x18\x01 \x03(\v2\x13.asset.ImportResultR\aresults\x12\x18\n" +
+	"\acreated\x18\x02 \x01(\x05R\acreated\x12\x18\n" +
+	"\aupdated\x18\x03 \x01(\x05R\aupdated\x12\x1c\n" +
+	"\tunchanged\x18\x04 \x01(\x05R\tunchanged\x12\x16\n" +
+	"\x06failed\x18\x05 \x01(\x05R\x06failed\")\n" +
+	"\x13ExportAssetsRequest\x12\x12\n" +
+	"\x04type\x18\x01 \x01(\tR\x04type*y\n" +
+	"\x0eConnectionType\x12\x16\n" +
+	"\x12CONNECTION_UNKNOWN\x10\x00\x12\t\n" +
+	"\x05POWER\x10\x01\x12\x11\n" +
+	"\rCHILLED_WATER\x10\x02\x12\r\n" +
+	"\tHOT_WATER\x10\x03\x12\x10\n" +
+	"\fSTEAM_SUPPLY\x10\x04\x12\a\n" +
+	"\x03GAS\x10\x05\x12\a\n" +
+	"\x03AIR\x10\x06*\\\n" +
+	"\x0eAssetEventType\x12\x17\n" +
+	"\x13ASSET_EVENT_UNKNOWN\x10\x00\x12\v\n" +
+	"\aCREATED\x10\x01\x12\v\n" +
+	"\aUPDATED\x10\x02\x12\v\n" +
+	"\aDELETED\x10\x03\x12\n" +
+	"\n" +
+	"\x06SYNCED\x10\x04*3\n" +
+	"\tDirection\x12\x0e\n" +
+	"\n" +
+	"DOWNSTREAM\x10\x00\x12\f\n" +
+	"\bUPSTREAM\x10\x01\x12\b\n" +
+	"\x04BOTH\x10\x02*_\n" +
+	"\tFieldKind\x12\x16\n" +
+	"\x12FIELD_KIND_UNKNOWN\x10\x00\x12\n" +
+	"\n" +
+	"\x06STRING\x10\x01\x12\n" +
+	"\n" +
+	"\x06NUMBER\x10\x02\x12\v\n" +
+	"\aINTEGER\x10\x03\x12\v\n" +
+	"\aBOOLEAN\x10\x04\x12\b\n" +
+	"\x04ENUM\x10\x05*\\\n" +
+	"\fImportAction\x12\x19\n" +
+	"\x15IMPORT_ACTION_UNKNOWN\x10\x00\x12\n" +
+	"\n" +
+	"\x06CREATE\x10\x01\x12\n" +
+	"\n" +
+	"\x06UPDATE\x10\x02\x12\r\n" +
+	"\tUNCHANGED\x10\x03\x12\n" +
+	"\n" +
+	"\x06FAILED\x10\x042\xb0\b\n" +
 	"\rAssetRegistry\x12J\n" +
 	"\rRegisterAsset\x12\x1b.asset.RegisterAssetRequest\x1a\x1c.asset.RegisterAssetResponse\x12;\n" +
 	"\bGetAsset\x12\x16.asset.GetAssetRequest\x1a\x17.asset.GetAssetResponse\x12A\n" +
 	"\n" +
-	"ListAssets\x12\x18.asset.ListAssetsRequest\x1a\x19.asset.ListAssetsResponseB>Z<github.com/sairamkiran9/asset-telemetry-monitor/gen/go/assetb\x06proto3"
+	"ListAssets\x12\x18.asset.ListAssetsRequest\x1a\x19.asset.ListAssetsResponse\x12D\n" +
+	"\vUpdateAsset\x12\x19.asset.UpdateAssetRequest\x1a\x1a.asset.UpdateAssetResponse\x12D\n" +
+	"\vDeleteAsset\x12\x19.asset.DeleteAssetRequest\x1a\x1a.asset.DeleteAssetResponse\x12=\n" +
+	"\vWatchAssets\x12\x19.asset.WatchAssetsRequest\x1a\x11.asset.AssetEvent0\x01\x12>\n" +
+	"\tSetParent\x12\x17.asset.SetParentRequest\x1a\x18.asset.SetParentResponse\x12J\n" +
+	"\rConnectAssets\x12\x1b.asset.ConnectAssetsRequest\x1a\x1c.asset.ConnectAssetsResponse\x12S\n" +
+	"\x10DisconnectAssets\x12\x1e.asset.DisconnectAssetsRequest\x1a\x1f.asset.DisconnectAssetsResponse\x12A\n" +
+	"\n" +
+	"GetSubtree\x12\x18.asset.GetSubtreeRequest\x1a\x19.asset.GetSubtreeResponse\x12G\n" +
+	"\fGetAncestors\x12\x1a.asset.GetAncestorsRequest\x1a\x1b.asset.GetAncestorsResponse\x12G\n" +
+	"\fGetConnected\x12\x1a.asset.GetConnectedRequest\x1a\x1b.asset.GetConnectedResponse\x12M\n" +
+	"\x0eListAssetTypes\x12\x1c.asset.ListAssetTypesRequest\x1a\x1d.asset.ListAssetTypesResponse\x12G\n" +
+	"\fImportAssets\x12\x1a.asset.ImportAssetsRequest\x1a\x1b.asset.ImportAssetsResponse\x12:\n" +
+	"\fExportAssets\x12\x1a.asset.ExportAssetsRequest\x1a\f.asset.Asset0\x01B>Z<github.com/sairamkiran9/asset-telemetry-monitor/gen/go/assetb\x06proto3"
 
 var (
 	file_proto_asset_asset_proto_rawDescOnce sync.Once
@@ -492,37 +2646,124 @@ func file_proto_asset_asset_proto_rawDescGZIP() []byte {
 	return file_proto_asset_asset_proto_rawDescData
 }
 
-var file_proto_asset_asset_proto_msgTypes = make([]protoimpl.MessageInfo, 9)
+var file_proto_asset_asset_proto_enumTypes = make([]protoimpl.EnumInfo, 5)
+var file_proto_asset_asset_proto_msgTypes = make([]protoimpl.MessageInfo, 40)
 var file_proto_asset_asset_proto_goTypes = []any{
-	(*Asset)(nil),                 // 0: asset.Asset
-	(*RegisterAssetRequest)(nil),  // 1: asset.RegisterAssetRequest
-	(*RegisterAssetResponse)(nil), // 2: asset.RegisterAssetResponse
-	(*GetAssetRequest)(nil),       // 3: asset.GetAssetRequest
-	(*GetAssetResponse)(nil),      // 4: asset.GetAssetResponse
-	(*ListAssetsRequest)(nil),     // 5: asset.ListAssetsRequest
-	(*ListAssetsResponse)(nil),    // 6: asset.ListAssetsResponse
-	nil,                           // 7: asset.Asset.MetadataEntry
-	nil,                           // 8: asset.RegisterAssetRequest.MetadataEntry
-	(*timestamppb.Timestamp)(nil), // 9: google.protobuf.Timestamp
+	(ConnectionType)(0),              // 0: asset.ConnectionType
+	(AssetEventType)(0),              // 1: asset.AssetEventType
+	(Direction)(0),                   // 2: asset.Direction
+	(FieldKind)(0),                   // 3: asset.FieldKind
+	(ImportAction)(0),                // 4: asset.ImportAction
+	(*Asset)(nil),                    // 5: asset.Asset
+	(*Connection)(nil),               // 6: asset.Connection
+	(*RegisterAssetRequest)(nil),     // 7: asset.RegisterAssetRequest
+	(*RegisterAssetResponse)(nil),    // 8: asset.RegisterAssetResponse
+	(*GetAssetRequest)(nil),          // 9: asset.GetAssetRequest
+	(*GetAssetResponse)(nil),         // 10: asset.GetAssetResponse
+	(*ListAssetsRequest)(nil),        // 11: asset.ListAssetsRequest
+	(*ListAssetsResponse)(nil),       // 12: asset.ListAssetsResponse
+	(*UpdateAssetRequest)(nil),       // 13: asset.UpdateAssetRequest
+	(*UpdateAssetResponse)(nil),      // 14: asset.UpdateAssetResponse
+	(*DeleteAssetRequest)(nil),       // 15: asset.DeleteAssetRequest
+	(*DeleteAssetResponse)(nil),      // 16: asset.DeleteAssetResponse
+	(*WatchAssetsRequest)(nil),       // 17: asset.WatchAssetsRequest
+	(*AssetEvent)(nil),               // 18: asset.AssetEvent
+	(*SetParentRequest)(nil),         // 19: asset.SetParentRequest
+	(*SetParentResponse)(nil),        // 20: asset.SetParentResponse
+	(*ConnectAssetsRequest)(nil),     // 21: asset.ConnectAssetsRequest
+	(*ConnectAssetsResponse)(nil),    // 22: asset.ConnectAssetsResponse
+	(*DisconnectAssetsRequest)(nil),  // 23: asset.DisconnectAssetsRequest
+	(*DisconnectAssetsResponse)(nil), // 24: asset.DisconnectAssetsResponse
+	(*GetSubtreeRequest)(nil),        // 25: asset.GetSubtreeRequest
+	(*GetSubtreeResponse)(nil),       // 26: asset.GetSubtreeResponse
+	(*GetAncestorsRequest)(nil),      // 27: asset.GetAncestorsRequest
+	(*GetAncestorsResponse)(nil),     // 28: asset.GetAncestorsResponse
+	(*GetConnectedRequest)(nil),      // 29: asset.GetConnectedRequest
+	(*GetConnectedResponse)(nil),     // 30: asset.GetConnectedResponse
+	(*MetadataField)(nil),            // 31: asset.MetadataField
+	(*AssetTypeSchema)(nil),          // 32: asset.AssetTypeSchema
+	(*ReadingChannel)(nil),           // 33: asset.ReadingChannel
+	(*ListAssetTypesRequest)(nil),    // 34: asset.ListAssetTypesRequest
+	(*ListAssetTypesResponse)(nil),   // 35: asset.ListAssetTypesResponse
+	(*AssetRecord)(nil),              // 36: asset.AssetRecord
+	(*ImportAssetsRequest)(nil),      // 37: asset.ImportAssetsRequest
+	(*ImportResult)(nil),             // 38: asset.ImportResult
+	(*ImportAssetsResponse)(nil),     // 39: asset.ImportAssetsResponse
+	(*ExportAssetsRequest)(nil),      // 40: asset.ExportAssetsRequest
+	nil,                              // 41: asset.Asset.MetadataEntry
+	nil,                              // 42: asset.RegisterAssetRequest.MetadataEntry
+	nil,                              // 43: asset.UpdateAssetRequest.MetadataEntry
+	nil,                              // 44: asset.AssetRecord.MetadataEntry
+	(*timestamppb.Timestamp)(nil),    // 45: google.protobuf.Timestamp
 }
 var file_proto_asset_asset_proto_depIdxs = []int32{
-	9, // 0: asset.Asset.created_at:type_name -> google.protobuf.Timestamp
-	7, // 1: asset.Asset.metadata:type_name -> asset.Asset.MetadataEntry
-	8, // 2: asset.RegisterAssetRequest.metadata:type_name -> asset.RegisterAssetRequest.MetadataEntry
-	0, // 3: asset.RegisterAssetResponse.asset:type_name -> asset.Asset
-	0, // 4: asset.GetAssetResponse.asset:type_name -> asset.Asset
-	0, // 5: asset.ListAssetsResponse.assets:type_name -> asset.Asset
-	1, // 6: asset.AssetRegistry.RegisterAsset:input_type -> asset.RegisterAssetRequest
-	3, // 7: asset.AssetRegistry.GetAsset:input_type -> asset.GetAssetRequest
-	5, // 8: asset.AssetRegistry.ListAssets:input_type -> asset.ListAssetsRequest
-	2, // 9: asset.AssetRegistry.RegisterAsset:output_type -> asset.RegisterAssetResponse
-	4, // 10: asset.AssetRegistry.GetAsset:output_type -> asset.GetAssetResponse
-	6, // 11: asset.AssetRegistry.ListAssets:output_type -> asset.ListAssetsResponse
-	9, // [9:12] is the sub-list for method output_type
-	6, // [6:9] is the sub-list for method input_type
-	6, // [6:6] is the sub-list for extension type_name
-	6, // [6:6] is the sub-list for extension extendee
-	0, // [0:6] is the sub-list for field type_name
+	45, // 0: asset.Asset.created_at:type_name -> google.protobuf.Timestamp
+	41, // 1: asset.Asset.metadata:type_name -> asset.Asset.MetadataEntry
+	6,  // 2: asset.Asset.connections:type_name -> asset.Connection
+	0,  // 3: asset.Connection.type:type_name -> asset.ConnectionType
+	42, // 4: asset.RegisterAssetRequest.metadata:type_name -> asset.RegisterAssetRequest.MetadataEntry
+	5,  // 5: asset.RegisterAssetResponse.asset:type_name -> asset.Asset
+	5,  // 6: asset.GetAssetResponse.asset:type_name -> asset.Asset
+	5,  // 7: asset.ListAssetsResponse.assets:type_name -> asset.Asset
+	43, // 8: asset.UpdateAssetRequest.metadata:type_name -> asset.UpdateAssetRequest.MetadataEntry
+	5,  // 9: asset.UpdateAssetResponse.asset:type_name -> asset.Asset
+	5,  // 10: asset.DeleteAssetResponse.asset:type_name -> asset.Asset
+	1,  // 11: asset.AssetEvent.type:type_name -> asset.AssetEventType
+	5,  // 12: asset.AssetEvent.asset:type_name -> asset.Asset
+	5,  // 13: asset.SetParentResponse.asset:type_name -> asset.Asset
+	0,  // 14: asset.ConnectAssetsRequest.type:type_name -> asset.ConnectionType
+	5,  // 15: asset.ConnectAssetsResponse.asset:type_name -> asset.Asset
+	0,  // 16: asset.DisconnectAssetsRequest.type:type_name -> asset.ConnectionType
+	5,  // 17: asset.DisconnectAssetsResponse.asset:type_name -> asset.Asset
+	5,  // 18: asset.GetSubtreeResponse.assets:type_name -> asset.Asset
+	5,  // 19: asset.GetAncestorsResponse.ancestors:type_name -> asset.Asset
+	2,  // 20: asset.GetConnectedRequest.direction:type_name -> asset.Direction
+	0,  // 21: asset.GetConnectedRequest.connection_types:type_name -> asset.ConnectionType
+	5,  // 22: asset.GetConnectedResponse.assets:type_name -> asset.Asset
+	6,  // 23: asset.GetConnectedResponse.connections:type_name -> asset.Connection
+	3,  // 24: asset.MetadataField.kind:type_name -> asset.FieldKind
+	31, // 25: asset.AssetTypeSchema.fields:type_name -> asset.MetadataField
+	33, // 26: asset.AssetTypeSchema.channels:type_name -> asset.ReadingChannel
+	32, // 27: asset.ListAssetTypesResponse.types:type_name -> asset.AssetTypeSchema
+	44, // 28: asset.AssetRecord.metadata:type_name -> asset.AssetRecord.MetadataEntry
+	36, // 29: asset.ImportAssetsRequest.records:type_name -> asset.AssetRecord
+	4,  // 30: asset.ImportResult.action:type_name -> asset.ImportAction
+	38, // 31: asset.ImportAssetsResponse.results:type_name -> asset.ImportResult
+	7,  // 32: asset.AssetRegistry.RegisterAsset:input_type -> asset.RegisterAssetRequest
+	9,  // 33: asset.AssetRegistry.GetAsset:input_type -> asset.GetAssetRequest
+	11, // 34: asset.AssetRegistry.ListAssets:input_type -> asset.ListAssetsRequest
+	13, // 35: asset.AssetRegistry.UpdateAsset:input_type -> asset.UpdateAssetRequest
+	15, // 36: asset.AssetRegistry.DeleteAsset:input_type -> asset.DeleteAssetRequest
+	17, // 37: asset.AssetRegistry.WatchAssets:input_type -> asset.WatchAssetsRequest
+	19, // 38: asset.AssetRegistry.SetParent:input_type -> asset.SetParentRequest
+	21, // 39: asset.AssetRegistry.ConnectAssets:input_type -> asset.ConnectAssetsRequest
+	23, // 40: asset.AssetRegistry.DisconnectAssets:input_type -> asset.DisconnectAssetsRequest
+	25, // 41: asset.AssetRegistry.GetSubtree:input_type -> asset.GetSubtreeRequest
+	27, // 42: asset.AssetRegistry.GetAncestors:input_type -> asset.GetAncestorsRequest
+	29, // 43: asset.AssetRegistry.GetConnected:input_type -> asset.GetConnectedRequest
+	34, // 44: asset.AssetRegistry.ListAssetTypes:input_type -> asset.ListAssetTypesRequest
+	37, // 45: asset.AssetRegistry.ImportAssets:input_type -> asset.ImportAssetsRequest
+	40, // 46: asset.AssetRegistry.ExportAssets:input_type -> asset.ExportAssetsRequest
+	8,  // 47: asset.AssetRegistry.RegisterAsset:output_type -> asset.RegisterAssetResponse
+	10, // 48: asset.AssetRegistry.GetAsset:output_type -> asset.GetAssetResponse
+	12, // 49: asset.AssetRegistry.ListAssets:output_type -> asset.ListAssetsResponse
+	14, // 50: asset.AssetRegistry.UpdateAsset:output_type -> asset.UpdateAssetResponse
+	16, // 51: asset.AssetRegistry.DeleteAsset:output_type -> asset.DeleteAssetResponse
+	18, // 52: asset.AssetRegistry.WatchAssets:output_type -> asset.AssetEvent
+	20, // 53: asset.AssetRegistry.SetParent:output_type -> asset.SetParentResponse
+	22, // 54: asset.AssetRegistry.ConnectAssets:output_type -> asset.ConnectAssetsResponse
+	24, // 55: asset.AssetRegistry.DisconnectAssets:output_type -> asset.DisconnectAssetsResponse
+	26, // 56: asset.AssetRegistry.GetSubtree:output_type -> asset.GetSubtreeResponse
+	28, // 57: asset.AssetRegistry.GetAncestors:output_type -> asset.GetAncestorsResponse
+	30, // 58: asset.AssetRegistry.GetConnected:output_type -> asset.GetConnectedResponse
+	35, // 59: asset.AssetRegistry.ListAssetTypes:output_type -> asset.ListAssetTypesResponse
+	39, // 60: asset.AssetRegistry.ImportAssets:output_type -> asset.ImportAssetsResponse
+	5,  // 61: asset.AssetRegistry.ExportAssets:output_type -> asset.Asset
+	47, // [47:62] is the sub-list for method output_type
+	32, // [32:47] is the sub-list for method input_type
+	32, // [32:32] is the sub-list for extension type_name
+	32, // [32:32] is the sub-list for extension extendee
+	0,  // [0:32] is the sub-list for field type_name
 }
 
 func init() { file_proto_asset_asset_proto_init() }
@@ -535,13 +2776,14 @@ func file_proto_asset_asset_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_asset_asset_proto_rawDesc), len(file_proto_asset_asset_proto_rawDesc)),
-			NumEnums:      0,
-			NumMessages:   9,
+			NumEnums:      5,
+			NumMessages:   40,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
 		GoTypes:           file_proto_asset_asset_proto_goTypes,
 		DependencyIndexes: file_proto_asset_asset_proto_depIdxs,
+		EnumInfos:         file_proto_asset_asset_proto_enumTypes,
 		MessageInfos:      file_proto_asset_asset_proto_msgTypes,
 	}.Build()
 	File_proto_asset_asset_proto = out.File
@@ -19,9 +19,21 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	AssetRegistry_RegisterAsset_FullMethodName = "/asset.AssetRegistry/RegisterAsset"
-	AssetRegistry_GetAsset_FullMethodName      = "/asset.AssetRegistry/GetAsset"
-	AssetRegistry_ListAssets_FullMethodName    = "/asset.AssetRegistry/ListAssets"
+	AssetRegistry_RegisterAsset_FullMethodName    = "/asset.AssetRegistry/RegisterAsset"
+	AssetRegistry_GetAsset_FullMethodName         = "/asset.AssetRegistry/GetAsset"
+	AssetRegistry_ListAssets_FullMethodName       = "/asset.AssetRegistry/ListAssets"
+	AssetRegistry_UpdateAsset_FullMethodName      = "/asset.AssetRegistry/UpdateAsset"
+	AssetRegistry_DeleteAsset_FullMethodName      = "/asset.AssetRegistry/DeleteAsset"
+	AssetRegistry_WatchAssets_FullMethodName      = "/asset.AssetRegistry/WatchAssets"
+	AssetRegistry_SetParent_FullMethodName        = "/asset.AssetRegistry/SetParent"
+	AssetRegistry_ConnectAssets_FullMethodName    = "/asset.AssetRegistry/ConnectAssets"
+	AssetRegistry_DisconnectAssets_FullMethodName = "/asset.AssetRegistry/DisconnectAssets"
+	AssetRegistry_GetSubtree_FullMethodName       = "/asset.AssetRegistry/GetSubtree"
+	AssetRegistry_GetAncestors_FullMethodName     = "/asset.AssetRegistry/GetAncestors"
+	AssetRegistry_GetConnected_FullMethodName     = "/asset.AssetRegistry/GetConnected"
+	AssetRegistry_ListAssetTypes_FullMethodName   = "/asset.AssetRegistry/ListAssetTypes"
+	AssetRegistry_ImportAssets_FullMethodName     = "/asset.AssetRegistry/ImportAssets"
+	AssetRegistry_ExportAssets_FullMethodName     = "/asset.AssetRegistry/ExportAssets"
 )
 
 // AssetRegistryClient is the client API for AssetRegistry service.
@@ -31,6 +43,24 @@ type AssetRegistryClient interface {
 	RegisterAsset(ctx context.Context, in *RegisterAssetRequest, opts ...grpc.CallOption) (*RegisterAssetResponse, error)
 	GetAsset(ctx context.Context, in *GetAssetRequest, opts ...grpc.CallOption) (*GetAssetResponse, error)
 	ListAssets(ctx context.Context, in *ListAssetsRequest, opts ...grpc.CallOption) (*ListAssetsResponse, error)
+	UpdateAsset(ctx context.Context, in *UpdateAssetRequest, opts ...grpc.CallOption) (*UpdateAssetResponse, error)
+	DeleteAsset(ctx context.Context, in *DeleteAssetRequest, opts ...grpc.CallOption) (*DeleteAssetResponse, error)
+	// Streams changes to assets, optionally starting with every current asset
+	WatchAssets(ctx context.Context, in *WatchAssetsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[AssetEvent], error)
+	// Hierarchy (campus, building, plant, equipment) and connections
+	// (a feeder supplies a panel) between assets of the same tenant
+	SetParent(ctx context.Context, in *SetParentRequest, opts ...grpc.CallOption) (*SetParentResponse, error)
+	ConnectAssets(ctx context.Context, in *ConnectAssetsRequest, opts ...grpc.CallOption) (*ConnectAssetsResponse, error)
+	DisconnectAssets(ctx context.Context, in *DisconnectAssetsRequest, opts ...grpc.CallOption) (*DisconnectAssetsResponse, error)
+	GetSubtree(ctx context.Context, in *GetSubtreeRequest, opts ...grpc.CallOption) (*GetSubtreeResponse, error)
+	GetAncestors(ctx context.Context, in *GetAncestorsRequest, opts ...grpc.CallOption) (*GetAncestorsResponse, error)
+	GetConnected(ctx context.Context, in *GetConnectedRequest, opts ...grpc.CallOption) (*GetConnectedResponse, error)
+	// The asset types assets may have, with the metadata each expects
+	ListAssetTypes(ctx context.Context, in *ListAssetTypesRequest, opts ...grpc.CallOption) (*ListAssetTypesResponse, error)
+	// Bulk onboarding: upserts assets keyed on an external ID kept in their
+	// metadata, and streams every asset back out, parents first
+	ImportAssets(ctx context.Context, in *ImportAssetsRequest, opts ...grpc.CallOption) (*ImportAssetsResponse, error)
+	ExportAssets(ctx context.Context, in *ExportAssetsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Asset], error)
 }
 
 type assetRegistryClient struct {
@@ -71,6 +101,144 @@ func (c *assetRegistryClient) ListAssets(ctx context.Context, in *ListAssetsRequ
 	return out, nil
 }
 
+func (c *assetRegistryClient) UpdateAsset(ctx context.Context, in *UpdateAssetRequest, opts ...grpc.CallOption) (*UpdateAssetResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UpdateAssetResponse)
+	err := c.cc.Invoke(ctx, AssetRegistry_UpdateAsset_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *assetRegistryClient) DeleteAsset(ctx context.Context, in *DeleteAssetRequest, opts ...grpc.CallOption) (*DeleteAssetResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteAssetResponse)
+	err := c.cc.Invoke(ctx, AssetRegistry_DeleteAsset_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *assetRegistryClient) WatchAssets(ctx context.Context, in *WatchAssetsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[AssetEvent], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &AssetRegistry_ServiceDesc.Streams[0], AssetRegistry_WatchAssets_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WatchAssetsRequest, AssetEvent]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type AssetRegistry_WatchAssetsClient = grpc.ServerStreamingClient[AssetEvent]
+
+func (c *assetRegistryClient) SetParent(ctx context.Context, in *SetParentRequest, opts ...grpc.CallOption) (*SetParentResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SetParentResponse)
+	err := c.cc.Invoke(ctx, AssetRegistry_SetParent_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *assetRegistryClient) ConnectAssets(ctx context.Context, in *ConnectAssetsRequest, opts ...grpc.CallOption) (*ConnectAssetsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ConnectAssetsResponse)
+	err := c.cc.Invoke(ctx, AssetRegistry_ConnectAssets_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *assetRegistryClient) DisconnectAssets(ctx context.Context, in *DisconnectAssetsRequest, opts ...grpc.CallOption) (*DisconnectAssetsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DisconnectAssetsResponse)
+	err := c.cc.Invoke(ctx, AssetRegistry_DisconnectAssets_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *assetRegistryClient) GetSubtree(ctx context.Context, in *GetSubtreeRequest, opts ...grpc.CallOption) (*GetSubtreeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetSubtreeResponse)
+	err := c.cc.Invoke(ctx, AssetRegistry_GetSubtree_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *assetRegistryClient) GetAncestors(ctx context.Context, in *GetAncestorsRequest, opts ...grpc.CallOption) (*GetAncestorsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetAncestorsResponse)
+	err := c.cc.Invoke(ctx, AssetRegistry_GetAncestors_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *assetRegistryClient) GetConnected(ctx context.Context, in *GetConnectedRequest, opts ...grpc.CallOption) (*GetConnectedResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetConnectedResponse)
+	err := c.cc.Invoke(ctx, AssetRegistry_GetConnected_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *assetRegistryClient) ListAssetTypes(ctx context.Context, in *ListAssetTypesRequest, opts ...grpc.CallOption) (*ListAssetTypesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListAssetTypesResponse)
+	err := c.cc.Invoke(ctx, AssetRegistry_ListAssetTypes_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *assetRegistryClient) ImportAssets(ctx context.Context, in *ImportAssetsRequest, opts ...grpc.CallOption) (*ImportAssetsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ImportAssetsResponse)
+	err := c.cc.Invoke(ctx, AssetRegistry_ImportAssets_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *assetRegistryClient) ExportAssets(ctx context.Context, in *ExportAssetsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Asset], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &AssetRegistry_ServiceDesc.Streams[1], AssetRegistry_ExportAssets_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ExportAssetsRequest, Asset]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type AssetRegistry_ExportAssetsClient = grpc.ServerStreamingClient[Asset]
+
 // AssetRegistryServer is the server API for AssetRegistry service.
 // All implementations must embed UnimplementedAssetRegistryServer
 // for forward compatibility.
@@ -78,6 +246,24 @@ type AssetRegistryServer interface {
 	RegisterAsset(context.Context, *RegisterAssetRequest) (*RegisterAssetResponse, error)
 	GetAsset(context.Context, *GetAssetRequest) (*GetAssetResponse, error)
 	ListAssets(context.Context, *ListAssetsRequest) (*ListAssetsResponse, error)
+	UpdateAsset(context.Context, *UpdateAssetRequest) (*UpdateAssetResponse, error)
+	DeleteAsset(context.Context, *DeleteAssetRequest) (*DeleteAssetResponse, error)
+	// Streams changes to assets, optionally starting with every current asset
+	WatchAssets(*WatchAssetsRequest, grpc.ServerStreamingServer[AssetEvent]) error
+	// Hierarchy (campus, building, plant, equipment) and connections
+	// (a feeder supplies a panel) between assets of the same tenant
+	SetParent(context.Context, *SetParentRequest) (*SetParentResponse, error)
+	ConnectAssets(context.Context, *ConnectAssetsRequest) (*ConnectAssetsResponse, error)
+	DisconnectAssets(context.Context, *DisconnectAssetsRequest) (*DisconnectAssetsResponse, error)
+	GetSubtree(context.Context, *GetSubtreeRequest) (*GetSubtreeResponse, error)
+	GetAncestors(context.Context, *GetAncestorsRequest) (*GetAncestorsResponse, error)
+	GetConnected(context.Context, *GetConnectedRequest) (*GetConnectedResponse, error)
+	// The asset types assets may have, with the metadata each expects
+	ListAssetTypes(context.Context, *ListAssetTypesRequest) (*ListAssetTypesResponse, error)
+	// Bulk onboarding: upserts assets keyed on an external ID kept in their
+	// metadata, and streams every asset back out, parents first
+	ImportAssets(context.Context, *ImportAssetsRequest) (*ImportAssetsResponse, error)
+	ExportAssets(*ExportAssetsRequest, grpc.ServerStreamingServer[Asset]) error
 	mustEmbedUnimplementedAssetRegistryServer()
 }
 
@@ -97,6 +283,42 @@ func (UnimplementedAssetRegistryServer) GetAsset(context.Context, *GetAssetReque
 func (UnimplementedAssetRegistryServer) ListAssets(context.Context, *ListAssetsRequest) (*ListAssetsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListAssets not implemented")
 }
+func (UnimplementedAssetRegistryServer) UpdateAsset(context.Context, *UpdateAssetRequest) (*UpdateAssetResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateAsset not implemented")
+}
+func (UnimplementedAssetRegistryServer) DeleteAsset(context.Context, *DeleteAssetRequest) (*DeleteAssetResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteAsset not implemented")
+}
+func (UnimplementedAssetRegistryServer) WatchAssets(*WatchAssetsRequest, grpc.ServerStreamingServer[AssetEvent]) error {
+	return status.Errorf(codes.Unimplemented, "method WatchAssets not implemented")
+}
+func (UnimplementedAssetRegistryServer) SetParent(context.Context, *SetParentRequest) (*SetParentResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetParent not implemented")
+}
+func (UnimplementedAssetRegistryServer) ConnectAssets(context.Context, *ConnectAssetsRequest) (*ConnectAssetsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ConnectAssets not implemented")
+}
+func (UnimplementedAssetRegistryServer) DisconnectAssets(context.Context, *DisconnectAssetsRequest) (*DisconnectAssetsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DisconnectAssets not implemented")
+}
+func (UnimplementedAssetRegistryServer) GetSubtree(context.Context, *GetSubtreeRequest) (*GetSubtreeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSubtree not implemented")
+}
+func (UnimplementedAssetRegistryServer) GetAncestors(context.Context, *GetAncestorsRequest) (*GetAncestorsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAncestors not implemented")
+}
+func (UnimplementedAssetRegistryServer) GetConnected(context.Context, *GetConnectedRequest) (*GetConnectedResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetConnected not implemented")
+}
+func (UnimplementedAssetRegistryServer) ListAssetTypes(context.Context, *ListAssetTypesRequest) (*ListAssetTypesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListAssetTypes not implemented")
+}
+func (UnimplementedAssetRegistryServer) ImportAssets(context.Context, *ImportAssetsRequest) (*ImportAssetsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ImportAssets not implemented")
+}
+func (UnimplementedAssetRegistryServer) ExportAssets(*ExportAssetsRequest, grpc.ServerStreamingServer[Asset]) error {
+	return status.Errorf(codes.Unimplemented, "method ExportAssets not implemented")
+}
 func (UnimplementedAssetRegistryServer) mustEmbedUnimplementedAssetRegistryServer() {}
 func (UnimplementedAssetRegistryServer) testEmbeddedByValue()                       {}
 
@@ -172,6 +394,208 @@ func _AssetRegistry_ListAssets_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _AssetRegistry_UpdateAsset_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateAssetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AssetRegistryServer).UpdateAsset(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AssetRegistry_UpdateAsset_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AssetRegistryServer).UpdateAsset(ctx, req.(*UpdateAssetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AssetRegistry_DeleteAsset_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteAssetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AssetRegistryServer).DeleteAsset(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AssetRegistry_DeleteAsset_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AssetRegistryServer).DeleteAsset(ctx, req.(*DeleteAssetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AssetRegistry_WatchAssets_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchAssetsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(AssetRegistryServer).WatchAssets(m, &grpc.GenericServerStream[WatchAssetsRequest, AssetEvent]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type AssetRegistry_WatchAssetsServer = grpc.ServerStreamingServer[AssetEvent]
+
+func _AssetRegistry_SetParent_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetParentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AssetRegistryServer).SetParent(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AssetRegistry_SetParent_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AssetRegistryServer).SetParent(ctx, req.(*SetParentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AssetRegistry_ConnectAssets_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ConnectAssetsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AssetRegistryServer).ConnectAssets(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AssetRegistry_ConnectAssets_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AssetRegistryServer).ConnectAssets(ctx, req.(*ConnectAssetsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AssetRegistry_DisconnectAssets_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DisconnectAssetsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AssetRegistryServer).DisconnectAssets(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AssetRegistry_DisconnectAssets_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AssetRegistryServer).DisconnectAssets(ctx, req.(*DisconnectAssetsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AssetRegistry_GetSubtree_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSubtreeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AssetRegistryServer).GetSubtree(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AssetRegistry_GetSubtree_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AssetRegistryServer).GetSubtree(ctx, req.(*GetSubtreeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AssetRegistry_GetAncestors_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAncestorsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AssetRegistryServer).GetAncestors(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AssetRegistry_GetAncestors_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AssetRegistryServer).GetAncestors(ctx, req.(*GetAncestorsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AssetRegistry_GetConnected_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetConnectedRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AssetRegistryServer).GetConnected(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AssetRegistry_GetConnected_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AssetRegistryServer).GetConnected(ctx, req.(*GetConnectedRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AssetRegistry_ListAssetTypes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListAssetTypesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AssetRegistryServer).ListAssetTypes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AssetRegistry_ListAssetTypes_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AssetRegistryServer).ListAssetTypes(ctx, req.(*ListAssetTypesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AssetRegistry_ImportAssets_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ImportAssetsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AssetRegistryServer).ImportAssets(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AssetRegistry_ImportAssets_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AssetRegistryServer).ImportAssets(ctx, req.(*ImportAssetsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AssetRegistry_ExportAssets_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ExportAssetsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(AssetRegistryServer).ExportAssets(m, &grpc.GenericServerStream[ExportAssetsRequest, Asset]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type AssetRegistry_ExportAssetsServer = grpc.ServerStreamingServer[Asset]
+
 // AssetRegistry_ServiceDesc is the grpc.ServiceDesc for AssetRegistry service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -191,7 +615,58 @@ var AssetRegistry_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ListAssets",
 			Handler:    _AssetRegistry_ListAssets_Handler,
 		},
+		{
+			MethodName: "UpdateAsset",
+			Handler:    _AssetRegistry_UpdateAsset_Handler,
+		},
+		{
+			MethodName: "DeleteAsset",
+			Handler:    _AssetRegistry_DeleteAsset_Handler,
+		},
+		{
+			MethodName: "SetParent",
+			Handler:    _AssetRegistry_SetParent_Handler,
+		},
+		{
+			MethodName: "ConnectAssets",
+			Handler:    _AssetRegistry_ConnectAssets_Handler,
+		},
+		{
+			MethodName: "DisconnectAssets",
+			Handler:    _AssetRegistry_DisconnectAssets_Handler,
+		},
+		{
+			MethodName: "GetSubtree",
+			Handler:    _AssetRegistry_GetSubtree_Handler,
+		},
+		{
+			MethodName: "GetAncestors",
+			Handler:    _AssetRegistry_GetAncestors_Handler,
+		},
+		{
+			MethodName: "GetConnected",
+			Handler:    _AssetRegistry_GetConnected_Handler,
+		},
+		{
+			MethodName: "ListAssetTypes",
+			Handler:    _AssetRegistry_ListAssetTypes_Handler,
+		},
+		{
+			MethodName: "ImportAssets",
+			Handler:    _AssetRegistry_ImportAssets_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchAssets",
+			Handler:       _AssetRegistry_WatchAssets_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ExportAssets",
+			Handler:       _AssetRegistry_ExportAssets_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "proto/asset/asset.proto",
 }
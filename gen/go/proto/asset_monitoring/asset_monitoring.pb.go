@@ -25,11 +25,12 @@ const (
 type AssetStatus int32
 
 const (
-	AssetStatus_UNKNOWN  AssetStatus = 0
-	AssetStatus_ONLINE   AssetStatus = 1
-	AssetStatus_OFFLINE  AssetStatus = 2
-	AssetStatus_DEGRADED AssetStatus = 3
-	AssetStatus_ERROR    AssetStatus = 4
+	AssetStatus_UNKNOWN     AssetStatus = 0
+	AssetStatus_ONLINE      AssetStatus = 1
+	AssetStatus_OFFLINE     AssetStatus = 2
+	AssetStatus_DEGRADED    AssetStatus = 3
+	AssetStatus_ERROR       AssetStatus = 4
+	AssetStatus_MAINTENANCE AssetStatus = 5
 )
 
 // Enum value maps for AssetStatus.
@@ -40,13 +41,15 @@ var (
 		2: "OFFLINE",
 		3: "DEGRADED",
 		4: "ERROR",
+		5: "MAINTENANCE",
 	}
 	AssetStatus_value = map[string]int32{
-		"UNKNOWN":  0,
-		"ONLINE":   1,
-		"OFFLINE":  2,
-		"DEGRADED": 3,
-		"ERROR":    4,
+		"UNKNOWN":     0,
+		"ONLINE":      1,
+		"OFFLINE":     2,
+		"DEGRADED":    3,
+		"ERROR":       4,
+		"MAINTENANCE": 5,
 	}
 )
 
@@ -129,6 +132,116 @@ func (AssetType) EnumDescriptor() ([]byte, []int) {
 	return file_proto_asset_monitoring_asset_monitoring_proto_rawDescGZIP(), []int{1}
 }
 
+type PowerQualityEventType int32
+
+const (
+	PowerQualityEventType_PQ_EVENT_UNKNOWN    PowerQualityEventType = 0
+	PowerQualityEventType_VOLTAGE_SAG         PowerQualityEventType = 1 // 0.1-0.9 pu
+	PowerQualityEventType_VOLTAGE_SWELL       PowerQualityEventType = 2 // >1.1 pu
+	PowerQualityEventType_INTERRUPTION        PowerQualityEventType = 3 // <0.1 pu
+	PowerQualityEventType_FREQUENCY_EXCURSION PowerQualityEventType = 4
+	PowerQualityEventType_LOW_POWER_FACTOR    PowerQualityEventType = 5
+	PowerQualityEventType_PHASE_IMBALANCE     PowerQualityEventType = 6
+)
+
+// Enum value maps for PowerQualityEventType.
+var (
+	PowerQualityEventType_name = map[int32]string{
+		0: "PQ_EVENT_UNKNOWN",
+		1: "VOLTAGE_SAG",
+		2: "VOLTAGE_SWELL",
+		3: "INTERRUPTION",
+		4: "FREQUENCY_EXCURSION",
+		5: "LOW_POWER_FACTOR",
+		6: "PHASE_IMBALANCE",
+	}
+	PowerQualityEventType_value = map[string]int32{
+		"PQ_EVENT_UNKNOWN":    0,
+		"VOLTAGE_SAG":         1,
+		"VOLTAGE_SWELL":       2,
+		"INTERRUPTION":        3,
+		"FREQUENCY_EXCURSION": 4,
+		"LOW_POWER_FACTOR":    5,
+		"PHASE_IMBALANCE":     6,
+	}
+)
+
+func (x PowerQualityEventType) Enum() *PowerQualityEventType {
+	p := new(PowerQualityEventType)
+	*p = x
+	return p
+}
+
+func (x PowerQualityEventType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (PowerQualityEventType) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_asset_monitoring_asset_monitoring_proto_enumTypes[2].Descriptor()
+}
+
+func (PowerQualityEventType) Type() protoreflect.EnumType {
+	return &file_proto_asset_monitoring_asset_monitoring_proto_enumTypes[2]
+}
+
+func (x PowerQualityEventType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use PowerQualityEventType.Descriptor instead.
+func (PowerQualityEventType) EnumDescriptor() ([]byte, []int) {
+	return file_proto_asset_monitoring_asset_monitoring_proto_rawDescGZIP(), []int{2}
+}
+
+type Recurrence int32
+
+const (
+	Recurrence_RECURRENCE_NONE Recurrence = 0
+	Recurrence_DAILY           Recurrence = 1
+	Recurrence_WEEKLY          Recurrence = 2
+)
+
+// Enum value maps for Recurrence.
+var (
+	Recurrence_name = map[int32]string{
+		0: "RECURRENCE_NONE",
+		1: "DAILY",
+		2: "WEEKLY",
+	}
+	Recurrence_value = map[string]int32{
+		"RECURRENCE_NONE": 0,
+		"DAILY":           1,
+		"WEEKLY":          2,
+	}
+)
+
+func (x Recurrence) Enum() *Recurrence {
+	p := new(Recurrence)
+	*p = x
+	return p
+}
+
+func (x Recurrence) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Recurrence) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_asset_monitoring_asset_monitoring_proto_enumTypes[3].Descriptor()
+}
+
+func (Recurrence) Type() protoreflect.EnumType {
+	return &file_proto_asset_monitoring_asset_monitoring_proto_enumTypes[3]
+}
+
+func (x Recurrence) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Recurrence.Descriptor instead.
+func (Recurrence) EnumDescriptor() ([]byte, []int) {
+	return file_proto_asset_monitoring_asset_monitoring_proto_rawDescGZIP(), []int{3}
+}
+
 type StreamAssetStatusRequest struct {
 	state                 protoimpl.MessageState `protogen:"open.v1"`
 	AssetId               string                 `protobuf:"bytes,1,opt,name=asset_id,json=assetId,proto3" json:"asset_id,omitempty"`
@@ -194,7 +307,11 @@ type AssetStatusUpdate struct {
 	//	*AssetStatusUpdate_Electric
 	//	*AssetStatusUpdate_Chillwater
 	//	*AssetStatusUpdate_Steam
-	Readings      isAssetStatusUpdate_Readings `protobuf_oneof:"readings"`
+	Readings isAssetStatusUpdate_Readings `protobuf_oneof:"readings"`
+	// The registry type of the asset and its readings by name, for types
+	// without typed readings as well as those with them
+	AssetType     string            `protobuf:"bytes,8,opt,name=asset_type,json=assetType,proto3" json:"asset_type,omitempty"`
+	Channels      []*ChannelReading `protobuf:"bytes,9,rep,name=channels,proto3" json:"channels,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -291,6 +408,20 @@ func (x *AssetStatusUpdate) GetSteam() *SteamReadings {
 	return nil
 }
 
+func (x *AssetStatusUpdate) GetAssetType() string {
+	if x != nil {
+		return x.AssetType
+	}
+	return ""
+}
+
+func (x *AssetStatusUpdate) GetChannels() []*ChannelReading {
+	if x != nil {
+		return x.Channels
+	}
+	return nil
+}
+
 type isAssetStatusUpdate_Readings interface {
 	isAssetStatusUpdate_Readings()
 }
@@ -313,20 +444,89 @@ func (*AssetStatusUpdate_Chillwater) isAssetStatusUpdate_Readings() {}
 
 func (*AssetStatusUpdate_Steam) isAssetStatusUpdate_Readings() {}
 
-type ElectricReadings struct {
+type ChannelReading struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Voltage       float64                `protobuf:"fixed64,1,opt,name=voltage,proto3" json:"voltage,omitempty"`                            // Volts
-	Current       float64                `protobuf:"fixed64,2,opt,name=current,proto3" json:"current,omitempty"`                            // Amperes
-	Power         float64                `protobuf:"fixed64,3,opt,name=power,proto3" json:"power,omitempty"`                                // Kilowatts
-	Frequency     float64                `protobuf:"fixed64,4,opt,name=frequency,proto3" json:"frequency,omitempty"`                        // Hertz
-	PowerFactor   float64                `protobuf:"fixed64,5,opt,name=power_factor,json=powerFactor,proto3" json:"power_factor,omitempty"` // 0-1
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Value         float64                `protobuf:"fixed64,2,opt,name=value,proto3" json:"value,omitempty"`
+	Unit          string                 `protobuf:"bytes,3,opt,name=unit,proto3" json:"unit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ChannelReading) Reset() {
+	*x = ChannelReading{}
+	mi := &file_proto_asset_monitoring_asset_monitoring_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChannelReading) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChannelReading) ProtoMessage() {}
+
+func (x *ChannelReading) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_asset_monitoring_asset_monitoring_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChannelReading.ProtoReflect.Descriptor instead.
+func (*ChannelReading) Descriptor() ([]byte, []int) {
+	return file_proto_asset_monitoring_asset_monitoring_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ChannelReading) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ChannelReading) GetValue() float64 {
+	if x != nil {
+		return x.Value
+	}
+	return 0
+}
+
+func (x *ChannelReading) GetUnit() string {
+	if x != nil {
+		return x.Unit
+	}
+	return ""
+}
+
+type ElectricReadings struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Voltage     float64                `protobuf:"fixed64,1,opt,name=voltage,proto3" json:"voltage,omitempty"`                            // Volts
+	Current     float64                `protobuf:"fixed64,2,opt,name=current,proto3" json:"current,omitempty"`                            // Amperes
+	Power       float64                `protobuf:"fixed64,3,opt,name=power,proto3" json:"power,omitempty"`                                // Kilowatts
+	Frequency   float64                `protobuf:"fixed64,4,opt,name=frequency,proto3" json:"frequency,omitempty"`                        // Hertz
+	PowerFactor float64                `protobuf:"fixed64,5,opt,name=power_factor,json=powerFactor,proto3" json:"power_factor,omitempty"` // 0-1
+	Energy      float64                `protobuf:"fixed64,6,opt,name=energy,proto3" json:"energy,omitempty"`                              // Kilowatt-hours (cumulative)
+	Demand      float64                `protobuf:"fixed64,7,opt,name=demand,proto3" json:"demand,omitempty"`                              // Kilowatts (current 15-min interval average)
+	// Per-phase readings, zero when the asset is single-phase
+	VoltageA      float64 `protobuf:"fixed64,8,opt,name=voltage_a,json=voltageA,proto3" json:"voltage_a,omitempty"`  // Volts
+	VoltageB      float64 `protobuf:"fixed64,9,opt,name=voltage_b,json=voltageB,proto3" json:"voltage_b,omitempty"`  // Volts
+	VoltageC      float64 `protobuf:"fixed64,10,opt,name=voltage_c,json=voltageC,proto3" json:"voltage_c,omitempty"` // Volts
+	CurrentA      float64 `protobuf:"fixed64,11,opt,name=current_a,json=currentA,proto3" json:"current_a,omitempty"` // Amperes
+	CurrentB      float64 `protobuf:"fixed64,12,opt,name=current_b,json=currentB,proto3" json:"current_b,omitempty"` // Amperes
+	CurrentC      float64 `protobuf:"fixed64,13,opt,name=current_c,json=currentC,proto3" json:"current_c,omitempty"` // Amperes
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *ElectricReadings) Reset() {
 	*x = ElectricReadings{}
-	mi := &file_proto_asset_monitoring_asset_monitoring_proto_msgTypes[2]
+	mi := &file_proto_asset_monitoring_asset_monitoring_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -338,7 +538,7 @@ func (x *ElectricReadings) String() string {
 func (*ElectricReadings) ProtoMessage() {}
 
 func (x *ElectricReadings) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_asset_monitoring_asset_monitoring_proto_msgTypes[2]
+	mi := &file_proto_asset_monitoring_asset_monitoring_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -351,7 +551,7 @@ func (x *ElectricReadings) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ElectricReadings.ProtoReflect.Descriptor instead.
 func (*ElectricReadings) Descriptor() ([]byte, []int) {
-	return file_proto_asset_monitoring_asset_monitoring_proto_rawDescGZIP(), []int{2}
+	return file_proto_asset_monitoring_asset_monitoring_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *ElectricReadings) GetVoltage() float64 {
@@ -389,6 +589,62 @@ func (x *ElectricReadings) GetPowerFactor() float64 {
 	return 0
 }
 
+func (x *ElectricReadings) GetEnergy() float64 {
+	if x != nil {
+		return x.Energy
+	}
+	return 0
+}
+
+func (x *ElectricReadings) GetDemand() float64 {
+	if x != nil {
+		return x.Demand
+	}
+	return 0
+}
+
+func (x *ElectricReadings) GetVoltageA() float64 {
+	if x != nil {
+		return x.VoltageA
+	}
+	return 0
+}
+
+func (x *ElectricReadings) GetVoltageB() float64 {
+	if x != nil {
+		return x.VoltageB
+	}
+	return 0
+}
+
+func (x *ElectricReadings) GetVoltageC() float64 {
+	if x != nil {
+		return x.VoltageC
+	}
+	return 0
+}
+
+func (x *ElectricReadings) GetCurrentA() float64 {
+	if x != nil {
+		return x.CurrentA
+	}
+	return 0
+}
+
+func (x *ElectricReadings) GetCurrentB() float64 {
+	if x != nil {
+		return x.CurrentB
+	}
+	return 0
+}
+
+func (x *ElectricReadings) GetCurrentC() float64 {
+	if x != nil {
+		return x.CurrentC
+	}
+	return 0
+}
+
 type ChillWaterReadings struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	SupplyTemp    float64                `protobuf:"fixed64,1,opt,name=supply_temp,json=supplyTemp,proto3" json:"supply_temp,omitempty"` // Celsius
@@ -401,7 +657,7 @@ type ChillWaterReadings struct {
 
 func (x *ChillWaterReadings) Reset() {
 	*x = ChillWaterReadings{}
-	mi := &file_proto_asset_monitoring_asset_monitoring_proto_msgTypes[3]
+	mi := &file_proto_asset_monitoring_asset_monitoring_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -413,7 +669,7 @@ func (x *ChillWaterReadings) String() string {
 func (*ChillWaterReadings) ProtoMessage() {}
 
 func (x *ChillWaterReadings) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_asset_monitoring_asset_monitoring_proto_msgTypes[3]
+	mi := &file_proto_asset_monitoring_asset_monitoring_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -426,7 +682,7 @@ func (x *ChillWaterReadings) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ChillWaterReadings.ProtoReflect.Descriptor instead.
 func (*ChillWaterReadings) Descriptor() ([]byte, []int) {
-	return file_proto_asset_monitoring_asset_monitoring_proto_rawDescGZIP(), []int{3}
+	return file_proto_asset_monitoring_asset_monitoring_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *ChillWaterReadings) GetSupplyTemp() float64 {
@@ -469,7 +725,7 @@ type SteamReadings struct {
 
 func (x *SteamReadings) Reset() {
 	*x = SteamReadings{}
-	mi := &file_proto_asset_monitoring_asset_monitoring_proto_msgTypes[4]
+	mi := &file_proto_asset_monitoring_asset_monitoring_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -481,7 +737,7 @@ func (x *SteamReadings) String() string {
 func (*SteamReadings) ProtoMessage() {}
 
 func (x *SteamReadings) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_asset_monitoring_asset_monitoring_proto_msgTypes[4]
+	mi := &file_proto_asset_monitoring_asset_monitoring_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -494,7 +750,7 @@ func (x *SteamReadings) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SteamReadings.ProtoReflect.Descriptor instead.
 func (*SteamReadings) Descriptor() ([]byte, []int) {
-	return file_proto_asset_monitoring_asset_monitoring_proto_rawDescGZIP(), []int{4}
+	return file_proto_asset_monitoring_asset_monitoring_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *SteamReadings) GetPressure() float64 {
@@ -535,7 +791,7 @@ type SubscribeRequest struct {
 
 func (x *SubscribeRequest) Reset() {
 	*x = SubscribeRequest{}
-	mi := &file_proto_asset_monitoring_asset_monitoring_proto_msgTypes[5]
+	mi := &file_proto_asset_monitoring_asset_monitoring_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -547,7 +803,7 @@ func (x *SubscribeRequest) String() string {
 func (*SubscribeRequest) ProtoMessage() {}
 
 func (x *SubscribeRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_asset_monitoring_asset_monitoring_proto_msgTypes[5]
+	mi := &file_proto_asset_monitoring_asset_monitoring_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -560,7 +816,7 @@ func (x *SubscribeRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SubscribeRequest.ProtoReflect.Descriptor instead.
 func (*SubscribeRequest) Descriptor() ([]byte, []int) {
-	return file_proto_asset_monitoring_asset_monitoring_proto_rawDescGZIP(), []int{5}
+	return file_proto_asset_monitoring_asset_monitoring_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *SubscribeRequest) GetAssetIds() []string {
@@ -588,13 +844,15 @@ type ReadingUpdate struct {
 	//	*ReadingUpdate_Chillwater
 	//	*ReadingUpdate_Steam
 	Reading       isReadingUpdate_Reading `protobuf_oneof:"reading"`
+	AssetTypeName string                  `protobuf:"bytes,7,opt,name=asset_type_name,json=assetTypeName,proto3" json:"asset_type_name,omitempty"` // Registry type, see AssetStatusUpdate
+	Channels      []*ChannelReading       `protobuf:"bytes,8,rep,name=channels,proto3" json:"channels,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *ReadingUpdate) Reset() {
 	*x = ReadingUpdate{}
-	mi := &file_proto_asset_monitoring_asset_monitoring_proto_msgTypes[6]
+	mi := &file_proto_asset_monitoring_asset_monitoring_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -606,7 +864,7 @@ func (x *ReadingUpdate) String() string {
 func (*ReadingUpdate) ProtoMessage() {}
 
 func (x *ReadingUpdate) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_asset_monitoring_asset_monitoring_proto_msgTypes[6]
+	mi := &file_proto_asset_monitoring_asset_monitoring_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -619,7 +877,7 @@ func (x *ReadingUpdate) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ReadingUpdate.ProtoReflect.Descriptor instead.
 func (*ReadingUpdate) Descriptor() ([]byte, []int) {
-	return file_proto_asset_monitoring_asset_monitoring_proto_rawDescGZIP(), []int{6}
+	return file_proto_asset_monitoring_asset_monitoring_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *ReadingUpdate) GetAssetId() string {
@@ -677,6 +935,20 @@ func (x *ReadingUpdate) GetSteam() *SteamReadings {
 	return nil
 }
 
+func (x *ReadingUpdate) GetAssetTypeName() string {
+	if x != nil {
+		return x.AssetTypeName
+	}
+	return ""
+}
+
+func (x *ReadingUpdate) GetChannels() []*ChannelReading {
+	if x != nil {
+		return x.Channels
+	}
+	return nil
+}
+
 type isReadingUpdate_Reading interface {
 	isReadingUpdate_Reading()
 }
@@ -708,7 +980,7 @@ type GetStatusRequest struct {
 
 func (x *GetStatusRequest) Reset() {
 	*x = GetStatusRequest{}
-	mi := &file_proto_asset_monitoring_asset_monitoring_proto_msgTypes[7]
+	mi := &file_proto_asset_monitoring_asset_monitoring_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -720,7 +992,7 @@ func (x *GetStatusRequest) String() string {
 func (*GetStatusRequest) ProtoMessage() {}
 
 func (x *GetStatusRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_asset_monitoring_asset_monitoring_proto_msgTypes[7]
+	mi := &file_proto_asset_monitoring_asset_monitoring_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -733,7 +1005,7 @@ func (x *GetStatusRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetStatusRequest.ProtoReflect.Descriptor instead.
 func (*GetStatusRequest) Descriptor() ([]byte, []int) {
-	return file_proto_asset_monitoring_asset_monitoring_proto_rawDescGZIP(), []int{7}
+	return file_proto_asset_monitoring_asset_monitoring_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *GetStatusRequest) GetAssetId() string {
@@ -755,7 +1027,7 @@ type AssetStatusResponse struct {
 
 func (x *AssetStatusResponse) Reset() {
 	*x = AssetStatusResponse{}
-	mi := &file_proto_asset_monitoring_asset_monitoring_proto_msgTypes[8]
+	mi := &file_proto_asset_monitoring_asset_monitoring_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -767,7 +1039,7 @@ func (x *AssetStatusResponse) String() string {
 func (*AssetStatusResponse) ProtoMessage() {}
 
 func (x *AssetStatusResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_asset_monitoring_asset_monitoring_proto_msgTypes[8]
+	mi := &file_proto_asset_monitoring_asset_monitoring_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -780,7 +1052,7 @@ func (x *AssetStatusResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AssetStatusResponse.ProtoReflect.Descriptor instead.
 func (*AssetStatusResponse) Descriptor() ([]byte, []int) {
-	return file_proto_asset_monitoring_asset_monitoring_proto_rawDescGZIP(), []int{8}
+	return file_proto_asset_monitoring_asset_monitoring_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *AssetStatusResponse) GetAssetId() string {
@@ -811,83 +1083,798 @@ func (x *AssetStatusResponse) GetIsMonitoring() bool {
 	return false
 }
 
-var File_proto_asset_monitoring_asset_monitoring_proto protoreflect.FileDescriptor
+type PowerQualityEvent struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Id             string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	AssetId        string                 `protobuf:"bytes,2,opt,name=asset_id,json=assetId,proto3" json:"asset_id,omitempty"`
+	Type           PowerQualityEventType  `protobuf:"varint,3,opt,name=type,proto3,enum=asset_monitoring.PowerQualityEventType" json:"type,omitempty"`
+	Phase          string                 `protobuf:"bytes,4,opt,name=phase,proto3" json:"phase,omitempty"` // "A", "B", "C" or empty for the whole asset
+	StartTime      *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
+	EndTime        *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"` // Unset while ongoing
+	Magnitude      float64                `protobuf:"fixed64,7,opt,name=magnitude,proto3" json:"magnitude,omitempty"`          // pu for voltage, Hz deviation, power factor or % imbalance
+	Classification string                 `protobuf:"bytes,8,opt,name=classification,proto3" json:"classification,omitempty"`  // IEEE 1159 duration category, e.g. "momentary"
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
 
-const file_proto_asset_monitoring_asset_monitoring_proto_rawDesc = "" +
-	"\n" +
-	"-proto/asset_monitoring/asset_monitoring.proto\x12\x10asset_monitoring\x1a\x1fgoogle/protobuf/timestamp.proto\"m\n" +
-	"\x18StreamAssetStatusRequest\x12\x19\n" +
-	"\basset_id\x18\x01 \x01(\tR\aassetId\x126\n" +
-	"\x17update_interval_seconds\x18\x02 \x01(\x05R\x15updateIntervalSeconds\"\x88\x03\n" +
-	"\x11AssetStatusUpdate\x12\x19\n" +
-	"\basset_id\x18\x01 \x01(\tR\aassetId\x125\n" +
-	"\x06status\x18\x02 \x01(\x0e2\x1d.asset_monitoring.AssetStatusR\x06status\x128\n" +
-	"\ttimestamp\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\ttimestamp\x12\x18\n" +
-	"\amessage\x18\x04 \x01(\tR\amessage\x12@\n" +
-	"\belectric\x18\x05 \x01(\v2\".asset_monitoring.ElectricReadingsH\x00R\belectric\x12F\n" +
-	"\n" +
-	"chillwater\x18\x06 \x01(\v2$.asset_monitoring.ChillWaterReadingsH\x00R\n" +
-	"chillwater\x127\n" +
-	"\x05steam\x18\a \x01(\v2\x1f.asset_monitoring.SteamReadingsH\x00R\x05steamB\n" +
-	"\n" +
-	"\breadings\"\x9d\x01\n" +
-	"\x10ElectricReadings\x12\x18\n" +
-	"\avoltage\x18\x01 \x01(\x01R\avoltage\x12\x18\n" +
-	"\acurrent\x18\x02 \x01(\x01R\acurrent\x12\x14\n" +
-	"\x05power\x18\x03 \x01(\x01R\x05power\x12\x1c\n" +
-	"\tfrequency\x18\x04 \x01(\x01R\tfrequency\x12!\n" +
-	"\fpower_factor\x18\x05 \x01(\x01R\vpowerFactor\"\x8f\x01\n" +
-	"\x12ChillWaterReadings\x12\x1f\n" +
-	"\vsupply_temp\x18\x01 \x01(\x01R\n" +
-	"supplyTemp\x12\x1f\n" +
-	"\vreturn_temp\x18\x02 \x01(\x01R\n" +
-	"returnTemp\x12\x1a\n" +
-	"\bpressure\x18\x03 \x01(\x01R\bpressure\x12\x1b\n" +
-	"\tflow_rate\x18\x04 \x01(\x01R\bflowRate\"\x83\x01\n" +
-	"\rSteamReadings\x12\x1a\n" +
-	"\bpressure\x18\x01 \x01(\x01R\bpressure\x12 \n" +
-	"\vtemperature\x18\x02 \x01(\x01R\vtemperature\x12\x18\n" +
-	"\aquality\x18\x03 \x01(\x01R\aquality\x12\x1a\n" +
-	"\benthalpy\x18\x04 \x01(\x01R\benthalpy\"k\n" +
-	"\x10SubscribeRequest\x12\x1b\n" +
-	"\tasset_ids\x18\x01 \x03(\tR\bassetIds\x12:\n" +
-	"\n" +
-	"asset_type\x18\x02 \x01(\x0e2\x1b.asset_monitoring.AssetTypeR\tassetType\"\xee\x02\n" +
-	"\rReadingUpdate\x12\x19\n" +
-	"\basset_id\x18\x01 \x01(\tR\aassetId\x12:\n" +
-	"\n" +
-	"asset_type\x18\x02 \x01(\x0e2\x1b.asset_monitoring.AssetTypeR\tassetType\x128\n" +
-	"\ttimestamp\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\ttimestamp\x12@\n" +
-	"\belectric\x18\x04 \x01(\v2\".asset_monitoring.ElectricReadingsH\x00R\belectric\x12F\n" +
-	"\n" +
-	"chillwater\x18\x05 \x01(\v2$.asset_monitoring.ChillWaterReadingsH\x00R\n" +
-	"chillwater\x127\n" +
-	"\x05steam\x18\x06 \x01(\v2\x1f.asset_monitoring.SteamReadingsH\x00R\x05steamB\t\n" +
-	"\areading\"-\n" +
-	"\x10GetStatusRequest\x12\x19\n" +
-	"\basset_id\x18\x01 \x01(\tR\aassetId\"\xc6\x01\n" +
-	"\x13AssetStatusResponse\x12\x19\n" +
-	"\basset_id\x18\x01 \x01(\tR\aassetId\x125\n" +
-	"\x06status\x18\x02 \x01(\x0e2\x1d.asset_monitoring.AssetStatusR\x06status\x128\n" +
-	"\ttimestamp\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\ttimestamp\x12#\n" +
-	"\ris_monitoring\x18\x04 \x01(\bR\fisMonitoring*L\n" +
-	"\vAssetStatus\x12\v\n" +
-	"\aUNKNOWN\x10\x00\x12\n" +
-	"\n" +
-	"\x06ONLINE\x10\x01\x12\v\n" +
-	"\aOFFLINE\x10\x02\x12\f\n" +
-	"\bDEGRADED\x10\x03\x12\t\n" +
-	"\x05ERROR\x10\x04*L\n" +
-	"\tAssetType\x12\x16\n" +
+func (x *PowerQualityEvent) Reset() {
+	*x = PowerQualityEvent{}
+	mi := &file_proto_asset_monitoring_asset_monitoring_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PowerQualityEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PowerQualityEvent) ProtoMessage() {}
+
+func (x *PowerQualityEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_asset_monitoring_asset_monitoring_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PowerQualityEvent.ProtoReflect.Descriptor instead.
+func (*PowerQualityEvent) Descriptor() ([]byte, []int) {
+	return file_proto_asset_monitoring_asset_monitoring_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *PowerQualityEvent) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *PowerQualityEvent) GetAssetId() string {
+	if x != nil {
+		return x.AssetId
+	}
+	return ""
+}
+
+func (x *PowerQualityEvent) GetType() PowerQualityEventType {
+	if x != nil {
+		return x.Type
+	}
+	return PowerQualityEventType_PQ_EVENT_UNKNOWN
+}
+
+func (x *PowerQualityEvent) GetPhase() string {
+	if x != nil {
+		return x.Phase
+	}
+	return ""
+}
+
+func (x *PowerQualityEvent) GetStartTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartTime
+	}
+	return nil
+}
+
+func (x *PowerQualityEvent) GetEndTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EndTime
+	}
+	return nil
+}
+
+func (x *PowerQualityEvent) GetMagnitude() float64 {
+	if x != nil {
+		return x.Magnitude
+	}
+	return 0
+}
+
+func (x *PowerQualityEvent) GetClassification() string {
+	if x != nil {
+		return x.Classification
+	}
+	return ""
+}
+
+type ListPowerQualityEventsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AssetId       string                 `protobuf:"bytes,1,opt,name=asset_id,json=assetId,proto3" json:"asset_id,omitempty"`
+	StartTime     *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
+	EndTime       *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPowerQualityEventsRequest) Reset() {
+	*x = ListPowerQualityEventsRequest{}
+	mi := &file_proto_asset_monitoring_asset_monitoring_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPowerQualityEventsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPowerQualityEventsRequest) ProtoMessage() {}
+
+func (x *ListPowerQualityEventsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_asset_monitoring_asset_monitoring_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPowerQualityEventsRequest.ProtoReflect.Descriptor instead.
+func (*ListPowerQualityEventsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_asset_monitoring_asset_monitoring_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *ListPowerQualityEventsRequest) GetAssetId() string {
+	if x != nil {
+		return x.AssetId
+	}
+	return ""
+}
+
+func (x *ListPowerQualityEventsRequest) GetStartTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartTime
+	}
+	return nil
+}
+
+func (x *ListPowerQualityEventsRequest) GetEndTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EndTime
+	}
+	return nil
+}
+
+type ListPowerQualityEventsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Events        []*PowerQualityEvent   `protobuf:"bytes,1,rep,name=events,proto3" json:"events,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPowerQualityEventsResponse) Reset() {
+	*x = ListPowerQualityEventsResponse{}
+	mi := &file_proto_asset_monitoring_asset_monitoring_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPowerQualityEventsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPowerQualityEventsResponse) ProtoMessage() {}
+
+func (x *ListPowerQualityEventsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_asset_monitoring_asset_monitoring_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPowerQualityEventsResponse.ProtoReflect.Descriptor instead.
+func (*ListPowerQualityEventsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_asset_monitoring_asset_monitoring_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *ListPowerQualityEventsResponse) GetEvents() []*PowerQualityEvent {
+	if x != nil {
+		return x.Events
+	}
+	return nil
+}
+
+type MaintenanceWindow struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Id              string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	AssetId         string                 `protobuf:"bytes,2,opt,name=asset_id,json=assetId,proto3" json:"asset_id,omitempty"`                                                              // Either asset_id or selector is required
+	Selector        map[string]string      `protobuf:"bytes,3,rep,name=selector,proto3" json:"selector,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // Asset metadata that must all match
+	StartTime       *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
+	DurationSeconds int32                  `protobuf:"varint,5,opt,name=duration_seconds,json=durationSeconds,proto3" json:"duration_seconds,omitempty"`
+	Recurrence      Recurrence             `protobuf:"varint,6,opt,name=recurrence,proto3,enum=asset_monitoring.Recurrence" json:"recurrence,omitempty"`
+	RecurrenceEnd   *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=recurrence_end,json=recurrenceEnd,proto3" json:"recurrence_end,omitempty"` // Optional: last occurrence start
+	Timezone        string                 `protobuf:"bytes,8,opt,name=timezone,proto3" json:"timezone,omitempty"`                                // IANA name, recurrences keep local wall-clock time
+	Owner           string                 `protobuf:"bytes,9,opt,name=owner,proto3" json:"owner,omitempty"`
+	Comment         string                 `protobuf:"bytes,10,opt,name=comment,proto3" json:"comment,omitempty"`
+	CreatedAt       *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *MaintenanceWindow) Reset() {
+	*x = MaintenanceWindow{}
+	mi := &file_proto_asset_monitoring_asset_monitoring_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MaintenanceWindow) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MaintenanceWindow) ProtoMessage() {}
+
+func (x *MaintenanceWindow) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_asset_monitoring_asset_monitoring_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MaintenanceWindow.ProtoReflect.Descriptor instead.
+func (*MaintenanceWindow) Descriptor() ([]byte, []int) {
+	return file_proto_asset_monitoring_asset_monitoring_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *MaintenanceWindow) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *MaintenanceWindow) GetAssetId() string {
+	if x != nil {
+		return x.AssetId
+	}
+	return ""
+}
+
+func (x *MaintenanceWindow) GetSelector() map[string]string {
+	if x != nil {
+		return x.Selector
+	}
+	return nil
+}
+
+func (x *MaintenanceWindow) GetStartTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartTime
+	}
+	return nil
+}
+
+func (x *MaintenanceWindow) GetDurationSeconds() int32 {
+	if x != nil {
+		return x.DurationSeconds
+	}
+	return 0
+}
+
+func (x *MaintenanceWindow) GetRecurrence() Recurrence {
+	if x != nil {
+		return x.Recurrence
+	}
+	return Recurrence_RECURRENCE_NONE
+}
+
+func (x *MaintenanceWindow) GetRecurrenceEnd() *timestamppb.Timestamp {
+	if x != nil {
+		return x.RecurrenceEnd
+	}
+	return nil
+}
+
+func (x *MaintenanceWindow) GetTimezone() string {
+	if x != nil {
+		return x.Timezone
+	}
+	return ""
+}
+
+func (x *MaintenanceWindow) GetOwner() string {
+	if x != nil {
+		return x.Owner
+	}
+	return ""
+}
+
+func (x *MaintenanceWindow) GetComment() string {
+	if x != nil {
+		return x.Comment
+	}
+	return ""
+}
+
+func (x *MaintenanceWindow) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+type ScheduleMaintenanceRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Window        *MaintenanceWindow     `protobuf:"bytes,1,opt,name=window,proto3" json:"window,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ScheduleMaintenanceRequest) Reset() {
+	*x = ScheduleMaintenanceRequest{}
+	mi := &file_proto_asset_monitoring_asset_monitoring_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ScheduleMaintenanceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ScheduleMaintenanceRequest) ProtoMessage() {}
+
+func (x *ScheduleMaintenanceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_asset_monitoring_asset_monitoring_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ScheduleMaintenanceRequest.ProtoReflect.Descriptor instead.
+func (*ScheduleMaintenanceRequest) Descriptor() ([]byte, []int) {
+	return file_proto_asset_monitoring_asset_monitoring_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *ScheduleMaintenanceRequest) GetWindow() *MaintenanceWindow {
+	if x != nil {
+		return x.Window
+	}
+	return nil
+}
+
+type ScheduleMaintenanceResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Window        *MaintenanceWindow     `protobuf:"bytes,1,opt,name=window,proto3" json:"window,omitempty"`
+	Success       bool                   `protobuf:"varint,2,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ScheduleMaintenanceResponse) Reset() {
+	*x = ScheduleMaintenanceResponse{}
+	mi := &file_proto_asset_monitoring_asset_monitoring_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ScheduleMaintenanceResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ScheduleMaintenanceResponse) ProtoMessage() {}
+
+func (x *ScheduleMaintenanceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_asset_monitoring_asset_monitoring_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ScheduleMaintenanceResponse.ProtoReflect.Descriptor instead.
+func (*ScheduleMaintenanceResponse) Descriptor() ([]byte, []int) {
+	return file_proto_asset_monitoring_asset_monitoring_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *ScheduleMaintenanceResponse) GetWindow() *MaintenanceWindow {
+	if x != nil {
+		return x.Window
+	}
+	return nil
+}
+
+func (x *ScheduleMaintenanceResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *ScheduleMaintenanceResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type ListMaintenanceWindowsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AssetId       string                 `protobuf:"bytes,1,opt,name=asset_id,json=assetId,proto3" json:"asset_id,omitempty"` // Optional: only windows applying to this asset
+	ActiveOnly    bool                   `protobuf:"varint,2,opt,name=active_only,json=activeOnly,proto3" json:"active_only,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListMaintenanceWindowsRequest) Reset() {
+	*x = ListMaintenanceWindowsRequest{}
+	mi := &file_proto_asset_monitoring_asset_monitoring_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListMaintenanceWindowsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListMaintenanceWindowsRequest) ProtoMessage() {}
+
+func (x *ListMaintenanceWindowsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_asset_monitoring_asset_monitoring_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListMaintenanceWindowsRequest.ProtoReflect.Descriptor instead.
+func (*ListMaintenanceWindowsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_asset_monitoring_asset_monitoring_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *ListMaintenanceWindowsRequest) GetAssetId() string {
+	if x != nil {
+		return x.AssetId
+	}
+	return ""
+}
+
+func (x *ListMaintenanceWindowsRequest) GetActiveOnly() bool {
+	if x != nil {
+		return x.ActiveOnly
+	}
+	return false
+}
+
+type ListMaintenanceWindowsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Windows       []*MaintenanceWindow   `protobuf:"bytes,1,rep,name=windows,proto3" json:"windows,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListMaintenanceWindowsResponse) Reset() {
+	*x = ListMaintenanceWindowsResponse{}
+	mi := &file_proto_asset_monitoring_asset_monitoring_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListMaintenanceWindowsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListMaintenanceWindowsResponse) ProtoMessage() {}
+
+func (x *ListMaintenanceWindowsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_asset_monitoring_asset_monitoring_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListMaintenanceWindowsResponse.ProtoReflect.Descriptor instead.
+func (*ListMaintenanceWindowsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_asset_monitoring_asset_monitoring_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *ListMaintenanceWindowsResponse) GetWindows() []*MaintenanceWindow {
+	if x != nil {
+		return x.Windows
+	}
+	return nil
+}
+
+type CancelMaintenanceRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelMaintenanceRequest) Reset() {
+	*x = CancelMaintenanceRequest{}
+	mi := &file_proto_asset_monitoring_asset_monitoring_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelMaintenanceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelMaintenanceRequest) ProtoMessage() {}
+
+func (x *CancelMaintenanceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_asset_monitoring_asset_monitoring_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelMaintenanceRequest.ProtoReflect.Descriptor instead.
+func (*CancelMaintenanceRequest) Descriptor() ([]byte, []int) {
+	return file_proto_asset_monitoring_asset_monitoring_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *CancelMaintenanceRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type CancelMaintenanceResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelMaintenanceResponse) Reset() {
+	*x = CancelMaintenanceResponse{}
+	mi := &file_proto_asset_monitoring_asset_monitoring_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelMaintenanceResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelMaintenanceResponse) ProtoMessage() {}
+
+func (x *CancelMaintenanceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_asset_monitoring_asset_monitoring_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelMaintenanceResponse.ProtoReflect.Descriptor instead.
+func (*CancelMaintenanceResponse) Descriptor() ([]byte, []int) {
+	return file_proto_asset_monitoring_asset_monitoring_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *CancelMaintenanceResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *CancelMaintenanceResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+var File_proto_asset_monitoring_asset_monitoring_proto protoreflect.FileDescriptor
+
+const file_proto_asset_monitoring_asset_monitoring_proto_rawDesc = "" +
+	"\n" +
+	"-proto/asset_monitoring/asset_monitoring.proto\x12\x10asset_monitoring\x1a\x1fgoogle/protobuf/timestamp.proto\"m\n" +
+	"\x18StreamAssetStatusRequest\x12\x19\n" +
+	"\basset_id\x18\x01 \x01(\tR\aassetId\x126\n" +
+	"\x17update_interval_seconds\x18\x02 \x01(\x05R\x15updateIntervalSeconds\"\xe5\x03\n" +
+	"\x11AssetStatusUpdate\x12\x19\n" +
+	"\basset_id\x18\x01 \x01(\tR\aassetId\x125\n" +
+	"\x06status\x18\x02 \x01(\x0e2\x1d.asset_monitoring.AssetStatusR\x06status\x128\n" +
+	"\ttimestamp\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\ttimestamp\x12\x18\n" +
+	"\amessage\x18\x04 \x01(\tR\amessage\x12@\n" +
+	"\belectric\x18\x05 \x01(\v2\".asset_monitoring.ElectricReadingsH\x00R\belectric\x12F\n" +
+	"\n" +
+	"chillwater\x18\x06 \x01(\v2$.asset_monitoring.ChillWaterReadingsH\x00R\n" +
+	"chillwater\x127\n" +
+	"\x05steam\x18\a \x01(\v2\x1f.asset_monitoring.SteamReadingsH\x00R\x05steam\x12\x1d\n" +
+	"\n" +
+	"asset_type\x18\b \x01(\tR\tassetType\x12<\n" +
+	"\bchannels\x18\t \x03(\v2 .asset_monitoring.ChannelReadingR\bchannelsB\n" +
+	"\n" +
+	"\breadings\"N\n" +
+	"\x0eChannelReading\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x01R\x05value\x12\x12\n" +
+	"\x04unit\x18\x03 \x01(\tR\x04unit\"\xfb\x02\n" +
+	"\x10ElectricReadings\x12\x18\n" +
+	"\avoltage\x18\x01 \x01(\x01R\avoltage\x12\x18\n" +
+	"\acurrent\x18\x02 \x01(\x01R\acurrent\x12\x14\n" +
+	"\x05power\x18\x03 \x01(\x01R\x05power\x12\x1c\n" +
+	"\tfrequency\x18\x04 \x01(\x01R\tfrequency\x12!\n" +
+	"\fpower_factor\x18\x05 \x01(\x01R\vpowerFactor\x12\x16\n" +
+	"\x06energy\x18\x06 \x01(\x01R\x06energy\x12\x16\n" +
+	"\x06demand\x18\a \x01(\x01R\x06demand\x12\x1b\n" +
+	"\tvoltage_a\x18\b \x01(\x01R\bvoltageA\x12\x1b\n" +
+	"\tvoltage_b\x18\t \x01(\x01R\bvoltageB\x12\x1b\n" +
+	"\tvoltage_c\x18\n" +
+	" \x01(\x01R\bvoltageC\x12\x1b\n" +
+	"\tcurrent_a\x18\v \x01(\x01R\bcurrentA\x12\x1b\n" +
+	"\tcurrent_b\x18\f \x01(\x01R\bcurrentB\x12\x1b\n" +
+	"\tcurrent_c\x18\r \x01(\x01R\bcurrentC\"\x8f\x01\n" +
+	"\x12ChillWaterReadings\x12\x1f\n" +
+	"\vsupply_temp\x18\x01 \x01(\x01R\n" +
+	"supplyTemp\x12\x1f\n" +
+	"\vreturn_temp\x18\x02 \x01(\x01R\n" +
+	"returnTemp\x12\x1a\n" +
+	"\bpressure\x18\x03 \x01(\x01R\bpressure\x12\x1b\n" +
+	"\tflow_rate\x18\x04 \x01(\x01R\bflowRate\"\x83\x01\n" +
+	"\rSteamReadings\x12\x1a\n" +
+	"\bpressure\x18\x01 \x01(\x01R\bpressure\x12 \n" +
+	"\vtemperature\x18\x02 \x01(\x01R\vtemperature\x12\x18\n" +
+	"\aquality\x18\x03 \x01(\x01R\aquality\x12\x1a\n" +
+	"\benthalpy\x18\x04 \x01(\x01R\benthalpy\"k\n" +
+	"\x10SubscribeRequest\x12\x1b\n" +
+	"\tasset_ids\x18\x01 \x03(\tR\bassetIds\x12:\n" +
+	"\n" +
+	"asset_type\x18\x02 \x01(\x0e2\x1b.asset_monitoring.AssetTypeR\tassetType\"\xd4\x03\n" +
+	"\rReadingUpdate\x12\x19\n" +
+	"\basset_id\x18\x01 \x01(\tR\aassetId\x12:\n" +
+	"\n" +
+	"asset_type\x18\x02 \x01(\x0e2\x1b.asset_monitoring.AssetTypeR\tassetType\x128\n" +
+	"\ttimestamp\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\ttimestamp\x12@\n" +
+	"\belectric\x18\x04 \x01(\v2\".asset_monitoring.ElectricReadingsH\x00R\belectric\x12F\n" +
+	"\n" +
+	"chillwater\x18\x05 \x01(\v2$.asset_monitoring.ChillWaterReadingsH\x00R\n" +
+	"chillwater\x127\n" +
+	"\x05steam\x18\x06 \x01(\v2\x1f.asset_monitoring.SteamReadingsH\x00R\x05steam\x12&\n" +
+	"\x0fasset_type_name\x18\a \x01(\tR\rassetTypeName\x12<\n" +
+	"\bchannels\x18\b \x03(\v2 .asset_monitoring.ChannelReadingR\bchannelsB\t\n" +
+	"\areading\"-\n" +
+	"\x10GetStatusRequest\x12\x19\n" +
+	"\basset_id\x18\x01 \x01(\tR\aassetId\"\xc6\x01\n" +
+	"\x13AssetStatusResponse\x12\x19\n" +
+	"\basset_id\x18\x01 \x01(\tR\aassetId\x125\n" +
+	"\x06status\x18\x02 \x01(\x0e2\x1d.asset_monitoring.AssetStatusR\x06status\x128\n" +
+	"\ttimestamp\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\ttimestamp\x12#\n" +
+	"\ris_monitoring\x18\x04 \x01(\bR\fisMonitoring\"\xc9\x02\n" +
+	"\x11PowerQualityEvent\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x19\n" +
+	"\basset_id\x18\x02 \x01(\tR\aassetId\x12;\n" +
+	"\x04type\x18\x03 \x01(\x0e2'.asset_monitoring.PowerQualityEventTypeR\x04type\x12\x14\n" +
+	"\x05phase\x18\x04 \x01(\tR\x05phase\x129\n" +
+	"\n" +
+	"start_time\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tstartTime\x125\n" +
+	"\bend_time\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\aendTime\x12\x1c\n" +
+	"\tmagnitude\x18\a \x01(\x01R\tmagnitude\x12&\n" +
+	"\x0eclassification\x18\b \x01(\tR\x0eclassification\"\xac\x01\n" +
+	"\x1dListPowerQualityEventsRequest\x12\x19\n" +
+	"\basset_id\x18\x01 \x01(\tR\aassetId\x129\n" +
+	"\n" +
+	"start_time\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\tstartTime\x125\n" +
+	"\bend_time\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\aendTime\"]\n" +
+	"\x1eListPowerQualityEventsResponse\x12;\n" +
+	"\x06events\x18\x01 \x03(\v2#.asset_monitoring.PowerQualityEventR\x06events\"\xb8\x04\n" +
+	"\x11MaintenanceWindow\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x19\n" +
+	"\basset_id\x18\x02 \x01(\tR\aassetId\x12M\n" +
+	"\bselector\x18\x03 \x03(\v21.asset_monitoring.MaintenanceWindow.SelectorEntryR\bselector\x129\n" +
+	"\n" +
+	"start_time\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\tstartTime\x12)\n" +
+	"\x10duration_seconds\x18\x05 \x01(\x05R\x0fdurationSeconds\x12<\n" +
+	"\n" +
+	"recurrence\x18\x06 \x01(\x0e2\x1c.asset_monitoring.RecurrenceR\n" +
+	"recurrence\x12A\n" +
+	"\x0erecurrence_end\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\rrecurrenceEnd\x12\x1a\n" +
+	"\btimezone\x18\b \x01(\tR\btimezone\x12\x14\n" +
+	"\x05owner\x18\t \x01(\tR\x05owner\x12\x18\n" +
+	"\acomment\x18\n" +
+	" \x01(\tR\acomment\x129\n" +
+	"\n" +
+	"created_at\x18\v \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x1a;\n" +
+	"\rSelectorEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"Y\n" +
+	"\x1aScheduleMaintenanceRequest\x12;\n" +
+	"\x06window\x18\x01 \x01(\v2#.asset_monitoring.MaintenanceWindowR\x06window\"\x8e\x01\n" +
+	"\x1bScheduleMaintenanceResponse\x12;\n" +
+	"\x06window\x18\x01 \x01(\v2#.asset_monitoring.MaintenanceWindowR\x06window\x12\x18\n" +
+	"\asuccess\x18\x02 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x03 \x01(\tR\amessage\"[\n" +
+	"\x1dListMaintenanceWindowsRequest\x12\x19\n" +
+	"\basset_id\x18\x01 \x01(\tR\aassetId\x12\x1f\n" +
+	"\vactive_only\x18\x02 \x01(\bR\n" +
+	"activeOnly\"_\n" +
+	"\x1eListMaintenanceWindowsResponse\x12=\n" +
+	"\awindows\x18\x01 \x03(\v2#.asset_monitoring.MaintenanceWindowR\awindows\"*\n" +
+	"\x18CancelMaintenanceRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"O\n" +
+	"\x19CancelMaintenanceResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage*]\n" +
+	"\vAssetStatus\x12\v\n" +
+	"\aUNKNOWN\x10\x00\x12\n" +
+	"\n" +
+	"\x06ONLINE\x10\x01\x12\v\n" +
+	"\aOFFLINE\x10\x02\x12\f\n" +
+	"\bDEGRADED\x10\x03\x12\t\n" +
+	"\x05ERROR\x10\x04\x12\x0f\n" +
+	"\vMAINTENANCE\x10\x05*L\n" +
+	"\tAssetType\x12\x16\n" +
 	"\x12ASSET_TYPE_UNKNOWN\x10\x00\x12\f\n" +
 	"\bELECTRIC\x10\x01\x12\x0e\n" +
 	"\n" +
 	"CHILLWATER\x10\x02\x12\t\n" +
-	"\x05STEAM\x10\x032\xbd\x02\n" +
+	"\x05STEAM\x10\x03*\xa7\x01\n" +
+	"\x15PowerQualityEventType\x12\x14\n" +
+	"\x10PQ_EVENT_UNKNOWN\x10\x00\x12\x0f\n" +
+	"\vVOLTAGE_SAG\x10\x01\x12\x11\n" +
+	"\rVOLTAGE_SWELL\x10\x02\x12\x10\n" +
+	"\fINTERRUPTION\x10\x03\x12\x17\n" +
+	"\x13FREQUENCY_EXCURSION\x10\x04\x12\x14\n" +
+	"\x10LOW_POWER_FACTOR\x10\x05\x12\x13\n" +
+	"\x0fPHASE_IMBALANCE\x10\x06*8\n" +
+	"\n" +
+	"Recurrence\x12\x13\n" +
+	"\x0fRECURRENCE_NONE\x10\x00\x12\t\n" +
+	"\x05DAILY\x10\x01\x12\n" +
+	"\n" +
+	"\x06WEEKLY\x10\x022\x99\x06\n" +
 	"\x16AssetMonitoringService\x12f\n" +
 	"\x11StreamAssetStatus\x12*.asset_monitoring.StreamAssetStatusRequest\x1a#.asset_monitoring.AssetStatusUpdate0\x01\x12\\\n" +
 	"\x13SubscribeToReadings\x12\".asset_monitoring.SubscribeRequest\x1a\x1f.asset_monitoring.ReadingUpdate0\x01\x12]\n" +
-	"\x10GetCurrentStatus\x12\".asset_monitoring.GetStatusRequest\x1a%.asset_monitoring.AssetStatusResponseB5Z3github.com/yourorg/grpc-demo/proto/asset_monitoringb\x06proto3"
+	"\x10GetCurrentStatus\x12\".asset_monitoring.GetStatusRequest\x1a%.asset_monitoring.AssetStatusResponse\x12{\n" +
+	"\x16ListPowerQualityEvents\x12/.asset_monitoring.ListPowerQualityEventsRequest\x1a0.asset_monitoring.ListPowerQualityEventsResponse\x12r\n" +
+	"\x13ScheduleMaintenance\x12,.asset_monitoring.ScheduleMaintenanceRequest\x1a-.asset_monitoring.ScheduleMaintenanceResponse\x12{\n" +
+	"\x16ListMaintenanceWindows\x12/.asset_monitoring.ListMaintenanceWindowsRequest\x1a0.asset_monitoring.ListMaintenanceWindowsResponse\x12l\n" +
+	"\x11CancelMaintenance\x12*.asset_monitoring.CancelMaintenanceRequest\x1a+.asset_monitoring.CancelMaintenanceResponseB5Z3github.com/yourorg/grpc-demo/proto/asset_monitoringb\x06proto3"
 
 var (
 	file_proto_asset_monitoring_asset_monitoring_proto_rawDescOnce sync.Once
@@ -901,47 +1888,85 @@ func file_proto_asset_monitoring_asset_monitoring_proto_rawDescGZIP() []byte {
 	return file_proto_asset_monitoring_asset_monitoring_proto_rawDescData
 }
 
-var file_proto_asset_monitoring_asset_monitoring_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
-var file_proto_asset_monitoring_asset_monitoring_proto_msgTypes = make([]protoimpl.MessageInfo, 9)
+var file_proto_asset_monitoring_asset_monitoring_proto_enumTypes = make([]protoimpl.EnumInfo, 4)
+var file_proto_asset_monitoring_asset_monitoring_proto_msgTypes = make([]protoimpl.MessageInfo, 21)
 var file_proto_asset_monitoring_asset_monitoring_proto_goTypes = []any{
-	(AssetStatus)(0),                 // 0: asset_monitoring.AssetStatus
-	(AssetType)(0),                   // 1: asset_monitoring.AssetType
-	(*StreamAssetStatusRequest)(nil), // 2: asset_monitoring.StreamAssetStatusRequest
-	(*AssetStatusUpdate)(nil),        // 3: asset_monitoring.AssetStatusUpdate
-	(*ElectricReadings)(nil),         // 4: asset_monitoring.ElectricReadings
-	(*ChillWaterReadings)(nil),       // 5: asset_monitoring.ChillWaterReadings
-	(*SteamReadings)(nil),            // 6: asset_monitoring.SteamReadings
-	(*SubscribeRequest)(nil),         // 7: asset_monitoring.SubscribeRequest
-	(*ReadingUpdate)(nil),            // 8: asset_monitoring.ReadingUpdate
-	(*GetStatusRequest)(nil),         // 9: asset_monitoring.GetStatusRequest
-	(*AssetStatusResponse)(nil),      // 10: asset_monitoring.AssetStatusResponse
-	(*timestamppb.Timestamp)(nil),    // 11: google.protobuf.Timestamp
+	(AssetStatus)(0),                       // 0: asset_monitoring.AssetStatus
+	(AssetType)(0),                         // 1: asset_monitoring.AssetType
+	(PowerQualityEventType)(0),             // 2: asset_monitoring.PowerQualityEventType
+	(Recurrence)(0),                        // 3: asset_monitoring.Recurrence
+	(*StreamAssetStatusRequest)(nil),       // 4: asset_monitoring.StreamAssetStatusRequest
+	(*AssetStatusUpdate)(nil),              // 5: asset_monitoring.AssetStatusUpdate
+	(*ChannelReading)(nil),                 // 6: asset_monitoring.ChannelReading
+	(*ElectricReadings)(nil),               // 7: asset_monitoring.ElectricReadings
+	(*ChillWaterReadings)(nil),             // 8: asset_monitoring.ChillWaterReadings
+	(*SteamReadings)(nil),                  // 9: asset_monitoring.SteamReadings
+	(*SubscribeRequest)(nil),               // 10: asset_monitoring.SubscribeRequest
+	(*ReadingUpdate)(nil),                  // 11: asset_monitoring.ReadingUpdate
+	(*GetStatusRequest)(nil),               // 12: asset_monitoring.GetStatusRequest
+	(*AssetStatusResponse)(nil),            // 13: asset_monitoring.AssetStatusResponse
+	(*PowerQualityEvent)(nil),              // 14: asset_monitoring.PowerQualityEvent
+	(*ListPowerQualityEventsRequest)(nil),  // 15: asset_monitoring.ListPowerQualityEventsRequest
+	(*ListPowerQualityEventsResponse)(nil), // 16: asset_monitoring.ListPowerQualityEventsResponse
+	(*MaintenanceWindow)(nil),              // 17: asset_monitoring.MaintenanceWindow
+	(*ScheduleMaintenanceRequest)(nil),     // 18: asset_monitoring.ScheduleMaintenanceRequest
+	(*ScheduleMaintenanceResponse)(nil),    // 19: asset_monitoring.ScheduleMaintenanceResponse
+	(*ListMaintenanceWindowsRequest)(nil),  // 20: asset_monitoring.ListMaintenanceWindowsRequest
+	(*ListMaintenanceWindowsResponse)(nil), // 21: asset_monitoring.ListMaintenanceWindowsResponse
+	(*CancelMaintenanceRequest)(nil),       // 22: asset_monitoring.CancelMaintenanceRequest
+	(*CancelMaintenanceResponse)(nil),      // 23: asset_monitoring.CancelMaintenanceResponse
+	nil,                                    // 24: asset_monitoring.MaintenanceWindow.SelectorEntry
+	(*timestamppb.Timestamp)(nil),          // 25: google.protobuf.Timestamp
 }
 var file_proto_asset_monitoring_asset_monitoring_proto_depIdxs = []int32{
 	0,  // 0: asset_monitoring.AssetStatusUpdate.status:type_name -> asset_monitoring.AssetStatus
-	11, // 1: asset_monitoring.AssetStatusUpdate.timestamp:type_name -> google.protobuf.Timestamp
-	4,  // 2: asset_monitoring.AssetStatusUpdate.electric:type_name -> asset_monitoring.ElectricReadings
-	5,  // 3: asset_monitoring.AssetStatusUpdate.chillwater:type_name -> asset_monitoring.ChillWaterReadings
-	6,  // 4: asset_monitoring.AssetStatusUpdate.steam:type_name -> asset_monitoring.SteamReadings
-	1,  // 5: asset_monitoring.SubscribeRequest.asset_type:type_name -> asset_monitoring.AssetType
-	1,  // 6: asset_monitoring.ReadingUpdate.asset_type:type_name -> asset_monitoring.AssetType
-	11, // 7: asset_monitoring.ReadingUpdate.timestamp:type_name -> google.protobuf.Timestamp
-	4,  // 8: asset_monitoring.ReadingUpdate.electric:type_name -> asset_monitoring.ElectricReadings
-	5,  // 9: asset_monitoring.ReadingUpdate.chillwater:type_name -> asset_monitoring.ChillWaterReadings
-	6,  // 10: asset_monitoring.ReadingUpdate.steam:type_name -> asset_monitoring.SteamReadings
-	0,  // 11: asset_monitoring.AssetStatusResponse.status:type_name -> asset_monitoring.AssetStatus
-	11, // 12: asset_monitoring.AssetStatusResponse.timestamp:type_name -> google.protobuf.Timestamp
-	2,  // 13: asset_monitoring.AssetMonitoringService.StreamAssetStatus:input_type -> asset_monitoring.StreamAssetStatusRequest
-	7,  // 14: asset_monitoring.AssetMonitoringService.SubscribeToReadings:input_type -> asset_monitoring.SubscribeRequest
-	9,  // 15: asset_monitoring.AssetMonitoringService.GetCurrentStatus:input_type -> asset_monitoring.GetStatusRequest
-	3,  // 16: asset_monitoring.AssetMonitoringService.StreamAssetStatus:output_type -> asset_monitoring.AssetStatusUpdate
-	8,  // 17: asset_monitoring.AssetMonitoringService.SubscribeToReadings:output_type -> asset_monitoring.ReadingUpdate
-	10, // 18: asset_monitoring.AssetMonitoringService.GetCurrentStatus:output_type -> asset_monitoring.AssetStatusResponse
-	16, // [16:19] is the sub-list for method output_type
-	13, // [13:16] is the sub-list for method input_type
-	13, // [13:13] is the sub-list for extension type_name
-	13, // [13:13] is the sub-list for extension extendee
-	0,  // [0:13] is the sub-list for field type_name
+	25, // 1: asset_monitoring.AssetStatusUpdate.timestamp:type_name -> google.protobuf.Timestamp
+	7,  // 2: asset_monitoring.AssetStatusUpdate.electric:type_name -> asset_monitoring.ElectricReadings
+	8,  // 3: asset_monitoring.AssetStatusUpdate.chillwater:type_name -> asset_monitoring.ChillWaterReadings
+	9,  // 4: asset_monitoring.AssetStatusUpdate.steam:type_name -> asset_monitoring.SteamReadings
+	6,  // 5: asset_monitoring.AssetStatusUpdate.channels:type_name -> asset_monitoring.ChannelReading
+	1,  // 6: asset_monitoring.SubscribeRequest.asset_type:type_name -> asset_monitoring.AssetType
+	1,  // 7: asset_monitoring.ReadingUpdate.asset_type:type_name -> asset_monitoring.AssetType
+	25, // 8: asset_monitoring.ReadingUpdate.timestamp:type_name -> google.protobuf.Timestamp
+	7,  // 9: asset_monitoring.ReadingUpdate.electric:type_name -> asset_monitoring.ElectricReadings
+	8,  // 10: asset_monitoring.ReadingUpdate.chillwater:type_name -> asset_monitoring.ChillWaterReadings
+	9,  // 11: asset_monitoring.ReadingUpdate.steam:type_name -> asset_monitoring.SteamReadings
+	6,  // 12: asset_monitoring.ReadingUpdate.channels:type_name -> asset_monitoring.ChannelReading
+	0,  // 13: asset_monitoring.AssetStatusResponse.status:type_name -> asset_monitoring.AssetStatus
+	25, // 14: asset_monitoring.AssetStatusResponse.timestamp:type_name -> google.protobuf.Timestamp
+	2,  // 15: asset_monitoring.PowerQualityEvent.type:type_name -> asset_monitoring.PowerQualityEventType
+	25, // 16: asset_monitoring.PowerQualityEvent.start_time:type_name -> google.protobuf.Timestamp
+	25, // 17: asset_monitoring.PowerQualityEvent.end_time:type_name -> google.protobuf.Timestamp
+	25, // 18: asset_monitoring.ListPowerQualityEventsRequest.start_time:type_name -> google.protobuf.Timestamp
+	25, // 19: asset_monitoring.ListPowerQualityEventsRequest.end_time:type_name -> google.protobuf.Timestamp
+	14, // 20: asset_monitoring.ListPowerQualityEventsResponse.events:type_name -> asset_monitoring.PowerQualityEvent
+	24, // 21: asset_monitoring.MaintenanceWindow.selector:type_name -> asset_monitoring.MaintenanceWindow.SelectorEntry
+	25, // 22: asset_monitoring.MaintenanceWindow.start_time:type_name -> google.protobuf.Timestamp
+	3,  // 23: asset_monitoring.MaintenanceWindow.recurrence:type_name -> asset_monitoring.Recurrence
+	25, // 24: asset_monitoring.MaintenanceWindow.recurrence_end:type_name -> google.protobuf.Timestamp
+	25, // 25: asset_monitoring.MaintenanceWindow.created_at:type_name -> google.protobuf.Timestamp
+	17, // 26: asset_monitoring.ScheduleMaintenanceRequest.window:type_name -> asset_monitoring.MaintenanceWindow
+	17, // 27: asset_monitoring.ScheduleMaintenanceResponse.window:type_name -> asset_monitoring.MaintenanceWindow
+	17, // 28: asset_monitoring.ListMaintenanceWindowsResponse.windows:type_name -> asset_monitoring.MaintenanceWindow
+	4,  // 29: asset_monitoring.AssetMonitoringService.StreamAssetStatus:input_type -> asset_monitoring.StreamAssetStatusRequest
+	10, // 30: asset_monitoring.AssetMonitoringService.SubscribeToReadings:input_type -> asset_monitoring.SubscribeRequest
+	12, // 31: asset_monitoring.AssetMonitoringService.GetCurrentStatus:input_type -> asset_monitoring.GetStatusRequest
+	15, // 32: asset_monitoring.AssetMonitoringService.ListPowerQualityEvents:input_type -> asset_monitoring.ListPowerQualityEventsRequest
+	18, // 33: asset_monitoring.AssetMonitoringService.ScheduleMaintenance:input_type -> asset_monitoring.ScheduleMaintenanceRequest
+	20, // 34: asset_monitoring.AssetMonitoringService.ListMaintenanceWindows:input_type -> asset_monitoring.ListMaintenanceWindowsRequest
+	22, // 35: asset_monitoring.AssetMonitoringService.CancelMaintenance:input_type -> asset_monitoring.CancelMaintenanceRequest
+	5,  // 36: asset_monitoring.AssetMonitoringService.StreamAssetStatus:output_type -> asset_monitoring.AssetStatusUpdate
+	11, // 37: asset_monitoring.AssetMonitoringService.SubscribeToReadings:output_type -> asset_monitoring.ReadingUpdate
+	13, // 38: asset_monitoring.AssetMonitoringService.GetCurrentStatus:output_type -> asset_monitoring.AssetStatusResponse
+	16, // 39: asset_monitoring.AssetMonitoringService.ListPowerQualityEvents:output_type -> asset_monitoring.ListPowerQualityEventsResponse
+	19, // 40: asset_monitoring.AssetMonitoringService.ScheduleMaintenance:output_type -> asset_monitoring.ScheduleMaintenanceResponse
+	21, // 41: asset_monitoring.AssetMonitoringService.ListMaintenanceWindows:output_type -> asset_monitoring.ListMaintenanceWindowsResponse
+	23, // 42: asset_monitoring.AssetMonitoringService.CancelMaintenance:output_type -> asset_monitoring.CancelMaintenanceResponse
+	36, // [36:43] is the sub-list for method output_type
+	29, // [29:36] is the sub-list for method input_type
+	29, // [29:29] is the sub-list for extension type_name
+	29, // [29:29] is the sub-list for extension extendee
+	0,  // [0:29] is the sub-list for field type_name
 }
 
 func init() { file_proto_asset_monitoring_asset_monitoring_proto_init() }
@@ -954,7 +1979,7 @@ func file_proto_asset_monitoring_asset_monitoring_proto_init() {
 		(*AssetStatusUpdate_Chillwater)(nil),
 		(*AssetStatusUpdate_Steam)(nil),
 	}
-	file_proto_asset_monitoring_asset_monitoring_proto_msgTypes[6].OneofWrappers = []any{
+	file_proto_asset_monitoring_asset_monitoring_proto_msgTypes[7].OneofWrappers = []any{
 		(*ReadingUpdate_Electric)(nil),
 		(*ReadingUpdate_Chillwater)(nil),
 		(*ReadingUpdate_Steam)(nil),
@@ -964,8 +1989,8 @@ func file_proto_asset_monitoring_asset_monitoring_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_asset_monitoring_asset_monitoring_proto_rawDesc), len(file_proto_asset_monitoring_asset_monitoring_proto_rawDesc)),
-			NumEnums:      2,
-			NumMessages:   9,
+			NumEnums:      4,
+			NumMessages:   21,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -19,9 +19,13 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	AssetMonitoringService_StreamAssetStatus_FullMethodName   = "/asset_monitoring.AssetMonitoringService/StreamAssetStatus"
-	AssetMonitoringService_SubscribeToReadings_FullMethodName = "/asset_monitoring.AssetMonitoringService/SubscribeToReadings"
-	AssetMonitoringService_GetCurrentStatus_FullMethodName    = "/asset_monitoring.AssetMonitoringService/GetCurrentStatus"
+	AssetMonitoringService_StreamAssetStatus_FullMethodName      = "/asset_monitoring.AssetMonitoringService/StreamAssetStatus"
+	AssetMonitoringService_SubscribeToReadings_FullMethodName    = "/asset_monitoring.AssetMonitoringService/SubscribeToReadings"
+	AssetMonitoringService_GetCurrentStatus_FullMethodName       = "/asset_monitoring.AssetMonitoringService/GetCurrentStatus"
+	AssetMonitoringService_ListPowerQualityEvents_FullMethodName = "/asset_monitoring.AssetMonitoringService/ListPowerQualityEvents"
+	AssetMonitoringService_ScheduleMaintenance_FullMethodName    = "/asset_monitoring.AssetMonitoringService/ScheduleMaintenance"
+	AssetMonitoringService_ListMaintenanceWindows_FullMethodName = "/asset_monitoring.AssetMonitoringService/ListMaintenanceWindows"
+	AssetMonitoringService_CancelMaintenance_FullMethodName      = "/asset_monitoring.AssetMonitoringService/CancelMaintenance"
 )
 
 // AssetMonitoringServiceClient is the client API for AssetMonitoringService service.
@@ -34,6 +38,12 @@ type AssetMonitoringServiceClient interface {
 	SubscribeToReadings(ctx context.Context, in *SubscribeRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ReadingUpdate], error)
 	// Get current snapshot (unary for quick check)
 	GetCurrentStatus(ctx context.Context, in *GetStatusRequest, opts ...grpc.CallOption) (*AssetStatusResponse, error)
+	// List detected power quality events for an electric asset
+	ListPowerQualityEvents(ctx context.Context, in *ListPowerQualityEventsRequest, opts ...grpc.CallOption) (*ListPowerQualityEventsResponse, error)
+	// Schedule, list and cancel maintenance windows
+	ScheduleMaintenance(ctx context.Context, in *ScheduleMaintenanceRequest, opts ...grpc.CallOption) (*ScheduleMaintenanceResponse, error)
+	ListMaintenanceWindows(ctx context.Context, in *ListMaintenanceWindowsRequest, opts ...grpc.CallOption) (*ListMaintenanceWindowsResponse, error)
+	CancelMaintenance(ctx context.Context, in *CancelMaintenanceRequest, opts ...grpc.CallOption) (*CancelMaintenanceResponse, error)
 }
 
 type assetMonitoringServiceClient struct {
@@ -92,6 +102,46 @@ func (c *assetMonitoringServiceClient) GetCurrentStatus(ctx context.Context, in
 	return out, nil
 }
 
+func (c *assetMonitoringServiceClient) ListPowerQualityEvents(ctx context.Context, in *ListPowerQualityEventsRequest, opts ...grpc.CallOption) (*ListPowerQualityEventsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListPowerQualityEventsResponse)
+	err := c.cc.Invoke(ctx, AssetMonitoringService_ListPowerQualityEvents_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *assetMonitoringServiceClient) ScheduleMaintenance(ctx context.Context, in *ScheduleMaintenanceRequest, opts ...grpc.CallOption) (*ScheduleMaintenanceResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ScheduleMaintenanceResponse)
+	err := c.cc.Invoke(ctx, AssetMonitoringService_ScheduleMaintenance_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *assetMonitoringServiceClient) ListMaintenanceWindows(ctx context.Context, in *ListMaintenanceWindowsRequest, opts ...grpc.CallOption) (*ListMaintenanceWindowsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListMaintenanceWindowsResponse)
+	err := c.cc.Invoke(ctx, AssetMonitoringService_ListMaintenanceWindows_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *assetMonitoringServiceClient) CancelMaintenance(ctx context.Context, in *CancelMaintenanceRequest, opts ...grpc.CallOption) (*CancelMaintenanceResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CancelMaintenanceResponse)
+	err := c.cc.Invoke(ctx, AssetMonitoringService_CancelMaintenance_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // AssetMonitoringServiceServer is the server API for AssetMonitoringService service.
 // All implementations must embed UnimplementedAssetMonitoringServiceServer
 // for forward compatibility.
@@ -102,6 +152,12 @@ type AssetMonitoringServiceServer interface {
 	SubscribeToReadings(*SubscribeRequest, grpc.ServerStreamingServer[ReadingUpdate]) error
 	// Get current snapshot (unary for quick check)
 	GetCurrentStatus(context.Context, *GetStatusRequest) (*AssetStatusResponse, error)
+	// List detected power quality events for an electric asset
+	ListPowerQualityEvents(context.Context, *ListPowerQualityEventsRequest) (*ListPowerQualityEventsResponse, error)
+	// Schedule, list and cancel maintenance windows
+	ScheduleMaintenance(context.Context, *ScheduleMaintenanceRequest) (*ScheduleMaintenanceResponse, error)
+	ListMaintenanceWindows(context.Context, *ListMaintenanceWindowsRequest) (*ListMaintenanceWindowsResponse, error)
+	CancelMaintenance(context.Context, *CancelMaintenanceRequest) (*CancelMaintenanceResponse, error)
 	mustEmbedUnimplementedAssetMonitoringServiceServer()
 }
 
@@ -121,6 +177,18 @@ func (UnimplementedAssetMonitoringServiceServer) SubscribeToReadings(*SubscribeR
 func (UnimplementedAssetMonitoringServiceServer) GetCurrentStatus(context.Context, *GetStatusRequest) (*AssetStatusResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetCurrentStatus not implemented")
 }
+func (UnimplementedAssetMonitoringServiceServer) ListPowerQualityEvents(context.Context, *ListPowerQualityEventsRequest) (*ListPowerQualityEventsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListPowerQualityEvents not implemented")
+}
+func (UnimplementedAssetMonitoringServiceServer) ScheduleMaintenance(context.Context, *ScheduleMaintenanceRequest) (*ScheduleMaintenanceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ScheduleMaintenance not implemented")
+}
+func (UnimplementedAssetMonitoringServiceServer) ListMaintenanceWindows(context.Context, *ListMaintenanceWindowsRequest) (*ListMaintenanceWindowsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListMaintenanceWindows not implemented")
+}
+func (UnimplementedAssetMonitoringServiceServer) CancelMaintenance(context.Context, *CancelMaintenanceRequest) (*CancelMaintenanceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelMaintenance not implemented")
+}
 func (UnimplementedAssetMonitoringServiceServer) mustEmbedUnimplementedAssetMonitoringServiceServer() {
 }
 func (UnimplementedAssetMonitoringServiceServer) testEmbeddedByValue() {}
@@ -183,6 +251,78 @@ func _AssetMonitoringService_GetCurrentStatus_Handler(srv interface{}, ctx conte
 	return interceptor(ctx, in, info, handler)
 }
 
+func _AssetMonitoringService_ListPowerQualityEvents_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListPowerQualityEventsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AssetMonitoringServiceServer).ListPowerQualityEvents(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AssetMonitoringService_ListPowerQualityEvents_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AssetMonitoringServiceServer).ListPowerQualityEvents(ctx, req.(*ListPowerQualityEventsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AssetMonitoringService_ScheduleMaintenance_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ScheduleMaintenanceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AssetMonitoringServiceServer).ScheduleMaintenance(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AssetMonitoringService_ScheduleMaintenance_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AssetMonitoringServiceServer).ScheduleMaintenance(ctx, req.(*ScheduleMaintenanceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AssetMonitoringService_ListMaintenanceWindows_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListMaintenanceWindowsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AssetMonitoringServiceServer).ListMaintenanceWindows(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AssetMonitoringService_ListMaintenanceWindows_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AssetMonitoringServiceServer).ListMaintenanceWindows(ctx, req.(*ListMaintenanceWindowsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AssetMonitoringService_CancelMaintenance_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelMaintenanceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AssetMonitoringServiceServer).CancelMaintenance(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AssetMonitoringService_CancelMaintenance_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AssetMonitoringServiceServer).CancelMaintenance(ctx, req.(*CancelMaintenanceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // AssetMonitoringService_ServiceDesc is the grpc.ServiceDesc for AssetMonitoringService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -194,6 +334,22 @@ var AssetMonitoringService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetCurrentStatus",
 			Handler:    _AssetMonitoringService_GetCurrentStatus_Handler,
 		},
+		{
+			MethodName: "ListPowerQualityEvents",
+			Handler:    _AssetMonitoringService_ListPowerQualityEvents_Handler,
+		},
+		{
+			MethodName: "ScheduleMaintenance",
+			Handler:    _AssetMonitoringService_ScheduleMaintenance_Handler,
+		},
+		{
+			MethodName: "ListMaintenanceWindows",
+			Handler:    _AssetMonitoringService_ListMaintenanceWindows_Handler,
+		},
+		{
+			MethodName: "CancelMaintenance",
+			Handler:    _AssetMonitoringService_CancelMaintenance_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
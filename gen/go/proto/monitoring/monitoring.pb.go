@@ -196,8 +196,8 @@ func (x *HealthCheckResponse) GetDetails() map[string]string {
 
 type GetMetricsRequest struct {
 	state           protoimpl.MessageState `protogen:"open.v1"`
-	MetricNames     []string               `protobuf:"bytes,1,rep,name=metric_names,json=metricNames,proto3" json:"metric_names,omitempty"`
-	IntervalSeconds int32                  `protobuf:"varint,2,opt,name=interval_seconds,json=intervalSeconds,proto3" json:"interval_seconds,omitempty"`
+	MetricNames     []string               `protobuf:"bytes,1,rep,name=metric_names,json=metricNames,proto3" json:"metric_names,omitempty"`              // Glob patterns, e.g. "grpc_server_*"; default all
+	IntervalSeconds int32                  `protobuf:"varint,2,opt,name=interval_seconds,json=intervalSeconds,proto3" json:"interval_seconds,omitempty"` // Default 5
 	unknownFields   protoimpl.UnknownFields
 	sizeCache       protoimpl.SizeCache
 }
@@ -314,6 +314,86 @@ func (x *MetricsResponse) GetLabels() map[string]string {
 	return nil
 }
 
+type ExportMetricsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportMetricsRequest) Reset() {
+	*x = ExportMetricsRequest{}
+	mi := &file_proto_monitoring_monitoring_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportMetricsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportMetricsRequest) ProtoMessage() {}
+
+func (x *ExportMetricsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_monitoring_monitoring_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportMetricsRequest.ProtoReflect.Descriptor instead.
+func (*ExportMetricsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_monitoring_monitoring_proto_rawDescGZIP(), []int{4}
+}
+
+type ExportMetricsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Metrics       []*MetricsResponse     `protobuf:"bytes,1,rep,name=metrics,proto3" json:"metrics,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportMetricsResponse) Reset() {
+	*x = ExportMetricsResponse{}
+	mi := &file_proto_monitoring_monitoring_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportMetricsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportMetricsResponse) ProtoMessage() {}
+
+func (x *ExportMetricsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_monitoring_monitoring_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportMetricsResponse.ProtoReflect.Descriptor instead.
+func (*ExportMetricsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_monitoring_monitoring_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ExportMetricsResponse) GetMetrics() []*MetricsResponse {
+	if x != nil {
+		return x.Metrics
+	}
+	return nil
+}
+
 var File_proto_monitoring_monitoring_proto protoreflect.FileDescriptor
 
 const file_proto_monitoring_monitoring_proto_rawDesc = "" +
@@ -342,7 +422,10 @@ const file_proto_monitoring_monitoring_proto_rawDesc = "" +
 	"\x06labels\x18\x04 \x03(\v2'.monitoring.MetricsResponse.LabelsEntryR\x06labels\x1a9\n" +
 	"\vLabelsEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
-	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01*E\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\x16\n" +
+	"\x14ExportMetricsRequest\"N\n" +
+	"\x15ExportMetricsResponse\x125\n" +
+	"\ametrics\x18\x01 \x03(\v2\x1b.monitoring.MetricsResponseR\ametrics*E\n" +
 	"\fHealthStatus\x12\v\n" +
 	"\aUNKNOWN\x10\x00\x12\v\n" +
 	"\aHEALTHY\x10\x01\x12\f\n" +
@@ -351,7 +434,9 @@ const file_proto_monitoring_monitoring_proto_rawDesc = "" +
 	"\x11MonitoringService\x12N\n" +
 	"\vHealthCheck\x12\x1e.monitoring.HealthCheckRequest\x1a\x1f.monitoring.HealthCheckResponse\x12J\n" +
 	"\n" +
-	"GetMetrics\x12\x1d.monitoring.GetMetricsRequest\x1a\x1b.monitoring.MetricsResponse0\x01BCZAgithub.com/sairamkiran9/asset-telemetry-monitor/gen/go/monitoringb\x06proto3"
+	"GetMetrics\x12\x1d.monitoring.GetMetricsRequest\x1a\x1b.monitoring.MetricsResponse0\x012g\n" +
+	"\x0fMetricsExporter\x12T\n" +
+	"\rExportMetrics\x12 .monitoring.ExportMetricsRequest\x1a!.monitoring.ExportMetricsResponseBCZAgithub.com/sairamkiran9/asset-telemetry-monitor/gen/go/monitoringb\x06proto3"
 
 var (
 	file_proto_monitoring_monitoring_proto_rawDescOnce sync.Once
@@ -366,32 +451,37 @@ func file_proto_monitoring_monitoring_proto_rawDescGZIP() []byte {
 }
 
 var file_proto_monitoring_monitoring_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_proto_monitoring_monitoring_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
+var file_proto_monitoring_monitoring_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
 var file_proto_monitoring_monitoring_proto_goTypes = []any{
 	(HealthStatus)(0),             // 0: monitoring.HealthStatus
 	(*HealthCheckRequest)(nil),    // 1: monitoring.HealthCheckRequest
 	(*HealthCheckResponse)(nil),   // 2: monitoring.HealthCheckResponse
 	(*GetMetricsRequest)(nil),     // 3: monitoring.GetMetricsRequest
 	(*MetricsResponse)(nil),       // 4: monitoring.MetricsResponse
-	nil,                           // 5: monitoring.HealthCheckResponse.DetailsEntry
-	nil,                           // 6: monitoring.MetricsResponse.LabelsEntry
-	(*timestamppb.Timestamp)(nil), // 7: google.protobuf.Timestamp
+	(*ExportMetricsRequest)(nil),  // 5: monitoring.ExportMetricsRequest
+	(*ExportMetricsResponse)(nil), // 6: monitoring.ExportMetricsResponse
+	nil,                           // 7: monitoring.HealthCheckResponse.DetailsEntry
+	nil,                           // 8: monitoring.MetricsResponse.LabelsEntry
+	(*timestamppb.Timestamp)(nil), // 9: google.protobuf.Timestamp
 }
 var file_proto_monitoring_monitoring_proto_depIdxs = []int32{
 	0, // 0: monitoring.HealthCheckResponse.status:type_name -> monitoring.HealthStatus
-	7, // 1: monitoring.HealthCheckResponse.timestamp:type_name -> google.protobuf.Timestamp
-	5, // 2: monitoring.HealthCheckResponse.details:type_name -> monitoring.HealthCheckResponse.DetailsEntry
-	7, // 3: monitoring.MetricsResponse.timestamp:type_name -> google.protobuf.Timestamp
-	6, // 4: monitoring.MetricsResponse.labels:type_name -> monitoring.MetricsResponse.LabelsEntry
-	1, // 5: monitoring.MonitoringService.HealthCheck:input_type -> monitoring.HealthCheckRequest
-	3, // 6: monitoring.MonitoringService.GetMetrics:input_type -> monitoring.GetMetricsRequest
-	2, // 7: monitoring.MonitoringService.HealthCheck:output_type -> monitoring.HealthCheckResponse
-	4, // 8: monitoring.MonitoringService.GetMetrics:output_type -> monitoring.MetricsResponse
-	7, // [7:9] is the sub-list for method output_type
-	5, // [5:7] is the sub-list for method input_type
-	5, // [5:5] is the sub-list for extension type_name
-	5, // [5:5] is the sub-list for extension extendee
-	0, // [0:5] is the sub-list for field type_name
+	9, // 1: monitoring.HealthCheckResponse.timestamp:type_name -> google.protobuf.Timestamp
+	7, // 2: monitoring.HealthCheckResponse.details:type_name -> monitoring.HealthCheckResponse.DetailsEntry
+	9, // 3: monitoring.MetricsResponse.timestamp:type_name -> google.protobuf.Timestamp
+	8, // 4: monitoring.MetricsResponse.labels:type_name -> monitoring.MetricsResponse.LabelsEntry
+	4, // 5: monitoring.ExportMetricsResponse.metrics:type_name -> monitoring.MetricsResponse
+	1, // 6: monitoring.MonitoringService.HealthCheck:input_type -> monitoring.HealthCheckRequest
+	3, // 7: monitoring.MonitoringService.GetMetrics:input_type -> monitoring.GetMetricsRequest
+	5, // 8: monitoring.MetricsExporter.ExportMetrics:input_type -> monitoring.ExportMetricsRequest
+	2, // 9: monitoring.MonitoringService.HealthCheck:output_type -> monitoring.HealthCheckResponse
+	4, // 10: monitoring.MonitoringService.GetMetrics:output_type -> monitoring.MetricsResponse
+	6, // 11: monitoring.MetricsExporter.ExportMetrics:output_type -> monitoring.ExportMetricsResponse
+	9, // [9:12] is the sub-list for method output_type
+	6, // [6:9] is the sub-list for method input_type
+	6, // [6:6] is the sub-list for extension type_name
+	6, // [6:6] is the sub-list for extension extendee
+	0, // [0:6] is the sub-list for field type_name
 }
 
 func init() { file_proto_monitoring_monitoring_proto_init() }
@@ -405,9 +495,9 @@ func file_proto_monitoring_monitoring_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_monitoring_monitoring_proto_rawDesc), len(file_proto_monitoring_monitoring_proto_rawDesc)),
 			NumEnums:      1,
-			NumMessages:   6,
+			NumMessages:   8,
 			NumExtensions: 0,
-			NumServices:   1,
+			NumServices:   2,
 		},
 		GoTypes:           file_proto_monitoring_monitoring_proto_goTypes,
 		DependencyIndexes: file_proto_monitoring_monitoring_proto_depIdxs,
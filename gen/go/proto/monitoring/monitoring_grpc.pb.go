@@ -161,3 +161,111 @@ var MonitoringService_ServiceDesc = grpc.ServiceDesc{
 	},
 	Metadata: "proto/monitoring/monitoring.proto",
 }
+
+const (
+	MetricsExporter_ExportMetrics_FullMethodName = "/monitoring.MetricsExporter/ExportMetrics"
+)
+
+// MetricsExporterClient is the client API for MetricsExporter service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// Implemented by every service so the monitoring service can collect its
+// metrics registry.
+type MetricsExporterClient interface {
+	ExportMetrics(ctx context.Context, in *ExportMetricsRequest, opts ...grpc.CallOption) (*ExportMetricsResponse, error)
+}
+
+type metricsExporterClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewMetricsExporterClient(cc grpc.ClientConnInterface) MetricsExporterClient {
+	return &metricsExporterClient{cc}
+}
+
+func (c *metricsExporterClient) ExportMetrics(ctx context.Context, in *ExportMetricsRequest, opts ...grpc.CallOption) (*ExportMetricsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ExportMetricsResponse)
+	err := c.cc.Invoke(ctx, MetricsExporter_ExportMetrics_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// MetricsExporterServer is the server API for MetricsExporter service.
+// All implementations must embed UnimplementedMetricsExporterServer
+// for forward compatibility.
+//
+// Implemented by every service so the monitoring service can collect its
+// metrics registry.
+type MetricsExporterServer interface {
+	ExportMetrics(context.Context, *ExportMetricsRequest) (*ExportMetricsResponse, error)
+	mustEmbedUnimplementedMetricsExporterServer()
+}
+
+// UnimplementedMetricsExporterServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedMetricsExporterServer struct{}
+
+func (UnimplementedMetricsExporterServer) ExportMetrics(context.Context, *ExportMetricsRequest) (*ExportMetricsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExportMetrics not implemented")
+}
+func (UnimplementedMetricsExporterServer) mustEmbedUnimplementedMetricsExporterServer() {}
+func (UnimplementedMetricsExporterServer) testEmbeddedByValue()                         {}
+
+// UnsafeMetricsExporterServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to MetricsExporterServer will
+// result in compilation errors.
+type UnsafeMetricsExporterServer interface {
+	mustEmbedUnimplementedMetricsExporterServer()
+}
+
+func RegisterMetricsExporterServer(s grpc.ServiceRegistrar, srv MetricsExporterServer) {
+	// If the following call pancis, it indicates UnimplementedMetricsExporterServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&MetricsExporter_ServiceDesc, srv)
+}
+
+func _MetricsExporter_ExportMetrics_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExportMetricsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MetricsExporterServer).ExportMetrics(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MetricsExporter_ExportMetrics_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MetricsExporterServer).ExportMetrics(ctx, req.(*ExportMetricsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// MetricsExporter_ServiceDesc is the grpc.ServiceDesc for MetricsExporter service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var MetricsExporter_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "monitoring.MetricsExporter",
+	HandlerType: (*MetricsExporterServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ExportMetrics",
+			Handler:    _MetricsExporter_ExportMetrics_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "proto/monitoring/monitoring.proto",
+}
@@ -31,6 +31,7 @@ type TelemetryData struct {
 	Unit          string                 `protobuf:"bytes,5,opt,name=unit,proto3" json:"unit,omitempty"`
 	Timestamp     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
 	Tags          map[string]string      `protobuf:"bytes,7,rep,name=tags,proto3" json:"tags,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Tenant        string                 `protobuf:"bytes,8,opt,name=tenant,proto3" json:"tenant,omitempty"` // Tenant of the asset, set by the service
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -114,13 +115,23 @@ func (x *TelemetryData) GetTags() map[string]string {
 	return nil
 }
 
+func (x *TelemetryData) GetTenant() string {
+	if x != nil {
+		return x.Tenant
+	}
+	return ""
+}
+
 type SubmitTelemetryRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	AssetId       string                 `protobuf:"bytes,1,opt,name=asset_id,json=assetId,proto3" json:"asset_id,omitempty"`
-	MetricName    string                 `protobuf:"bytes,2,opt,name=metric_name,json=metricName,proto3" json:"metric_name,omitempty"`
-	Value         float64                `protobuf:"fixed64,3,opt,name=value,proto3" json:"value,omitempty"`
-	Unit          string                 `protobuf:"bytes,4,opt,name=unit,proto3" json:"unit,omitempty"`
-	Tags          map[string]string      `protobuf:"bytes,5,rep,name=tags,proto3" json:"tags,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	AssetId    string                 `protobuf:"bytes,1,opt,name=asset_id,json=assetId,proto3" json:"asset_id,omitempty"`
+	MetricName string                 `protobuf:"bytes,2,opt,name=metric_name,json=metricName,proto3" json:"metric_name,omitempty"`
+	Value      float64                `protobuf:"fixed64,3,opt,name=value,proto3" json:"value,omitempty"`
+	Unit       string                 `protobuf:"bytes,4,opt,name=unit,proto3" json:"unit,omitempty"`
+	Tags       map[string]string      `protobuf:"bytes,5,rep,name=tags,proto3" json:"tags,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// When the value was measured; defaults to when it's received. Late
+	// points are stored in time order.
+	Timestamp     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -190,6 +201,13 @@ func (x *SubmitTelemetryRequest) GetTags() map[string]string {
 	return nil
 }
 
+func (x *SubmitTelemetryRequest) GetTimestamp() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Timestamp
+	}
+	return nil
+}
+
 type SubmitTelemetryResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Data          *TelemetryData         `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
@@ -354,11 +372,283 @@ func (x *GetTelemetryDataResponse) GetData() []*TelemetryData {
 	return nil
 }
 
+type GetEnergyIntervalsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AssetId       string                 `protobuf:"bytes,1,opt,name=asset_id,json=assetId,proto3" json:"asset_id,omitempty"`
+	StartTime     *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
+	EndTime       *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetEnergyIntervalsRequest) Reset() {
+	*x = GetEnergyIntervalsRequest{}
+	mi := &file_proto_telemetry_telemetry_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetEnergyIntervalsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetEnergyIntervalsRequest) ProtoMessage() {}
+
+func (x *GetEnergyIntervalsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_telemetry_telemetry_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetEnergyIntervalsRequest.ProtoReflect.Descriptor instead.
+func (*GetEnergyIntervalsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_telemetry_telemetry_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *GetEnergyIntervalsRequest) GetAssetId() string {
+	if x != nil {
+		return x.AssetId
+	}
+	return ""
+}
+
+func (x *GetEnergyIntervalsRequest) GetStartTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartTime
+	}
+	return nil
+}
+
+func (x *GetEnergyIntervalsRequest) GetEndTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EndTime
+	}
+	return nil
+}
+
+type EnergyInterval struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	StartTime     *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
+	EndTime       *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`
+	EnergyKwh     float64                `protobuf:"fixed64,3,opt,name=energy_kwh,json=energyKwh,proto3" json:"energy_kwh,omitempty"`
+	DemandKw      float64                `protobuf:"fixed64,4,opt,name=demand_kw,json=demandKw,proto3" json:"demand_kw,omitempty"`
+	Complete      bool                   `protobuf:"varint,5,opt,name=complete,proto3" json:"complete,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EnergyInterval) Reset() {
+	*x = EnergyInterval{}
+	mi := &file_proto_telemetry_telemetry_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EnergyInterval) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EnergyInterval) ProtoMessage() {}
+
+func (x *EnergyInterval) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_telemetry_telemetry_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EnergyInterval.ProtoReflect.Descriptor instead.
+func (*EnergyInterval) Descriptor() ([]byte, []int) {
+	return file_proto_telemetry_telemetry_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *EnergyInterval) GetStartTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartTime
+	}
+	return nil
+}
+
+func (x *EnergyInterval) GetEndTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EndTime
+	}
+	return nil
+}
+
+func (x *EnergyInterval) GetEnergyKwh() float64 {
+	if x != nil {
+		return x.EnergyKwh
+	}
+	return 0
+}
+
+func (x *EnergyInterval) GetDemandKw() float64 {
+	if x != nil {
+		return x.DemandKw
+	}
+	return 0
+}
+
+func (x *EnergyInterval) GetComplete() bool {
+	if x != nil {
+		return x.Complete
+	}
+	return false
+}
+
+type BillingPeriodDemand struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	StartTime         *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
+	EndTime           *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`
+	EnergyKwh         float64                `protobuf:"fixed64,3,opt,name=energy_kwh,json=energyKwh,proto3" json:"energy_kwh,omitempty"`
+	PeakDemandKw      float64                `protobuf:"fixed64,4,opt,name=peak_demand_kw,json=peakDemandKw,proto3" json:"peak_demand_kw,omitempty"`
+	PeakIntervalStart *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=peak_interval_start,json=peakIntervalStart,proto3" json:"peak_interval_start,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *BillingPeriodDemand) Reset() {
+	*x = BillingPeriodDemand{}
+	mi := &file_proto_telemetry_telemetry_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BillingPeriodDemand) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BillingPeriodDemand) ProtoMessage() {}
+
+func (x *BillingPeriodDemand) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_telemetry_telemetry_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BillingPeriodDemand.ProtoReflect.Descriptor instead.
+func (*BillingPeriodDemand) Descriptor() ([]byte, []int) {
+	return file_proto_telemetry_telemetry_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *BillingPeriodDemand) GetStartTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartTime
+	}
+	return nil
+}
+
+func (x *BillingPeriodDemand) GetEndTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EndTime
+	}
+	return nil
+}
+
+func (x *BillingPeriodDemand) GetEnergyKwh() float64 {
+	if x != nil {
+		return x.EnergyKwh
+	}
+	return 0
+}
+
+func (x *BillingPeriodDemand) GetPeakDemandKw() float64 {
+	if x != nil {
+		return x.PeakDemandKw
+	}
+	return 0
+}
+
+func (x *BillingPeriodDemand) GetPeakIntervalStart() *timestamppb.Timestamp {
+	if x != nil {
+		return x.PeakIntervalStart
+	}
+	return nil
+}
+
+type GetEnergyIntervalsResponse struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Intervals      []*EnergyInterval      `protobuf:"bytes,1,rep,name=intervals,proto3" json:"intervals,omitempty"`
+	BillingPeriods []*BillingPeriodDemand `protobuf:"bytes,2,rep,name=billing_periods,json=billingPeriods,proto3" json:"billing_periods,omitempty"`
+	TotalEnergyKwh float64                `protobuf:"fixed64,3,opt,name=total_energy_kwh,json=totalEnergyKwh,proto3" json:"total_energy_kwh,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *GetEnergyIntervalsResponse) Reset() {
+	*x = GetEnergyIntervalsResponse{}
+	mi := &file_proto_telemetry_telemetry_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetEnergyIntervalsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetEnergyIntervalsResponse) ProtoMessage() {}
+
+func (x *GetEnergyIntervalsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_telemetry_telemetry_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetEnergyIntervalsResponse.ProtoReflect.Descriptor instead.
+func (*GetEnergyIntervalsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_telemetry_telemetry_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *GetEnergyIntervalsResponse) GetIntervals() []*EnergyInterval {
+	if x != nil {
+		return x.Intervals
+	}
+	return nil
+}
+
+func (x *GetEnergyIntervalsResponse) GetBillingPeriods() []*BillingPeriodDemand {
+	if x != nil {
+		return x.BillingPeriods
+	}
+	return nil
+}
+
+func (x *GetEnergyIntervalsResponse) GetTotalEnergyKwh() float64 {
+	if x != nil {
+		return x.TotalEnergyKwh
+	}
+	return 0
+}
+
 var File_proto_telemetry_telemetry_proto protoreflect.FileDescriptor
 
 const file_proto_telemetry_telemetry_proto_rawDesc = "" +
 	"\n" +
-	"\x1fproto/telemetry/telemetry.proto\x12\ttelemetry\x1a\x1fgoogle/protobuf/timestamp.proto\"\xb0\x02\n" +
+	"\x1fproto/telemetry/telemetry.proto\x12\ttelemetry\x1a\x1fgoogle/protobuf/timestamp.proto\"\xc8\x02\n" +
 	"\rTelemetryData\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x19\n" +
 	"\basset_id\x18\x02 \x01(\tR\aassetId\x12\x1f\n" +
@@ -367,17 +657,19 @@ const file_proto_telemetry_telemetry_proto_rawDesc = "" +
 	"\x05value\x18\x04 \x01(\x01R\x05value\x12\x12\n" +
 	"\x04unit\x18\x05 \x01(\tR\x04unit\x128\n" +
 	"\ttimestamp\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\ttimestamp\x126\n" +
-	"\x04tags\x18\a \x03(\v2\".telemetry.TelemetryData.TagsEntryR\x04tags\x1a7\n" +
+	"\x04tags\x18\a \x03(\v2\".telemetry.TelemetryData.TagsEntryR\x04tags\x12\x16\n" +
+	"\x06tenant\x18\b \x01(\tR\x06tenant\x1a7\n" +
 	"\tTagsEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
-	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xf8\x01\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xb2\x02\n" +
 	"\x16SubmitTelemetryRequest\x12\x19\n" +
 	"\basset_id\x18\x01 \x01(\tR\aassetId\x12\x1f\n" +
 	"\vmetric_name\x18\x02 \x01(\tR\n" +
 	"metricName\x12\x14\n" +
 	"\x05value\x18\x03 \x01(\x01R\x05value\x12\x12\n" +
 	"\x04unit\x18\x04 \x01(\tR\x04unit\x12?\n" +
-	"\x04tags\x18\x05 \x03(\v2+.telemetry.SubmitTelemetryRequest.TagsEntryR\x04tags\x1a7\n" +
+	"\x04tags\x18\x05 \x03(\v2+.telemetry.SubmitTelemetryRequest.TagsEntryR\x04tags\x128\n" +
+	"\ttimestamp\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\ttimestamp\x1a7\n" +
 	"\tTagsEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
 	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"{\n" +
@@ -391,10 +683,36 @@ const file_proto_telemetry_telemetry_proto_rawDesc = "" +
 	"start_time\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\tstartTime\x125\n" +
 	"\bend_time\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\aendTime\"H\n" +
 	"\x18GetTelemetryDataResponse\x12,\n" +
-	"\x04data\x18\x01 \x03(\v2\x18.telemetry.TelemetryDataR\x04data2\xc9\x01\n" +
+	"\x04data\x18\x01 \x03(\v2\x18.telemetry.TelemetryDataR\x04data\"\xa8\x01\n" +
+	"\x19GetEnergyIntervalsRequest\x12\x19\n" +
+	"\basset_id\x18\x01 \x01(\tR\aassetId\x129\n" +
+	"\n" +
+	"start_time\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\tstartTime\x125\n" +
+	"\bend_time\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\aendTime\"\xda\x01\n" +
+	"\x0eEnergyInterval\x129\n" +
+	"\n" +
+	"start_time\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\tstartTime\x125\n" +
+	"\bend_time\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\aendTime\x12\x1d\n" +
+	"\n" +
+	"energy_kwh\x18\x03 \x01(\x01R\tenergyKwh\x12\x1b\n" +
+	"\tdemand_kw\x18\x04 \x01(\x01R\bdemandKw\x12\x1a\n" +
+	"\bcomplete\x18\x05 \x01(\bR\bcomplete\"\x98\x02\n" +
+	"\x13BillingPeriodDemand\x129\n" +
+	"\n" +
+	"start_time\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\tstartTime\x125\n" +
+	"\bend_time\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\aendTime\x12\x1d\n" +
+	"\n" +
+	"energy_kwh\x18\x03 \x01(\x01R\tenergyKwh\x12$\n" +
+	"\x0epeak_demand_kw\x18\x04 \x01(\x01R\fpeakDemandKw\x12J\n" +
+	"\x13peak_interval_start\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\x11peakIntervalStart\"\xc8\x01\n" +
+	"\x1aGetEnergyIntervalsResponse\x127\n" +
+	"\tintervals\x18\x01 \x03(\v2\x19.telemetry.EnergyIntervalR\tintervals\x12G\n" +
+	"\x0fbilling_periods\x18\x02 \x03(\v2\x1e.telemetry.BillingPeriodDemandR\x0ebillingPeriods\x12(\n" +
+	"\x10total_energy_kwh\x18\x03 \x01(\x01R\x0etotalEnergyKwh2\xac\x02\n" +
 	"\x10TelemetryService\x12X\n" +
 	"\x0fSubmitTelemetry\x12!.telemetry.SubmitTelemetryRequest\x1a\".telemetry.SubmitTelemetryResponse\x12[\n" +
-	"\x10GetTelemetryData\x12\".telemetry.GetTelemetryDataRequest\x1a#.telemetry.GetTelemetryDataResponseBBZ@github.com/sairamkiran9/asset-telemetry-monitor/gen/go/telemetryb\x06proto3"
+	"\x10GetTelemetryData\x12\".telemetry.GetTelemetryDataRequest\x1a#.telemetry.GetTelemetryDataResponse\x12a\n" +
+	"\x12GetEnergyIntervals\x12$.telemetry.GetEnergyIntervalsRequest\x1a%.telemetry.GetEnergyIntervalsResponseBBZ@github.com/sairamkiran9/asset-telemetry-monitor/gen/go/telemetryb\x06proto3"
 
 var (
 	file_proto_telemetry_telemetry_proto_rawDescOnce sync.Once
@@ -408,34 +726,50 @@ func file_proto_telemetry_telemetry_proto_rawDescGZIP() []byte {
 	return file_proto_telemetry_telemetry_proto_rawDescData
 }
 
-var file_proto_telemetry_telemetry_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
+var file_proto_telemetry_telemetry_proto_msgTypes = make([]protoimpl.MessageInfo, 11)
 var file_proto_telemetry_telemetry_proto_goTypes = []any{
-	(*TelemetryData)(nil),            // 0: telemetry.TelemetryData
-	(*SubmitTelemetryRequest)(nil),   // 1: telemetry.SubmitTelemetryRequest
-	(*SubmitTelemetryResponse)(nil),  // 2: telemetry.SubmitTelemetryResponse
-	(*GetTelemetryDataRequest)(nil),  // 3: telemetry.GetTelemetryDataRequest
-	(*GetTelemetryDataResponse)(nil), // 4: telemetry.GetTelemetryDataResponse
-	nil,                              // 5: telemetry.TelemetryData.TagsEntry
-	nil,                              // 6: telemetry.SubmitTelemetryRequest.TagsEntry
-	(*timestamppb.Timestamp)(nil),    // 7: google.protobuf.Timestamp
+	(*TelemetryData)(nil),              // 0: telemetry.TelemetryData
+	(*SubmitTelemetryRequest)(nil),     // 1: telemetry.SubmitTelemetryRequest
+	(*SubmitTelemetryResponse)(nil),    // 2: telemetry.SubmitTelemetryResponse
+	(*GetTelemetryDataRequest)(nil),    // 3: telemetry.GetTelemetryDataRequest
+	(*GetTelemetryDataResponse)(nil),   // 4: telemetry.GetTelemetryDataResponse
+	(*GetEnergyIntervalsRequest)(nil),  // 5: telemetry.GetEnergyIntervalsRequest
+	(*EnergyInterval)(nil),             // 6: telemetry.EnergyInterval
+	(*BillingPeriodDemand)(nil),        // 7: telemetry.BillingPeriodDemand
+	(*GetEnergyIntervalsResponse)(nil), // 8: telemetry.GetEnergyIntervalsResponse
+	nil,                                // 9: telemetry.TelemetryData.TagsEntry
+	nil,                                // 10: telemetry.SubmitTelemetryRequest.TagsEntry
+	(*timestamppb.Timestamp)(nil),      // 11: google.protobuf.Timestamp
 }
 var file_proto_telemetry_telemetry_proto_depIdxs = []int32{
-	7, // 0: telemetry.TelemetryData.timestamp:type_name -> google.protobuf.Timestamp
-	5, // 1: telemetry.TelemetryData.tags:type_name -> telemetry.TelemetryData.TagsEntry
-	6, // 2: telemetry.SubmitTelemetryRequest.tags:type_name -> telemetry.SubmitTelemetryRequest.TagsEntry
-	0, // 3: telemetry.SubmitTelemetryResponse.data:type_name -> telemetry.TelemetryData
-	7, // 4: telemetry.GetTelemetryDataRequest.start_time:type_name -> google.protobuf.Timestamp
-	7, // 5: telemetry.GetTelemetryDataRequest.end_time:type_name -> google.protobuf.Timestamp
-	0, // 6: telemetry.GetTelemetryDataResponse.data:type_name -> telemetry.TelemetryData
-	1, // 7: telemetry.TelemetryService.SubmitTelemetry:input_type -> telemetry.SubmitTelemetryRequest
-	3, // 8: telemetry.TelemetryService.GetTelemetryData:input_type -> telemetry.GetTelemetryDataRequest
-	2, // 9: telemetry.TelemetryService.SubmitTelemetry:output_type -> telemetry.SubmitTelemetryResponse
-	4, // 10: telemetry.TelemetryService.GetTelemetryData:output_type -> telemetry.GetTelemetryDataResponse
-	9, // [9:11] is the sub-list for method output_type
-	7, // [7:9] is the sub-list for method input_type
-	7, // [7:7] is the sub-list for extension type_name
-	7, // [7:7] is the sub-list for extension extendee
-	0, // [0:7] is the sub-list for field type_name
+	11, // 0: telemetry.TelemetryData.timestamp:type_name -> google.protobuf.Timestamp
+	9,  // 1: telemetry.TelemetryData.tags:type_name -> telemetry.TelemetryData.TagsEntry
+	10, // 2: telemetry.SubmitTelemetryRequest.tags:type_name -> telemetry.SubmitTelemetryRequest.TagsEntry
+	11, // 3: telemetry.SubmitTelemetryRequest.timestamp:type_name -> google.protobuf.Timestamp
+	0,  // 4: telemetry.SubmitTelemetryResponse.data:type_name -> telemetry.TelemetryData
+	11, // 5: telemetry.GetTelemetryDataRequest.start_time:type_name -> google.protobuf.Timestamp
+	11, // 6: telemetry.GetTelemetryDataRequest.end_time:type_name -> google.protobuf.Timestamp
+	0,  // 7: telemetry.GetTelemetryDataResponse.data:type_name -> telemetry.TelemetryData
+	11, // 8: telemetry.GetEnergyIntervalsRequest.start_time:type_name -> google.protobuf.Timestamp
+	11, // 9: telemetry.GetEnergyIntervalsRequest.end_time:type_name -> google.protobuf.Timestamp
+	11, // 10: telemetry.EnergyInterval.start_time:type_name -> google.protobuf.Timestamp
+	11, // 11: telemetry.EnergyInterval.end_time:type_name -> google.protobuf.Timestamp
+	11, // 12: telemetry.BillingPeriodDemand.start_time:type_name -> google.protobuf.Timestamp
+	11, // 13: telemetry.BillingPeriodDemand.end_time:type_name -> google.protobuf.Timestamp
+	11, // 14: telemetry.BillingPeriodDemand.peak_interval_start:type_name -> google.protobuf.Timestamp
+	6,  // 15: telemetry.GetEnergyIntervalsResponse.intervals:type_name -> telemetry.EnergyInterval
+	7,  // 16: telemetry.GetEnergyIntervalsResponse.billing_periods:type_name -> telemetry.BillingPeriodDemand
+	1,  // 17: telemetry.TelemetryService.SubmitTelemetry:input_type -> telemetry.SubmitTelemetryRequest
+	3,  // 18: telemetry.TelemetryService.GetTelemetryData:input_type -> telemetry.GetTelemetryDataRequest
+	5,  // 19: telemetry.TelemetryService.GetEnergyIntervals:input_type -> telemetry.GetEnergyIntervalsRequest
+	2,  // 20: telemetry.TelemetryService.SubmitTelemetry:output_type -> telemetry.SubmitTelemetryResponse
+	4,  // 21: telemetry.TelemetryService.GetTelemetryData:output_type -> telemetry.GetTelemetryDataResponse
+	8,  // 22: telemetry.TelemetryService.GetEnergyIntervals:output_type -> telemetry.GetEnergyIntervalsResponse
+	20, // [20:23] is the sub-list for method output_type
+	17, // [17:20] is the sub-list for method input_type
+	17, // [17:17] is the sub-list for extension type_name
+	17, // [17:17] is the sub-list for extension extendee
+	0,  // [0:17] is the sub-list for field type_name
 }
 
 func init() { file_proto_telemetry_telemetry_proto_init() }
@@ -449,7 +783,7 @@ func file_proto_telemetry_telemetry_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_telemetry_telemetry_proto_rawDesc), len(file_proto_telemetry_telemetry_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   7,
+			NumMessages:   11,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -19,8 +19,9 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	TelemetryService_SubmitTelemetry_FullMethodName  = "/telemetry.TelemetryService/SubmitTelemetry"
-	TelemetryService_GetTelemetryData_FullMethodName = "/telemetry.TelemetryService/GetTelemetryData"
+	TelemetryService_SubmitTelemetry_FullMethodName    = "/telemetry.TelemetryService/SubmitTelemetry"
+	TelemetryService_GetTelemetryData_FullMethodName   = "/telemetry.TelemetryService/GetTelemetryData"
+	TelemetryService_GetEnergyIntervals_FullMethodName = "/telemetry.TelemetryService/GetEnergyIntervals"
 )
 
 // TelemetryServiceClient is the client API for TelemetryService service.
@@ -29,6 +30,7 @@ const (
 type TelemetryServiceClient interface {
 	SubmitTelemetry(ctx context.Context, in *SubmitTelemetryRequest, opts ...grpc.CallOption) (*SubmitTelemetryResponse, error)
 	GetTelemetryData(ctx context.Context, in *GetTelemetryDataRequest, opts ...grpc.CallOption) (*GetTelemetryDataResponse, error)
+	GetEnergyIntervals(ctx context.Context, in *GetEnergyIntervalsRequest, opts ...grpc.CallOption) (*GetEnergyIntervalsResponse, error)
 }
 
 type telemetryServiceClient struct {
@@ -59,12 +61,23 @@ func (c *telemetryServiceClient) GetTelemetryData(ctx context.Context, in *GetTe
 	return out, nil
 }
 
+func (c *telemetryServiceClient) GetEnergyIntervals(ctx context.Context, in *GetEnergyIntervalsRequest, opts ...grpc.CallOption) (*GetEnergyIntervalsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetEnergyIntervalsResponse)
+	err := c.cc.Invoke(ctx, TelemetryService_GetEnergyIntervals_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // TelemetryServiceServer is the server API for TelemetryService service.
 // All implementations must embed UnimplementedTelemetryServiceServer
 // for forward compatibility.
 type TelemetryServiceServer interface {
 	SubmitTelemetry(context.Context, *SubmitTelemetryRequest) (*SubmitTelemetryResponse, error)
 	GetTelemetryData(context.Context, *GetTelemetryDataRequest) (*GetTelemetryDataResponse, error)
+	GetEnergyIntervals(context.Context, *GetEnergyIntervalsRequest) (*GetEnergyIntervalsResponse, error)
 	mustEmbedUnimplementedTelemetryServiceServer()
 }
 
@@ -81,6 +94,9 @@ func (UnimplementedTelemetryServiceServer) SubmitTelemetry(context.Context, *Sub
 func (UnimplementedTelemetryServiceServer) GetTelemetryData(context.Context, *GetTelemetryDataRequest) (*GetTelemetryDataResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetTelemetryData not implemented")
 }
+func (UnimplementedTelemetryServiceServer) GetEnergyIntervals(context.Context, *GetEnergyIntervalsRequest) (*GetEnergyIntervalsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetEnergyIntervals not implemented")
+}
 func (UnimplementedTelemetryServiceServer) mustEmbedUnimplementedTelemetryServiceServer() {}
 func (UnimplementedTelemetryServiceServer) testEmbeddedByValue()                          {}
 
@@ -138,6 +154,24 @@ func _TelemetryService_GetTelemetryData_Handler(srv interface{}, ctx context.Con
 	return interceptor(ctx, in, info, handler)
 }
 
+func _TelemetryService_GetEnergyIntervals_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetEnergyIntervalsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TelemetryServiceServer).GetEnergyIntervals(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TelemetryService_GetEnergyIntervals_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TelemetryServiceServer).GetEnergyIntervals(ctx, req.(*GetEnergyIntervalsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // TelemetryService_ServiceDesc is the grpc.ServiceDesc for TelemetryService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -153,6 +187,10 @@ var TelemetryService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetTelemetryData",
 			Handler:    _TelemetryService_GetTelemetryData_Handler,
 		},
+		{
+			MethodName: "GetEnergyIntervals",
+			Handler:    _TelemetryService_GetEnergyIntervals_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "proto/telemetry/telemetry.proto",
@@ -0,0 +1,223 @@
+package assetcache
+
+import (
+	"context"
+	"log"
+	"sort"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/sairamkiran9/asset-telemetry-monitor/gen/go/proto/asset"
+	"github.com/sairamkiran9/asset-telemetry-monitor/internal/auth"
+)
+
+const (
+	minRetryDelay = time.Second
+	maxRetryDelay = 30 * time.Second
+)
+
+// Cache mirrors the asset registry's assets by watching them. It watches for
+// every tenant, which takes credentials for any tenant when authentication is
+// enabled. Until the first full list arrives the cache finds nothing, so
+// callers should fall back to asking the registry on a miss. A nil Cache is
+// always empty.
+type Cache struct {
+	client pb.AssetRegistryClient
+
+	mu       sync.RWMutex
+	assets   map[string]*pb.Asset
+	version  uint64 // Registry version of the latest change seen
+	synced   bool
+	handlers []func(*pb.AssetEvent)
+}
+
+// New returns a cache of the assets client's registry holds. It stays empty
+// until Run.
+func New(client pb.AssetRegistryClient) *Cache {
+	return &Cache{client: client, assets: make(map[string]*pb.Asset)}
+}
+
+// OnChange calls fn, from Run's goroutine, with every asset the cache sees
+// created, updated or deleted after it first synced. Changes missed while the
+// cache was disconnected are reported when it catches up. Call it before Run.
+func (c *Cache) OnChange(fn func(*pb.AssetEvent)) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.handlers = append(c.handlers, fn)
+}
+
+// Get returns the cached asset with id.
+func (c *Cache) Get(id string) (*pb.Asset, bool) {
+	if c == nil {
+		return nil, false
+	}
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	asset, found := c.assets[id]
+	return asset, found
+}
+
+// Children returns the cached assets whose parent is id, in ID order.
+func (c *Cache) Children(id string) []*pb.Asset {
+	if c == nil {
+		return nil
+	}
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	var children []*pb.Asset
+	for _, asset := range c.assets {
+		if asset.ParentId == id {
+			children = append(children, asset)
+		}
+	}
+	sort.Slice(children, func(i, j int) bool { return children[i].Id < children[j].Id })
+	return children
+}
+
+// Synced reports whether the cache has received the full list of assets. It
+// keeps serving its last state while reconnecting.
+func (c *Cache) Synced() bool {
+	if c == nil {
+		return false
+	}
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.synced
+}
+
+// Run watches the registry until ctx is done, reconnecting with backoff and
+// resuming where the last watch ended.
+func (c *Cache) Run(ctx context.Context) {
+	if c == nil {
+		return
+	}
+	delay := minRetryDelay
+	for {
+		synced, err := c.watch(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+		if synced {
+			delay = minRetryDelay
+		}
+		if status.Code(err) == codes.OutOfRange {
+			// The registry no longer has the changes since our version
+			log.Printf("Relisting assets: %v", err)
+			c.mu.Lock()
+			c.version = 0
+			c.mu.Unlock()
+			continue
+		}
+		log.Printf("Asset watch ended, retrying in %v: %v", delay, err)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(delay):
+		}
+		delay = min(delay*2, maxRetryDelay)
+	}
+}
+
+// watch applies one WatchAssets call's events to the cache until it fails,
+// and reports whether it got as far as syncing.
+func (c *Cache) watch(ctx context.Context) (bool, error) {
+	c.mu.RLock()
+	version := c.version
+	c.mu.RUnlock()
+
+	ctx, cancel := context.WithCancel(metadata.AppendToOutgoingContext(ctx, auth.TenantHeader, auth.AnyTenant))
+	defer cancel()
+	stream, err := c.client.WatchAssets(ctx, &pb.WatchAssetsRequest{ResourceVersion: version})
+	if err != nil {
+		return false, err
+	}
+
+	// A watch from version 0 lists every asset before SYNCED; the list
+	// replaces the cache's contents
+	var listed map[string]*pb.Asset
+	if version == 0 {
+		listed = make(map[string]*pb.Asset)
+	}
+	synced := false
+	for {
+		event, err := stream.Recv()
+		if err != nil {
+			return synced, err
+		}
+		switch {
+		case event.Type == pb.AssetEventType_SYNCED:
+			if listed != nil {
+				c.replace(listed, event.ResourceVersion)
+				listed = nil
+			}
+			synced = true
+		case listed != nil:
+			listed[event.Asset.GetId()] = event.Asset
+		default:
+			c.apply(event)
+		}
+	}
+}
+
+// apply records a single change.
+func (c *Cache) apply(event *pb.AssetEvent) {
+	if event.Asset == nil {
+		return
+	}
+	c.mu.Lock()
+	if event.Type == pb.AssetEventType_DELETED {
+		delete(c.assets, event.Asset.Id)
+	} else {
+		c.assets[event.Asset.Id] = event.Asset
+	}
+	c.version = event.ResourceVersion
+	handlers := c.handlers
+	synced := c.synced
+	c.mu.Unlock()
+
+	if synced {
+		for _, fn := range handlers {
+			fn(event)
+		}
+	}
+}
+
+// replace swaps in a full list of assets, reporting how it differs from the
+// previous contents.
+func (c *Cache) replace(assets map[string]*pb.Asset, version uint64) {
+	c.mu.Lock()
+	var changes []*pb.AssetEvent
+	if c.synced {
+		for id, old := range c.assets {
+			if _, found := assets[id]; !found {
+				changes = append(changes, &pb.AssetEvent{Type: pb.AssetEventType_DELETED, Asset: old, ResourceVersion: version})
+			}
+		}
+		for id, asset := range assets {
+			if old, found := c.assets[id]; !found {
+				changes = append(changes, &pb.AssetEvent{Type: pb.AssetEventType_CREATED, Asset: asset, ResourceVersion: asset.ResourceVersion})
+			} else if old.ResourceVersion != asset.ResourceVersion {
+				changes = append(changes, &pb.AssetEvent{Type: pb.AssetEventType_UPDATED, Asset: asset, ResourceVersion: asset.ResourceVersion})
+			}
+		}
+	}
+	c.assets = assets
+	c.version = version
+	c.synced = true
+	handlers := c.handlers
+	c.mu.Unlock()
+
+	for _, event := range changes {
+		for _, fn := range handlers {
+			fn(event)
+		}
+	}
+}
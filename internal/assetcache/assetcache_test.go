@@ -0,0 +1,177 @@
+package assetcache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/sairamkiran9/asset-telemetry-monitor/gen/go/proto/asset"
+	"github.com/sairamkiran9/asset-telemetry-monitor/internal/auth"
+)
+
+// fakeRegistry hands each WatchAssets call to the test.
+type fakeRegistry struct {
+	pb.AssetRegistryClient
+	watches chan *fakeWatch
+}
+
+func (f *fakeRegistry) WatchAssets(ctx context.Context, req *pb.WatchAssetsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[pb.AssetEvent], error) {
+	w := &fakeWatch{ctx: ctx, version: req.ResourceVersion, events: make(chan *pb.AssetEvent, 10), fail: make(chan error, 1)}
+	f.watches <- w
+	return w, nil
+}
+
+type fakeWatch struct {
+	grpc.ClientStream
+	ctx     context.Context
+	version uint64
+	events  chan *pb.AssetEvent
+	fail    chan error
+}
+
+func (w *fakeWatch) Recv() (*pb.AssetEvent, error) {
+	select {
+	case event := <-w.events:
+		return event, nil
+	case err := <-w.fail:
+		return nil, err
+	case <-w.ctx.Done():
+		return nil, w.ctx.Err()
+	}
+}
+
+func (w *fakeWatch) send(eventType pb.AssetEventType, id string, version uint64) {
+	event := &pb.AssetEvent{Type: eventType, ResourceVersion: version}
+	if id != "" {
+		event.Asset = &pb.Asset{Id: id, ResourceVersion: version}
+	}
+	w.events <- event
+}
+
+func nextWatch(t *testing.T, f *fakeRegistry) *fakeWatch {
+	t.Helper()
+	select {
+	case w := <-f.watches:
+		return w
+	case <-time.After(5 * time.Second):
+		t.Fatal("Timed out waiting for the cache to watch")
+		return nil
+	}
+}
+
+func nextChange(t *testing.T, changes <-chan *pb.AssetEvent) *pb.AssetEvent {
+	t.Helper()
+	select {
+	case event := <-changes:
+		return event
+	case <-time.After(5 * time.Second):
+		t.Fatal("Timed out waiting for a change")
+		return nil
+	}
+}
+
+func waitFor(t *testing.T, what string, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatalf("Timed out waiting for %s", what)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestCache(t *testing.T) {
+	registry := &fakeRegistry{watches: make(chan *fakeWatch, 1)}
+	c := New(registry)
+	changes := make(chan *pb.AssetEvent, 10)
+	c.OnChange(func(event *pb.AssetEvent) { changes <- event })
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go c.Run(ctx)
+
+	// The cache lists every tenant's assets
+	w := nextWatch(t, registry)
+	md, _ := metadata.FromOutgoingContext(w.ctx)
+	if w.version != 0 || len(md.Get(auth.TenantHeader)) != 1 || md.Get(auth.TenantHeader)[0] != auth.AnyTenant {
+		t.Errorf("Expected a watch from version 0 for any tenant, got version %d with %v", w.version, md)
+	}
+	w.send(pb.AssetEventType_CREATED, "asset-1", 1)
+	w.send(pb.AssetEventType_CREATED, "asset-2", 2)
+	if _, found := c.Get("asset-1"); found {
+		t.Error("Expected nothing to be found before the list is complete")
+	}
+	w.send(pb.AssetEventType_SYNCED, "", 2)
+	waitFor(t, "the cache to sync", c.Synced)
+	if _, found := c.Get("asset-2"); !found {
+		t.Error("Expected listed assets to be found")
+	}
+
+	w.send(pb.AssetEventType_UPDATED, "asset-1", 3)
+	if event := nextChange(t, changes); event.Type != pb.AssetEventType_UPDATED || event.Asset.Id != "asset-1" {
+		t.Errorf("Expected asset-1 updated, got %v", event)
+	}
+	if asset, _ := c.Get("asset-1"); asset.ResourceVersion != 3 {
+		t.Errorf("Expected the updated asset, got %v", asset)
+	}
+
+	// When the registry no longer has the missed changes, the cache relists
+	// and reports the difference
+	w.fail <- status.Error(codes.OutOfRange, "too old")
+	w = nextWatch(t, registry)
+	if w.version != 0 {
+		t.Errorf("Expected a relist from version 0, got %d", w.version)
+	}
+	w.send(pb.AssetEventType_CREATED, "asset-1", 3)
+	w.send(pb.AssetEventType_CREATED, "asset-3", 5)
+	w.send(pb.AssetEventType_SYNCED, "", 5)
+
+	got := map[string]pb.AssetEventType{}
+	for i := 0; i < 2; i++ {
+		event := nextChange(t, changes)
+		got[event.Asset.Id] = event.Type
+	}
+	if got["asset-2"] != pb.AssetEventType_DELETED || got["asset-3"] != pb.AssetEventType_CREATED {
+		t.Errorf("Expected asset-2 deleted and asset-3 created, got %v", got)
+	}
+	if _, found := c.Get("asset-2"); found {
+		t.Error("Expected asset-2 to be gone after the relist")
+	}
+	select {
+	case event := <-changes:
+		t.Errorf("Expected no change for the unchanged asset-1, got %v", event)
+	default:
+	}
+}
+
+func TestCacheChildren(t *testing.T) {
+	c := New(nil)
+	c.replace(map[string]*pb.Asset{
+		"asset-1": {Id: "asset-1"},
+		"asset-2": {Id: "asset-2", ParentId: "asset-1"},
+		"asset-3": {Id: "asset-3", ParentId: "asset-2"},
+		"asset-4": {Id: "asset-4", ParentId: "asset-1"},
+	}, 4)
+
+	children := c.Children("asset-1")
+	if len(children) != 2 || children[0].Id != "asset-2" || children[1].Id != "asset-4" {
+		t.Errorf("Expected asset-2 and asset-4 in order, got %v", children)
+	}
+	if children := c.Children("asset-4"); len(children) != 0 {
+		t.Errorf("Expected no children of asset-4, got %v", children)
+	}
+}
+
+func TestNilCache(t *testing.T) {
+	var c *Cache
+	c.OnChange(func(*pb.AssetEvent) {})
+	c.Run(context.Background())
+	if _, found := c.Get("asset-1"); found || c.Synced() || c.Children("asset-1") != nil {
+		t.Error("Expected a nil cache to be empty and never synced")
+	}
+}
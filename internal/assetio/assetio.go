@@ -0,0 +1,260 @@
+package assetio
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	pb "github.com/sairamkiran9/asset-telemetry-monitor/gen/go/proto/asset"
+)
+
+// Format is a file format assets are imported from and exported to.
+type Format int
+
+const (
+	CSV       Format = iota // A header row naming the columns, then an asset per row
+	JSONLines               // A JSON object per line
+)
+
+func (f Format) String() string {
+	if f == JSONLines {
+		return "jsonl"
+	}
+	return "csv"
+}
+
+// ParseFormat returns the format named name, or the format of a file named
+// name by its extension.
+func ParseFormat(name string) (Format, error) {
+	switch strings.ToLower(strings.TrimPrefix(filepath.Ext("."+name), ".")) {
+	case "csv":
+		return CSV, nil
+	case "jsonl", "ndjson":
+		return JSONLines, nil
+	}
+	return 0, fmt.Errorf("unknown format %q; use csv or jsonl", name)
+}
+
+// Fields records can be mapped to. Columns mapped to "metadata.<key>" set
+// that metadata, and columns mapped to "-" are ignored.
+const (
+	FieldExternalID  = "external_id"
+	FieldName        = "name"
+	FieldType        = "type"
+	FieldDescription = "description"
+	FieldParent      = "parent" // The parent's external ID
+	FieldID          = "id"     // The registry's ID, written by exports and ignored by imports
+
+	metadataPrefix = "metadata."
+	ignored        = "-"
+)
+
+// Mapping maps column names, or JSON keys, to the fields they fill. Columns
+// named after a field fill it without being mapped.
+type Mapping map[string]string
+
+// ParseMapping parses comma-separated column=field pairs, as in
+// "Asset Tag=external_id,Area (m2)=metadata.floor_area,Notes=-".
+func ParseMapping(s string) (Mapping, error) {
+	m := make(Mapping)
+	if strings.TrimSpace(s) == "" {
+		return m, nil
+	}
+	for _, pair := range strings.Split(s, ",") {
+		column, field, found := strings.Cut(pair, "=")
+		column, field = strings.TrimSpace(column), strings.TrimSpace(field)
+		if !found || column == "" {
+			return nil, fmt.Errorf("mapping %q isn't of the form column=field", pair)
+		}
+		if !validField(field) {
+			return nil, fmt.Errorf("column %s is mapped to unknown field %q", column, field)
+		}
+		m[column] = field
+	}
+	return m, nil
+}
+
+func validField(field string) bool {
+	switch field {
+	case FieldExternalID, FieldName, FieldType, FieldDescription, FieldParent, FieldID, ignored:
+		return true
+	}
+	return strings.HasPrefix(field, metadataPrefix) && len(field) > len(metadataPrefix)
+}
+
+// field returns the field a column fills.
+func (m Mapping) field(column string) (string, error) {
+	if field, mapped := m[column]; mapped {
+		return field, nil
+	}
+	if validField(column) && column != ignored {
+		return column, nil
+	}
+	return "", fmt.Errorf("unknown column %q; map it to a field, metadata.<key> or -", column)
+}
+
+// Row is a record read from line Line, or why it couldn't be read.
+type Row struct {
+	Line   int
+	Record *pb.AssetRecord
+	Err    error
+}
+
+// Read reads the records in r. Rows that can't be read are returned with
+// their error so every problem in a file can be reported at once; an error
+// is returned only when nothing can be read, such as for a CSV header naming
+// an unknown column.
+func Read(r io.Reader, format Format, mapping Mapping) ([]Row, error) {
+	if format == JSONLines {
+		return readJSONLines(r, mapping)
+	}
+	return readCSV(r, mapping)
+}
+
+func readCSV(r io.Reader, mapping Mapping) ([]Row, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	fields := make([]string, len(header))
+	for i, column := range header {
+		// Spreadsheets often save a byte order mark
+		if i == 0 {
+			column = strings.TrimPrefix(column, "\ufeff")
+		}
+		if fields[i], err = mapping.field(strings.TrimSpace(column)); err != nil {
+			return nil, err
+		}
+	}
+
+	var rows []Row
+	for {
+		values, err := reader.Read()
+		if err == io.EOF {
+			return rows, nil
+		}
+		var parseErr *csv.ParseError
+		if errors.As(err, &parseErr) {
+			rows = append(rows, Row{Line: parseErr.StartLine, Err: parseErr.Err})
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		line, _ := reader.FieldPos(0)
+		if len(values) != len(fields) {
+			rows = append(rows, Row{Line: line, Err: fmt.Errorf("expected %d columns, got %d", len(fields), len(values))})
+			continue
+		}
+		record := &pb.AssetRecord{}
+		for i, value := range values {
+			set(record, fields[i], strings.TrimSpace(value))
+		}
+		rows = append(rows, Row{Line: line, Record: record})
+	}
+}
+
+func readJSONLines(r io.Reader, mapping Mapping) ([]Row, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	var rows []Row
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" {
+			continue
+		}
+		record, err := parseObject(text, mapping)
+		rows = append(rows, Row{Line: line, Record: record, Err: err})
+	}
+	return rows, scanner.Err()
+}
+
+func parseObject(text string, mapping Mapping) (*pb.AssetRecord, error) {
+	decoder := json.NewDecoder(strings.NewReader(text))
+	decoder.UseNumber()
+	var object map[string]any
+	if err := decoder.Decode(&object); err != nil {
+		return nil, err
+	}
+
+	// Nested objects, such as metadata, are flattened to "outer.inner" keys
+	values := make(map[string]string)
+	var flatten func(prefix string, object map[string]any) error
+	flatten = func(prefix string, object map[string]any) error {
+		for key, value := range object {
+			switch v := value.(type) {
+			case nil:
+			case string:
+				values[prefix+key] = v
+			case json.Number:
+				values[prefix+key] = v.String()
+			case bool:
+				values[prefix+key] = strconv.FormatBool(v)
+			case map[string]any:
+				if err := flatten(prefix+key+".", v); err != nil {
+					return err
+				}
+			default:
+				return fmt.Errorf("%s%s: expected a string, number or boolean", prefix, key)
+			}
+		}
+		return nil
+	}
+	if err := flatten("", object); err != nil {
+		return nil, err
+	}
+
+	record := &pb.AssetRecord{}
+	var errs []error
+	for _, key := range slices.Sorted(maps.Keys(values)) {
+		field, err := mapping.field(key)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		set(record, field, values[key])
+	}
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	return record, nil
+}
+
+// set fills field of record with value. Empty values leave fields unset.
+func set(record *pb.AssetRecord, field, value string) {
+	if value == "" {
+		return
+	}
+	switch field {
+	case FieldExternalID:
+		record.ExternalId = value
+	case FieldName:
+		record.Name = value
+	case FieldType:
+		record.Type = value
+	case FieldDescription:
+		record.Description = value
+	case FieldParent:
+		record.ParentExternalId = value
+	default:
+		if key, found := strings.CutPrefix(field, metadataPrefix); found {
+			if record.Metadata == nil {
+				record.Metadata = make(map[string]string)
+			}
+			record.Metadata[key] = value
+		}
+	}
+}
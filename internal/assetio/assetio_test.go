@@ -0,0 +1,171 @@
+package assetio
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+
+	pb "github.com/sairamkiran9/asset-telemetry-monitor/gen/go/proto/asset"
+)
+
+func TestParseFormat(t *testing.T) {
+	tests := []struct {
+		name   string
+		format Format
+		valid  bool
+	}{
+		{"csv", CSV, true},
+		{"CSV", CSV, true},
+		{"jsonl", JSONLines, true},
+		{"assets.ndjson", JSONLines, true},
+		{"exports/building-1.csv", CSV, true},
+		{"assets.xlsx", 0, false},
+		{"-", 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			format, err := ParseFormat(tt.name)
+			if tt.valid && (err != nil || format != tt.format) {
+				t.Errorf("Expected %v, got %v, %v", tt.format, format, err)
+			}
+			if !tt.valid && err == nil {
+				t.Errorf("Expected an error, got %v", format)
+			}
+		})
+	}
+}
+
+func TestParseMapping(t *testing.T) {
+	m, err := ParseMapping("Asset Tag=external_id, Area (m2) = metadata.floor_area,Notes=-")
+	if err != nil {
+		t.Fatalf("ParseMapping failed: %v", err)
+	}
+	if m["Asset Tag"] != "external_id" || m["Area (m2)"] != "metadata.floor_area" || m["Notes"] != "-" {
+		t.Errorf("Expected three mapped columns, got %v", m)
+	}
+
+	for _, bad := range []string{"Tag", "=name", "Tag=tag", "Area=metadata."} {
+		if _, err := ParseMapping(bad); err == nil {
+			t.Errorf("Expected %q to fail", bad)
+		}
+	}
+}
+
+func TestReadCSV(t *testing.T) {
+	input := "\ufeffAsset Tag,name,Type,Area,parent,Notes\n" +
+		"B1,Building 1,building,12000,,Main campus\n" +
+		"B1-F1, Floor 1 ,floor,,B1,\n" +
+		"B1-F2,Floor 2\n" +
+		"\"B1-M1,Meter,electric,,B1-F1,\n"
+	mapping, _ := ParseMapping("Asset Tag=external_id,Type=type,Area=metadata.floor_area,Notes=-")
+
+	rows, err := Read(strings.NewReader(input), CSV, mapping)
+	if err != nil {
+		t.Fatalf("Read failed: %v", err)
+	}
+	if len(rows) != 4 {
+		t.Fatalf("Expected 4 rows, got %d", len(rows))
+	}
+	want := []*pb.AssetRecord{
+		{ExternalId: "B1", Name: "Building 1", Type: "building", Metadata: map[string]string{"floor_area": "12000"}},
+		{ExternalId: "B1-F1", Name: "Floor 1", Type: "floor", ParentExternalId: "B1"},
+	}
+	for i, record := range want {
+		if rows[i].Err != nil || rows[i].Line != i+2 || !proto.Equal(rows[i].Record, record) {
+			t.Errorf("Expected line %d to be %v, got %+v", i+2, record, rows[i])
+		}
+	}
+	if rows[2].Line != 4 || rows[2].Err == nil || !strings.Contains(rows[2].Err.Error(), "expected 6 columns, got 2") {
+		t.Errorf("Expected line 4 to be short, got %+v", rows[2])
+	}
+	if rows[3].Line != 5 || rows[3].Err == nil {
+		t.Errorf("Expected line 5 to be malformed, got %+v", rows[3])
+	}
+
+	if _, err := Read(strings.NewReader("Asset Tag,name\nB1,Building 1\n"), CSV, Mapping{}); err == nil || !strings.Contains(err.Error(), `"Asset Tag"`) {
+		t.Errorf("Expected an unmapped column to fail, got %v", err)
+	}
+}
+
+func TestReadJSONLines(t *testing.T) {
+	input := `{"tag": "B1", "name": "Building 1", "type": "building", "metadata": {"floor_area": 12000, "listed": false}}
+
+{"tag": "B1-F1", "name": "Floor 1", "parent": "B1", "id": "asset-7", "level": 1}
+{"tag": "B1-F2", "name": "Floor 2", "rooms": [1, 2]}
+{"tag": "B1-F3", "colour": "red"}
+not json
+`
+	mapping, _ := ParseMapping("tag=external_id,level=metadata.level")
+
+	rows, err := Read(strings.NewReader(input), JSONLines, mapping)
+	if err != nil {
+		t.Fatalf("Read failed: %v", err)
+	}
+	if len(rows) != 5 {
+		t.Fatalf("Expected 5 rows, got %d", len(rows))
+	}
+	want := []*pb.AssetRecord{
+		{ExternalId: "B1", Name: "Building 1", Type: "building", Metadata: map[string]string{"floor_area": "12000", "listed": "false"}},
+		{ExternalId: "B1-F1", Name: "Floor 1", ParentExternalId: "B1", Metadata: map[string]string{"level": "1"}},
+	}
+	for i, record := range want {
+		if rows[i].Err != nil || !proto.Equal(rows[i].Record, record) {
+			t.Errorf("Expected row %d to be %v, got %+v", i, record, rows[i])
+		}
+	}
+	if rows[1].Line != 3 {
+		t.Errorf("Expected blank lines to count, got line %d", rows[1].Line)
+	}
+	for i, want := range []string{"rooms", `"colour"`, "invalid character"} {
+		if row := rows[i+2]; row.Err == nil || !strings.Contains(row.Err.Error(), want) {
+			t.Errorf("Expected line %d to fail with %q, got %+v", row.Line, want, row)
+		}
+	}
+}
+
+func TestWriteReadBack(t *testing.T) {
+	assets := []*pb.Asset{
+		{Id: "asset-1", Name: "Building 1", Type: "building", Metadata: map[string]string{"external_id": "B1", "floor_area": "12000"}},
+		{Id: "asset-2", Name: "Floor 1, east", Type: "floor", ParentId: "asset-1", Metadata: map[string]string{"external_id": "B1-F1", "level": "1"}},
+		{Id: "asset-3", Name: "Meter", Type: "electric", Description: "Says \"main\"", ParentId: "asset-2", Metadata: map[string]string{"external_id": "B1-M1"}},
+		{Id: "asset-4", Name: "Untagged", ParentId: "asset-3"},
+		{Id: "asset-5", Name: "Under untagged", ParentId: "asset-4", Metadata: map[string]string{"external_id": "X1"}},
+	}
+	want := []*pb.AssetRecord{
+		{ExternalId: "B1", Name: "Building 1", Type: "building", Metadata: map[string]string{"floor_area": "12000"}},
+		{ExternalId: "B1-F1", Name: "Floor 1, east", Type: "floor", ParentExternalId: "B1", Metadata: map[string]string{"level": "1"}},
+		{ExternalId: "B1-M1", Name: "Meter", Type: "electric", Description: "Says \"main\"", ParentExternalId: "B1-F1"},
+		{Name: "Untagged", ParentExternalId: "B1-M1"},
+		{ExternalId: "X1", Name: "Under untagged"},
+	}
+
+	for _, format := range []Format{CSV, JSONLines} {
+		t.Run(format.String(), func(t *testing.T) {
+			var buf bytes.Buffer
+			w := NewWriter(&buf, format, "external_id")
+			for _, asset := range assets {
+				if err := w.Write(asset); err != nil {
+					t.Fatalf("Write failed: %v", err)
+				}
+			}
+			if err := w.Close(); err != nil {
+				t.Fatalf("Close failed: %v", err)
+			}
+
+			rows, err := Read(&buf, format, Mapping{})
+			if err != nil {
+				t.Fatalf("Read failed: %v", err)
+			}
+			if len(rows) != len(want) {
+				t.Fatalf("Expected %d rows, got %d", len(want), len(rows))
+			}
+			for i, record := range want {
+				if rows[i].Err != nil || !proto.Equal(rows[i].Record, record) {
+					t.Errorf("Expected %v, got %+v", record, rows[i])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,106 @@
+package assetio
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"maps"
+	"slices"
+
+	pb "github.com/sairamkiran9/asset-telemetry-monitor/gen/go/proto/asset"
+)
+
+// Writer writes assets in a form Read reads back: their external ID, the
+// external ID of their parent and the rest of their metadata. Parents must be
+// written before their children, as ExportAssets sends them.
+type Writer struct {
+	w      io.Writer
+	format Format
+	key    string // Metadata key holding external IDs
+
+	// External IDs of the assets written so far, by registry ID
+	externalIDs map[string]string
+
+	// CSV columns depend on every asset's metadata, so CSV rows are held
+	// until Close
+	rows []exported
+}
+
+// exported is an asset as Writer writes it.
+type exported struct {
+	ID          string            `json:"id"`
+	ExternalID  string            `json:"external_id,omitempty"`
+	Name        string            `json:"name"`
+	Type        string            `json:"type,omitempty"`
+	Description string            `json:"description,omitempty"`
+	Parent      string            `json:"parent,omitempty"`
+	Metadata    map[string]string `json:"metadata,omitempty"`
+}
+
+// NewWriter returns a Writer of assets to w keeping external IDs in the
+// metadata key externalIDKey.
+func NewWriter(w io.Writer, format Format, externalIDKey string) *Writer {
+	return &Writer{w: w, format: format, key: externalIDKey, externalIDs: make(map[string]string)}
+}
+
+// Write writes an asset. Parents without an external ID are left out, since
+// imports couldn't find them.
+func (w *Writer) Write(asset *pb.Asset) error {
+	metadata := maps.Clone(asset.Metadata)
+	delete(metadata, w.key)
+	e := exported{
+		ID:          asset.Id,
+		ExternalID:  asset.Metadata[w.key],
+		Name:        asset.Name,
+		Type:        asset.Type,
+		Description: asset.Description,
+		Parent:      w.externalIDs[asset.ParentId],
+		Metadata:    metadata,
+	}
+	if e.ExternalID != "" {
+		w.externalIDs[asset.Id] = e.ExternalID
+	}
+
+	if w.format == CSV {
+		w.rows = append(w.rows, e)
+		return nil
+	}
+	encoder := json.NewEncoder(w.w)
+	encoder.SetEscapeHTML(false)
+	return encoder.Encode(e)
+}
+
+// Close writes what's held back. It doesn't close the underlying writer.
+func (w *Writer) Close() error {
+	if w.format != CSV {
+		return nil
+	}
+
+	keys := make(map[string]bool)
+	for _, row := range w.rows {
+		for key := range row.Metadata {
+			keys[key] = true
+		}
+	}
+	metadataKeys := slices.Sorted(maps.Keys(keys))
+	header := []string{FieldID, FieldExternalID, FieldName, FieldType, FieldDescription, FieldParent}
+	for _, key := range metadataKeys {
+		header = append(header, metadataPrefix+key)
+	}
+
+	writer := csv.NewWriter(w.w)
+	if err := writer.Write(header); err != nil {
+		return err
+	}
+	for _, row := range w.rows {
+		values := []string{row.ID, row.ExternalID, row.Name, row.Type, row.Description, row.Parent}
+		for _, key := range metadataKeys {
+			values = append(values, row.Metadata[key])
+		}
+		if err := writer.Write(values); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
@@ -0,0 +1,191 @@
+package assettypes
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	pb "github.com/sairamkiran9/asset-telemetry-monitor/gen/go/proto/asset"
+)
+
+// Registry holds the asset types assets may have and the metadata each
+// expects. Names match case-insensitively and ignoring '-', '_' and spaces,
+// so "Chilled-Water" finds chillwater by its alias.
+type Registry struct {
+	types []*pb.AssetTypeSchema
+	names map[string]*pb.AssetTypeSchema // Normalized names and aliases
+}
+
+// Builtin returns a registry of the built-in types. The monitored ones are
+// named after asset-monitoring's AssetType values in lower case.
+func Builtin() *Registry {
+	r := &Registry{names: make(map[string]*pb.AssetTypeSchema)}
+	for _, t := range builtin {
+		if err := r.add(proto.Clone(t).(*pb.AssetTypeSchema)); err != nil {
+			panic(err)
+		}
+	}
+	return r
+}
+
+// Load returns the built-in types plus those defined in the JSON file at
+// path, as in
+//
+//	{"types": [{"name": "boiler", "fields": [{"name": "rated_kw", "kind": "NUMBER", "unit": "kW"}],
+//	  "channels": [{"name": "flow_temp", "unit": "°C", "min": 60, "max": 80}]}]}
+//
+// with each type in the form ListAssetTypes returns. An empty path loads
+// only the built-in types.
+func Load(path string) (*Registry, error) {
+	r := Builtin()
+	if path == "" {
+		return r, nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var file struct {
+		Types []json.RawMessage `json:"types"`
+	}
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	for i, raw := range file.Types {
+		t := &pb.AssetTypeSchema{}
+		if err := protojson.Unmarshal(raw, t); err != nil {
+			return nil, fmt.Errorf("%s: type %d: %w", path, i+1, err)
+		}
+		if err := r.add(t); err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+	}
+	return r, nil
+}
+
+// add checks a type's definition and adds it.
+func (r *Registry) add(t *pb.AssetTypeSchema) error {
+	if t.Name == "" {
+		return errors.New("asset type name is required")
+	}
+	for _, name := range append([]string{t.Name}, t.Aliases...) {
+		if existing, taken := r.names[normalize(name)]; taken {
+			return fmt.Errorf("asset type name %q is already used by %s", name, existing.Name)
+		}
+	}
+	seen := make(map[string]bool)
+	for _, f := range t.Fields {
+		switch {
+		case f.Name == "":
+			return fmt.Errorf("asset type %s has a field without a name", t.Name)
+		case seen[f.Name]:
+			return fmt.Errorf("asset type %s defines field %s twice", t.Name, f.Name)
+		case f.Kind == pb.FieldKind_FIELD_KIND_UNKNOWN:
+			return fmt.Errorf("field %s of asset type %s needs a kind", f.Name, t.Name)
+		case f.Kind == pb.FieldKind_ENUM && len(f.Values) == 0:
+			return fmt.Errorf("enum field %s of asset type %s needs values", f.Name, t.Name)
+		}
+		seen[f.Name] = true
+	}
+	channels := make(map[string]bool)
+	for _, c := range t.Channels {
+		switch {
+		case c.Name == "":
+			return fmt.Errorf("asset type %s has a channel without a name", t.Name)
+		case channels[c.Name]:
+			return fmt.Errorf("asset type %s defines channel %s twice", t.Name, c.Name)
+		case c.Max < c.Min:
+			return fmt.Errorf("channel %s of asset type %s has max %g below min %g", c.Name, t.Name, c.Max, c.Min)
+		}
+		channels[c.Name] = true
+	}
+
+	r.types = append(r.types, t)
+	sort.Slice(r.types, func(i, j int) bool { return r.types[i].Name < r.types[j].Name })
+	for _, name := range append([]string{t.Name}, t.Aliases...) {
+		r.names[normalize(name)] = t
+	}
+	return nil
+}
+
+// Lookup finds a type by its name or an alias.
+func (r *Registry) Lookup(name string) (*pb.AssetTypeSchema, bool) {
+	t, found := r.names[normalize(name)]
+	return t, found
+}
+
+// Types returns every type in name order. The caller must not modify them.
+func (r *Registry) Types() []*pb.AssetTypeSchema {
+	return r.types
+}
+
+// Names returns the canonical type names in order.
+func (r *Registry) Names() []string {
+	names := make([]string, len(r.types))
+	for i, t := range r.types {
+		names[i] = t.Name
+	}
+	return names
+}
+
+func normalize(name string) string {
+	return strings.ToLower(strings.NewReplacer("-", "", "_", "", " ", "").Replace(name))
+}
+
+// Validate checks metadata against the fields t declares. Other metadata is
+// left alone.
+func Validate(t *pb.AssetTypeSchema, metadata map[string]string) error {
+	var errs []error
+	for _, f := range t.Fields {
+		value, set := metadata[f.Name]
+		if !set || value == "" {
+			if f.Required {
+				errs = append(errs, fmt.Errorf("metadata %s is required for %s assets", f.Name, t.Name))
+			}
+			continue
+		}
+		if err := checkValue(f, value); err != nil {
+			errs = append(errs, fmt.Errorf("metadata %s: %w", f.Name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func checkValue(f *pb.MetadataField, value string) error {
+	switch f.Kind {
+	case pb.FieldKind_NUMBER:
+		v, err := strconv.ParseFloat(value, 64)
+		if err != nil || math.IsInf(v, 0) || math.IsNaN(v) {
+			return fmt.Errorf("expected a number%s, got %q", inUnit(f), value)
+		}
+	case pb.FieldKind_INTEGER:
+		if _, err := strconv.ParseInt(value, 10, 64); err != nil {
+			return fmt.Errorf("expected an integer%s, got %q", inUnit(f), value)
+		}
+	case pb.FieldKind_BOOLEAN:
+		if _, err := strconv.ParseBool(value); err != nil {
+			return fmt.Errorf("expected true or false, got %q", value)
+		}
+	case pb.FieldKind_ENUM:
+		if !slices.Contains(f.Values, value) {
+			return fmt.Errorf("expected one of %s, got %q", strings.Join(f.Values, ", "), value)
+		}
+	}
+	return nil
+}
+
+func inUnit(f *pb.MetadataField) string {
+	if f.Unit == "" {
+		return ""
+	}
+	return " in " + f.Unit
+}
@@ -0,0 +1,129 @@
+package assettypes
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	pb "github.com/sairamkiran9/asset-telemetry-monitor/gen/go/proto/asset"
+)
+
+func TestLookup(t *testing.T) {
+	r := Builtin()
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"electric", "electric"},
+		{"Electric", "electric"},
+		{"chilled-water", "chillwater"},
+		{"Chilled Water", "chillwater"},
+		{"CHW", "chillwater"},
+		{"steam", "steam"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, found := r.Lookup(tt.name)
+			if !found || got.Name != tt.want {
+				t.Errorf("Expected %q to find %s, got %v", tt.name, tt.want, got)
+			}
+		})
+	}
+	if _, found := r.Lookup("temperature"); found {
+		t.Error("Expected no temperature type")
+	}
+}
+
+func TestValidate(t *testing.T) {
+	schema := &pb.AssetTypeSchema{
+		Name: "boiler",
+		Fields: []*pb.MetadataField{
+			{Name: "rated_kw", Kind: pb.FieldKind_NUMBER, Unit: "kW", Required: true},
+			{Name: "burners", Kind: pb.FieldKind_INTEGER},
+			{Name: "condensing", Kind: pb.FieldKind_BOOLEAN},
+			{Name: "fuel", Kind: pb.FieldKind_ENUM, Values: []string{"gas", "oil"}},
+		},
+	}
+	tests := []struct {
+		name     string
+		metadata map[string]string
+		err      string
+	}{
+		{"valid", map[string]string{"rated_kw": "850.5", "burners": "2", "condensing": "true", "fuel": "gas", "building": "plant-1"}, ""},
+		{"missing required", map[string]string{"fuel": "gas"}, "rated_kw is required"},
+		{"empty required", map[string]string{"rated_kw": ""}, "rated_kw is required"},
+		{"not a number", map[string]string{"rated_kw": "lots"}, `expected a number in kW, got "lots"`},
+		{"infinite", map[string]string{"rated_kw": "Inf"}, "expected a number"},
+		{"not an integer", map[string]string{"rated_kw": "1", "burners": "1.5"}, "expected an integer"},
+		{"not a boolean", map[string]string{"rated_kw": "1", "condensing": "maybe"}, "expected true or false"},
+		{"not a value", map[string]string{"rated_kw": "1", "fuel": "coal"}, "expected one of gas, oil"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Validate(schema, tt.metadata)
+			if tt.err == "" && err != nil {
+				t.Errorf("Expected valid metadata, got %v", err)
+			}
+			if tt.err != "" && (err == nil || !strings.Contains(err.Error(), tt.err)) {
+				t.Errorf("Expected an error containing %q, got %v", tt.err, err)
+			}
+		})
+	}
+}
+
+func TestLoad(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+
+	r, err := Load(write("types.json", `{"types": [
+		{"name": "boiler", "aliases": ["hot-water-boiler"], "fields": [{"name": "rated_kw", "kind": "NUMBER", "unit": "kW", "required": true}],
+		 "channels": [{"name": "flow_temp", "unit": "°C", "min": 60, "max": 80}]}
+	]}`))
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	boiler, found := r.Lookup("Hot Water Boiler")
+	if !found || boiler.Name != "boiler" || !boiler.Fields[0].Required {
+		t.Errorf("Expected the boiler type by its alias, got %v", boiler)
+	}
+	if c := boiler.GetChannels(); len(c) != 1 || c[0].Unit != "°C" || c[0].Max != 80 {
+		t.Errorf("Expected the boiler's flow_temp channel, got %v", c)
+	}
+	if _, found := r.Lookup("electric"); !found {
+		t.Error("Expected the built-in types to be kept")
+	}
+
+	tests := []struct {
+		name    string
+		content string
+	}{
+		{"not JSON", `types:`},
+		{"unknown field", `{"types": [{"name": "boiler", "colour": "red"}]}`},
+		{"no name", `{"types": [{"description": "nameless"}]}`},
+		{"built-in name", `{"types": [{"name": "Electric"}]}`},
+		{"taken alias", `{"types": [{"name": "chiller", "aliases": ["chw"]}]}`},
+		{"field without a kind", `{"types": [{"name": "boiler", "fields": [{"name": "rated_kw"}]}]}`},
+		{"enum without values", `{"types": [{"name": "boiler", "fields": [{"name": "fuel", "kind": "ENUM"}]}]}`},
+		{"channel without a name", `{"types": [{"name": "boiler", "channels": [{"unit": "°C"}]}]}`},
+		{"repeated channel", `{"types": [{"name": "boiler", "channels": [{"name": "flow_temp"}, {"name": "flow_temp"}]}]}`},
+		{"max below min", `{"types": [{"name": "boiler", "channels": [{"name": "flow_temp", "min": 80, "max": 60}]}]}`},
+		{"repeated field", `{"types": [{"name": "boiler", "fields": [{"name": "fuel", "kind": "STRING"}, {"name": "fuel", "kind": "STRING"}]}]}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Load(write("bad.json", tt.content)); err == nil {
+				t.Error("Expected Load to fail")
+			}
+		})
+	}
+	if _, err := Load(filepath.Join(dir, "missing.json")); err == nil {
+		t.Error("Expected a missing file to fail")
+	}
+}
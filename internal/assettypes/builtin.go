@@ -0,0 +1,95 @@
+package assettypes
+
+import (
+	pb "github.com/sairamkiran9/asset-telemetry-monitor/gen/go/proto/asset"
+)
+
+func number(name, unit, description string) *pb.MetadataField {
+	return &pb.MetadataField{Name: name, Kind: pb.FieldKind_NUMBER, Unit: unit, Description: description}
+}
+
+func channel(name, unit string, min, max float64) *pb.ReadingChannel {
+	return &pb.ReadingChannel{Name: name, Unit: unit, Min: min, Max: max}
+}
+
+// builtin are the types every registry has. Their fields are optional, so
+// assets registered before types were checked stay valid. The channels of
+// the monitored types are named after the fields of their typed readings.
+var builtin = []*pb.AssetTypeSchema{
+	{
+		Name:        "electric",
+		Description: "Electrical equipment or meter: feeders, panels, switchgear",
+		Aliases:     []string{"electrical"},
+		Fields: []*pb.MetadataField{
+			number("rated_kw", "kW", "Rated power"),
+			number("nominal_voltage", "V", "Nominal voltage power quality is measured against"),
+			number("nominal_frequency", "Hz", "Nominal frequency power quality is measured against"),
+			{Name: "phases", Kind: pb.FieldKind_ENUM, Values: []string{"1", "3"}, Description: "Single or three phase"},
+		},
+		Channels: []*pb.ReadingChannel{
+			channel("voltage", "V", 200, 240),
+			channel("current", "A", 10, 100),
+			channel("power", "kW", 100, 1000),
+			channel("frequency", "Hz", 49.5, 50.5),
+			channel("power_factor", "", 0.85, 0.99),
+			{Name: "energy", Unit: "kWh", Description: "Cumulative energy"},
+			{Name: "demand", Unit: "kW", Description: "Average power over the current 15-minute interval"},
+		},
+	},
+	{
+		Name:        "chillwater",
+		Description: "Chilled-water plant equipment: chillers, pumps, loops",
+		Aliases:     []string{"chilledwater", "chw"},
+		Fields: []*pb.MetadataField{
+			number("rated_tons", "tons", "Rated cooling capacity"),
+			number("design_delta_t", "°C", "Design difference between return and supply temperature"),
+			number("design_flow", "L/min", "Design flow rate"),
+		},
+		Channels: []*pb.ReadingChannel{
+			channel("supply_temp", "°C", 6, 8),
+			channel("return_temp", "°C", 12, 15),
+			channel("pressure", "bar", 3, 5),
+			channel("flow_rate", "L/min", 1000, 5000),
+		},
+	},
+	{
+		Name:        "steam",
+		Description: "Steam generation and distribution",
+		Fields: []*pb.MetadataField{
+			number("design_pressure", "bar", "Design operating pressure"),
+			number("rated_capacity", "kg/h", "Rated steam output"),
+		},
+		Channels: []*pb.ReadingChannel{
+			channel("pressure", "bar", 10, 50),
+			channel("temperature", "°C", 150, 250),
+			channel("quality", "%", 95, 99.5),
+			channel("enthalpy", "kJ/kg", 2500, 2800),
+		},
+	},
+	{
+		Name:        "site",
+		Description: "A campus or site grouping buildings",
+	},
+	{
+		Name:        "building",
+		Description: "A building",
+		Fields: []*pb.MetadataField{
+			number("floor_area", "m²", "Gross floor area"),
+			{Name: "year_built", Kind: pb.FieldKind_INTEGER},
+		},
+	},
+	{
+		Name:        "floor",
+		Description: "A floor of a building",
+		Fields: []*pb.MetadataField{
+			{Name: "level", Kind: pb.FieldKind_INTEGER, Description: "Floor number, negative below ground"},
+		},
+	},
+	{
+		Name:        "sensor",
+		Description: "A standalone sensor, such as a room thermometer",
+		Fields: []*pb.MetadataField{
+			{Name: "measurement", Kind: pb.FieldKind_STRING, Description: "What it measures, e.g. temperature"},
+		},
+	},
+}
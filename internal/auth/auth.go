@@ -0,0 +1,364 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// Role grants access to groups of RPCs.
+type Role string
+
+const (
+	// RoleViewer may call read-only RPCs.
+	RoleViewer Role = "viewer"
+	// RoleOperator may also change assets, rules, alerts and maintenance.
+	RoleOperator Role = "operator"
+	// RoleIngestDevice may only submit telemetry, typically for its own assets.
+	RoleIngestDevice Role = "ingest-device"
+	// RoleAdmin may call every RPC.
+	RoleAdmin Role = "admin"
+)
+
+// implied lists the roles each role also has.
+var implied = map[Role][]Role{
+	RoleOperator: {RoleViewer},
+	RoleAdmin:    {RoleViewer, RoleOperator, RoleIngestDevice},
+}
+
+// ParseRole validates a role name.
+func ParseRole(name string) (Role, error) {
+	switch r := Role(name); r {
+	case RoleViewer, RoleOperator, RoleIngestDevice, RoleAdmin:
+		return r, nil
+	default:
+		return "", fmt.Errorf("unknown role %q, expected viewer, operator, ingest-device or admin", name)
+	}
+}
+
+// Principal is an authenticated caller.
+type Principal struct {
+	Subject string
+	Roles   []Role
+	// Assets limits the caller to these asset IDs; empty means all assets
+	Assets []string
+	// Tenant owns everything the caller creates and bounds what it sees.
+	// AnyTenant marks service credentials that act for the tenant named in
+	// the TenantHeader metadata.
+	Tenant string
+}
+
+// HasRole reports whether p has role, directly or through a broader role.
+func (p *Principal) HasRole(role Role) bool {
+	for _, r := range p.Roles {
+		if r == role {
+			return true
+		}
+		for _, sub := range implied[r] {
+			if sub == role {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// CanAccessAsset reports whether p's asset scope includes assetID.
+func (p *Principal) CanAccessAsset(assetID string) bool {
+	if len(p.Assets) == 0 {
+		return true
+	}
+	for _, id := range p.Assets {
+		if id == assetID {
+			return true
+		}
+	}
+	return false
+}
+
+type principalKey struct{}
+
+// PrincipalFromContext returns the caller authenticated by the server
+// interceptors, or nil when authentication is disabled.
+func PrincipalFromContext(ctx context.Context) *Principal {
+	p, _ := ctx.Value(principalKey{}).(*Principal)
+	return p
+}
+
+// ContextWithPrincipal returns ctx carrying p.
+func ContextWithPrincipal(ctx context.Context, p *Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, p)
+}
+
+// Rule states who may call a method.
+type Rule struct {
+	// Public methods need no credentials
+	Public bool
+	// Roles that may call the method, in addition to admin
+	Roles []Role
+	// AssetField names the request field holding the asset ID, or IDs for a
+	// repeated field, checked against scoped credentials. Scoped credentials
+	// can't call methods without one.
+	AssetField string
+}
+
+// Policy maps full method names ("/asset.AssetRegistry/GetAsset") or service
+// prefixes ("/grpc.health.v1.Health/") to rules. Methods it doesn't cover are
+// limited to admins.
+type Policy map[string]Rule
+
+// CommonPolicy covers the services every server registers.
+var CommonPolicy = Policy{
+	"/grpc.health.v1.Health/":                    {Public: true},
+	"/grpc.reflection.v1.ServerReflection/":      {Roles: []Role{RoleViewer}},
+	"/grpc.reflection.v1alpha.ServerReflection/": {Roles: []Role{RoleViewer}},
+	"/monitoring.MetricsExporter/":               {Roles: []Role{RoleViewer}},
+}
+
+// With returns a policy combining p and other, with other taking precedence.
+func (p Policy) With(other Policy) Policy {
+	merged := make(Policy, len(p)+len(other))
+	for method, rule := range p {
+		merged[method] = rule
+	}
+	for method, rule := range other {
+		merged[method] = rule
+	}
+	return merged
+}
+
+func (p Policy) rule(method string) Rule {
+	if rule, ok := p[method]; ok {
+		return rule
+	}
+	if i := strings.LastIndex(method, "/"); i > 0 {
+		if rule, ok := p[method[:i+1]]; ok {
+			return rule
+		}
+	}
+	return Rule{}
+}
+
+// Authenticator authenticates callers by bearer token and authorizes them
+// against a Policy. A nil Authenticator allows every call.
+type Authenticator struct {
+	policy Policy
+	keys   map[string]*Principal // API keys by SHA-256 hex digest
+	jwt    *jwtVerifier
+}
+
+// New loads the credentials configured by cfg, or returns nil when
+// authentication is disabled.
+func New(cfg Config, policy Policy) (*Authenticator, error) {
+	if !cfg.Enabled() {
+		return nil, nil
+	}
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
+	a := &Authenticator{policy: policy}
+	if cfg.APIKeysFile != "" {
+		keys, err := loadAPIKeys(cfg.APIKeysFile)
+		if err != nil {
+			return nil, err
+		}
+		a.keys = keys
+	}
+	if cfg.JWKSFile != "" {
+		v, err := newJWTVerifier(cfg)
+		if err != nil {
+			return nil, err
+		}
+		a.jwt = v
+	}
+	return a, nil
+}
+
+var errMissingToken = errors.New("missing bearer token in authorization metadata")
+
+func bearerToken(ctx context.Context) (string, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	values := md.Get("authorization")
+	if len(values) == 0 {
+		return "", errMissingToken
+	}
+	scheme, token, ok := strings.Cut(values[0], " ")
+	if !ok || !strings.EqualFold(scheme, "bearer") || token == "" {
+		return "", errors.New("authorization metadata must be \"Bearer <token>\"")
+	}
+	return token, nil
+}
+
+// authenticate resolves the caller's token. JWTs are told apart from API keys
+// by their three dot separated parts.
+func (a *Authenticator) authenticate(ctx context.Context) (*Principal, error) {
+	token, err := bearerToken(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if strings.Count(token, ".") == 2 {
+		if a.jwt == nil {
+			return nil, errors.New("JWT authentication is not enabled")
+		}
+		return a.jwt.verify(token)
+	}
+	if p, ok := a.keys[hashKey(token)]; ok {
+		return p, nil
+	}
+	return nil, errors.New("invalid API key")
+}
+
+// authorize authenticates the caller of method, returning the context handlers
+// run with and the rule that requests must also pass.
+func (a *Authenticator) authorize(ctx context.Context, method string) (context.Context, Rule, error) {
+	rule := a.policy.rule(method)
+	if rule.Public {
+		return ctx, rule, nil
+	}
+
+	p, err := a.authenticate(ctx)
+	if err != nil {
+		return nil, rule, status.Error(codes.Unauthenticated, err.Error())
+	}
+
+	allowed := p.HasRole(RoleAdmin)
+	for _, role := range rule.Roles {
+		allowed = allowed || p.HasRole(role)
+	}
+	if !allowed {
+		return nil, rule, status.Errorf(codes.PermissionDenied, "%s is not allowed to call %s", p.Subject, method)
+	}
+
+	tenant, err := resolveTenant(ctx, p)
+	if err != nil {
+		return nil, rule, err
+	}
+	return ContextWithTenant(ContextWithPrincipal(ctx, p), tenant), rule, nil
+}
+
+// checkAssets verifies that a scoped caller only names its own assets.
+func checkAssets(ctx context.Context, rule Rule, req interface{}) error {
+	p := PrincipalFromContext(ctx)
+	if p == nil || len(p.Assets) == 0 {
+		return nil
+	}
+	ids, ok := assetIDs(req, rule.AssetField)
+	if !ok || len(ids) == 0 {
+		return status.Errorf(codes.PermissionDenied, "%s is limited to specific assets and must name them", p.Subject)
+	}
+	for _, id := range ids {
+		if !p.CanAccessAsset(id) {
+			return status.Errorf(codes.PermissionDenied, "%s is not allowed to access asset %s", p.Subject, id)
+		}
+	}
+	return nil
+}
+
+// assetIDs reads the string or repeated string field named field of req.
+func assetIDs(req interface{}, field string) ([]string, bool) {
+	msg, ok := req.(proto.Message)
+	if field == "" || !ok {
+		return nil, false
+	}
+	m := msg.ProtoReflect()
+	fd := m.Descriptor().Fields().ByName(protoreflect.Name(field))
+	if fd == nil || fd.Kind() != protoreflect.StringKind {
+		return nil, false
+	}
+
+	if !fd.IsList() {
+		if id := m.Get(fd).String(); id != "" {
+			return []string{id}, true
+		}
+		return nil, true
+	}
+	list := m.Get(fd).List()
+	ids := make([]string, list.Len())
+	for i := range ids {
+		ids[i] = list.Get(i).String()
+	}
+	return ids, true
+}
+
+// UnaryServerInterceptor rejects unauthenticated and unauthorized calls, and
+// makes the caller available through PrincipalFromContext and its tenant
+// through TenantFromContext.
+func (a *Authenticator) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if a == nil {
+			return handler(unauthenticatedTenant(ctx), req)
+		}
+		ctx, rule, err := a.authorize(ctx, info.FullMethod)
+		if err != nil {
+			return nil, err
+		}
+		if err := checkAssets(ctx, rule, req); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// authorizedStream checks every received message against the caller's asset
+// scope.
+type authorizedStream struct {
+	grpc.ServerStream
+	ctx  context.Context
+	rule Rule
+}
+
+func (s *authorizedStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *authorizedStream) RecvMsg(m interface{}) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+	return checkAssets(s.ctx, s.rule, m)
+}
+
+// StreamServerInterceptor is the streaming counterpart of
+// UnaryServerInterceptor.
+func (a *Authenticator) StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if a == nil {
+			return handler(srv, &authorizedStream{ServerStream: ss, ctx: unauthenticatedTenant(ss.Context())})
+		}
+		ctx, rule, err := a.authorize(ss.Context(), info.FullMethod)
+		if err != nil {
+			return err
+		}
+		return handler(srv, &authorizedStream{ServerStream: ss, ctx: ctx, rule: rule})
+	}
+}
+
+// tokenCredentials attaches a bearer token to outgoing calls.
+type tokenCredentials string
+
+func (t tokenCredentials) GetRequestMetadata(ctx context.Context, uri ...string) (map[string]string, error) {
+	return map[string]string{"authorization": "Bearer " + string(t)}, nil
+}
+
+// Plaintext is allowed so that development setups without TLS keep working
+func (t tokenCredentials) RequireTransportSecurity() bool {
+	return false
+}
+
+// DialOptions returns the options that present token on outgoing calls, or
+// none when token is empty.
+func DialOptions(token string) []grpc.DialOption {
+	if token == "" {
+		return nil
+	}
+	return []grpc.DialOption{grpc.WithPerRPCCredentials(tokenCredentials(token))}
+}
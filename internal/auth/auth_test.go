@@ -0,0 +1,456 @@
+package auth
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	assetpb "github.com/sairamkiran9/asset-telemetry-monitor/gen/go/proto/asset"
+	monitoringpb "github.com/sairamkiran9/asset-telemetry-monitor/gen/go/proto/asset_monitoring"
+	telemetrypb "github.com/sairamkiran9/asset-telemetry-monitor/gen/go/proto/telemetry"
+)
+
+const testKeys = `
+keys:
+  - subject: dashboard
+    key: viewer-key
+    roles: [viewer]
+  - subject: ops
+    key: operator-key
+    roles: [operator]
+  - subject: gateway-7
+    key: gateway-key
+    roles: [ingest-device]
+    assets: [pump-1, pump-2]
+  - subject: root
+    key_sha256: "%s"
+    roles: [admin]
+`
+
+var testPolicy = CommonPolicy.With(Policy{
+	telemetrypb.TelemetryService_SubmitTelemetry_FullMethodName:            {Roles: []Role{RoleOperator, RoleIngestDevice}, AssetField: "asset_id"},
+	telemetrypb.TelemetryService_GetTelemetryData_FullMethodName:           {Roles: []Role{RoleViewer}, AssetField: "asset_id"},
+	assetpb.AssetRegistry_RegisterAsset_FullMethodName:                     {Roles: []Role{RoleOperator}},
+	assetpb.AssetRegistry_ListAssets_FullMethodName:                        {Roles: []Role{RoleViewer}},
+	monitoringpb.AssetMonitoringService_SubscribeToReadings_FullMethodName: {Roles: []Role{RoleViewer}, AssetField: "asset_ids"},
+})
+
+func writeFile(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatalf("Failed to write %s: %v", name, err)
+	}
+	return path
+}
+
+func newTestAuthenticator(t *testing.T) *Authenticator {
+	t.Helper()
+	keys := writeFile(t, "keys.yaml", strings.Replace(testKeys, "%s", hashKey("admin-key"), 1))
+	a, err := New(Config{APIKeysFile: keys}, testPolicy)
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	return a
+}
+
+func withToken(token string) context.Context {
+	if token == "" {
+		return context.Background()
+	}
+	return metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer "+token))
+}
+
+func callUnary(a *Authenticator, token, method string, req interface{}) error {
+	_, err := a.UnaryServerInterceptor()(withToken(token), req, &grpc.UnaryServerInfo{FullMethod: method}, func(ctx context.Context, req interface{}) (interface{}, error) {
+		if PrincipalFromContext(ctx) == nil && method != "/grpc.health.v1.Health/Check" {
+			return nil, status.Error(codes.Internal, "no principal in handler context")
+		}
+		return nil, nil
+	})
+	return err
+}
+
+func TestUnaryAuthorization(t *testing.T) {
+	a := newTestAuthenticator(t)
+	submit := telemetrypb.TelemetryService_SubmitTelemetry_FullMethodName
+	register := assetpb.AssetRegistry_RegisterAsset_FullMethodName
+
+	tests := []struct {
+		name   string
+		token  string
+		method string
+		req    interface{}
+		expect codes.Code
+	}{
+		{"Health is public", "", "/grpc.health.v1.Health/Check", nil, codes.OK},
+		{"Missing token", "", register, &assetpb.RegisterAssetRequest{}, codes.Unauthenticated},
+		{"Unknown key", "nope", register, &assetpb.RegisterAssetRequest{}, codes.Unauthenticated},
+		{"Viewer can't write", "viewer-key", register, &assetpb.RegisterAssetRequest{}, codes.PermissionDenied},
+		{"Operator writes", "operator-key", register, &assetpb.RegisterAssetRequest{}, codes.OK},
+		{"Operator implies viewer", "operator-key", assetpb.AssetRegistry_ListAssets_FullMethodName, &assetpb.ListAssetsRequest{}, codes.OK},
+		{"Admin by hashed key", "admin-key", register, &assetpb.RegisterAssetRequest{}, codes.OK},
+		{"Unlisted method is admin only", "operator-key", "/asset.AssetRegistry/DeleteEverything", nil, codes.PermissionDenied},
+		{"Device submits for own asset", "gateway-key", submit, &telemetrypb.SubmitTelemetryRequest{AssetId: "pump-1"}, codes.OK},
+		{"Device can't submit for other asset", "gateway-key", submit, &telemetrypb.SubmitTelemetryRequest{AssetId: "pump-9"}, codes.PermissionDenied},
+		{"Device must name asset", "gateway-key", submit, &telemetrypb.SubmitTelemetryRequest{}, codes.PermissionDenied},
+		{"Device can't read", "gateway-key", telemetrypb.TelemetryService_GetTelemetryData_FullMethodName, &telemetrypb.GetTelemetryDataRequest{AssetId: "pump-1"}, codes.PermissionDenied},
+		{"Unscoped operator submits anywhere", "operator-key", submit, &telemetrypb.SubmitTelemetryRequest{AssetId: "pump-9"}, codes.OK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := callUnary(a, tt.token, tt.method, tt.req)
+			if status.Code(err) != tt.expect {
+				t.Errorf("Expected %v, got %v", tt.expect, err)
+			}
+		})
+	}
+}
+
+func TestNilAuthenticatorAllows(t *testing.T) {
+	a, err := New(Config{}, testPolicy)
+	if err != nil || a != nil {
+		t.Fatalf("Expected nil authenticator when disabled, got %v, %v", a, err)
+	}
+	_, err = a.UnaryServerInterceptor()(context.Background(), nil, &grpc.UnaryServerInfo{FullMethod: "/x.Y/Z"}, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return nil, nil
+	})
+	if err != nil {
+		t.Errorf("Expected call to be allowed, got %v", err)
+	}
+}
+
+func TestTenantResolution(t *testing.T) {
+	keys := writeFile(t, "keys.yaml", `
+keys:
+  - subject: north-ops
+    key: north-key
+    roles: [operator]
+    tenant: north-campus
+  - subject: legacy
+    key: legacy-key
+    roles: [operator]
+  - subject: asset-monitoring
+    key: service-key
+    roles: [admin]
+    tenant: "*"
+`)
+	a, err := New(Config{APIKeysFile: keys}, testPolicy)
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		token  string
+		header string
+		expect codes.Code
+		tenant string
+	}{
+		{"Tenant from key", "north-key", "", codes.OK, "north-campus"},
+		{"Repeating own tenant", "north-key", "north-campus", codes.OK, "north-campus"},
+		{"Other tenant denied", "north-key", "south-campus", codes.PermissionDenied, ""},
+		{"Key without tenant", "legacy-key", "", codes.OK, DefaultTenant},
+		{"Service acts for tenant", "service-key", "south-campus", codes.OK, "south-campus"},
+		{"Service without header", "service-key", "", codes.OK, AnyTenant},
+		{"Service with invalid tenant", "service-key", "south campus", codes.InvalidArgument, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			md := metadata.Pairs("authorization", "Bearer "+tt.token)
+			if tt.header != "" {
+				md.Set(TenantHeader, tt.header)
+			}
+			var tenant string
+			_, err := a.UnaryServerInterceptor()(metadata.NewIncomingContext(context.Background(), md), &assetpb.ListAssetsRequest{},
+				&grpc.UnaryServerInfo{FullMethod: assetpb.AssetRegistry_ListAssets_FullMethodName},
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					tenant = TenantFromContext(ctx)
+					return nil, nil
+				})
+			if status.Code(err) != tt.expect {
+				t.Fatalf("Expected %v, got %v", tt.expect, err)
+			}
+			if tenant != tt.tenant {
+				t.Errorf("Expected tenant %q, got %q", tt.tenant, tenant)
+			}
+		})
+	}
+}
+
+func TestTenantWithoutAuthentication(t *testing.T) {
+	var a *Authenticator
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(TenantHeader, "south-campus"))
+
+	var tenant string
+	a.UnaryServerInterceptor()(ctx, nil, &grpc.UnaryServerInfo{FullMethod: "/x.Y/Z"}, func(ctx context.Context, req interface{}) (interface{}, error) {
+		tenant = TenantFromContext(ctx)
+		return nil, nil
+	})
+	if tenant != "south-campus" {
+		t.Errorf("Expected tenant from metadata, got %q", tenant)
+	}
+
+	if tenant := TenantFromContext(context.Background()); tenant != DefaultTenant {
+		t.Errorf("Expected default tenant, got %q", tenant)
+	}
+}
+
+func TestTenantAccess(t *testing.T) {
+	north := ContextWithTenant(context.Background(), "north-campus")
+	service := ContextWithTenant(context.Background(), AnyTenant)
+
+	if !CanAccessTenant(north, "north-campus") || CanAccessTenant(north, "south-campus") {
+		t.Error("Expected a tenant to see only its own data")
+	}
+	if !CanAccessTenant(service, "south-campus") {
+		t.Error("Expected any tenant to see every tenant's data")
+	}
+	if !CanAccessTenant(context.Background(), "") {
+		t.Error("Expected data without a tenant to belong to the default tenant")
+	}
+
+	if tenant, err := RequireTenant(north); err != nil || tenant != "north-campus" {
+		t.Errorf("Expected north-campus, got %q, %v", tenant, err)
+	}
+	if _, err := RequireTenant(service); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Expected InvalidArgument for any tenant, got %v", err)
+	}
+}
+
+func TestTenantPropagation(t *testing.T) {
+	var sent []string
+	invoker := func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		md, _ := metadata.FromOutgoingContext(ctx)
+		sent = md.Get(TenantHeader)
+		return nil
+	}
+	interceptor := UnaryClientInterceptor()
+
+	interceptor(ContextWithTenant(context.Background(), "north-campus"), "/x.Y/Z", nil, nil, nil, invoker)
+	if len(sent) != 1 || sent[0] != "north-campus" {
+		t.Errorf("Expected tenant to be forwarded, got %v", sent)
+	}
+
+	for _, ctx := range []context.Context{context.Background(), ContextWithTenant(context.Background(), AnyTenant)} {
+		interceptor(ctx, "/x.Y/Z", nil, nil, nil, invoker)
+		if len(sent) != 0 {
+			t.Errorf("Expected no tenant header, got %v", sent)
+		}
+	}
+}
+
+type fakeStream struct {
+	grpc.ServerStream
+	ctx context.Context
+	req *monitoringpb.SubscribeRequest
+}
+
+func (f *fakeStream) Context() context.Context { return f.ctx }
+
+func (f *fakeStream) RecvMsg(m interface{}) error {
+	m.(*monitoringpb.SubscribeRequest).AssetIds = f.req.AssetIds
+	return nil
+}
+
+func TestStreamAssetScope(t *testing.T) {
+	keys := writeFile(t, "keys.yaml", `
+keys:
+  - subject: site-viewer
+    key: site-key
+    roles: [viewer]
+    assets: [pump-1, pump-2]
+`)
+	a, err := New(Config{APIKeysFile: keys}, testPolicy)
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		assets []string
+		expect codes.Code
+	}{
+		{"Own assets", []string{"pump-1", "pump-2"}, codes.OK},
+		{"Includes other asset", []string{"pump-1", "pump-3"}, codes.PermissionDenied},
+		{"All assets", nil, codes.PermissionDenied},
+	}
+
+	info := &grpc.StreamServerInfo{FullMethod: monitoringpb.AssetMonitoringService_SubscribeToReadings_FullMethodName}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stream := &fakeStream{ctx: withToken("site-key"), req: &monitoringpb.SubscribeRequest{AssetIds: tt.assets}}
+			err := a.StreamServerInterceptor()(nil, stream, info, func(srv interface{}, ss grpc.ServerStream) error {
+				return ss.RecvMsg(&monitoringpb.SubscribeRequest{})
+			})
+			if status.Code(err) != tt.expect {
+				t.Errorf("Expected %v, got %v", tt.expect, err)
+			}
+		})
+	}
+}
+
+func TestLoadAPIKeysErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		file   string
+		expect string
+	}{
+		{"Unknown role", "keys:\n  - {subject: a, key: k, roles: [superuser]}\n", `unknown role "superuser"`},
+		{"No roles", "keys:\n  - {subject: a, key: k}\n", "at least one role is required"},
+		{"No key", "keys:\n  - {subject: a, roles: [viewer]}\n", "key or key_sha256 is required"},
+		{"Bad digest", "keys:\n  - {subject: a, key_sha256: abc, roles: [viewer]}\n", "64 hex characters"},
+		{"Duplicate", "keys:\n  - {subject: a, key: k, roles: [viewer]}\n  - {subject: b, key: k, roles: [admin]}\n", "duplicate key"},
+		{"Invalid tenant", "keys:\n  - {subject: a, key: k, roles: [viewer], tenant: ../x}\n", "invalid tenant"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := loadAPIKeys(writeFile(t, "keys.yaml", tt.file))
+			if err == nil || !strings.Contains(err.Error(), tt.expect) {
+				t.Errorf("Expected error containing %q, got %v", tt.expect, err)
+			}
+		})
+	}
+}
+
+func b64(data []byte) string {
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func signJWT(t *testing.T, alg, kid string, key crypto.Signer, claims map[string]interface{}) string {
+	t.Helper()
+	header, _ := json.Marshal(map[string]string{"alg": alg, "kid": kid, "typ": "JWT"})
+	payload, _ := json.Marshal(claims)
+	signingInput := b64(header) + "." + b64(payload)
+	digest := sha256.Sum256([]byte(signingInput))
+
+	var sig []byte
+	switch k := key.(type) {
+	case *rsa.PrivateKey:
+		s, err := rsa.SignPKCS1v15(rand.Reader, k, crypto.SHA256, digest[:])
+		if err != nil {
+			t.Fatalf("Failed to sign: %v", err)
+		}
+		sig = s
+	case *ecdsa.PrivateKey:
+		r, s, err := ecdsa.Sign(rand.Reader, k, digest[:])
+		if err != nil {
+			t.Fatalf("Failed to sign: %v", err)
+		}
+		sig = append(r.FillBytes(make([]byte, 32)), s.FillBytes(make([]byte, 32))...)
+	}
+	return signingInput + "." + b64(sig)
+}
+
+func TestJWTVerification(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("Failed to generate RSA key: %v", err)
+	}
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("Failed to generate EC key: %v", err)
+	}
+
+	jwks, _ := json.Marshal(map[string]interface{}{"keys": []map[string]string{
+		{"kty": "RSA", "kid": "rsa-1", "alg": "RS256", "n": b64(rsaKey.N.Bytes()), "e": b64(big.NewInt(int64(rsaKey.E)).Bytes())},
+		{"kty": "EC", "kid": "ec-1", "crv": "P-256", "x": b64(ecKey.X.FillBytes(make([]byte, 32))), "y": b64(ecKey.Y.FillBytes(make([]byte, 32)))},
+	}})
+	cfg := Config{JWKSFile: writeFile(t, "jwks.json", string(jwks)), JWTIssuer: "https://idp.example", JWTAudience: "asset-telemetry"}
+	v, err := newJWTVerifier(cfg)
+	if err != nil {
+		t.Fatalf("newJWTVerifier failed: %v", err)
+	}
+
+	now := time.Now()
+	valid := func() map[string]interface{} {
+		return map[string]interface{}{
+			"sub":    "gateway-9",
+			"iss":    "https://idp.example",
+			"aud":    []string{"asset-telemetry", "other"},
+			"exp":    now.Add(time.Hour).Unix(),
+			"roles":  []string{"ingest-device"},
+			"assets": []string{"pump-1"},
+			"tenant": "north-campus",
+		}
+	}
+	with := func(key string, value interface{}) map[string]interface{} {
+		c := valid()
+		if value == nil {
+			delete(c, key)
+		} else {
+			c[key] = value
+		}
+		return c
+	}
+
+	rsaToken := signJWT(t, "RS256", "rsa-1", rsaKey, valid())
+	tampered := rsaToken[:strings.LastIndex(rsaToken, ".")-2] + "xx" + rsaToken[strings.LastIndex(rsaToken, "."):]
+
+	tests := []struct {
+		name   string
+		token  string
+		expect string
+	}{
+		{"RS256", rsaToken, ""},
+		{"ES256", signJWT(t, "ES256", "ec-1", ecKey, valid()), ""},
+		{"Expired", signJWT(t, "RS256", "rsa-1", rsaKey, with("exp", now.Add(-time.Hour).Unix())), "expired"},
+		{"No expiry", signJWT(t, "RS256", "rsa-1", rsaKey, with("exp", nil)), "no exp claim"},
+		{"Not yet valid", signJWT(t, "RS256", "rsa-1", rsaKey, with("nbf", now.Add(time.Hour).Unix())), "not valid yet"},
+		{"Wrong issuer", signJWT(t, "RS256", "rsa-1", rsaKey, with("iss", "https://evil.example")), "issuer"},
+		{"Wrong audience", signJWT(t, "RS256", "rsa-1", rsaKey, with("aud", "other")), "audience"},
+		{"Unknown role", signJWT(t, "RS256", "rsa-1", rsaKey, with("roles", []string{"root"})), "unknown role"},
+		{"Invalid tenant", signJWT(t, "RS256", "rsa-1", rsaKey, with("tenant", "north campus")), "invalid tenant"},
+		{"Unknown kid", signJWT(t, "RS256", "rsa-2", rsaKey, valid()), "unknown JWT key"},
+		{"Algorithm mismatch", signJWT(t, "ES256", "rsa-1", ecKey, valid()), "doesn't match"},
+		{"Tampered payload", tampered, "invalid JWT signature"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, err := v.verify(tt.token)
+			if tt.expect == "" {
+				if err != nil {
+					t.Fatalf("Expected valid token, got %v", err)
+				}
+				if p.Subject != "gateway-9" || !p.HasRole(RoleIngestDevice) || !p.CanAccessAsset("pump-1") || p.CanAccessAsset("pump-2") || p.Tenant != "north-campus" {
+					t.Errorf("Unexpected principal %+v", p)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.expect) {
+				t.Errorf("Expected error containing %q, got %v", tt.expect, err)
+			}
+		})
+	}
+}
+
+func TestTokenCredentials(t *testing.T) {
+	if opts := DialOptions(""); len(opts) != 0 {
+		t.Errorf("Expected no dial options without a token, got %d", len(opts))
+	}
+	md, err := tokenCredentials("abc").GetRequestMetadata(context.Background())
+	if err != nil || md["authorization"] != "Bearer abc" {
+		t.Errorf("Expected bearer metadata, got %v, %v", md, err)
+	}
+}
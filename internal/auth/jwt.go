@@ -0,0 +1,256 @@
+package auth
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"strings"
+	"time"
+)
+
+// clockSkew tolerates clock differences between the token issuer and us.
+const clockSkew = time.Minute
+
+// jwk is one key of a JWKS file. RSA keys verify RS256 and P-256 keys ES256.
+type jwk struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Alg string `json:"alg"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+func decodeInt(field, s string) (*big.Int, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil || len(b) == 0 {
+		return nil, fmt.Errorf("invalid %s", field)
+	}
+	return new(big.Int).SetBytes(b), nil
+}
+
+func (k jwk) publicKey() (crypto.PublicKey, string, error) {
+	switch k.Kty {
+	case "RSA":
+		n, err := decodeInt("n", k.N)
+		if err != nil {
+			return nil, "", err
+		}
+		e, err := decodeInt("e", k.E)
+		if err != nil {
+			return nil, "", err
+		}
+		if !e.IsInt64() || e.Int64() < 3 {
+			return nil, "", errors.New("invalid e")
+		}
+		return &rsa.PublicKey{N: n, E: int(e.Int64())}, "RS256", nil
+	case "EC":
+		if k.Crv != "P-256" {
+			return nil, "", fmt.Errorf("unsupported curve %q, expected P-256", k.Crv)
+		}
+		x, err := decodeInt("x", k.X)
+		if err != nil {
+			return nil, "", err
+		}
+		y, err := decodeInt("y", k.Y)
+		if err != nil {
+			return nil, "", err
+		}
+		pub := &ecdsa.PublicKey{Curve: elliptic.P256(), X: x, Y: y}
+		if !pub.Curve.IsOnCurve(x, y) {
+			return nil, "", errors.New("point is not on the curve")
+		}
+		return pub, "ES256", nil
+	default:
+		return nil, "", fmt.Errorf("unsupported key type %q, expected RSA or EC", k.Kty)
+	}
+}
+
+type verificationKey struct {
+	alg string
+	key crypto.PublicKey
+}
+
+// jwtVerifier verifies RS256 and ES256 JWTs against the keys of a JWKS file.
+type jwtVerifier struct {
+	keys     map[string]verificationKey // By kid
+	issuer   string
+	audience string
+	now      func() time.Time
+}
+
+func newJWTVerifier(cfg Config) (*jwtVerifier, error) {
+	data, err := os.ReadFile(cfg.JWKSFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read JWKS: %w", err)
+	}
+	var set struct {
+		Keys []jwk `json:"keys"`
+	}
+	if err := json.Unmarshal(data, &set); err != nil {
+		return nil, fmt.Errorf("failed to parse JWKS file %s: %w", cfg.JWKSFile, err)
+	}
+	if len(set.Keys) == 0 {
+		return nil, fmt.Errorf("JWKS file %s has no keys", cfg.JWKSFile)
+	}
+
+	v := &jwtVerifier{
+		keys:     make(map[string]verificationKey, len(set.Keys)),
+		issuer:   cfg.JWTIssuer,
+		audience: cfg.JWTAudience,
+		now:      time.Now,
+	}
+	for i, k := range set.Keys {
+		pub, alg, err := k.publicKey()
+		if err != nil {
+			return nil, fmt.Errorf("JWKS file %s: key %d: %w", cfg.JWKSFile, i+1, err)
+		}
+		if k.Alg != "" && k.Alg != alg {
+			return nil, fmt.Errorf("JWKS file %s: key %d: alg %s doesn't match key type %s", cfg.JWKSFile, i+1, k.Alg, k.Kty)
+		}
+		if _, dup := v.keys[k.Kid]; dup {
+			return nil, fmt.Errorf("JWKS file %s: duplicate kid %q", cfg.JWKSFile, k.Kid)
+		}
+		v.keys[k.Kid] = verificationKey{alg: alg, key: pub}
+	}
+	return v, nil
+}
+
+// audience accepts the aud claim as a string or an array of strings.
+type audience []string
+
+func (a *audience) UnmarshalJSON(data []byte) error {
+	var single string
+	if err := json.Unmarshal(data, &single); err == nil {
+		*a = audience{single}
+		return nil
+	}
+	var list []string
+	if err := json.Unmarshal(data, &list); err != nil {
+		return errors.New("aud must be a string or an array of strings")
+	}
+	*a = list
+	return nil
+}
+
+type claims struct {
+	Subject   string   `json:"sub"`
+	Issuer    string   `json:"iss"`
+	Audience  audience `json:"aud"`
+	ExpiresAt *int64   `json:"exp"`
+	NotBefore *int64   `json:"nbf"`
+	Roles     []string `json:"roles"`
+	Assets    []string `json:"assets"`
+	Tenant    string   `json:"tenant"`
+}
+
+// verify checks the token's signature and claims, returning its principal.
+// Tokens must expire; roles, assets and tenant come from the claims of the
+// same names.
+func (v *jwtVerifier) verify(token string) (*Principal, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, errors.New("malformed JWT")
+	}
+
+	var header struct {
+		Alg string `json:"alg"`
+		Kid string `json:"kid"`
+	}
+	if err := decodeSegment(parts[0], &header); err != nil {
+		return nil, fmt.Errorf("malformed JWT header: %w", err)
+	}
+	key, ok := v.keys[header.Kid]
+	if !ok && header.Kid == "" && len(v.keys) == 1 {
+		for _, only := range v.keys {
+			key, ok = only, true
+		}
+	}
+	if !ok {
+		return nil, fmt.Errorf("unknown JWT key %q", header.Kid)
+	}
+	// The algorithm is fixed by the key, never chosen by the token
+	if header.Alg != key.alg {
+		return nil, fmt.Errorf("JWT algorithm %q doesn't match key algorithm %s", header.Alg, key.alg)
+	}
+
+	sig, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, errors.New("malformed JWT signature")
+	}
+	digest := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+	if !verifySignature(key, digest[:], sig) {
+		return nil, errors.New("invalid JWT signature")
+	}
+
+	var c claims
+	if err := decodeSegment(parts[1], &c); err != nil {
+		return nil, fmt.Errorf("malformed JWT claims: %w", err)
+	}
+	now := v.now()
+	if c.ExpiresAt == nil {
+		return nil, errors.New("JWT has no exp claim")
+	}
+	if now.After(time.Unix(*c.ExpiresAt, 0).Add(clockSkew)) {
+		return nil, errors.New("JWT has expired")
+	}
+	if c.NotBefore != nil && now.Add(clockSkew).Before(time.Unix(*c.NotBefore, 0)) {
+		return nil, errors.New("JWT is not valid yet")
+	}
+	if v.issuer != "" && c.Issuer != v.issuer {
+		return nil, fmt.Errorf("JWT issuer %q is not accepted", c.Issuer)
+	}
+	if v.audience != "" && !c.Audience.contains(v.audience) {
+		return nil, errors.New("JWT is not intended for this audience")
+	}
+
+	p, err := newPrincipal(c.Subject, c.Tenant, c.Roles, c.Assets)
+	if err != nil {
+		return nil, fmt.Errorf("invalid JWT claims: %w", err)
+	}
+	return p, nil
+}
+
+func (a audience) contains(aud string) bool {
+	for _, s := range a {
+		if s == aud {
+			return true
+		}
+	}
+	return false
+}
+
+func decodeSegment(segment string, v interface{}) error {
+	data, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
+
+func verifySignature(key verificationKey, digest, sig []byte) bool {
+	switch pub := key.key.(type) {
+	case *rsa.PublicKey:
+		return rsa.VerifyPKCS1v15(pub, crypto.SHA256, digest, sig) == nil
+	case *ecdsa.PublicKey:
+		// JWS encodes ES256 signatures as the 32-byte r and s concatenated
+		if len(sig) != 64 {
+			return false
+		}
+		r := new(big.Int).SetBytes(sig[:32])
+		s := new(big.Int).SetBytes(sig[32:])
+		return ecdsa.Verify(pub, digest, r, s)
+	default:
+		return false
+	}
+}
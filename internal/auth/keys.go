@@ -0,0 +1,139 @@
+package auth
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Config enables authentication when either credential source is set.
+type Config struct {
+	APIKeysFile string `config:"api_keys_file" env:"AUTH_API_KEYS_FILE" usage:"YAML file of API keys with their roles and assets; enables authentication"`
+	JWKSFile    string `config:"jwks_file" env:"AUTH_JWKS_FILE" usage:"JWKS file of the keys that sign accepted JWTs; enables authentication"`
+	JWTIssuer   string `config:"jwt_issuer" env:"AUTH_JWT_ISSUER" usage:"iss claim required in JWTs; empty accepts any"`
+	JWTAudience string `config:"jwt_audience" env:"AUTH_JWT_AUDIENCE" usage:"aud claim required in JWTs; empty accepts any"`
+	Token       string `config:"token" env:"AUTH_TOKEN" usage:"API key or JWT presented when calling other services" secret:"true"`
+}
+
+// Enabled reports whether servers require credentials.
+func (c Config) Enabled() bool {
+	return c.APIKeysFile != "" || c.JWKSFile != ""
+}
+
+// Validate checks that JWT claims are only required when JWTs are accepted.
+func (c Config) Validate() error {
+	if c.JWKSFile == "" && (c.JWTIssuer != "" || c.JWTAudience != "") {
+		return errors.New("auth.jwks_file is required with auth.jwt_issuer or auth.jwt_audience")
+	}
+	return nil
+}
+
+// apiKeysFile is the format of Config.APIKeysFile:
+//
+//	keys:
+//	  - subject: gateway-7
+//	    key_sha256: 9f86d081884c7d65...
+//	    roles: [ingest-device]
+//	    assets: [pump-1, pump-2]
+//	    tenant: north-campus
+//
+// key holds the key itself instead of key_sha256, for development only.
+// Keys without a tenant belong to DefaultTenant; "*" is AnyTenant.
+type apiKeysFile struct {
+	Keys []struct {
+		Subject   string   `yaml:"subject"`
+		Key       string   `yaml:"key"`
+		KeySHA256 string   `yaml:"key_sha256"`
+		Roles     []string `yaml:"roles"`
+		Assets    []string `yaml:"assets"`
+		Tenant    string   `yaml:"tenant"`
+	} `yaml:"keys"`
+}
+
+func hashKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+// loadAPIKeys reads the API keys file, indexing principals by key digest.
+func loadAPIKeys(path string) (map[string]*Principal, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read API keys: %w", err)
+	}
+	var file apiKeysFile
+	if err := yaml.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("failed to parse API keys file %s: %w", path, err)
+	}
+
+	keys := make(map[string]*Principal, len(file.Keys))
+	var errs []error
+	for i, k := range file.Keys {
+		where := fmt.Sprintf("%s: key %d", path, i+1)
+		if k.Subject != "" {
+			where += " (" + k.Subject + ")"
+		}
+
+		var digest string
+		switch {
+		case k.Key != "" && k.KeySHA256 != "":
+			errs = append(errs, fmt.Errorf("%s: set only one of key and key_sha256", where))
+			continue
+		case k.Key != "":
+			digest = hashKey(k.Key)
+		case k.KeySHA256 != "":
+			digest = strings.ToLower(k.KeySHA256)
+			if b, err := hex.DecodeString(digest); err != nil || len(b) != sha256.Size {
+				errs = append(errs, fmt.Errorf("%s: key_sha256 must be 64 hex characters", where))
+				continue
+			}
+		default:
+			errs = append(errs, fmt.Errorf("%s: key or key_sha256 is required", where))
+			continue
+		}
+		if _, dup := keys[digest]; dup {
+			errs = append(errs, fmt.Errorf("%s: duplicate key", where))
+			continue
+		}
+
+		p, err := newPrincipal(k.Subject, k.Tenant, k.Roles, k.Assets)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", where, err))
+			continue
+		}
+		keys[digest] = p
+	}
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
+	return keys, nil
+}
+
+func newPrincipal(subject, tenant string, roles, assets []string) (*Principal, error) {
+	if subject == "" {
+		return nil, errors.New("subject is required")
+	}
+	if len(roles) == 0 {
+		return nil, errors.New("at least one role is required")
+	}
+	if tenant == "" {
+		tenant = DefaultTenant
+	}
+	if err := ValidateTenant(tenant); err != nil {
+		return nil, err
+	}
+	p := &Principal{Subject: subject, Assets: assets, Tenant: tenant}
+	for _, name := range roles {
+		role, err := ParseRole(name)
+		if err != nil {
+			return nil, err
+		}
+		p.Roles = append(p.Roles, role)
+	}
+	return p, nil
+}
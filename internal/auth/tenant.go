@@ -0,0 +1,128 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// DefaultTenant owns the data of callers whose credentials name no tenant,
+	// and of every caller when authentication is disabled.
+	DefaultTenant = "default"
+	// AnyTenant marks credentials, typically those services present to each
+	// other, that may act for any tenant. Without a TenantHeader they see
+	// every tenant's data but can't create any.
+	AnyTenant = "*"
+	// TenantHeader is the metadata key naming the tenant a call acts for.
+	TenantHeader = "x-tenant-id"
+)
+
+var tenantPattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]{0,62}$`)
+
+// ValidateTenant checks that name can identify a tenant.
+func ValidateTenant(name string) error {
+	if name != AnyTenant && !tenantPattern.MatchString(name) {
+		return fmt.Errorf("invalid tenant %q: use up to 63 letters, digits, '.', '_' or '-'", name)
+	}
+	return nil
+}
+
+type tenantKey struct{}
+
+// TenantFromContext returns the tenant a call acts for, DefaultTenant when the
+// server interceptors didn't set one.
+func TenantFromContext(ctx context.Context) string {
+	if tenant, ok := ctx.Value(tenantKey{}).(string); ok {
+		return tenant
+	}
+	return DefaultTenant
+}
+
+// ContextWithTenant returns ctx acting for tenant. Calls made with it to other
+// services carry the tenant in TenantHeader.
+func ContextWithTenant(ctx context.Context, tenant string) context.Context {
+	return context.WithValue(ctx, tenantKey{}, tenant)
+}
+
+// CanAccessTenant reports whether a call acting for ctx's tenant may see data
+// owned by tenant. Data without a tenant belongs to DefaultTenant.
+func CanAccessTenant(ctx context.Context, tenant string) bool {
+	if tenant == "" {
+		tenant = DefaultTenant
+	}
+	caller := TenantFromContext(ctx)
+	return caller == AnyTenant || caller == tenant
+}
+
+// RequireTenant returns the tenant that owns data created by the call,
+// rejecting AnyTenant callers that didn't name one.
+func RequireTenant(ctx context.Context) (string, error) {
+	tenant := TenantFromContext(ctx)
+	if tenant == AnyTenant {
+		return "", status.Errorf(codes.InvalidArgument, "credentials for any tenant must name one in %s metadata to create data", TenantHeader)
+	}
+	return tenant, nil
+}
+
+func requestedTenant(ctx context.Context) string {
+	md, _ := metadata.FromIncomingContext(ctx)
+	if values := md.Get(TenantHeader); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
+
+// resolveTenant picks the tenant an authenticated call acts for. Only AnyTenant
+// credentials may choose it; others may only repeat their own.
+func resolveTenant(ctx context.Context, p *Principal) (string, error) {
+	requested := requestedTenant(ctx)
+	if requested == "" || requested == p.Tenant {
+		return p.Tenant, nil
+	}
+	if p.Tenant != AnyTenant {
+		return "", status.Errorf(codes.PermissionDenied, "%s is not allowed to act for tenant %s", p.Subject, requested)
+	}
+	if err := ValidateTenant(requested); err != nil {
+		return "", status.Error(codes.InvalidArgument, err.Error())
+	}
+	return requested, nil
+}
+
+// unauthenticatedTenant trusts TenantHeader when authentication is disabled,
+// which offers no isolation but lets development setups use several tenants.
+func unauthenticatedTenant(ctx context.Context) context.Context {
+	tenant := requestedTenant(ctx)
+	if tenant == "" || ValidateTenant(tenant) != nil {
+		tenant = DefaultTenant
+	}
+	return ContextWithTenant(ctx, tenant)
+}
+
+func outgoingTenant(ctx context.Context) context.Context {
+	if tenant, ok := ctx.Value(tenantKey{}).(string); ok && tenant != AnyTenant {
+		return metadata.AppendToOutgoingContext(ctx, TenantHeader, tenant)
+	}
+	return ctx
+}
+
+// UnaryClientInterceptor forwards the tenant a server call acts for to the
+// services it calls.
+func UnaryClientInterceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		return invoker(outgoingTenant(ctx), method, req, reply, cc, opts...)
+	}
+}
+
+// StreamClientInterceptor is the streaming counterpart of
+// UnaryClientInterceptor.
+func StreamClientInterceptor() grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		return streamer(outgoingTenant(ctx), desc, cc, method, opts...)
+	}
+}
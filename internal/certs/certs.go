@@ -0,0 +1,307 @@
+package certs
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"os"
+	"sync/atomic"
+	"time"
+
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/peer"
+)
+
+// DefaultReloadInterval is how often certificate files are checked for
+// changes.
+const DefaultReloadInterval = 30 * time.Second
+
+// Config selects the certificate files. TLS is enabled when CertFile is set;
+// the same certificate is presented as a server and, for mTLS, as a client.
+type Config struct {
+	CertFile       string        `config:"cert_file" env:"TLS_CERT_FILE" usage:"PEM certificate for serving and dialing; empty disables TLS"`
+	KeyFile        string        `config:"key_file" env:"TLS_KEY_FILE" usage:"PEM private key of cert_file"`
+	CAFile         string        `config:"ca_file" env:"TLS_CA_FILE" usage:"PEM CA bundle that peers are verified against; empty uses the system roots"`
+	ClientAuth     bool          `config:"client_auth" env:"TLS_CLIENT_AUTH" usage:"require clients to present a certificate signed by ca_file (mTLS)"`
+	ReloadInterval time.Duration `config:"reload_interval" env:"TLS_RELOAD_INTERVAL" usage:"how often certificate files are checked for changes"`
+}
+
+// DefaultConfig disables TLS.
+func DefaultConfig() Config {
+	return Config{ReloadInterval: DefaultReloadInterval}
+}
+
+// Enabled reports whether TLS is configured.
+func (c Config) Enabled() bool {
+	return c.CertFile != ""
+}
+
+// Validate checks that the files needed by the configured mode are set.
+func (c Config) Validate() error {
+	var errs []error
+	if c.CertFile == "" && (c.KeyFile != "" || c.CAFile != "" || c.ClientAuth) {
+		errs = append(errs, errors.New("tls.cert_file is required when other TLS settings are set"))
+	}
+	if c.CertFile != "" && c.KeyFile == "" {
+		errs = append(errs, errors.New("tls.key_file is required with tls.cert_file"))
+	}
+	if c.ClientAuth && c.CAFile == "" {
+		errs = append(errs, errors.New("tls.ca_file is required with tls.client_auth"))
+	}
+	if c.ReloadInterval <= 0 {
+		errs = append(errs, fmt.Errorf("tls.reload_interval must be positive, got %v", c.ReloadInterval))
+	}
+	return errors.Join(errs...)
+}
+
+// keyPair is one loaded generation of the certificate files.
+type keyPair struct {
+	cert  tls.Certificate
+	pool  *x509.CertPool // nil means the system roots
+	stamp string         // Sizes and modification times of the files
+}
+
+// Reloader serves the current certificates from Config and reloads them when
+// the files change, so certificates can be rotated without a restart. A nil
+// Reloader means TLS is disabled.
+type Reloader struct {
+	cfg     Config
+	current atomic.Pointer[keyPair]
+}
+
+// New loads the certificates selected by cfg, or returns nil when TLS is
+// disabled.
+func New(cfg Config) (*Reloader, error) {
+	if !cfg.Enabled() {
+		return nil, nil
+	}
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
+	r := &Reloader{cfg: cfg}
+	kp, err := r.load()
+	if err != nil {
+		return nil, err
+	}
+	r.current.Store(kp)
+	return r, nil
+}
+
+func (r *Reloader) files() []string {
+	files := []string{r.cfg.CertFile, r.cfg.KeyFile}
+	if r.cfg.CAFile != "" {
+		files = append(files, r.cfg.CAFile)
+	}
+	return files
+}
+
+// stamp identifies the current contents of the files without reading them.
+func (r *Reloader) stamp() (string, error) {
+	var stamp string
+	for _, file := range r.files() {
+		info, err := os.Stat(file)
+		if err != nil {
+			return "", err
+		}
+		stamp += fmt.Sprintf("%s:%d:%d;", file, info.Size(), info.ModTime().UnixNano())
+	}
+	return stamp, nil
+}
+
+func (r *Reloader) load() (*keyPair, error) {
+	stamp, err := r.stamp()
+	if err != nil {
+		return nil, err
+	}
+
+	cert, err := tls.LoadX509KeyPair(r.cfg.CertFile, r.cfg.KeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load certificate: %w", err)
+	}
+
+	kp := &keyPair{cert: cert, stamp: stamp}
+	if r.cfg.CAFile != "" {
+		pem, err := os.ReadFile(r.cfg.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CA bundle: %w", err)
+		}
+		kp.pool = x509.NewCertPool()
+		if !kp.pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificates found in CA bundle %s", r.cfg.CAFile)
+		}
+	}
+	return kp, nil
+}
+
+// reload loads the files again if they changed. A failed reload keeps the
+// previous certificates, since the files may be mid-rotation.
+func (r *Reloader) reload() (bool, error) {
+	stamp, err := r.stamp()
+	if err != nil {
+		return false, err
+	}
+	if stamp == r.current.Load().stamp {
+		return false, nil
+	}
+
+	kp, err := r.load()
+	if err != nil {
+		return false, err
+	}
+	r.current.Store(kp)
+	return true, nil
+}
+
+// Run reloads the certificates whenever the files change, until ctx is done.
+func (r *Reloader) Run(ctx context.Context) {
+	if r == nil {
+		return
+	}
+
+	ticker := time.NewTicker(r.cfg.ReloadInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			reloaded, err := r.reload()
+			if err != nil {
+				log.Printf("Failed to reload TLS certificates, keeping previous: %v", err)
+			} else if reloaded {
+				log.Printf("Reloaded TLS certificates from %s", r.cfg.CertFile)
+			}
+		}
+	}
+}
+
+func (r *Reloader) serverConfig() *tls.Config {
+	kp := r.current.Load()
+	cfg := &tls.Config{
+		MinVersion:   tls.VersionTLS12,
+		Certificates: []tls.Certificate{kp.cert},
+		ClientCAs:    kp.pool,
+	}
+	if r.cfg.ClientAuth {
+		cfg.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+	return cfg
+}
+
+func (r *Reloader) clientConfig() *tls.Config {
+	kp := r.current.Load()
+	return &tls.Config{
+		MinVersion:   tls.VersionTLS12,
+		Certificates: []tls.Certificate{kp.cert},
+		RootCAs:      kp.pool,
+	}
+}
+
+// ClientAuth reports whether callers must present a verified certificate.
+func (r *Reloader) ClientAuth() bool {
+	return r != nil && r.cfg.ClientAuth
+}
+
+// IsReplica reports whether the caller of ctx presented a verified client
+// certificate naming the same subject as this service's own, as other
+// instances of the service do. It's false without mTLS.
+func (r *Reloader) IsReplica(ctx context.Context) bool {
+	if !r.ClientAuth() {
+		return false
+	}
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return false
+	}
+	info, ok := p.AuthInfo.(credentials.TLSInfo)
+	if !ok || len(info.State.VerifiedChains) == 0 {
+		return false
+	}
+	name := r.name()
+	return name != "" && info.State.VerifiedChains[0][0].Subject.CommonName == name
+}
+
+// name is the common name of this service's certificate.
+func (r *Reloader) name() string {
+	if leaf := r.current.Load().cert.Leaf; leaf != nil {
+		return leaf.Subject.CommonName
+	}
+	return ""
+}
+
+// replicaConfig narrows cfg to connections between replicas: both ends
+// present a verified certificate, and each names the same subject as this
+// service's own. Dialers check the server's certificate is valid for that
+// name rather than for the address dialed.
+func (r *Reloader) replicaConfig(cfg *tls.Config) *tls.Config {
+	name := r.name()
+	cfg.ServerName = name
+	cfg.ClientAuth = tls.RequireAndVerifyClientCert
+	cfg.VerifyConnection = func(cs tls.ConnectionState) error {
+		if name == "" || len(cs.PeerCertificates) == 0 || cs.PeerCertificates[0].Subject.CommonName != name {
+			return fmt.Errorf("peer is not a replica of %q", name)
+		}
+		return nil
+	}
+	return cfg
+}
+
+// ReplicaListener wraps lis to accept only TLS connections from replicas,
+// which present a certificate verified against ca_file naming the same
+// subject as this service's. It needs mTLS configured.
+func (r *Reloader) ReplicaListener(lis net.Listener) net.Listener {
+	return tls.NewListener(lis, &tls.Config{
+		GetConfigForClient: func(*tls.ClientHelloInfo) (*tls.Config, error) {
+			return r.replicaConfig(r.serverConfig()), nil
+		},
+	})
+}
+
+// DialReplica connects to a replica's ReplicaListener, completing the TLS
+// handshake within timeout.
+func (r *Reloader) DialReplica(addr string, timeout time.Duration) (net.Conn, error) {
+	return tls.DialWithDialer(&net.Dialer{Timeout: timeout}, "tcp", addr, r.replicaConfig(r.clientConfig()))
+}
+
+// Credentials returns transport credentials for both grpc.Creds and
+// grpc.WithTransportCredentials. Each handshake uses the certificates current
+// at the time. With a nil Reloader they are plaintext.
+func (r *Reloader) Credentials() credentials.TransportCredentials {
+	if r == nil {
+		return insecure.NewCredentials()
+	}
+	return &reloadingCredentials{r: r}
+}
+
+// reloadingCredentials builds standard TLS credentials per handshake, since
+// credentials.NewTLS fixes its tls.Config when created.
+type reloadingCredentials struct {
+	r *Reloader
+}
+
+func (c *reloadingCredentials) ClientHandshake(ctx context.Context, authority string, conn net.Conn) (net.Conn, credentials.AuthInfo, error) {
+	return credentials.NewTLS(c.r.clientConfig()).ClientHandshake(ctx, authority, conn)
+}
+
+func (c *reloadingCredentials) ServerHandshake(conn net.Conn) (net.Conn, credentials.AuthInfo, error) {
+	return credentials.NewTLS(c.r.serverConfig()).ServerHandshake(conn)
+}
+
+func (c *reloadingCredentials) Info() credentials.ProtocolInfo {
+	return credentials.NewTLS(&tls.Config{}).Info()
+}
+
+func (c *reloadingCredentials) Clone() credentials.TransportCredentials {
+	return &reloadingCredentials{r: c.r}
+}
+
+func (c *reloadingCredentials) OverrideServerName(string) error {
+	return errors.New("overriding the server name is not supported")
+}
@@ -0,0 +1,344 @@
+package certs
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// issueFiles writes a certificate for name, issued by ca, and ca.pem into
+// dir, returning a config that uses them.
+func issueFiles(t *testing.T, ca *CA, dir, name string) Config {
+	t.Helper()
+	certPEM, keyPEM, err := ca.Issue(name, []string{name, "localhost", "127.0.0.1"}, time.Hour)
+	if err != nil {
+		t.Fatalf("Issue failed: %v", err)
+	}
+
+	cfg := DefaultConfig()
+	cfg.CertFile = filepath.Join(dir, name+".pem")
+	cfg.KeyFile = filepath.Join(dir, name+"-key.pem")
+	cfg.CAFile = filepath.Join(dir, "ca.pem")
+	for path, data := range map[string][]byte{cfg.CertFile: certPEM, cfg.KeyFile: keyPEM, cfg.CAFile: ca.CertPEM()} {
+		if err := os.WriteFile(path, data, 0600); err != nil {
+			t.Fatalf("Failed to write %s: %v", path, err)
+		}
+	}
+	return cfg
+}
+
+func newCA(t *testing.T) *CA {
+	t.Helper()
+	ca, err := NewCA("test CA", time.Hour)
+	if err != nil {
+		t.Fatalf("NewCA failed: %v", err)
+	}
+	return ca
+}
+
+func newReloader(t *testing.T, cfg Config) *Reloader {
+	t.Helper()
+	r, err := New(cfg)
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	return r
+}
+
+// serveHealth serves a health server with creds and returns its address.
+func serveHealth(t *testing.T, creds credentials.TransportCredentials) string {
+	t.Helper()
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+	srv := grpc.NewServer(grpc.Creds(creds))
+	healthpb.RegisterHealthServer(srv, health.NewServer())
+	go srv.Serve(lis)
+	t.Cleanup(srv.Stop)
+	return lis.Addr().String()
+}
+
+func check(t *testing.T, addr string, creds credentials.TransportCredentials) error {
+	t.Helper()
+	conn, err := grpc.NewClient(addr, grpc.WithTransportCredentials(creds))
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	_, err = healthpb.NewHealthClient(conn).Check(ctx, &healthpb.HealthCheckRequest{})
+	return err
+}
+
+func TestMutualTLS(t *testing.T) {
+	dir := t.TempDir()
+	ca := newCA(t)
+
+	serverCfg := issueFiles(t, ca, dir, "server")
+	serverCfg.ClientAuth = true
+	addr := serveHealth(t, newReloader(t, serverCfg).Credentials())
+	// Dial by name so the certificate's DNS name is verified
+	addr = strings.Replace(addr, "127.0.0.1", "localhost", 1)
+
+	client := newReloader(t, issueFiles(t, ca, dir, "client"))
+	if err := check(t, addr, client.Credentials()); err != nil {
+		t.Errorf("Expected mTLS call to succeed, got %v", err)
+	}
+
+	// Server verification alone isn't enough without a client certificate
+	noCert := credentials.NewTLS(&tls.Config{RootCAs: client.current.Load().pool})
+	if err := check(t, addr, noCert); err == nil {
+		t.Error("Expected call without a client certificate to fail")
+	}
+
+	// A client certificate from another CA is rejected
+	other := newReloader(t, issueFiles(t, newCA(t), t.TempDir(), "client"))
+	if err := check(t, addr, other.Credentials()); err == nil {
+		t.Error("Expected call with an untrusted certificate to fail")
+	}
+}
+
+func TestIsReplica(t *testing.T) {
+	dir := t.TempDir()
+	ca := newCA(t)
+	serverCfg := issueFiles(t, ca, dir, "asset-monitoring")
+	serverCfg.ClientAuth = true
+	server := newReloader(t, serverCfg)
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+	replica := make(chan bool, 1)
+	srv := grpc.NewServer(grpc.Creds(server.Credentials()), grpc.UnaryInterceptor(
+		func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+			replica <- server.IsReplica(ctx)
+			return handler(ctx, req)
+		}))
+	healthpb.RegisterHealthServer(srv, health.NewServer())
+	go srv.Serve(lis)
+	t.Cleanup(srv.Stop)
+	addr := strings.Replace(lis.Addr().String(), "127.0.0.1", "localhost", 1)
+
+	// Instances of the service share its certificate; other callers don't
+	for name, want := range map[string]bool{"asset-monitoring": true, "client": false} {
+		cfg := issueFiles(t, ca, t.TempDir(), name)
+		if err := check(t, addr, newReloader(t, cfg).Credentials()); err != nil {
+			t.Fatalf("Expected %s's call to succeed, got %v", name, err)
+		}
+		if got := <-replica; got != want {
+			t.Errorf("Expected IsReplica=%v for %s, got %v", want, name, got)
+		}
+	}
+
+	if (*Reloader)(nil).IsReplica(context.Background()) {
+		t.Error("Expected no replicas without TLS")
+	}
+}
+
+func TestReplicaConnections(t *testing.T) {
+	dir := t.TempDir()
+	ca := newCA(t)
+	serverCfg := issueFiles(t, ca, dir, "asset-registry")
+	serverCfg.ClientAuth = true
+	server := newReloader(t, serverCfg)
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+	lis = server.ReplicaListener(lis)
+	t.Cleanup(func() { lis.Close() })
+	accepted := make(chan error, 1)
+	go func() {
+		for {
+			conn, err := lis.Accept()
+			if err != nil {
+				return
+			}
+			accepted <- conn.(*tls.Conn).Handshake()
+			conn.Close()
+		}
+	}()
+
+	tests := []struct {
+		name  string
+		cert  string
+		valid bool
+	}{
+		{"replica", "asset-registry", true},
+		{"other service", "alerting", false},
+		{"client", "client", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := issueFiles(t, ca, t.TempDir(), tt.cert)
+			cfg.ClientAuth = true
+			if conn, err := newReloader(t, cfg).DialReplica(lis.Addr().String(), 2*time.Second); err == nil {
+				conn.Close()
+			}
+			if err := <-accepted; (err == nil) != tt.valid {
+				t.Errorf("Expected accepted=%v, got %v", tt.valid, err)
+			}
+		})
+	}
+
+	// Plain TCP, as from a scanner, never gets through
+	conn, err := net.Dial("tcp", lis.Addr().String())
+	if err != nil {
+		t.Fatalf("Failed to dial: %v", err)
+	}
+	conn.Write([]byte("not a TLS handshake"))
+	conn.Close()
+	if err := <-accepted; err == nil {
+		t.Error("Expected a plaintext connection to be refused")
+	}
+}
+
+func TestReload(t *testing.T) {
+	dir := t.TempDir()
+	oldCA, newerCA := newCA(t), newCA(t)
+
+	serverCfg := issueFiles(t, oldCA, dir, "server")
+	serverCfg.ReloadInterval = 10 * time.Millisecond
+	server := newReloader(t, serverCfg)
+	addr := serveHealth(t, server.Credentials())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go server.Run(ctx)
+
+	client := newReloader(t, issueFiles(t, newerCA, t.TempDir(), "client"))
+	if err := check(t, addr, client.Credentials()); err == nil {
+		t.Fatal("Expected client trusting another CA to fail before rotation")
+	}
+
+	// Rotate the server to a certificate the client trusts
+	rotated := issueFiles(t, newerCA, dir, "server")
+	future := time.Now().Add(time.Minute)
+	for _, path := range []string{rotated.CertFile, rotated.KeyFile, rotated.CAFile} {
+		os.Chtimes(path, future, future)
+	}
+
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		err := check(t, addr, client.Credentials())
+		if err == nil {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("Expected call to succeed after rotation, got %v", err)
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+}
+
+func TestReloadKeepsCertificatesOnError(t *testing.T) {
+	dir := t.TempDir()
+	cfg := issueFiles(t, newCA(t), dir, "server")
+	r := newReloader(t, cfg)
+	before := r.current.Load()
+
+	if err := os.WriteFile(cfg.CertFile, []byte("not a certificate"), 0600); err != nil {
+		t.Fatalf("Failed to write: %v", err)
+	}
+	if _, err := r.reload(); err == nil {
+		t.Error("Expected reload of a broken certificate to fail")
+	}
+	if r.current.Load() != before {
+		t.Error("Expected previous certificates to be kept")
+	}
+}
+
+func TestConfigValidate(t *testing.T) {
+	tests := []struct {
+		name   string
+		cfg    Config
+		expect string
+	}{
+		{"Disabled", Config{ReloadInterval: time.Second}, ""},
+		{"Server TLS", Config{CertFile: "c.pem", KeyFile: "k.pem", ReloadInterval: time.Second}, ""},
+		{"Missing key", Config{CertFile: "c.pem", ReloadInterval: time.Second}, "tls.key_file is required"},
+		{"Key without cert", Config{KeyFile: "k.pem", ReloadInterval: time.Second}, "tls.cert_file is required"},
+		{"Client auth without CA", Config{CertFile: "c.pem", KeyFile: "k.pem", ClientAuth: true, ReloadInterval: time.Second}, "tls.ca_file is required"},
+		{"Zero reload interval", Config{}, "tls.reload_interval must be positive"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.cfg.Validate()
+			if tt.expect == "" && err != nil {
+				t.Errorf("Expected valid config, got %v", err)
+			}
+			if tt.expect != "" && (err == nil || !strings.Contains(err.Error(), tt.expect)) {
+				t.Errorf("Expected error containing %q, got %v", tt.expect, err)
+			}
+		})
+	}
+}
+
+func TestNewDisabled(t *testing.T) {
+	r, err := New(DefaultConfig())
+	if err != nil || r != nil {
+		t.Fatalf("Expected nil reloader when disabled, got %v, %v", r, err)
+	}
+	if got := r.Credentials().Info().SecurityProtocol; got != "insecure" {
+		t.Errorf("Expected plaintext credentials, got %s", got)
+	}
+}
+
+func TestIssueAndLoadCA(t *testing.T) {
+	dir := t.TempDir()
+	ca := newCA(t)
+	keyPEM, err := ca.KeyPEM()
+	if err != nil {
+		t.Fatalf("KeyPEM failed: %v", err)
+	}
+	certFile, keyFile := filepath.Join(dir, "ca.pem"), filepath.Join(dir, "ca-key.pem")
+	os.WriteFile(certFile, ca.CertPEM(), 0600)
+	os.WriteFile(keyFile, keyPEM, 0600)
+
+	loaded, err := LoadCA(certFile, keyFile)
+	if err != nil {
+		t.Fatalf("LoadCA failed: %v", err)
+	}
+
+	certPEM, _, err := loaded.Issue("telemetry", []string{"telemetry", "127.0.0.1"}, time.Hour)
+	if err != nil {
+		t.Fatalf("Issue failed: %v", err)
+	}
+	block, _ := pem.Decode(certPEM)
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		t.Fatalf("Failed to parse issued certificate: %v", err)
+	}
+
+	if len(cert.DNSNames) != 1 || cert.DNSNames[0] != "telemetry" {
+		t.Errorf("Expected DNS name telemetry, got %v", cert.DNSNames)
+	}
+	if len(cert.IPAddresses) != 1 || !cert.IPAddresses[0].Equal(net.ParseIP("127.0.0.1")) {
+		t.Errorf("Expected IP 127.0.0.1, got %v", cert.IPAddresses)
+	}
+
+	// Certificates issued by the reloaded CA chain to the original
+	pool := x509.NewCertPool()
+	pool.AppendCertsFromPEM(ca.CertPEM())
+	if _, err := cert.Verify(x509.VerifyOptions{Roots: pool, KeyUsages: []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth}}); err != nil {
+		t.Errorf("Expected issued certificate to verify against the CA, got %v", err)
+	}
+}
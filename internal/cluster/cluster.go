@@ -0,0 +1,307 @@
+package cluster
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+)
+
+// ForwardedHeader is the metadata key marking a call one instance forwarded
+// to another. Forwarded calls are always served where they arrive, so
+// instances that briefly disagree about ownership can't bounce a call back
+// and forth.
+const ForwardedHeader = "x-cluster-forwarded-by"
+
+// DefaultProbeInterval is how often peers' health is checked.
+const DefaultProbeInterval = 2 * time.Second
+
+// maxProbeTimeout bounds a single peer probe.
+const maxProbeTimeout = 2 * time.Second
+
+// Config lists the instances sharing a service's work. Every instance should
+// be given the same peers; listing the instance itself among them is fine.
+type Config struct {
+	Self          string        `config:"self" env:"CLUSTER_SELF" usage:"address peers reach this instance at; empty disables clustering"`
+	Peers         []string      `config:"peers" env:"CLUSTER_PEERS" usage:"comma-separated addresses of the other instances"`
+	ProbeInterval time.Duration `config:"probe_interval" env:"CLUSTER_PROBE_INTERVAL" usage:"how often peers' health is checked"`
+}
+
+// DefaultConfig disables clustering.
+func DefaultConfig() Config {
+	return Config{ProbeInterval: DefaultProbeInterval}
+}
+
+// Validate checks the cluster settings.
+func (c Config) Validate() error {
+	var errs []error
+	if c.Self == "" && len(c.Peers) > 0 {
+		errs = append(errs, errors.New("cluster.self is required with cluster.peers"))
+	}
+	for _, addr := range append([]string{c.Self}, c.Peers...) {
+		if addr == "" {
+			continue
+		}
+		if _, _, err := net.SplitHostPort(addr); err != nil {
+			errs = append(errs, fmt.Errorf("cluster address %q is not a host:port address", addr))
+		}
+	}
+	if c.ProbeInterval <= 0 {
+		errs = append(errs, fmt.Errorf("cluster.probe_interval must be positive, got %v", c.ProbeInterval))
+	}
+	return errors.Join(errs...)
+}
+
+// Cluster tracks which instances are up and assigns keys to them on a
+// consistent-hash ring. An instance owns the keys the ring gives it while it's
+// up; peers join the ring when their health service reports the clustered
+// service as serving and leave it when they stop doing so. A nil Cluster is a
+// single instance owning everything.
+type Cluster struct {
+	self     string
+	peers    []string
+	service  string
+	interval time.Duration
+	conns    map[string]*grpc.ClientConn
+
+	mu      sync.RWMutex
+	members []string
+	ring    *Ring
+	changed chan struct{}
+}
+
+// New dials the configured peers of the named gRPC service (e.g.
+// "asset_monitoring.AssetMonitoringService") with opts. It returns nil when
+// clustering is disabled. Until the first Update, the instance owns every key.
+func New(cfg Config, service string, opts ...grpc.DialOption) (*Cluster, error) {
+	if cfg.Self == "" {
+		return nil, nil
+	}
+	c := &Cluster{
+		self:     cfg.Self,
+		service:  service,
+		interval: cfg.ProbeInterval,
+		conns:    make(map[string]*grpc.ClientConn),
+		changed:  make(chan struct{}),
+	}
+	for _, peer := range cfg.Peers {
+		if _, dup := c.conns[peer]; peer == cfg.Self || dup {
+			continue
+		}
+		conn, err := grpc.Dial(peer, opts...)
+		if err != nil {
+			c.Close()
+			return nil, fmt.Errorf("failed to dial peer %s: %w", peer, err)
+		}
+		c.peers = append(c.peers, peer)
+		c.conns[peer] = conn
+	}
+	c.setMembers([]string{c.self})
+	return c, nil
+}
+
+// Self returns the instance's own address, or "" for a nil Cluster.
+func (c *Cluster) Self() string {
+	if c == nil {
+		return ""
+	}
+	return c.self
+}
+
+// Owner returns the address of the instance owning key and whether that's
+// this instance.
+func (c *Cluster) Owner(key string) (string, bool) {
+	if c == nil {
+		return "", true
+	}
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	owner := c.ring.Owner(key)
+	return owner, owner == c.self
+}
+
+// Members returns the sorted addresses of the instances currently up,
+// including this one.
+func (c *Cluster) Members() []string {
+	if c == nil {
+		return nil
+	}
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return append([]string(nil), c.members...)
+}
+
+// Changed returns a channel closed at the next membership change, after which
+// keys may have new owners. It's nil, and never closed, for a nil Cluster.
+func (c *Cluster) Changed() <-chan struct{} {
+	if c == nil {
+		return nil
+	}
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.changed
+}
+
+// Conn returns the connection to a peer, or nil for an unknown address.
+func (c *Cluster) Conn(addr string) *grpc.ClientConn {
+	if c == nil {
+		return nil
+	}
+	return c.conns[addr]
+}
+
+// Run probes the peers immediately and then every probe interval until ctx is
+// done.
+func (c *Cluster) Run(ctx context.Context) {
+	if c == nil {
+		return
+	}
+	c.Update(ctx)
+
+	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			c.Update(ctx)
+		}
+	}
+}
+
+// Update probes every peer once and rebuilds the ring if any joined or left.
+func (c *Cluster) Update(ctx context.Context) {
+	if c == nil {
+		return
+	}
+	timeout := c.interval
+	if timeout > maxProbeTimeout {
+		timeout = maxProbeTimeout
+	}
+
+	up := make([]bool, len(c.peers))
+	var wg sync.WaitGroup
+	for i, peer := range c.peers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			probeCtx, cancel := context.WithTimeout(ctx, timeout)
+			defer cancel()
+			up[i] = c.probe(probeCtx, peer) == nil
+		}()
+	}
+	wg.Wait()
+
+	members := []string{c.self}
+	for i, peer := range c.peers {
+		if up[i] {
+			members = append(members, peer)
+		}
+	}
+	c.setMembers(members)
+}
+
+func (c *Cluster) probe(ctx context.Context, peer string) error {
+	resp, err := healthpb.NewHealthClient(c.conns[peer]).Check(ctx, &healthpb.HealthCheckRequest{Service: c.service})
+	if err != nil {
+		return err
+	}
+	if resp.Status != healthpb.HealthCheckResponse_SERVING {
+		return fmt.Errorf("peer %s is %v", peer, resp.Status)
+	}
+	return nil
+}
+
+// setMembers rebuilds the ring when the members differ from the current ones
+// and wakes everyone waiting on Changed.
+func (c *Cluster) setMembers(members []string) {
+	sort.Strings(members)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.ring != nil && strings.Join(members, ",") == strings.Join(c.members, ",") {
+		return
+	}
+	if c.ring != nil {
+		log.Printf("Cluster members changed from [%s] to [%s]", strings.Join(c.members, " "), strings.Join(members, " "))
+		close(c.changed)
+		c.changed = make(chan struct{})
+	}
+	c.members = members
+	c.ring = NewRing(members)
+}
+
+// Close closes the peer connections.
+func (c *Cluster) Close() error {
+	if c == nil {
+		return nil
+	}
+	var errs []error
+	for _, conn := range c.conns {
+		errs = append(errs, conn.Close())
+	}
+	return errors.Join(errs...)
+}
+
+// ForwardContext marks calls made with ctx as forwarded by the instance at
+// self.
+func ForwardContext(ctx context.Context, self string) context.Context {
+	return metadata.AppendToOutgoingContext(ctx, ForwardedHeader, self)
+}
+
+// IsForwarded reports whether an incoming call was forwarded by a peer. It's
+// only to be trusted behind the server interceptors, which drop the marker
+// from callers that aren't peers.
+func IsForwarded(ctx context.Context) bool {
+	md, _ := metadata.FromIncomingContext(ctx)
+	return len(md.Get(ForwardedHeader)) > 0
+}
+
+// withoutForgedMarker drops ForwardedHeader from calls isPeer doesn't accept
+// as coming from a peer.
+func withoutForgedMarker(ctx context.Context, isPeer func(context.Context) bool) context.Context {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok || len(md.Get(ForwardedHeader)) == 0 || isPeer(ctx) {
+		return ctx
+	}
+	md = md.Copy()
+	md.Delete(ForwardedHeader)
+	return metadata.NewIncomingContext(ctx, md)
+}
+
+// UnaryServerInterceptor keeps callers other than peers, as told by isPeer,
+// from marking their calls as forwarded. It must run after authentication.
+func UnaryServerInterceptor(isPeer func(context.Context) bool) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		return handler(withoutForgedMarker(ctx, isPeer), req)
+	}
+}
+
+// markedStream overrides the context of a server stream.
+type markedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *markedStream) Context() context.Context {
+	return s.ctx
+}
+
+// StreamServerInterceptor is the streaming counterpart of
+// UnaryServerInterceptor.
+func StreamServerInterceptor(isPeer func(context.Context) bool) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		return handler(srv, &markedStream{ServerStream: ss, ctx: withoutForgedMarker(ss.Context(), isPeer)})
+	}
+}
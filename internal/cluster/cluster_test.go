@@ -0,0 +1,242 @@
+package cluster
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+)
+
+const testService = "test.Service"
+
+func TestRingOwnerIgnoresMemberOrder(t *testing.T) {
+	a := NewRing([]string{"node-a:1", "node-b:1", "node-c:1"})
+	b := NewRing([]string{"node-c:1", "node-a:1", "node-b:1"})
+
+	for i := 0; i < 1000; i++ {
+		key := fmt.Sprintf("asset-%d", i)
+		if a.Owner(key) != b.Owner(key) {
+			t.Fatalf("Expected %s to have the same owner regardless of member order", key)
+		}
+	}
+	if owner := NewRing(nil).Owner("asset-1"); owner != "" {
+		t.Errorf("Expected no owner on an empty ring, got %q", owner)
+	}
+}
+
+func TestRingBalanceAndMinimalMoves(t *testing.T) {
+	const keys = 10000
+	three := NewRing([]string{"node-a:1", "node-b:1", "node-c:1"})
+	four := NewRing([]string{"node-a:1", "node-b:1", "node-c:1", "node-d:1"})
+
+	owned := make(map[string]int)
+	moved := 0
+	for i := 0; i < keys; i++ {
+		key := fmt.Sprintf("asset-%d", i)
+		before, after := three.Owner(key), four.Owner(key)
+		owned[after]++
+		if before != after {
+			moved++
+			if after != "node-d:1" {
+				t.Fatalf("Expected %s to move only to the new member, moved from %s to %s", key, before, after)
+			}
+		}
+	}
+
+	for member, count := range owned {
+		if count < keys*15/100 || count > keys*35/100 {
+			t.Errorf("Expected %s to own about a quarter of the keys, got %d of %d", member, count, keys)
+		}
+	}
+	if moved < keys*15/100 || moved > keys*35/100 {
+		t.Errorf("Expected about a quarter of the keys to move, got %d of %d", moved, keys)
+	}
+}
+
+// startPeer serves a health service on localhost reporting testService as
+// serving.
+func startPeer(t *testing.T) (string, *health.Server, *grpc.Server) {
+	t.Helper()
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+	hs := health.NewServer()
+	hs.SetServingStatus(testService, healthpb.HealthCheckResponse_SERVING)
+	srv := grpc.NewServer()
+	healthpb.RegisterHealthServer(srv, hs)
+	go srv.Serve(lis)
+	t.Cleanup(srv.Stop)
+	return lis.Addr().String(), hs, srv
+}
+
+func TestClusterMembership(t *testing.T) {
+	ctx := context.Background()
+	peerA, _, _ := startPeer(t)
+	peerB, healthB, _ := startPeer(t)
+	self := "127.0.0.1:1"
+
+	c, err := New(Config{Self: self, Peers: []string{self, peerA, peerB}, ProbeInterval: DefaultProbeInterval}, testService,
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("Failed to create cluster: %v", err)
+	}
+	defer c.Close()
+
+	// Alone until peers are probed
+	if members := c.Members(); len(members) != 1 || members[0] != self {
+		t.Errorf("Expected only self before probing, got %v", members)
+	}
+	if owner, local := c.Owner("asset-1"); owner != self || !local {
+		t.Errorf("Expected self to own everything before probing, got %s", owner)
+	}
+
+	changed := c.Changed()
+	c.Update(ctx)
+	if members := c.Members(); len(members) != 3 {
+		t.Fatalf("Expected 3 members after probing, got %v", members)
+	}
+	select {
+	case <-changed:
+	default:
+		t.Error("Expected Changed to be closed when peers joined")
+	}
+
+	// Find a key peer B owns, which it gives up when it stops serving
+	key := ""
+	for i := 0; key == ""; i++ {
+		if owner, _ := c.Owner(fmt.Sprintf("asset-%d", i)); owner == peerB {
+			key = fmt.Sprintf("asset-%d", i)
+		}
+	}
+	changed = c.Changed()
+	healthB.SetServingStatus(testService, healthpb.HealthCheckResponse_NOT_SERVING)
+	c.Update(ctx)
+
+	if members := c.Members(); len(members) != 2 {
+		t.Errorf("Expected peer B to leave, got %v", members)
+	}
+	if owner, _ := c.Owner(key); owner == peerB {
+		t.Errorf("Expected %s to move off peer B", key)
+	}
+	select {
+	case <-changed:
+	default:
+		t.Error("Expected Changed to be closed when a peer left")
+	}
+
+	// Unchanged membership leaves Changed open
+	changed = c.Changed()
+	c.Update(ctx)
+	select {
+	case <-changed:
+		t.Error("Expected Changed to stay open without membership changes")
+	default:
+	}
+}
+
+func TestNilCluster(t *testing.T) {
+	c, err := New(DefaultConfig(), testService)
+	if c != nil || err != nil {
+		t.Fatalf("Expected no cluster without self, got %v, %v", c, err)
+	}
+	if _, local := c.Owner("asset-1"); !local {
+		t.Error("Expected a nil cluster to own everything")
+	}
+	if c.Changed() != nil || c.Conn("peer:1") != nil || c.Members() != nil {
+		t.Error("Expected a nil cluster to have no peers")
+	}
+	c.Update(context.Background())
+	if err := c.Close(); err != nil {
+		t.Errorf("Expected closing a nil cluster to succeed, got %v", err)
+	}
+}
+
+func TestConfigValidate(t *testing.T) {
+	tests := []struct {
+		name  string
+		cfg   Config
+		valid bool
+	}{
+		{"disabled", DefaultConfig(), true},
+		{"clustered", Config{Self: "node-a:50054", Peers: []string{"node-a:50054", "node-b:50054"}, ProbeInterval: DefaultProbeInterval}, true},
+		{"peers without self", Config{Peers: []string{"node-b:50054"}, ProbeInterval: DefaultProbeInterval}, false},
+		{"bad address", Config{Self: "node-a", ProbeInterval: DefaultProbeInterval}, false},
+		{"zero interval", Config{Self: "node-a:50054"}, false},
+	}
+
+	for _, tt := range tests {
+		if err := tt.cfg.Validate(); (err == nil) != tt.valid {
+			t.Errorf("%s: expected valid=%v, got %v", tt.name, tt.valid, err)
+		}
+	}
+}
+
+func TestForwardContext(t *testing.T) {
+	ctx := ForwardContext(context.Background(), "node-a:50054")
+	md, _ := metadata.FromOutgoingContext(ctx)
+
+	if IsForwarded(context.Background()) {
+		t.Error("Expected a plain call not to be forwarded")
+	}
+	if !IsForwarded(metadata.NewIncomingContext(context.Background(), md)) {
+		t.Error("Expected the forwarded header to mark the call")
+	}
+}
+
+func TestServerInterceptorDropsForgedMarker(t *testing.T) {
+	md := metadata.Pairs(ForwardedHeader, "node-a:50054")
+	ctx := metadata.NewIncomingContext(context.Background(), md)
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return IsForwarded(ctx), nil
+	}
+	info := &grpc.UnaryServerInfo{FullMethod: "/test.Service/Method"}
+
+	for _, peer := range []bool{true, false} {
+		isPeer := func(context.Context) bool { return peer }
+		forwarded, _ := UnaryServerInterceptor(isPeer)(ctx, nil, info, handler)
+		if forwarded != peer {
+			t.Errorf("Expected forwarded=%v for peer=%v, got %v", peer, peer, forwarded)
+		}
+	}
+	if len(md.Get(ForwardedHeader)) != 1 {
+		t.Error("Expected the caller's metadata to be left alone")
+	}
+}
+
+func TestDialReplicas(t *testing.T) {
+	addrA, _, _ := startPeer(t)
+	addrB, _, srvB := startPeer(t)
+
+	conn, err := Dial(addrA+","+addrB, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("Failed to dial replicas: %v", err)
+	}
+	defer conn.Close()
+	client := healthpb.NewHealthClient(conn)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if _, err := client.Check(ctx, &healthpb.HealthCheckRequest{Service: testService}); err != nil {
+		t.Fatalf("Check failed: %v", err)
+	}
+
+	// Calls keep succeeding while one replica is down
+	srvB.Stop()
+	for i := 0; i < 10; i++ {
+		if _, err := client.Check(ctx, &healthpb.HealthCheckRequest{Service: testService}); err != nil {
+			t.Fatalf("Check %d with a replica down failed: %v", i+1, err)
+		}
+	}
+
+	if addrs := SplitAddrs(" node-a:1, ,node-b:1 "); len(addrs) != 2 || addrs[0] != "node-a:1" || addrs[1] != "node-b:1" {
+		t.Errorf("Expected two trimmed addresses, got %v", addrs)
+	}
+}
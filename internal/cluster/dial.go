@@ -0,0 +1,59 @@
+package cluster
+
+import (
+	"net"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/resolver"
+	"google.golang.org/grpc/resolver/manual"
+)
+
+// replicasServiceConfig spreads calls over the replicas that are up and
+// retries calls failing with Unavailable, such as those reaching a replica
+// with no leader to forward to, on another.
+const replicasServiceConfig = `{
+	"loadBalancingConfig": [{"round_robin": {}}],
+	"methodConfig": [{
+		"name": [{}],
+		"retryPolicy": {
+			"maxAttempts": 3,
+			"initialBackoff": "0.1s",
+			"maxBackoff": "1s",
+			"backoffMultiplier": 2,
+			"retryableStatusCodes": ["UNAVAILABLE"]
+		}
+	}]
+}`
+
+// Dial connects to a service at addr, which may list the addresses of several
+// replicas separated by commas. The addresses come from configuration, which
+// is trusted to name the replicas' certificates.
+func Dial(addr string, opts ...grpc.DialOption) (*grpc.ClientConn, error) {
+	addrs := SplitAddrs(addr)
+	if len(addrs) <= 1 {
+		return grpc.Dial(addr, opts...)
+	}
+
+	r := manual.NewBuilderWithScheme("replicas")
+	state := resolver.State{}
+	for _, a := range addrs {
+		// Each replica's certificate is checked against its own host name
+		host, _, _ := net.SplitHostPort(a)
+		state.Addresses = append(state.Addresses, resolver.Address{Addr: a, ServerName: host})
+	}
+	r.InitialState(state)
+	opts = append(opts, grpc.WithResolvers(r), grpc.WithDefaultServiceConfig(replicasServiceConfig))
+	return grpc.Dial(r.Scheme()+":///"+addrs[0], opts...)
+}
+
+// SplitAddrs splits a comma-separated list of addresses.
+func SplitAddrs(addr string) []string {
+	var addrs []string
+	for _, a := range strings.Split(addr, ",") {
+		if a = strings.TrimSpace(a); a != "" {
+			addrs = append(addrs, a)
+		}
+	}
+	return addrs
+}
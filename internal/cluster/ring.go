@@ -0,0 +1,66 @@
+package cluster
+
+import (
+	"sort"
+	"strconv"
+)
+
+// virtualNodes is the number of points each member has on the ring. More
+// points spread keys more evenly at the cost of a larger ring.
+const virtualNodes = 128
+
+// Ring assigns keys to members by consistent hashing, so adding or removing a
+// member only moves the keys it gains or loses.
+type Ring struct {
+	points  []uint64 // Sorted hashes of every virtual node
+	members map[uint64]string
+}
+
+// NewRing builds a ring of members.
+func NewRing(members []string) *Ring {
+	r := &Ring{members: make(map[uint64]string, len(members)*virtualNodes)}
+	for _, member := range members {
+		for i := 0; i < virtualNodes; i++ {
+			h := hash(member + "#" + strconv.Itoa(i))
+			// On the rare collision the lower member wins, on every node alike
+			if existing, taken := r.members[h]; taken && existing < member {
+				continue
+			} else if !taken {
+				r.points = append(r.points, h)
+			}
+			r.members[h] = member
+		}
+	}
+	sort.Slice(r.points, func(i, j int) bool { return r.points[i] < r.points[j] })
+	return r
+}
+
+// Owner returns the member owning key: the first virtual node at or after the
+// key's hash, wrapping around. It returns "" for an empty ring.
+func (r *Ring) Owner(key string) string {
+	if len(r.points) == 0 {
+		return ""
+	}
+	h := hash(key)
+	i := sort.Search(len(r.points), func(i int) bool { return r.points[i] >= h })
+	if i == len(r.points) {
+		i = 0
+	}
+	return r.members[r.points[i]]
+}
+
+// hash is 64-bit FNV-1a followed by a finalizer, since FNV alone clusters
+// similar strings such as a member's virtual node names.
+func hash(s string) uint64 {
+	h := uint64(14695981039346656037)
+	for i := 0; i < len(s); i++ {
+		h ^= uint64(s[i])
+		h *= 1099511628211
+	}
+	h ^= h >> 33
+	h *= 0xff51afd7ed558ccd
+	h ^= h >> 33
+	h *= 0xc4ceb9fe1a85ec53
+	h ^= h >> 33
+	return h
+}
@@ -0,0 +1,91 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/sairamkiran9/asset-telemetry-monitor/internal/auth"
+	"github.com/sairamkiran9/asset-telemetry-monitor/internal/certs"
+	"github.com/sairamkiran9/asset-telemetry-monitor/internal/interceptors"
+	"github.com/sairamkiran9/asset-telemetry-monitor/internal/metrics"
+	"github.com/sairamkiran9/asset-telemetry-monitor/internal/shutdown"
+	"github.com/sairamkiran9/asset-telemetry-monitor/internal/tracing"
+)
+
+// Common holds the settings every service shares. Services embed it in their
+// own config struct.
+type Common struct {
+	ListenAddr          string         `config:"listen_addr" env:"LISTEN_ADDR" usage:"gRPC listen address"`
+	MetricsAddr         string         `config:"metrics_addr" env:"METRICS_ADDR" usage:"address serving Prometheus /metrics"`
+	MaxRPCTimeout       time.Duration  `config:"max_rpc_timeout" env:"MAX_RPC_TIMEOUT" usage:"deadline cap for unary RPCs"`
+	ShutdownGracePeriod time.Duration  `config:"shutdown_grace_period" env:"SHUTDOWN_GRACE_PERIOD" usage:"time allowed to drain RPCs and clean up after SIGTERM"`
+	Tracing             tracing.Config `config:"tracing"`
+	TLS                 certs.Config   `config:"tls"`
+	Auth                auth.Config    `config:"auth"`
+}
+
+// DefaultCommon returns the shared defaults for a service listening on
+// listenAddr.
+func DefaultCommon(listenAddr string) Common {
+	return Common{
+		ListenAddr:          listenAddr,
+		MetricsAddr:         metrics.DefaultAddr,
+		MaxRPCTimeout:       interceptors.DefaultMaxTimeout,
+		ShutdownGracePeriod: shutdown.DefaultGracePeriod,
+		Tracing:             tracing.DefaultConfig(),
+		TLS:                 certs.DefaultConfig(),
+	}
+}
+
+// Validate checks the shared settings.
+func (c Common) Validate() error {
+	var errs []error
+	errs = append(errs, CheckAddr("listen_addr", c.ListenAddr))
+	errs = append(errs, CheckAddr("metrics_addr", c.MetricsAddr))
+	errs = append(errs, CheckPositive("max_rpc_timeout", c.MaxRPCTimeout))
+	errs = append(errs, CheckPositive("shutdown_grace_period", c.ShutdownGracePeriod))
+	errs = append(errs, c.Tracing.Validate())
+	errs = append(errs, c.TLS.Validate())
+	errs = append(errs, c.Auth.Validate())
+	return errors.Join(errs...)
+}
+
+// CheckAddr reports whether addr is a host:port address, naming the setting
+// in the error.
+func CheckAddr(key, addr string) error {
+	if addr == "" {
+		return fmt.Errorf("%s is required", key)
+	}
+	if _, _, err := net.SplitHostPort(addr); err != nil {
+		return fmt.Errorf("%s %q is not a host:port address", key, addr)
+	}
+	return nil
+}
+
+// CheckAddrs reports whether addrs is a comma-separated list of one or more
+// host:port addresses, such as the replicas of a service.
+func CheckAddrs(key, addrs string) error {
+	if strings.TrimSpace(addrs) == "" {
+		return fmt.Errorf("%s is required", key)
+	}
+	for _, addr := range strings.Split(addrs, ",") {
+		if addr = strings.TrimSpace(addr); addr == "" {
+			continue
+		}
+		if _, _, err := net.SplitHostPort(addr); err != nil {
+			return fmt.Errorf("%s %q is not a host:port address", key, addr)
+		}
+	}
+	return nil
+}
+
+// CheckPositive reports whether a duration setting is greater than zero.
+func CheckPositive(key string, d time.Duration) error {
+	if d <= 0 {
+		return fmt.Errorf("%s must be positive, got %v", key, d)
+	}
+	return nil
+}
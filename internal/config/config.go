@@ -0,0 +1,377 @@
+package config
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+)
+
+// Settings are declared as struct fields tagged with their file key, and
+// optionally an environment variable and usage text:
+//
+//	Interval time.Duration `config:"interval" env:"MONITORING_INTERVAL" usage:"time between updates"`
+//
+// Nested structs with a config tag become sections ("tracing.exporter" in
+// files, -tracing-exporter on the command line), while embedded structs are
+// inlined. Fields tagged secret:"true" are redacted by --print-config.
+//
+// Values are applied in increasing order of precedence: the defaults already
+// in the struct, the config file, environment variables and finally flags.
+
+// Source records where a setting's value came from.
+type Source string
+
+const (
+	SourceDefault Source = "default"
+	SourceFile    Source = "file"
+	SourceEnv     Source = "env"
+	SourceFlag    Source = "flag"
+)
+
+// ErrPrinted is returned by Loader.Load after --print-config has written the
+// configuration, in which case the program should exit successfully.
+var ErrPrinted = errors.New("configuration printed")
+
+// Validator is implemented by configs that check their values once loaded.
+type Validator interface {
+	Validate() error
+}
+
+// setting is one leaf field of a config struct.
+type setting struct {
+	key    string // Dotted file key, e.g. "tracing.exporter"
+	env    string
+	usage  string
+	secret bool
+	value  reflect.Value
+	source Source
+	origin string // Env var, flag or file the value came from
+}
+
+func (s *setting) flagName() string {
+	return strings.NewReplacer(".", "-", "_", "-").Replace(s.key)
+}
+
+// Loader loads configuration from explicit inputs. Load uses the process's
+// arguments, environment and standard output.
+type Loader struct {
+	Args   []string                        // Command line arguments, without the program name
+	Env    func(key string) (string, bool) // Environment lookup; nil means no environment
+	Stdout io.Writer                       // Destination of --print-config; default os.Stdout
+}
+
+// Load fills cfg, a pointer to a config struct holding its defaults, for the
+// named service. It exits with status 2 on invalid configuration and with
+// status 0 after --print-config, like a flag.ExitOnError FlagSet.
+func Load(service string, cfg interface{}) {
+	err := Loader{Args: os.Args[1:], Env: os.LookupEnv}.Load(service, cfg)
+	switch {
+	case err == nil:
+	case errors.Is(err, ErrPrinted), errors.Is(err, flag.ErrHelp):
+		os.Exit(0)
+	default:
+		fmt.Fprintf(os.Stderr, "%s: %v\n", service, err)
+		os.Exit(2)
+	}
+}
+
+// Load fills cfg for the named service. The config file is named by the
+// -config flag or the CONFIG_FILE environment variable; its format follows
+// its extension (.yaml, .yml or .toml).
+func (l Loader) Load(service string, cfg interface{}) error {
+	rv := reflect.ValueOf(cfg)
+	if rv.Kind() != reflect.Pointer || rv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("config must be a pointer to a struct, got %T", cfg)
+	}
+
+	var settings []*setting
+	if err := collect(rv.Elem(), "", &settings); err != nil {
+		return err
+	}
+	byKey := make(map[string]*setting, len(settings))
+	for _, s := range settings {
+		if _, dup := byKey[s.key]; dup {
+			return fmt.Errorf("setting %s is declared twice", s.key)
+		}
+		s.source = SourceDefault
+		byKey[s.key] = s
+	}
+
+	env := l.Env
+	if env == nil {
+		env = func(string) (string, bool) { return "", false }
+	}
+
+	// Flags are parsed first to find -config and -print-config, but applied
+	// last so that they take precedence
+	fs := flag.NewFlagSet(service, flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	configFile := fs.String("config", "", "path to a YAML or TOML config file (env CONFIG_FILE)")
+	printConfig := fs.Bool("print-config", false, "print the effective configuration and exit")
+	flagValues := make(map[string]*string)
+	for _, s := range settings {
+		usage := s.usage
+		if s.env != "" {
+			usage += " (env " + s.env + ")"
+		}
+		flagValues[s.key] = fs.String(s.flagName(), s.format(), usage)
+	}
+	if err := fs.Parse(l.Args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			fs.SetOutput(l.stdout())
+			fs.PrintDefaults()
+		}
+		return err
+	}
+	if fs.NArg() > 0 {
+		return fmt.Errorf("unexpected arguments: %s", strings.Join(fs.Args(), " "))
+	}
+	setFlags := make(map[string]bool)
+	fs.Visit(func(f *flag.Flag) { setFlags[f.Name] = true })
+
+	var errs []error
+
+	path := *configFile
+	if path == "" {
+		path, _ = env("CONFIG_FILE")
+	}
+	if path != "" {
+		values, err := readFile(path)
+		if err != nil {
+			return err
+		}
+		keys := make([]string, 0, len(values))
+		for k := range values {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			s, ok := byKey[k]
+			if !ok {
+				errs = append(errs, fmt.Errorf("%s: unknown setting %q", path, k))
+				continue
+			}
+			errs = append(errs, s.set(values[k], SourceFile, path))
+		}
+	}
+
+	for _, s := range settings {
+		if s.env == "" {
+			continue
+		}
+		if v, ok := env(s.env); ok {
+			errs = append(errs, s.set(v, SourceEnv, s.env))
+		}
+	}
+
+	for _, s := range settings {
+		if setFlags[s.flagName()] {
+			errs = append(errs, s.set(*flagValues[s.key], SourceFlag, "-"+s.flagName()))
+		}
+	}
+
+	if err := errors.Join(errs...); err != nil {
+		return err
+	}
+	if v, ok := cfg.(Validator); ok {
+		if err := v.Validate(); err != nil {
+			return fmt.Errorf("invalid configuration: %w", err)
+		}
+	}
+
+	if *printConfig {
+		if err := write(l.stdout(), service, settings); err != nil {
+			return err
+		}
+		return ErrPrinted
+	}
+	return nil
+}
+
+func (l Loader) stdout() io.Writer {
+	if l.Stdout != nil {
+		return l.Stdout
+	}
+	return os.Stdout
+}
+
+var durationType = reflect.TypeOf(time.Duration(0))
+
+// collect appends the leaf settings of the struct v, prefixing keys with
+// prefix.
+func collect(v reflect.Value, prefix string, settings *[]*setting) error {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		fv := v.Field(i)
+
+		if field.Anonymous && field.Type.Kind() == reflect.Struct {
+			if err := collect(fv, prefix, settings); err != nil {
+				return err
+			}
+			continue
+		}
+
+		key, ok := field.Tag.Lookup("config")
+		if !ok || key == "-" {
+			continue
+		}
+		key = prefix + key
+
+		if field.Type.Kind() == reflect.Struct && field.Type != durationType {
+			if err := collect(fv, key+".", settings); err != nil {
+				return err
+			}
+			continue
+		}
+
+		switch field.Type.Kind() {
+		case reflect.String, reflect.Bool, reflect.Int, reflect.Int64, reflect.Float64:
+		case reflect.Slice:
+			if field.Type.Elem().Kind() != reflect.String {
+				return fmt.Errorf("unsupported type %s for setting %s", field.Type, key)
+			}
+		default:
+			return fmt.Errorf("unsupported type %s for setting %s", field.Type, key)
+		}
+
+		*settings = append(*settings, &setting{
+			key:    key,
+			env:    field.Tag.Get("env"),
+			usage:  field.Tag.Get("usage"),
+			secret: field.Tag.Get("secret") == "true",
+			value:  fv,
+		})
+	}
+	return nil
+}
+
+// set parses raw into the setting, recording where it came from.
+func (s *setting) set(raw string, source Source, origin string) error {
+	if err := parseInto(s.value, raw); err != nil {
+		return fmt.Errorf("invalid value %q for %s (from %s %s): %v", raw, s.key, source, origin, err)
+	}
+	s.source = source
+	s.origin = origin
+	return nil
+}
+
+func parseInto(v reflect.Value, raw string) error {
+	raw = strings.TrimSpace(raw)
+
+	if v.Type() == durationType {
+		d, err := ParseDuration(raw)
+		if err != nil {
+			return err
+		}
+		v.SetInt(int64(d))
+		return nil
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(raw)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(raw)
+		if err != nil {
+			return errors.New("expected true or false")
+		}
+		v.SetBool(b)
+	case reflect.Int, reflect.Int64:
+		n, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil {
+			return errors.New("expected an integer")
+		}
+		v.SetInt(n)
+	case reflect.Float64:
+		f, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return errors.New("expected a number")
+		}
+		v.SetFloat(f)
+	case reflect.Slice:
+		var items []string
+		for _, item := range strings.Split(raw, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				items = append(items, item)
+			}
+		}
+		v.Set(reflect.ValueOf(items))
+	}
+	return nil
+}
+
+// ParseDuration accepts Go durations such as "500ms" or "1m30s", and bare
+// numbers as seconds, which is how the environment has historically expressed
+// intervals (MONITORING_INTERVAL=1).
+func ParseDuration(raw string) (time.Duration, error) {
+	if secs, err := strconv.ParseFloat(raw, 64); err == nil {
+		return time.Duration(secs * float64(time.Second)), nil
+	}
+	d, err := time.ParseDuration(raw)
+	if err != nil {
+		return 0, errors.New("expected a duration such as 1s, 500ms or a number of seconds")
+	}
+	return d, nil
+}
+
+// readFile reads a YAML or TOML file into dotted keys and string values.
+func readFile(path string) (map[string]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	doc := make(map[string]interface{})
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(data, &doc)
+	case ".toml":
+		err = toml.Unmarshal(data, &doc)
+	default:
+		return nil, fmt.Errorf("config file %s: unsupported format %q, expected .yaml, .yml or .toml", path, ext)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse config file %s: %w", path, err)
+	}
+
+	values := make(map[string]string)
+	flatten(doc, "", values)
+	return values, nil
+}
+
+// flatten converts nested sections to dotted keys and lists to comma
+// separated values.
+func flatten(doc map[string]interface{}, prefix string, values map[string]string) {
+	for k, v := range doc {
+		key := prefix + k
+		switch v := v.(type) {
+		case map[string]interface{}:
+			flatten(v, key+".", values)
+		case []interface{}:
+			items := make([]string, len(v))
+			for i, item := range v {
+				items[i] = fmt.Sprint(item)
+			}
+			values[key] = strings.Join(items, ",")
+		case nil:
+			values[key] = ""
+		default:
+			values[key] = fmt.Sprint(v)
+		}
+	}
+}
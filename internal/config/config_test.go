@@ -0,0 +1,231 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+type testConfig struct {
+	Common
+	UpstreamAddr string        `config:"upstream_addr" env:"UPSTREAM_ADDR" usage:"upstream address"`
+	Interval     time.Duration `config:"interval" env:"INTERVAL" usage:"update interval"`
+	Buffer       int           `config:"buffer" env:"BUFFER" usage:"buffer size"`
+	Login        struct {
+		Users    []string `config:"users" env:"LOGIN_USERS" usage:"user names"`
+		Password string   `config:"password" env:"LOGIN_PASSWORD" usage:"password" secret:"true"`
+	} `config:"login"`
+}
+
+func (c *testConfig) Validate() error {
+	return errors.Join(c.Common.Validate(), CheckAddr("upstream_addr", c.UpstreamAddr))
+}
+
+func defaultTestConfig() testConfig {
+	return testConfig{
+		Common:       DefaultCommon(":50051"),
+		UpstreamAddr: "upstream:50052",
+		Interval:     time.Second,
+		Buffer:       10,
+	}
+}
+
+func envFrom(vars map[string]string) func(string) (string, bool) {
+	return func(key string) (string, bool) {
+		v, ok := vars[key]
+		return v, ok
+	}
+}
+
+func writeFile(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write %s: %v", name, err)
+	}
+	return path
+}
+
+func TestLoadDefaults(t *testing.T) {
+	cfg := defaultTestConfig()
+	if err := (Loader{}).Load("test", &cfg); err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+
+	if cfg.ListenAddr != ":50051" || cfg.Interval != time.Second || cfg.Buffer != 10 {
+		t.Errorf("Expected defaults to be kept, got %+v", cfg)
+	}
+}
+
+func TestLoadPrecedence(t *testing.T) {
+	file := writeFile(t, "config.yaml", `
+upstream_addr: file:1
+interval: 5s
+buffer: 20
+login:
+  users: [alice, bob]
+`)
+
+	cfg := defaultTestConfig()
+	err := Loader{
+		Args: []string{"-config", file, "-buffer", "40"},
+		Env:  envFrom(map[string]string{"INTERVAL": "2", "BUFFER": "30"}),
+	}.Load("test", &cfg)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+
+	if cfg.UpstreamAddr != "file:1" {
+		t.Errorf("Expected file to override default, got %s", cfg.UpstreamAddr)
+	}
+	if cfg.Interval != 2*time.Second {
+		t.Errorf("Expected env to override file with bare seconds, got %v", cfg.Interval)
+	}
+	if cfg.Buffer != 40 {
+		t.Errorf("Expected flag to override env, got %d", cfg.Buffer)
+	}
+	if strings.Join(cfg.Login.Users, ",") != "alice,bob" {
+		t.Errorf("Expected nested list from file, got %v", cfg.Login.Users)
+	}
+}
+
+func TestLoadTOMLFromEnv(t *testing.T) {
+	file := writeFile(t, "config.toml", `
+listen_addr = ":6000"
+
+[tracing]
+exporter = "stdout"
+`)
+
+	cfg := defaultTestConfig()
+	if err := (Loader{Env: envFrom(map[string]string{"CONFIG_FILE": file})}).Load("test", &cfg); err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+
+	if cfg.ListenAddr != ":6000" || cfg.Tracing.Exporter != "stdout" {
+		t.Errorf("Expected TOML values, got listen %s, exporter %s", cfg.ListenAddr, cfg.Tracing.Exporter)
+	}
+}
+
+func TestLoadErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		file   string
+		args   []string
+		env    map[string]string
+		expect string
+	}{
+		{"Bad duration", "", nil, map[string]string{"INTERVAL": "soon"}, `invalid value "soon" for interval (from env INTERVAL)`},
+		{"Bad integer flag", "", []string{"-buffer", "many"}, nil, `invalid value "many" for buffer (from flag -buffer): expected an integer`},
+		{"Unknown file key", "buffr: 3\n", nil, nil, `unknown setting "buffr"`},
+		{"Validation", "", []string{"-upstream-addr", "nohost"}, nil, `upstream_addr "nohost" is not a host:port address`},
+		{"Tracing exporter", "", nil, map[string]string{"TRACING_EXPORTER": "jaeger"}, `unknown tracing exporter "jaeger"`},
+		{"Unknown flag", "", []string{"-nope"}, nil, "flag provided but not defined"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			args := tt.args
+			if tt.file != "" {
+				args = append([]string{"-config", writeFile(t, "config.yml", tt.file)}, args...)
+			}
+
+			cfg := defaultTestConfig()
+			err := Loader{Args: args, Env: envFrom(tt.env)}.Load("test", &cfg)
+			if err == nil || !strings.Contains(err.Error(), tt.expect) {
+				t.Errorf("Expected error containing %q, got %v", tt.expect, err)
+			}
+		})
+	}
+}
+
+func TestPrintConfig(t *testing.T) {
+	var out strings.Builder
+	cfg := defaultTestConfig()
+	err := Loader{
+		Args:   []string{"-print-config", "-login-users", "alice"},
+		Env:    envFrom(map[string]string{"LOGIN_PASSWORD": "hunter2", "INTERVAL": "250ms"}),
+		Stdout: &out,
+	}.Load("test", &cfg)
+	if !errors.Is(err, ErrPrinted) {
+		t.Fatalf("Expected ErrPrinted, got %v", err)
+	}
+
+	printed := out.String()
+	for _, line := range []string{
+		"listen_addr: \":50051\" # default\n",
+		"interval: \"250ms\" # env INTERVAL\n",
+		"tracing:\n  exporter: \"none\" # default\n",
+		"login:\n  users: [\"alice\"] # flag -login-users\n",
+		"  password: \"<redacted>\" # env LOGIN_PASSWORD\n",
+	} {
+		if !strings.Contains(printed, line) {
+			t.Errorf("Expected %q in printed config:\n%s", line, printed)
+		}
+	}
+	if strings.Contains(printed, "hunter2") {
+		t.Error("Expected secret to be redacted")
+	}
+
+	// The printed configuration loads back to the same values
+	reloaded := defaultTestConfig()
+	path := writeFile(t, "printed.yaml", strings.Replace(printed, "\"<redacted>\"", "\"\"", 1))
+	if err := (Loader{Args: []string{"-config", path}}).Load("test", &reloaded); err != nil {
+		t.Fatalf("Failed to reload printed config: %v", err)
+	}
+	if reloaded.Interval != 250*time.Millisecond || strings.Join(reloaded.Login.Users, ",") != "alice" {
+		t.Errorf("Expected printed values to round-trip, got %+v", reloaded)
+	}
+}
+
+func TestLoadDuplicateSetting(t *testing.T) {
+	var cfg struct {
+		Common
+		Tracing struct {
+			Exporter string `config:"exporter"`
+		} `config:"tracing"`
+	}
+	err := Loader{}.Load("test", &cfg)
+	if err == nil || !strings.Contains(err.Error(), "setting tracing.exporter is declared twice") {
+		t.Errorf("Expected duplicate setting error, got %v", err)
+	}
+}
+
+func TestParseDuration(t *testing.T) {
+	tests := []struct {
+		raw      string
+		expected time.Duration
+	}{
+		{"1", time.Second},
+		{"0.5", 500 * time.Millisecond},
+		{"1m30s", 90 * time.Second},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseDuration(tt.raw)
+		if err != nil || got != tt.expected {
+			t.Errorf("ParseDuration(%q) = %v, %v; expected %v", tt.raw, got, err, tt.expected)
+		}
+	}
+}
+
+func TestCheckAddrs(t *testing.T) {
+	tests := []struct {
+		addrs string
+		valid bool
+	}{
+		{"asset-registry:50051", true},
+		{"registry-1:50051, registry-2:50051,registry-3:50051", true},
+		{"", false},
+		{"registry-1:50051,registry-2", false},
+	}
+
+	for _, tt := range tests {
+		if err := CheckAddrs("asset_registry_addr", tt.addrs); (err == nil) != tt.valid {
+			t.Errorf("CheckAddrs(%q): expected valid=%v, got %v", tt.addrs, tt.valid, err)
+		}
+	}
+}
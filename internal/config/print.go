@@ -0,0 +1,79 @@
+package config
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// format renders the setting's current value the way it is parsed.
+func (s *setting) format() string {
+	v := s.value
+	if v.Type() == durationType {
+		return v.Interface().(fmt.Stringer).String()
+	}
+	switch v.Kind() {
+	case reflect.Slice:
+		return strings.Join(v.Interface().([]string), ",")
+	default:
+		return fmt.Sprint(v.Interface())
+	}
+}
+
+// yamlValue renders the setting as a YAML scalar or flow sequence.
+func (s *setting) yamlValue() string {
+	if s.secret && s.format() != "" {
+		return strconv.Quote("<redacted>")
+	}
+
+	v := s.value
+	switch {
+	case v.Type() == durationType:
+		return strconv.Quote(s.format())
+	case v.Kind() == reflect.String:
+		return strconv.Quote(v.String())
+	case v.Kind() == reflect.Slice:
+		items := v.Interface().([]string)
+		quoted := make([]string, len(items))
+		for i, item := range items {
+			quoted[i] = strconv.Quote(item)
+		}
+		return "[" + strings.Join(quoted, ", ") + "]"
+	default:
+		return s.format()
+	}
+}
+
+// write prints the settings as a YAML config file that reproduces them, with
+// each value's source as a trailing comment. Secrets are redacted.
+func write(w io.Writer, service string, settings []*setting) error {
+	bw := bufio.NewWriter(w)
+	fmt.Fprintf(bw, "# Effective configuration for %s\n", service)
+
+	var section []string
+	for _, s := range settings {
+		parts := strings.Split(s.key, ".")
+		path, name := parts[:len(parts)-1], parts[len(parts)-1]
+
+		// Open the sections this key needs beyond those already open
+		common := 0
+		for common < len(section) && common < len(path) && section[common] == path[common] {
+			common++
+		}
+		for i := common; i < len(path); i++ {
+			fmt.Fprintf(bw, "%s%s:\n", strings.Repeat("  ", i), path[i])
+		}
+		section = path
+
+		fmt.Fprintf(bw, "%s%s: %s", strings.Repeat("  ", len(path)), name, s.yamlValue())
+		if s.source == SourceDefault {
+			fmt.Fprintf(bw, " # %s\n", s.source)
+		} else {
+			fmt.Fprintf(bw, " # %s %s\n", s.source, s.origin)
+		}
+	}
+	return bw.Flush()
+}
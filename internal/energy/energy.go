@@ -0,0 +1,260 @@
+package energy
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// IntervalLength is the metering interval used for billing. Intervals are
+// aligned to clock boundaries (:00, :15, :30, :45), which also holds for every
+// real-world UTC offset since all of them are multiples of 15 minutes.
+const IntervalLength = 15 * time.Minute
+
+// DefaultMaxGap is the longest gap between two power samples that is still
+// integrated. Longer gaps are treated as missing data.
+const DefaultMaxGap = 5 * time.Minute
+
+// Interval holds the energy consumed during one metering interval.
+type Interval struct {
+	Start   time.Time
+	Energy  float64       // Kilowatt-hours
+	Covered time.Duration // Portion of the interval backed by data
+}
+
+// End returns the exclusive end of the interval.
+func (iv Interval) End() time.Time {
+	return iv.Start.Add(IntervalLength)
+}
+
+// Demand returns the average demand over the interval in kilowatts.
+func (iv Interval) Demand() float64 {
+	return iv.Energy / IntervalLength.Hours()
+}
+
+// Complete reports whether data covers the whole interval.
+func (iv Interval) Complete() bool {
+	return iv.Covered >= IntervalLength
+}
+
+// BillingPeriod summarizes consumption and peak demand for one calendar month.
+type BillingPeriod struct {
+	Start        time.Time
+	End          time.Time
+	Energy       float64 // Kilowatt-hours
+	PeakDemand   float64 // Kilowatts
+	PeakInterval time.Time
+}
+
+type powerSample struct {
+	at    time.Time
+	power float64
+}
+
+type registerSample struct {
+	at      time.Time
+	reading float64
+}
+
+// Accumulator integrates energy for a single asset. It accepts either
+// instantaneous power samples (integrated with the trapezoidal rule) or
+// cumulative meter register readings; an asset should feed one or the other.
+type Accumulator struct {
+	mu        sync.Mutex
+	maxGap    time.Duration
+	location  *time.Location
+	last      *powerSample
+	register  *registerSample
+	total     float64
+	intervals map[int64]*Interval
+}
+
+// NewAccumulator creates an accumulator. A zero maxGap uses DefaultMaxGap and
+// a nil location bills in UTC.
+func NewAccumulator(maxGap time.Duration, location *time.Location) *Accumulator {
+	if maxGap <= 0 {
+		maxGap = DefaultMaxGap
+	}
+	if location == nil {
+		location = time.UTC
+	}
+	return &Accumulator{
+		maxGap:    maxGap,
+		location:  location,
+		intervals: make(map[int64]*Interval),
+	}
+}
+
+// AddPower records an instantaneous power sample in kilowatts. Energy between
+// consecutive samples is integrated unless the gap exceeds maxGap. Samples that
+// are not newer than the previous one are ignored.
+func (a *Accumulator) AddPower(at time.Time, power float64) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.last != nil {
+		if !at.After(a.last.at) {
+			return
+		}
+		if at.Sub(a.last.at) <= a.maxGap {
+			a.integrate(a.last.at, a.last.power, at, power)
+		}
+	}
+	a.last = &powerSample{at: at, power: power}
+}
+
+// Restart forgets the previous power sample so the next one starts a new
+// segment. Use it when the source restarts and the time since its last sample
+// must not be integrated.
+func (a *Accumulator) Restart() {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.last = nil
+}
+
+// AddRegister records a cumulative register reading in kilowatt-hours and
+// returns the energy attributed since the previous reading. When the reading
+// goes backwards it is treated as a rollover if capacity (the register's
+// wrap-around value) is known, otherwise as a reset to zero. The delta is
+// spread linearly over the time since the previous reading.
+func (a *Accumulator) AddRegister(at time.Time, reading, capacity float64) float64 {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	prev := a.register
+	if prev != nil && !at.After(prev.at) {
+		return 0
+	}
+	a.register = &registerSample{at: at, reading: reading}
+	if prev == nil {
+		return 0
+	}
+
+	delta := reading - prev.reading
+	if delta < 0 {
+		if capacity > 0 {
+			delta = capacity - prev.reading + reading
+		} else {
+			delta = reading
+		}
+	}
+
+	span := at.Sub(prev.at)
+	a.split(prev.at, at, func(start, end time.Time) float64 {
+		return delta * float64(end.Sub(start)) / float64(span)
+	})
+	return delta
+}
+
+// integrate adds the trapezoidal energy between two power samples, splitting it
+// at interval boundaries using linear interpolation.
+func (a *Accumulator) integrate(t0 time.Time, p0 float64, t1 time.Time, p1 float64) {
+	span := float64(t1.Sub(t0))
+	powerAt := func(t time.Time) float64 {
+		return p0 + (p1-p0)*float64(t.Sub(t0))/span
+	}
+	a.split(t0, t1, func(start, end time.Time) float64 {
+		return (powerAt(start) + powerAt(end)) / 2 * end.Sub(start).Hours()
+	})
+}
+
+// split walks [from, to) one interval at a time and credits each piece with the
+// energy returned by energyFor.
+func (a *Accumulator) split(from, to time.Time, energyFor func(start, end time.Time) float64) {
+	for start := from; start.Before(to); {
+		intervalStart := start.Truncate(IntervalLength)
+		end := intervalStart.Add(IntervalLength)
+		if end.After(to) {
+			end = to
+		}
+
+		kwh := energyFor(start, end)
+		iv, exists := a.intervals[intervalStart.Unix()]
+		if !exists {
+			iv = &Interval{Start: intervalStart}
+			a.intervals[intervalStart.Unix()] = iv
+		}
+		iv.Energy += kwh
+		iv.Covered += end.Sub(start)
+		a.total += kwh
+
+		start = end
+	}
+}
+
+// Total returns the energy accumulated so far in kilowatt-hours.
+func (a *Accumulator) Total() float64 {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.total
+}
+
+// CurrentDemand returns the average demand of the interval containing the
+// most recent sample, based on the data received so far.
+func (a *Accumulator) CurrentDemand() float64 {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	var latest time.Time
+	switch {
+	case a.last != nil:
+		latest = a.last.at
+	case a.register != nil:
+		latest = a.register.at
+	default:
+		return 0
+	}
+
+	iv, exists := a.intervals[latest.Add(-time.Nanosecond).Truncate(IntervalLength).Unix()]
+	if !exists || iv.Covered == 0 {
+		return 0
+	}
+	return iv.Energy / iv.Covered.Hours()
+}
+
+// Intervals returns the intervals starting within [start, end) in time order.
+// A zero start or end leaves that side of the range open.
+func (a *Accumulator) Intervals(start, end time.Time) []Interval {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	result := make([]Interval, 0, len(a.intervals))
+	for _, iv := range a.intervals {
+		if !start.IsZero() && iv.Start.Before(start) {
+			continue
+		}
+		if !end.IsZero() && !iv.Start.Before(end) {
+			continue
+		}
+		result = append(result, *iv)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Start.Before(result[j].Start)
+	})
+	return result
+}
+
+// BillingPeriods groups the intervals within [start, end) into calendar months
+// in the accumulator's location and reports the peak demand of each.
+func (a *Accumulator) BillingPeriods(start, end time.Time) []BillingPeriod {
+	var periods []BillingPeriod
+	for _, iv := range a.Intervals(start, end) {
+		local := iv.Start.In(a.location)
+		periodStart := time.Date(local.Year(), local.Month(), 1, 0, 0, 0, 0, a.location)
+
+		if len(periods) == 0 || !periods[len(periods)-1].Start.Equal(periodStart) {
+			periods = append(periods, BillingPeriod{
+				Start: periodStart,
+				End:   periodStart.AddDate(0, 1, 0),
+			})
+		}
+
+		period := &periods[len(periods)-1]
+		period.Energy += iv.Energy
+		if demand := iv.Demand(); demand > period.PeakDemand {
+			period.PeakDemand = demand
+			period.PeakInterval = iv.Start
+		}
+	}
+	return periods
+}
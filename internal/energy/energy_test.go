@@ -0,0 +1,181 @@
+package energy
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func approxEqual(a, b float64) bool {
+	return math.Abs(a-b) < 1e-9
+}
+
+func TestAddPowerTrapezoidal(t *testing.T) {
+	acc := NewAccumulator(0, nil)
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	// Ramp from 0 to 120 kW over one minute: average 60 kW for 1/60 h = 1 kWh
+	acc.AddPower(start, 0)
+	acc.AddPower(start.Add(time.Minute), 120)
+
+	if !approxEqual(acc.Total(), 1) {
+		t.Errorf("Expected 1 kWh, got %f", acc.Total())
+	}
+}
+
+func TestAddPowerSplitsAtIntervalBoundary(t *testing.T) {
+	acc := NewAccumulator(0, nil)
+	start := time.Date(2024, 1, 1, 0, 14, 0, 0, time.UTC)
+
+	acc.AddPower(start, 60)
+	acc.AddPower(start.Add(2*time.Minute), 60)
+
+	intervals := acc.Intervals(time.Time{}, time.Time{})
+	if len(intervals) != 2 {
+		t.Fatalf("Expected 2 intervals, got %d", len(intervals))
+	}
+
+	if !intervals[0].Start.Equal(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("Expected first interval at 00:00, got %v", intervals[0].Start)
+	}
+	if !intervals[1].Start.Equal(time.Date(2024, 1, 1, 0, 15, 0, 0, time.UTC)) {
+		t.Errorf("Expected second interval at 00:15, got %v", intervals[1].Start)
+	}
+
+	for _, iv := range intervals {
+		if !approxEqual(iv.Energy, 1) {
+			t.Errorf("Expected 1 kWh in interval %v, got %f", iv.Start, iv.Energy)
+		}
+		if iv.Complete() {
+			t.Errorf("Expected interval %v to be incomplete", iv.Start)
+		}
+	}
+}
+
+func TestAddPowerSkipsGaps(t *testing.T) {
+	acc := NewAccumulator(time.Minute, nil)
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	acc.AddPower(start, 60)
+	acc.AddPower(start.Add(10*time.Minute), 60)
+	if acc.Total() != 0 {
+		t.Errorf("Expected gap to be skipped, got %f kWh", acc.Total())
+	}
+
+	acc.AddPower(start.Add(11*time.Minute), 60)
+	if !approxEqual(acc.Total(), 1) {
+		t.Errorf("Expected integration to resume after gap, got %f kWh", acc.Total())
+	}
+}
+
+func TestAddPowerIgnoresOutOfOrderSamples(t *testing.T) {
+	acc := NewAccumulator(0, nil)
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	acc.AddPower(start.Add(time.Minute), 60)
+	acc.AddPower(start, 6000)
+	acc.AddPower(start.Add(2*time.Minute), 60)
+
+	if !approxEqual(acc.Total(), 1) {
+		t.Errorf("Expected 1 kWh, got %f", acc.Total())
+	}
+}
+
+func TestRestart(t *testing.T) {
+	acc := NewAccumulator(0, nil)
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	acc.AddPower(start, 60)
+	acc.AddPower(start.Add(time.Minute), 60)
+	acc.Restart()
+	acc.AddPower(start.Add(3*time.Minute), 60)
+
+	if !approxEqual(acc.Total(), 1) {
+		t.Errorf("Expected restart to drop the gap, got %f kWh", acc.Total())
+	}
+}
+
+func TestAddRegister(t *testing.T) {
+	acc := NewAccumulator(0, nil)
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	if delta := acc.AddRegister(start, 1000, 0); delta != 0 {
+		t.Errorf("Expected first reading to set a baseline, got %f", delta)
+	}
+	if delta := acc.AddRegister(start.Add(30*time.Minute), 1010, 0); !approxEqual(delta, 10) {
+		t.Errorf("Expected delta=10, got %f", delta)
+	}
+
+	intervals := acc.Intervals(time.Time{}, time.Time{})
+	if len(intervals) != 2 {
+		t.Fatalf("Expected register delta spread over 2 intervals, got %d", len(intervals))
+	}
+	if !approxEqual(intervals[0].Energy, 5) || !approxEqual(intervals[1].Energy, 5) {
+		t.Errorf("Expected 5 kWh per interval, got %f and %f", intervals[0].Energy, intervals[1].Energy)
+	}
+}
+
+func TestAddRegisterRollover(t *testing.T) {
+	acc := NewAccumulator(0, nil)
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	acc.AddRegister(start, 99995, 100000)
+	delta := acc.AddRegister(start.Add(15*time.Minute), 3, 100000)
+
+	if !approxEqual(delta, 8) {
+		t.Errorf("Expected rollover delta=8, got %f", delta)
+	}
+}
+
+func TestAddRegisterReset(t *testing.T) {
+	acc := NewAccumulator(0, nil)
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	acc.AddRegister(start, 500, 0)
+	delta := acc.AddRegister(start.Add(15*time.Minute), 4, 0)
+
+	if !approxEqual(delta, 4) {
+		t.Errorf("Expected reset delta=4, got %f", delta)
+	}
+}
+
+func TestCurrentDemand(t *testing.T) {
+	acc := NewAccumulator(0, nil)
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	acc.AddPower(start, 200)
+	acc.AddPower(start.Add(5*time.Minute), 200)
+
+	if demand := acc.CurrentDemand(); !approxEqual(demand, 200) {
+		t.Errorf("Expected demand=200 kW, got %f", demand)
+	}
+}
+
+func TestBillingPeriods(t *testing.T) {
+	acc := NewAccumulator(0, nil)
+
+	// 100 kW through the end of January, then 400 kW for 15 minutes in February
+	jan := time.Date(2024, 1, 31, 23, 30, 0, 0, time.UTC)
+	acc.AddPower(jan, 100)
+	acc.AddPower(jan.Add(5*time.Minute), 100)
+	feb := time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
+	acc.AddPower(feb, 400)
+	acc.AddPower(feb.Add(5*time.Minute), 400)
+	acc.AddPower(feb.Add(10*time.Minute), 400)
+	acc.AddPower(feb.Add(15*time.Minute), 400)
+
+	periods := acc.BillingPeriods(time.Time{}, time.Time{})
+	if len(periods) != 2 {
+		t.Fatalf("Expected 2 billing periods, got %d", len(periods))
+	}
+
+	if !periods[1].Start.Equal(feb) {
+		t.Errorf("Expected February period to start at %v, got %v", feb, periods[1].Start)
+	}
+	if !approxEqual(periods[1].PeakDemand, 400) {
+		t.Errorf("Expected February peak demand=400 kW, got %f", periods[1].PeakDemand)
+	}
+	if !periods[1].PeakInterval.Equal(feb) {
+		t.Errorf("Expected February peak interval at %v, got %v", feb, periods[1].PeakInterval)
+	}
+}
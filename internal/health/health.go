@@ -0,0 +1,194 @@
+package health
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/connectivity"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// Liveness is the health service name reporting whether the process can still
+// make progress. Orchestrators should restart a service that fails it.
+const Liveness = ""
+
+// DefaultInterval is how often checks are re-evaluated.
+const DefaultInterval = 5 * time.Second
+
+// checkTimeout bounds a single check.
+const checkTimeout = 2 * time.Second
+
+// Check returns nil when the checked dependency is healthy.
+type Check func(ctx context.Context) error
+
+type namedCheck struct {
+	name  string
+	check Check
+}
+
+// Checker serves the standard grpc.health.v1.Health service. The empty
+// service name reports liveness and the service's own name reports readiness,
+// which additionally requires every readiness check to pass.
+type Checker struct {
+	server  *health.Server
+	service string
+
+	mu        sync.Mutex
+	liveness  []namedCheck
+	readiness []namedCheck
+	failures  map[string]string
+}
+
+// NewChecker creates a checker for the named gRPC service (e.g.
+// "asset.AssetRegistry"). The service starts live but not ready.
+func NewChecker(service string) *Checker {
+	c := &Checker{
+		server:   health.NewServer(),
+		service:  service,
+		failures: make(map[string]string),
+	}
+	c.server.SetServingStatus(Liveness, healthpb.HealthCheckResponse_SERVING)
+	c.server.SetServingStatus(service, healthpb.HealthCheckResponse_NOT_SERVING)
+	return c
+}
+
+// AddLivenessCheck adds a check that both liveness and readiness depend on.
+func (c *Checker) AddLivenessCheck(name string, check Check) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.liveness = append(c.liveness, namedCheck{name: name, check: check})
+}
+
+// AddReadinessCheck adds a check that only readiness depends on, such as an
+// upstream connection.
+func (c *Checker) AddReadinessCheck(name string, check Check) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.readiness = append(c.readiness, namedCheck{name: name, check: check})
+}
+
+// Register exposes the health service on a gRPC server.
+func (c *Checker) Register(s *grpc.Server) {
+	healthpb.RegisterHealthServer(s, c.server)
+}
+
+// Server returns the underlying health server.
+func (c *Checker) Server() healthpb.HealthServer {
+	return c.server
+}
+
+// Run evaluates the checks immediately and then every interval until ctx is
+// done.
+func (c *Checker) Run(ctx context.Context, interval time.Duration) {
+	c.Update(ctx)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			c.Update(ctx)
+		}
+	}
+}
+
+// Update evaluates every check once and publishes the resulting liveness and
+// readiness statuses.
+func (c *Checker) Update(ctx context.Context) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	live := c.runChecks(ctx, c.liveness)
+	ready := c.runChecks(ctx, c.readiness) && live
+
+	c.server.SetServingStatus(Liveness, servingStatus(live))
+	c.server.SetServingStatus(c.service, servingStatus(ready))
+}
+
+// Shutdown marks the service as neither live nor ready. Later updates are
+// ignored, so in-flight probes see NOT_SERVING while the server drains.
+func (c *Checker) Shutdown() {
+	c.server.Shutdown()
+}
+
+// runChecks runs checks and records failures, logging state changes. Callers
+// must hold c.mu.
+func (c *Checker) runChecks(ctx context.Context, checks []namedCheck) bool {
+	healthy := true
+	for _, nc := range checks {
+		checkCtx, cancel := context.WithTimeout(ctx, checkTimeout)
+		err := nc.check(checkCtx)
+		cancel()
+
+		previous, failing := c.failures[nc.name]
+		switch {
+		case err != nil:
+			healthy = false
+			if !failing || previous != err.Error() {
+				log.Printf("Health check %s failing: %v", nc.name, err)
+			}
+			c.failures[nc.name] = err.Error()
+		case failing:
+			log.Printf("Health check %s recovered", nc.name)
+			delete(c.failures, nc.name)
+		}
+	}
+	return healthy
+}
+
+func servingStatus(ok bool) healthpb.HealthCheckResponse_ServingStatus {
+	if ok {
+		return healthpb.HealthCheckResponse_SERVING
+	}
+	return healthpb.HealthCheckResponse_NOT_SERVING
+}
+
+// ConnReady checks that a client connection is READY. Idle connections are
+// asked to connect so the next evaluation can observe the result.
+func ConnReady(conn *grpc.ClientConn) Check {
+	return func(ctx context.Context) error {
+		state := conn.GetState()
+		if state == connectivity.Idle {
+			conn.Connect()
+			conn.WaitForStateChange(ctx, state)
+			state = conn.GetState()
+		}
+		if state != connectivity.Ready {
+			return fmt.Errorf("connection to %s is %s", conn.Target(), strings.ToLower(state.String()))
+		}
+		return nil
+	}
+}
+
+// RLockable is implemented by *sync.RWMutex.
+type RLockable interface {
+	TryRLock() bool
+	RUnlock()
+}
+
+// LockAcquirable checks that a read lock can be taken before the check times
+// out, catching stores wedged by a deadlocked or stuck writer.
+func LockAcquirable(mu RLockable) Check {
+	return func(ctx context.Context) error {
+		for {
+			if mu.TryRLock() {
+				mu.RUnlock()
+				return nil
+			}
+			select {
+			case <-ctx.Done():
+				return fmt.Errorf("lock not acquired: %w", ctx.Err())
+			case <-time.After(10 * time.Millisecond):
+			}
+		}
+	}
+}
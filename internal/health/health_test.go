@@ -0,0 +1,134 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+const testService = "test.TestService"
+
+func servingStatusOf(t *testing.T, c *Checker, service string) healthpb.HealthCheckResponse_ServingStatus {
+	t.Helper()
+	resp, err := c.Server().Check(context.Background(), &healthpb.HealthCheckRequest{Service: service})
+	if err != nil {
+		t.Fatalf("Check(%q) failed: %v", service, err)
+	}
+	return resp.Status
+}
+
+func TestCheckerStartsLiveButNotReady(t *testing.T) {
+	c := NewChecker(testService)
+
+	if got := servingStatusOf(t, c, Liveness); got != healthpb.HealthCheckResponse_SERVING {
+		t.Errorf("Expected liveness SERVING, got %v", got)
+	}
+	if got := servingStatusOf(t, c, testService); got != healthpb.HealthCheckResponse_NOT_SERVING {
+		t.Errorf("Expected readiness NOT_SERVING before the first update, got %v", got)
+	}
+}
+
+func TestCheckerUpdate(t *testing.T) {
+	var liveErr, readyErr error
+	c := NewChecker(testService)
+	c.AddLivenessCheck("store", func(ctx context.Context) error { return liveErr })
+	c.AddReadinessCheck("upstream", func(ctx context.Context) error { return readyErr })
+
+	tests := []struct {
+		name     string
+		liveErr  error
+		readyErr error
+		live     healthpb.HealthCheckResponse_ServingStatus
+		ready    healthpb.HealthCheckResponse_ServingStatus
+	}{
+		{"All passing", nil, nil, healthpb.HealthCheckResponse_SERVING, healthpb.HealthCheckResponse_SERVING},
+		{"Upstream down", nil, errors.New("down"), healthpb.HealthCheckResponse_SERVING, healthpb.HealthCheckResponse_NOT_SERVING},
+		{"Store wedged", errors.New("wedged"), nil, healthpb.HealthCheckResponse_NOT_SERVING, healthpb.HealthCheckResponse_NOT_SERVING},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			liveErr, readyErr = tt.liveErr, tt.readyErr
+			c.Update(context.Background())
+
+			if got := servingStatusOf(t, c, Liveness); got != tt.live {
+				t.Errorf("Expected liveness %v, got %v", tt.live, got)
+			}
+			if got := servingStatusOf(t, c, testService); got != tt.ready {
+				t.Errorf("Expected readiness %v, got %v", tt.ready, got)
+			}
+		})
+	}
+}
+
+func TestCheckerShutdown(t *testing.T) {
+	c := NewChecker(testService)
+	c.Update(context.Background())
+	c.Shutdown()
+	c.Update(context.Background())
+
+	if got := servingStatusOf(t, c, Liveness); got != healthpb.HealthCheckResponse_NOT_SERVING {
+		t.Errorf("Expected NOT_SERVING after shutdown, got %v", got)
+	}
+}
+
+func TestLockAcquirable(t *testing.T) {
+	var mu sync.RWMutex
+	check := LockAcquirable(&mu)
+
+	if err := check(context.Background()); err != nil {
+		t.Errorf("Expected free lock to pass, got %v", err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if err := check(ctx); err == nil {
+		t.Error("Expected held lock to fail the check")
+	}
+}
+
+func TestConnReady(t *testing.T) {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+	s := grpc.NewServer()
+	go s.Serve(lis)
+	defer s.Stop()
+
+	conn, err := grpc.NewClient(lis.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	defer conn.Close()
+
+	check := ConnReady(conn)
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+		err := check(ctx)
+		cancel()
+		if err == nil {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("Expected connection to become READY, got %v", err)
+		}
+	}
+
+	s.Stop()
+	conn.WaitForStateChange(context.Background(), conn.GetState())
+	if err := check(context.Background()); err == nil {
+		t.Error("Expected check to fail once the server is gone")
+	}
+}
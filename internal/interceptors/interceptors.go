@@ -0,0 +1,120 @@
+package interceptors
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"google.golang.org/grpc"
+
+	"github.com/sairamkiran9/asset-telemetry-monitor/internal/auth"
+	"github.com/sairamkiran9/asset-telemetry-monitor/internal/certs"
+	"github.com/sairamkiran9/asset-telemetry-monitor/internal/cluster"
+	"github.com/sairamkiran9/asset-telemetry-monitor/internal/metrics"
+	"github.com/sairamkiran9/asset-telemetry-monitor/internal/shutdown"
+	"github.com/sairamkiran9/asset-telemetry-monitor/internal/tracing"
+)
+
+// DefaultMaxTimeout bounds unary RPCs that arrive without a deadline or with a
+// longer one.
+const DefaultMaxTimeout = 30 * time.Second
+
+// Options configures the interceptor chains.
+type Options struct {
+	Logger     *slog.Logger          // Access and panic logs; default slog.Default()
+	Registry   *metrics.Registry     // Server metrics; nil disables them
+	Tracer     *tracing.Tracer       // Server and client spans; nil disables them
+	Shutdown   *shutdown.Coordinator // Ends open streams on shutdown; nil disables draining
+	Auth       *auth.Authenticator   // Authenticates and authorizes callers; nil allows all
+	TLS        *certs.Reloader       // Identifies replicas by certificate when Auth is nil
+	Token      string                // Bearer token presented to other services; empty sends none
+	MaxTimeout time.Duration         // Unary deadline cap; default DefaultMaxTimeout
+}
+
+// ServerOptions returns the interceptor chain shared by every service. In
+// order, requests are traced, get a request ID, are access logged, are
+// measured, are authorized, lose the cluster forwarding marker unless they
+// come from a replica, get a bounded deadline (streams are instead ended
+// when the server drains), and have panics converted to codes.Internal, so
+// logs and metrics see rejected calls and the final status of recovered panics
+// and drained streams.
+func ServerOptions(opts Options) []grpc.ServerOption {
+	logger := opts.Logger
+	if logger == nil {
+		logger = slog.Default()
+	}
+	maxTimeout := opts.MaxTimeout
+	if maxTimeout <= 0 {
+		maxTimeout = DefaultMaxTimeout
+	}
+
+	unary := []grpc.UnaryServerInterceptor{
+		tracing.UnaryServerInterceptor(opts.Tracer),
+		UnaryRequestID(),
+		UnaryAccessLog(logger),
+	}
+	stream := []grpc.StreamServerInterceptor{
+		tracing.StreamServerInterceptor(opts.Tracer),
+		StreamRequestID(),
+		StreamAccessLog(logger),
+	}
+	if opts.Registry != nil {
+		unary = append(unary, metrics.UnaryServerInterceptor(opts.Registry))
+		stream = append(stream, metrics.StreamServerInterceptor(opts.Registry))
+	}
+	unary = append(unary, opts.Auth.UnaryServerInterceptor(), cluster.UnaryServerInterceptor(opts.isPeer), UnaryDeadline(maxTimeout), UnaryRecovery(logger))
+	stream = append(stream, opts.Auth.StreamServerInterceptor(), cluster.StreamServerInterceptor(opts.isPeer))
+	if opts.Shutdown != nil {
+		stream = append(stream, opts.Shutdown.StreamServerInterceptor())
+	}
+	stream = append(stream, StreamRecovery(logger))
+
+	return []grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(unary...),
+		grpc.ChainStreamInterceptor(stream...),
+	}
+}
+
+// isPeer reports whether a caller may speak for another instance of the
+// service. With authentication, only service credentials acting for any tenant
+// may; without it, only mTLS callers presenting the service's own certificate
+// identity. Deployments with neither can't tell callers apart and trust them
+// all, as they trust the tenant callers name.
+func (opts Options) isPeer(ctx context.Context) bool {
+	if opts.Auth != nil {
+		p := auth.PrincipalFromContext(ctx)
+		return p != nil && p.Tenant == auth.AnyTenant
+	}
+	if opts.TLS.ClientAuth() {
+		return opts.TLS.IsReplica(ctx)
+	}
+	return true
+}
+
+// DialOptions returns the client interceptors every service uses for calls to
+// other services, which also forward the caller's tenant. Only opts.Tracer and
+// opts.Token apply to clients.
+func DialOptions(opts Options) []grpc.DialOption {
+	return append(auth.DialOptions(opts.Token),
+		grpc.WithChainUnaryInterceptor(
+			tracing.UnaryClientInterceptor(opts.Tracer),
+			UnaryClientRequestID(),
+			auth.UnaryClientInterceptor(),
+		),
+		grpc.WithChainStreamInterceptor(
+			tracing.StreamClientInterceptor(opts.Tracer),
+			StreamClientRequestID(),
+			auth.StreamClientInterceptor(),
+		),
+	)
+}
+
+// wrappedStream overrides the context of a server stream.
+type wrappedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (w *wrappedStream) Context() context.Context {
+	return w.ctx
+}
@@ -0,0 +1,164 @@
+package interceptors
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"log/slog"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/sairamkiran9/asset-telemetry-monitor/internal/auth"
+)
+
+var unaryInfo = &grpc.UnaryServerInfo{FullMethod: "/test.Service/Method"}
+
+func discardLogger() *slog.Logger {
+	return slog.New(slog.NewTextHandler(io.Discard, nil))
+}
+
+func TestUnaryRecovery(t *testing.T) {
+	var logs bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&logs, nil))
+
+	_, err := UnaryRecovery(logger)(context.Background(), nil, unaryInfo, func(ctx context.Context, req interface{}) (interface{}, error) {
+		panic("boom")
+	})
+
+	if status.Code(err) != codes.Internal {
+		t.Errorf("Expected Internal after panic, got %v", err)
+	}
+	if !strings.Contains(logs.String(), "boom") {
+		t.Errorf("Expected panic value in log, got %q", logs.String())
+	}
+}
+
+func TestUnaryRequestIDReusesIncoming(t *testing.T) {
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(RequestIDKey, "req-42"))
+
+	var got string
+	UnaryRequestID()(ctx, nil, unaryInfo, func(ctx context.Context, req interface{}) (interface{}, error) {
+		got = RequestIDFromContext(ctx)
+		return nil, nil
+	})
+
+	if got != "req-42" {
+		t.Errorf("Expected request ID req-42, got %q", got)
+	}
+}
+
+func TestUnaryRequestIDGenerates(t *testing.T) {
+	var got string
+	UnaryRequestID()(context.Background(), nil, unaryInfo, func(ctx context.Context, req interface{}) (interface{}, error) {
+		got = RequestIDFromContext(ctx)
+		return nil, nil
+	})
+
+	if len(got) != 16 {
+		t.Errorf("Expected a generated 16 character request ID, got %q", got)
+	}
+}
+
+func TestUnaryDeadline(t *testing.T) {
+	interceptor := UnaryDeadline(time.Second)
+
+	tests := []struct {
+		name     string
+		timeout  time.Duration
+		code     codes.Code
+		deadline time.Duration
+	}{
+		{"No deadline is capped", 0, codes.OK, time.Second},
+		{"Long deadline is capped", time.Hour, codes.OK, time.Second},
+		{"Short deadline is kept", 100 * time.Millisecond, codes.OK, 100 * time.Millisecond},
+		{"Expired deadline is rejected", -time.Second, codes.DeadlineExceeded, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.timeout != 0 {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithTimeout(ctx, tt.timeout)
+				defer cancel()
+			}
+
+			var remaining time.Duration
+			_, err := interceptor(ctx, nil, unaryInfo, func(ctx context.Context, req interface{}) (interface{}, error) {
+				deadline, _ := ctx.Deadline()
+				remaining = time.Until(deadline)
+				return nil, nil
+			})
+
+			if status.Code(err) != tt.code {
+				t.Fatalf("Expected %v, got %v", tt.code, err)
+			}
+			if tt.code == codes.OK && (remaining > tt.deadline || remaining < tt.deadline-50*time.Millisecond) {
+				t.Errorf("Expected handler deadline about %v away, got %v", tt.deadline, remaining)
+			}
+		})
+	}
+}
+
+func TestIsPeer(t *testing.T) {
+	service := auth.ContextWithPrincipal(context.Background(), &auth.Principal{Subject: "asset-monitoring", Tenant: auth.AnyTenant})
+	tenant := auth.ContextWithPrincipal(context.Background(), &auth.Principal{Subject: "north-ops", Tenant: "north-campus"})
+	authenticated := Options{Auth: &auth.Authenticator{}}
+
+	tests := []struct {
+		name string
+		opts Options
+		ctx  context.Context
+		want bool
+	}{
+		{"service credentials", authenticated, service, true},
+		{"tenant credentials", authenticated, tenant, false},
+		{"public method", authenticated, context.Background(), false},
+		{"no authentication or mTLS", Options{}, context.Background(), true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.opts.isPeer(tt.ctx); got != tt.want {
+				t.Errorf("Expected isPeer=%v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestRequestIDPropagatesOverGRPC(t *testing.T) {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+	s := grpc.NewServer(ServerOptions(Options{Logger: discardLogger()})...)
+	healthpb.RegisterHealthServer(s, health.NewServer())
+	go s.Serve(lis)
+	defer s.Stop()
+
+	conn, err := grpc.NewClient(lis.Addr().String(), append(DialOptions(Options{}), grpc.WithTransportCredentials(insecure.NewCredentials()))...)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	defer conn.Close()
+
+	// A request ID already on the context is forwarded and echoed back
+	var header metadata.MD
+	ctx := WithRequestID(context.Background(), "req-7")
+	if _, err := healthpb.NewHealthClient(conn).Check(ctx, &healthpb.HealthCheckRequest{}, grpc.Header(&header)); err != nil {
+		t.Fatalf("Check failed: %v", err)
+	}
+
+	if ids := header.Get(RequestIDKey); len(ids) != 1 || ids[0] != "req-7" {
+		t.Errorf("Expected response header %s=req-7, got %v", RequestIDKey, ids)
+	}
+}
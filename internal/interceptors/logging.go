@@ -0,0 +1,119 @@
+package interceptors
+
+import (
+	"context"
+	"log/slog"
+	"runtime/debug"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	"github.com/sairamkiran9/asset-telemetry-monitor/internal/tracing"
+)
+
+// logRPC writes one access log entry. Server errors are logged at error level,
+// client errors at warning level and everything else at info level.
+func logRPC(ctx context.Context, logger *slog.Logger, kind, method string, start time.Time, err error) {
+	code := status.Code(err)
+
+	level := slog.LevelInfo
+	switch code {
+	case codes.OK, codes.Canceled:
+	case codes.Internal, codes.Unknown, codes.DataLoss, codes.Unimplemented, codes.Unavailable:
+		level = slog.LevelError
+	default:
+		level = slog.LevelWarn
+	}
+
+	attrs := []slog.Attr{
+		slog.String("kind", kind),
+		slog.String("method", method),
+		slog.String("code", code.String()),
+		slog.Float64("duration_ms", float64(time.Since(start).Microseconds())/1000),
+		slog.String("request_id", RequestIDFromContext(ctx)),
+	}
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		attrs = append(attrs, slog.String("peer", p.Addr.String()))
+	}
+	if sc := tracing.SpanContextFromContext(ctx); sc.IsValid() {
+		attrs = append(attrs, slog.String("trace_id", sc.TraceID.String()))
+	}
+	if err != nil {
+		attrs = append(attrs, slog.String("error", status.Convert(err).Message()))
+	}
+	logger.LogAttrs(ctx, level, "rpc", attrs...)
+}
+
+// UnaryAccessLog logs every unary RPC with its status code and duration.
+func UnaryAccessLog(logger *slog.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		start := time.Now()
+		resp, err := handler(ctx, req)
+		logRPC(ctx, logger, "unary", info.FullMethod, start, err)
+		return resp, err
+	}
+}
+
+// StreamAccessLog logs every streaming RPC when it ends.
+func StreamAccessLog(logger *slog.Logger) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		start := time.Now()
+		err := handler(srv, ss)
+		logRPC(ss.Context(), logger, "stream", info.FullMethod, start, err)
+		return err
+	}
+}
+
+// recovered logs a panic with its stack and converts it to codes.Internal.
+func recovered(ctx context.Context, logger *slog.Logger, method string, p interface{}) error {
+	logger.ErrorContext(ctx, "panic in rpc handler",
+		slog.String("method", method),
+		slog.Any("panic", p),
+		slog.String("request_id", RequestIDFromContext(ctx)),
+		slog.String("stack", string(debug.Stack())),
+	)
+	return status.Errorf(codes.Internal, "internal error in %s", method)
+}
+
+// UnaryRecovery turns handler panics into codes.Internal errors instead of
+// crashing the service.
+func UnaryRecovery(logger *slog.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
+		defer func() {
+			if p := recover(); p != nil {
+				resp, err = nil, recovered(ctx, logger, info.FullMethod, p)
+			}
+		}()
+		return handler(ctx, req)
+	}
+}
+
+// StreamRecovery is UnaryRecovery for streaming RPCs.
+func StreamRecovery(logger *slog.Logger) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+		defer func() {
+			if p := recover(); p != nil {
+				err = recovered(ss.Context(), logger, info.FullMethod, p)
+			}
+		}()
+		return handler(srv, ss)
+	}
+}
+
+// UnaryDeadline rejects RPCs whose deadline already passed and caps the
+// deadline of the rest at maxTimeout, so a caller without a deadline can't hold
+// a handler forever. Streams are long-lived by design and are not limited.
+func UnaryDeadline(maxTimeout time.Duration) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if deadline, ok := ctx.Deadline(); ok && !time.Now().Before(deadline) {
+			return nil, status.Errorf(codes.DeadlineExceeded, "deadline for %s exceeded before handling", info.FullMethod)
+		}
+
+		ctx, cancel := context.WithTimeout(ctx, maxTimeout)
+		defer cancel()
+		return handler(ctx, req)
+	}
+}
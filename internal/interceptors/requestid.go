@@ -0,0 +1,93 @@
+package interceptors
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/sairamkiran9/asset-telemetry-monitor/internal/tracing"
+)
+
+// RequestIDKey is the metadata key carrying request IDs, both on incoming
+// requests and in response headers.
+const RequestIDKey = "x-request-id"
+
+type requestIDContextKey struct{}
+
+// RequestIDFromContext returns the request ID of the RPC being handled, or "".
+func RequestIDFromContext(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDContextKey{}).(string)
+	return id
+}
+
+// WithRequestID returns a context carrying the request ID.
+func WithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDContextKey{}, id)
+}
+
+func newRequestID() string {
+	b := make([]byte, 8)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// incomingRequestID reuses the caller's request ID, or generates one.
+func incomingRequestID(ctx context.Context) string {
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if ids := md.Get(RequestIDKey); len(ids) > 0 && ids[0] != "" {
+			return ids[0]
+		}
+	}
+	return newRequestID()
+}
+
+// UnaryRequestID assigns each RPC a request ID, taken from the caller's
+// metadata when present, and echoes it in the response header.
+func UnaryRequestID() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		id := incomingRequestID(ctx)
+		grpc.SetHeader(ctx, metadata.Pairs(RequestIDKey, id))
+		tracing.SpanFromContext(ctx).SetAttributes(tracing.String(tracing.AttrRequestID, id))
+		return handler(WithRequestID(ctx, id), req)
+	}
+}
+
+// StreamRequestID is UnaryRequestID for streaming RPCs.
+func StreamRequestID() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		id := incomingRequestID(ss.Context())
+		ss.SetHeader(metadata.Pairs(RequestIDKey, id))
+		tracing.SpanFromContext(ss.Context()).SetAttributes(tracing.String(tracing.AttrRequestID, id))
+		return handler(srv, &wrappedStream{ServerStream: ss, ctx: WithRequestID(ss.Context(), id)})
+	}
+}
+
+// outgoingRequestID forwards the context's request ID to the callee so a
+// request can be followed across services.
+func outgoingRequestID(ctx context.Context) context.Context {
+	id := RequestIDFromContext(ctx)
+	if id == "" {
+		return ctx
+	}
+	if md, ok := metadata.FromOutgoingContext(ctx); ok && len(md.Get(RequestIDKey)) > 0 {
+		return ctx
+	}
+	return metadata.AppendToOutgoingContext(ctx, RequestIDKey, id)
+}
+
+// UnaryClientRequestID propagates request IDs on outgoing unary calls.
+func UnaryClientRequestID() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		return invoker(outgoingRequestID(ctx), method, req, reply, cc, opts...)
+	}
+}
+
+// StreamClientRequestID propagates request IDs on outgoing streaming calls.
+func StreamClientRequestID() grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		return streamer(outgoingRequestID(ctx), desc, cc, method, opts...)
+	}
+}
@@ -0,0 +1,159 @@
+package metrics
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"log"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	// OpenMetricsContentType is served to scrapers that accept OpenMetrics.
+	OpenMetricsContentType = "application/openmetrics-text; version=1.0.0; charset=utf-8"
+	// TextContentType is the Prometheus text format served otherwise.
+	TextContentType = "text/plain; version=0.0.4; charset=utf-8"
+)
+
+// Handler serves the registry in OpenMetrics text format, falling back to the
+// Prometheus text format for clients that don't ask for OpenMetrics.
+func Handler(r *Registry) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		openMetrics := strings.Contains(req.Header.Get("Accept"), "application/openmetrics-text")
+		if openMetrics {
+			w.Header().Set("Content-Type", OpenMetricsContentType)
+		} else {
+			w.Header().Set("Content-Type", TextContentType)
+		}
+
+		if err := WriteText(w, r.Gather(), openMetrics); err != nil {
+			log.Printf("Failed to write metrics: %v", err)
+		}
+	})
+}
+
+// Serve serves the registry on addr at /metrics in the background. Stop it
+// with the returned server's Shutdown.
+func Serve(addr string, r *Registry) *http.Server {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", Handler(r))
+	srv := &http.Server{Addr: addr, Handler: mux}
+
+	go func() {
+		log.Printf("Metrics endpoint listening on %s/metrics", addr)
+		if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Printf("Metrics endpoint stopped: %v", err)
+		}
+	}()
+	return srv
+}
+
+// DefaultAddr is where services serve /metrics unless configured otherwise.
+const DefaultAddr = ":9090"
+
+// WriteText writes families in the Prometheus text exposition format, or in
+// OpenMetrics format when openMetrics is set. The formats differ in that
+// OpenMetrics names counter families without their _total suffix and
+// terminates the exposition with "# EOF".
+func WriteText(w io.Writer, families []Family, openMetrics bool) error {
+	bw := bufio.NewWriter(w)
+
+	for _, f := range families {
+		name := f.Name
+		if openMetrics && f.Kind == KindCounter {
+			name = strings.TrimSuffix(name, "_total")
+		}
+
+		if f.Help != "" {
+			bw.WriteString("# HELP " + name + " " + escapeHelp(f.Help) + "\n")
+		}
+		bw.WriteString("# TYPE " + name + " " + f.Kind.String() + "\n")
+
+		for _, sample := range f.Samples {
+			switch f.Kind {
+			case KindHistogram:
+				for _, b := range sample.Buckets {
+					writeSample(bw, f.Name+"_bucket", sample.Labels, "le", formatBound(b.UpperBound), float64(b.Count))
+				}
+				writeSample(bw, f.Name+"_count", sample.Labels, "", "", float64(sample.Count))
+				writeSample(bw, f.Name+"_sum", sample.Labels, "", "", sample.Sum)
+			case KindCounter:
+				sampleName := f.Name
+				if openMetrics && !strings.HasSuffix(sampleName, "_total") {
+					sampleName += "_total"
+				}
+				writeSample(bw, sampleName, sample.Labels, "", "", sample.Value)
+			default:
+				writeSample(bw, f.Name, sample.Labels, "", "", sample.Value)
+			}
+		}
+	}
+
+	if openMetrics {
+		bw.WriteString("# EOF\n")
+	}
+	return bw.Flush()
+}
+
+// writeSample writes one sample line, with an optional extra label appended
+// after the sample's own labels.
+func writeSample(w *bufio.Writer, name string, labels map[string]string, extraName, extraValue string, value float64) {
+	w.WriteString(name)
+
+	names := make([]string, 0, len(labels))
+	for k := range labels {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+
+	if len(names) > 0 || extraName != "" {
+		w.WriteByte('{')
+		for i, k := range names {
+			if i > 0 {
+				w.WriteByte(',')
+			}
+			w.WriteString(k + `="` + escapeLabelValue(labels[k]) + `"`)
+		}
+		if extraName != "" {
+			if len(names) > 0 {
+				w.WriteByte(',')
+			}
+			w.WriteString(extraName + `="` + escapeLabelValue(extraValue) + `"`)
+		}
+		w.WriteByte('}')
+	}
+
+	w.WriteByte(' ')
+	w.WriteString(formatValue(value))
+	w.WriteByte('\n')
+}
+
+func formatValue(value float64) string {
+	switch {
+	case math.IsInf(value, 1):
+		return "+Inf"
+	case math.IsInf(value, -1):
+		return "-Inf"
+	case math.IsNaN(value):
+		return "NaN"
+	default:
+		return strconv.FormatFloat(value, 'g', -1, 64)
+	}
+}
+
+var (
+	labelValueEscaper = strings.NewReplacer(`\`, `\\`, "\n", `\n`, `"`, `\"`)
+	helpEscaper       = strings.NewReplacer(`\`, `\\`, "\n", `\n`)
+)
+
+func escapeLabelValue(value string) string {
+	return labelValueEscaper.Replace(value)
+}
+
+func escapeHelp(help string) string {
+	return helpEscaper.Replace(help)
+}
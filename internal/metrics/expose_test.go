@@ -0,0 +1,96 @@
+package metrics
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func testRegistry() *Registry {
+	r := NewRegistry()
+	r.Counter("requests_total", "Requests handled.", "method").WithLabelValues("Get").Add(3)
+	r.Gauge("temperature", "Current \"temperature\".", "room").WithLabelValues("lab \"A\"\n").Set(21.5)
+	r.Histogram("latency_seconds", "Latency.", []float64{0.5}).WithLabelValues().Observe(0.25)
+	return r
+}
+
+func TestWriteTextPrometheus(t *testing.T) {
+	var b strings.Builder
+	if err := WriteText(&b, testRegistry().Gather(), false); err != nil {
+		t.Fatalf("WriteText failed: %v", err)
+	}
+
+	expected := `# HELP latency_seconds Latency.
+# TYPE latency_seconds histogram
+latency_seconds_bucket{le="0.5"} 1
+latency_seconds_bucket{le="+Inf"} 1
+latency_seconds_count 1
+latency_seconds_sum 0.25
+# HELP requests_total Requests handled.
+# TYPE requests_total counter
+requests_total{method="Get"} 3
+# HELP temperature Current "temperature".
+# TYPE temperature gauge
+temperature{room="lab \"A\"\n"} 21.5
+`
+	if b.String() != expected {
+		t.Errorf("Unexpected exposition:\n%s\nwant:\n%s", b.String(), expected)
+	}
+}
+
+func TestWriteTextOpenMetrics(t *testing.T) {
+	var b strings.Builder
+	if err := WriteText(&b, testRegistry().Gather(), true); err != nil {
+		t.Fatalf("WriteText failed: %v", err)
+	}
+	out := b.String()
+
+	for _, line := range []string{
+		"# TYPE requests counter\n",
+		"# HELP requests Requests handled.\n",
+		`requests_total{method="Get"} 3` + "\n",
+	} {
+		if !strings.Contains(out, line) {
+			t.Errorf("Expected %q in OpenMetrics output:\n%s", line, out)
+		}
+	}
+	if !strings.HasSuffix(out, "# EOF\n") {
+		t.Errorf("Expected OpenMetrics output to end with # EOF, got:\n%s", out)
+	}
+}
+
+func TestHandlerNegotiatesFormat(t *testing.T) {
+	ts := httptest.NewServer(Handler(testRegistry()))
+	defer ts.Close()
+
+	tests := []struct {
+		accept      string
+		contentType string
+		eof         bool
+	}{
+		{"", TextContentType, false},
+		{"application/openmetrics-text;version=1.0.0,text/plain;version=0.0.4;q=0.5", OpenMetricsContentType, true},
+	}
+
+	for _, tt := range tests {
+		req, _ := http.NewRequest(http.MethodGet, ts.URL, nil)
+		if tt.accept != "" {
+			req.Header.Set("Accept", tt.accept)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatalf("GET failed: %v", err)
+		}
+		body, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+
+		if got := resp.Header.Get("Content-Type"); got != tt.contentType {
+			t.Errorf("Accept %q: expected content type %q, got %q", tt.accept, tt.contentType, got)
+		}
+		if strings.HasSuffix(string(body), "# EOF\n") != tt.eof {
+			t.Errorf("Accept %q: unexpected EOF marker in body:\n%s", tt.accept, body)
+		}
+	}
+}
@@ -0,0 +1,157 @@
+package metrics
+
+import (
+	"context"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	pb "github.com/sairamkiran9/asset-telemetry-monitor/gen/go/proto/monitoring"
+)
+
+// serverMetrics are the standard gRPC server metrics.
+type serverMetrics struct {
+	requests      *CounterVec
+	errors        *CounterVec
+	latency       *HistogramVec
+	activeStreams *GaugeVec
+}
+
+func newServerMetrics(r *Registry) *serverMetrics {
+	return &serverMetrics{
+		requests:      r.Counter("grpc_server_handled_total", "RPCs completed on the server, by method and status code.", "grpc_service", "grpc_method", "grpc_code"),
+		errors:        r.Counter("grpc_server_errors_total", "RPCs that completed with a non-OK status.", "grpc_service", "grpc_method"),
+		latency:       r.Histogram("grpc_server_handling_seconds", "Time taken to handle RPCs.", DefaultBuckets, "grpc_service", "grpc_method"),
+		activeStreams: r.Gauge("grpc_server_active_streams", "Streaming RPCs currently in progress.", "grpc_service", "grpc_method"),
+	}
+}
+
+func (m *serverMetrics) observe(fullMethod string, start time.Time, err error) {
+	service, method := splitMethod(fullMethod)
+	code := status.Code(err)
+
+	m.requests.WithLabelValues(service, method, code.String()).Inc()
+	if err != nil {
+		m.errors.WithLabelValues(service, method).Inc()
+	}
+	m.latency.WithLabelValues(service, method).Observe(time.Since(start).Seconds())
+}
+
+// splitMethod splits "/package.Service/Method" into service and method.
+func splitMethod(fullMethod string) (string, string) {
+	fullMethod = strings.TrimPrefix(fullMethod, "/")
+	if i := strings.LastIndex(fullMethod, "/"); i >= 0 {
+		return fullMethod[:i], fullMethod[i+1:]
+	}
+	return "unknown", fullMethod
+}
+
+// UnaryServerInterceptor records request counts, errors and latency for unary
+// RPCs.
+func UnaryServerInterceptor(r *Registry) grpc.UnaryServerInterceptor {
+	m := newServerMetrics(r)
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		start := time.Now()
+		resp, err := handler(ctx, req)
+		m.observe(info.FullMethod, start, err)
+		return resp, err
+	}
+}
+
+// StreamServerInterceptor records request counts, errors, latency and active
+// streams for streaming RPCs.
+func StreamServerInterceptor(r *Registry) grpc.StreamServerInterceptor {
+	m := newServerMetrics(r)
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		active := m.activeStreams.WithLabelValues(splitMethod(info.FullMethod))
+		active.Inc()
+		defer active.Dec()
+
+		start := time.Now()
+		err := handler(srv, ss)
+		m.observe(info.FullMethod, start, err)
+		return err
+	}
+}
+
+// RegisterRuntime adds Go runtime gauges for memory, garbage collection and
+// goroutines.
+func RegisterRuntime(r *Registry) {
+	stats := &memStatsCache{}
+
+	r.GaugeFunc("go_goroutines", "Number of goroutines that currently exist.", func() float64 {
+		return float64(runtime.NumGoroutine())
+	})
+	r.GaugeFunc("go_memstats_alloc_bytes", "Bytes of allocated heap objects.", func() float64 {
+		return float64(stats.read().Alloc)
+	})
+	r.GaugeFunc("go_memstats_sys_bytes", "Bytes of memory obtained from the OS.", func() float64 {
+		return float64(stats.read().Sys)
+	})
+	r.GaugeFunc("go_memstats_heap_inuse_bytes", "Bytes in in-use heap spans.", func() float64 {
+		return float64(stats.read().HeapInuse)
+	})
+	r.GaugeFunc("go_memstats_heap_objects", "Number of allocated heap objects.", func() float64 {
+		return float64(stats.read().HeapObjects)
+	})
+	r.GaugeFunc("go_memstats_next_gc_bytes", "Heap size target of the next garbage collection.", func() float64 {
+		return float64(stats.read().NextGC)
+	})
+	r.GaugeFunc("go_memstats_gc_cycles", "Completed garbage collection cycles.", func() float64 {
+		return float64(stats.read().NumGC)
+	})
+}
+
+// memStatsCache shares one runtime.ReadMemStats call, which stops the world,
+// between the gauges of a single gather.
+type memStatsCache struct {
+	mu    sync.Mutex
+	stats runtime.MemStats
+	at    time.Time
+}
+
+func (c *memStatsCache) read() runtime.MemStats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if time.Since(c.at) > time.Second {
+		runtime.ReadMemStats(&c.stats)
+		c.at = time.Now()
+	}
+	return c.stats
+}
+
+// Exporter serves a registry over the MetricsExporter gRPC service so the
+// monitoring service can collect it.
+type Exporter struct {
+	pb.UnimplementedMetricsExporterServer
+	registry *Registry
+}
+
+// NewExporter creates an exporter for the registry.
+func NewExporter(r *Registry) *Exporter {
+	return &Exporter{registry: r}
+}
+
+func (e *Exporter) ExportMetrics(ctx context.Context, req *pb.ExportMetricsRequest) (*pb.ExportMetricsResponse, error) {
+	now := timestamppb.Now()
+	points := Flatten(e.registry.Gather())
+
+	metrics := make([]*pb.MetricsResponse, 0, len(points))
+	for _, p := range points {
+		metrics = append(metrics, &pb.MetricsResponse{
+			MetricName: p.Name,
+			Value:      p.Value,
+			Timestamp:  now,
+			Labels:     p.Labels,
+		})
+	}
+
+	return &pb.ExportMetricsResponse{
+		Metrics: metrics,
+	}, nil
+}
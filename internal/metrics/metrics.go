@@ -0,0 +1,392 @@
+package metrics
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// Kind is the type of a metric family.
+type Kind int
+
+const (
+	KindCounter Kind = iota
+	KindGauge
+	KindHistogram
+)
+
+func (k Kind) String() string {
+	switch k {
+	case KindCounter:
+		return "counter"
+	case KindGauge:
+		return "gauge"
+	case KindHistogram:
+		return "histogram"
+	default:
+		return "unknown"
+	}
+}
+
+// DefaultBuckets are latency buckets in seconds, from 1ms to 10s.
+var DefaultBuckets = []float64{0.001, 0.0025, 0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
+
+// Registry holds the metric families of a service. It is safe for concurrent
+// use; recording a value on an existing series is lock-free.
+type Registry struct {
+	mu       sync.RWMutex
+	families map[string]*family
+}
+
+// NewRegistry creates an empty registry.
+func NewRegistry() *Registry {
+	return &Registry{
+		families: make(map[string]*family),
+	}
+}
+
+type family struct {
+	name       string
+	help       string
+	kind       Kind
+	labelNames []string
+	buckets    []float64
+
+	mu     sync.RWMutex
+	series map[string]*series
+	fn     func() float64 // Set for gauge functions, which have no series
+}
+
+type series struct {
+	labelValues []string
+	value       atomicFloat
+
+	// Histograms only
+	bucketCounts []atomic.Uint64
+	count        atomic.Uint64
+	sum          atomicFloat
+}
+
+// register returns the family with the given name, creating it if needed.
+// Registering the same name with a different kind or labels panics, as that
+// is a programming error.
+func (r *Registry) register(name, help string, kind Kind, buckets []float64, labelNames []string) *family {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if f, exists := r.families[name]; exists {
+		if f.kind != kind || strings.Join(f.labelNames, ",") != strings.Join(labelNames, ",") {
+			panic(fmt.Sprintf("metrics: %s re-registered with a different kind or labels", name))
+		}
+		return f
+	}
+
+	f := &family{
+		name:       name,
+		help:       help,
+		kind:       kind,
+		labelNames: labelNames,
+		buckets:    buckets,
+		series:     make(map[string]*series),
+	}
+	r.families[name] = f
+	return f
+}
+
+// with returns the series for the label values, creating it if needed.
+func (f *family) with(labelValues []string) *series {
+	if len(labelValues) != len(f.labelNames) {
+		panic(fmt.Sprintf("metrics: %s expects %d label values, got %d", f.name, len(f.labelNames), len(labelValues)))
+	}
+	key := strings.Join(labelValues, "\xff")
+
+	f.mu.RLock()
+	s, exists := f.series[key]
+	f.mu.RUnlock()
+	if exists {
+		return s
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if s, exists = f.series[key]; !exists {
+		s = &series{labelValues: append([]string(nil), labelValues...)}
+		if f.kind == KindHistogram {
+			s.bucketCounts = make([]atomic.Uint64, len(f.buckets))
+		}
+		f.series[key] = s
+	}
+	return s
+}
+
+// CounterVec is a family of counters partitioned by labels.
+type CounterVec struct{ f *family }
+
+// Counter registers a counter family.
+func (r *Registry) Counter(name, help string, labelNames ...string) *CounterVec {
+	return &CounterVec{f: r.register(name, help, KindCounter, nil, labelNames)}
+}
+
+// WithLabelValues returns the counter for the given label values.
+func (v *CounterVec) WithLabelValues(labelValues ...string) *Counter {
+	return &Counter{s: v.f.with(labelValues)}
+}
+
+// Counter is a monotonically increasing value.
+type Counter struct{ s *series }
+
+// Inc adds one to the counter.
+func (c *Counter) Inc() {
+	c.s.value.add(1)
+}
+
+// Add adds a non-negative delta to the counter.
+func (c *Counter) Add(delta float64) {
+	if delta < 0 {
+		panic("metrics: counters cannot decrease")
+	}
+	c.s.value.add(delta)
+}
+
+// Value returns the current count.
+func (c *Counter) Value() float64 {
+	return c.s.value.load()
+}
+
+// GaugeVec is a family of gauges partitioned by labels.
+type GaugeVec struct{ f *family }
+
+// Gauge registers a gauge family.
+func (r *Registry) Gauge(name, help string, labelNames ...string) *GaugeVec {
+	return &GaugeVec{f: r.register(name, help, KindGauge, nil, labelNames)}
+}
+
+// WithLabelValues returns the gauge for the given label values.
+func (v *GaugeVec) WithLabelValues(labelValues ...string) *Gauge {
+	return &Gauge{s: v.f.with(labelValues)}
+}
+
+// Gauge is a value that can go up and down.
+type Gauge struct{ s *series }
+
+// Set replaces the gauge value.
+func (g *Gauge) Set(value float64) {
+	g.s.value.store(value)
+}
+
+// Add adds delta, which may be negative, to the gauge.
+func (g *Gauge) Add(delta float64) {
+	g.s.value.add(delta)
+}
+
+// Inc adds one to the gauge.
+func (g *Gauge) Inc() {
+	g.s.value.add(1)
+}
+
+// Dec subtracts one from the gauge.
+func (g *Gauge) Dec() {
+	g.s.value.add(-1)
+}
+
+// Value returns the current value.
+func (g *Gauge) Value() float64 {
+	return g.s.value.load()
+}
+
+// GaugeFunc registers an unlabelled gauge whose value is computed by fn each
+// time the registry is gathered. fn must be safe for concurrent use.
+func (r *Registry) GaugeFunc(name, help string, fn func() float64) {
+	f := r.register(name, help, KindGauge, nil, nil)
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.fn = fn
+}
+
+// HistogramVec is a family of histograms partitioned by labels.
+type HistogramVec struct{ f *family }
+
+// Histogram registers a histogram family with the given upper bucket bounds,
+// or DefaultBuckets if none are given.
+func (r *Registry) Histogram(name, help string, buckets []float64, labelNames ...string) *HistogramVec {
+	if len(buckets) == 0 {
+		buckets = DefaultBuckets
+	}
+	buckets = append([]float64(nil), buckets...)
+	sort.Float64s(buckets)
+	return &HistogramVec{f: r.register(name, help, KindHistogram, buckets, labelNames)}
+}
+
+// WithLabelValues returns the histogram for the given label values.
+func (v *HistogramVec) WithLabelValues(labelValues ...string) *Histogram {
+	return &Histogram{s: v.f.with(labelValues), buckets: v.f.buckets}
+}
+
+// Histogram counts observations into buckets.
+type Histogram struct {
+	s       *series
+	buckets []float64
+}
+
+// Observe records a value.
+func (h *Histogram) Observe(value float64) {
+	i := sort.SearchFloat64s(h.buckets, value)
+	if i < len(h.buckets) {
+		h.s.bucketCounts[i].Add(1)
+	}
+	h.s.count.Add(1)
+	h.s.sum.add(value)
+}
+
+// Family is a snapshot of a metric family.
+type Family struct {
+	Name    string
+	Help    string
+	Kind    Kind
+	Samples []Sample
+}
+
+// Sample is a snapshot of one series.
+type Sample struct {
+	Labels map[string]string
+	Value  float64
+
+	// Histograms only: cumulative counts per upper bound, total count and sum
+	Buckets []Bucket
+	Count   uint64
+	Sum     float64
+}
+
+// Bucket is a cumulative histogram bucket.
+type Bucket struct {
+	UpperBound float64
+	Count      uint64
+}
+
+// Gather returns a snapshot of every family, sorted by name with samples
+// sorted by label values.
+func (r *Registry) Gather() []Family {
+	r.mu.RLock()
+	families := make([]*family, 0, len(r.families))
+	for _, f := range r.families {
+		families = append(families, f)
+	}
+	r.mu.RUnlock()
+
+	sort.Slice(families, func(i, j int) bool {
+		return families[i].name < families[j].name
+	})
+
+	snapshot := make([]Family, 0, len(families))
+	for _, f := range families {
+		snapshot = append(snapshot, f.gather())
+	}
+	return snapshot
+}
+
+func (f *family) gather() Family {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	out := Family{Name: f.name, Help: f.help, Kind: f.kind}
+	if f.fn != nil {
+		out.Samples = []Sample{{Labels: map[string]string{}, Value: f.fn()}}
+		return out
+	}
+
+	keys := make([]string, 0, len(f.series))
+	for key := range f.series {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		s := f.series[key]
+		sample := Sample{Labels: make(map[string]string, len(f.labelNames))}
+		for i, name := range f.labelNames {
+			sample.Labels[name] = s.labelValues[i]
+		}
+
+		if f.kind == KindHistogram {
+			var cumulative uint64
+			for i, bound := range f.buckets {
+				cumulative += s.bucketCounts[i].Load()
+				sample.Buckets = append(sample.Buckets, Bucket{UpperBound: bound, Count: cumulative})
+			}
+			sample.Count = s.count.Load()
+			sample.Sum = s.sum.load()
+			sample.Buckets = append(sample.Buckets, Bucket{UpperBound: math.Inf(1), Count: sample.Count})
+		} else {
+			sample.Value = s.value.load()
+		}
+		out.Samples = append(out.Samples, sample)
+	}
+	return out
+}
+
+// Point is a single named value, as produced by Flatten.
+type Point struct {
+	Name   string
+	Labels map[string]string
+	Value  float64
+}
+
+// Flatten turns families into points. Histograms become _bucket (with an "le"
+// label), _sum and _count points, following Prometheus naming.
+func Flatten(families []Family) []Point {
+	var points []Point
+	for _, f := range families {
+		for _, sample := range f.Samples {
+			if f.Kind != KindHistogram {
+				points = append(points, Point{Name: f.Name, Labels: sample.Labels, Value: sample.Value})
+				continue
+			}
+			for _, b := range sample.Buckets {
+				labels := make(map[string]string, len(sample.Labels)+1)
+				for k, v := range sample.Labels {
+					labels[k] = v
+				}
+				labels["le"] = formatBound(b.UpperBound)
+				points = append(points, Point{Name: f.Name + "_bucket", Labels: labels, Value: float64(b.Count)})
+			}
+			points = append(points,
+				Point{Name: f.Name + "_sum", Labels: sample.Labels, Value: sample.Sum},
+				Point{Name: f.Name + "_count", Labels: sample.Labels, Value: float64(sample.Count)},
+			)
+		}
+	}
+	return points
+}
+
+func formatBound(bound float64) string {
+	if math.IsInf(bound, 1) {
+		return "+Inf"
+	}
+	return fmt.Sprintf("%g", bound)
+}
+
+// atomicFloat is a float64 updated with compare-and-swap.
+type atomicFloat struct {
+	bits atomic.Uint64
+}
+
+func (f *atomicFloat) load() float64 {
+	return math.Float64frombits(f.bits.Load())
+}
+
+func (f *atomicFloat) store(value float64) {
+	f.bits.Store(math.Float64bits(value))
+}
+
+func (f *atomicFloat) add(delta float64) {
+	for {
+		old := f.bits.Load()
+		updated := math.Float64bits(math.Float64frombits(old) + delta)
+		if f.bits.CompareAndSwap(old, updated) {
+			return
+		}
+	}
+}
@@ -0,0 +1,176 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"math"
+	"sync"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/sairamkiran9/asset-telemetry-monitor/gen/go/proto/monitoring"
+)
+
+func TestCounterAndGauge(t *testing.T) {
+	r := NewRegistry()
+	requests := r.Counter("requests_total", "Requests.", "method")
+	inFlight := r.Gauge("in_flight", "In flight.").WithLabelValues()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 100; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			requests.WithLabelValues("Get").Inc()
+			inFlight.Inc()
+		}()
+	}
+	wg.Wait()
+	requests.WithLabelValues("List").Add(2.5)
+	inFlight.Add(-40)
+
+	if got := requests.WithLabelValues("Get").Value(); got != 100 {
+		t.Errorf("Expected 100 Get requests, got %f", got)
+	}
+	if got := inFlight.Value(); got != 60 {
+		t.Errorf("Expected gauge 60, got %f", got)
+	}
+
+	families := r.Gather()
+	if len(families) != 2 || families[0].Name != "in_flight" || families[1].Name != "requests_total" {
+		t.Fatalf("Expected families sorted by name, got %v", families)
+	}
+	if samples := families[1].Samples; len(samples) != 2 || samples[0].Labels["method"] != "Get" || samples[1].Value != 2.5 {
+		t.Errorf("Unexpected counter samples %v", samples)
+	}
+}
+
+func TestHistogram(t *testing.T) {
+	r := NewRegistry()
+	h := r.Histogram("latency_seconds", "Latency.", []float64{0.1, 1}).WithLabelValues()
+
+	for _, v := range []float64{0.05, 0.1, 0.5, 2} {
+		h.Observe(v)
+	}
+
+	sample := r.Gather()[0].Samples[0]
+	expected := []Bucket{{0.1, 2}, {1, 3}, {math.Inf(1), 4}}
+	if len(sample.Buckets) != len(expected) {
+		t.Fatalf("Expected %d buckets, got %v", len(expected), sample.Buckets)
+	}
+	for i, b := range expected {
+		if sample.Buckets[i] != b {
+			t.Errorf("Bucket %d: expected %v, got %v", i, b, sample.Buckets[i])
+		}
+	}
+	if sample.Count != 4 || math.Abs(sample.Sum-2.65) > 1e-9 {
+		t.Errorf("Expected count 4 and sum 2.65, got %d and %f", sample.Count, sample.Sum)
+	}
+
+	points := Flatten(r.Gather())
+	if len(points) != 5 || points[2].Labels["le"] != "+Inf" || points[3].Name != "latency_seconds_sum" || points[4].Name != "latency_seconds_count" {
+		t.Errorf("Unexpected flattened histogram %v", points)
+	}
+}
+
+func TestGaugeFunc(t *testing.T) {
+	r := NewRegistry()
+	value := 1.0
+	r.GaugeFunc("computed", "Computed.", func() float64 { return value })
+	value = 42
+
+	if got := r.Gather()[0].Samples[0].Value; got != 42 {
+		t.Errorf("Expected gauge function evaluated at gather time, got %f", got)
+	}
+}
+
+func TestRegisterConflictPanics(t *testing.T) {
+	r := NewRegistry()
+	r.Counter("things_total", "Things.", "kind")
+	r.Counter("things_total", "Things.", "kind") // Same definition is fine
+
+	defer func() {
+		if recover() == nil {
+			t.Error("Expected re-registering with a different kind to panic")
+		}
+	}()
+	r.Gauge("things_total", "Things.")
+}
+
+func TestUnaryServerInterceptor(t *testing.T) {
+	r := NewRegistry()
+	interceptor := UnaryServerInterceptor(r)
+	info := &grpc.UnaryServerInfo{FullMethod: "/asset.AssetRegistry/GetAsset"}
+
+	interceptor(context.Background(), nil, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return "ok", nil
+	})
+	interceptor(context.Background(), nil, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return nil, status.Error(codes.NotFound, "missing")
+	})
+
+	handled := r.Counter("grpc_server_handled_total", "", "grpc_service", "grpc_method", "grpc_code")
+	if got := handled.WithLabelValues("asset.AssetRegistry", "GetAsset", "OK").Value(); got != 1 {
+		t.Errorf("Expected 1 OK request, got %f", got)
+	}
+	if got := handled.WithLabelValues("asset.AssetRegistry", "GetAsset", "NotFound").Value(); got != 1 {
+		t.Errorf("Expected 1 NotFound request, got %f", got)
+	}
+	errs := r.Counter("grpc_server_errors_total", "", "grpc_service", "grpc_method")
+	if got := errs.WithLabelValues("asset.AssetRegistry", "GetAsset").Value(); got != 1 {
+		t.Errorf("Expected 1 error, got %f", got)
+	}
+}
+
+func TestStreamServerInterceptorTracksActiveStreams(t *testing.T) {
+	r := NewRegistry()
+	interceptor := StreamServerInterceptor(r)
+	info := &grpc.StreamServerInfo{FullMethod: "/monitoring.MonitoringService/GetMetrics", IsServerStream: true}
+	active := r.Gauge("grpc_server_active_streams", "", "grpc_service", "grpc_method").WithLabelValues("monitoring.MonitoringService", "GetMetrics")
+
+	var during float64
+	interceptor(nil, nil, info, func(srv interface{}, stream grpc.ServerStream) error {
+		during = active.Value()
+		return errors.New("stream broken")
+	})
+
+	if during != 1 {
+		t.Errorf("Expected 1 active stream while handling, got %f", during)
+	}
+	if got := active.Value(); got != 0 {
+		t.Errorf("Expected 0 active streams afterwards, got %f", got)
+	}
+}
+
+func TestExporter(t *testing.T) {
+	r := NewRegistry()
+	RegisterRuntime(r)
+	r.Counter("requests_total", "Requests.").WithLabelValues().Inc()
+
+	resp, err := NewExporter(r).ExportMetrics(context.Background(), &pb.ExportMetricsRequest{})
+	if err != nil {
+		t.Fatalf("ExportMetrics failed: %v", err)
+	}
+
+	names := make(map[string]bool)
+	for _, m := range resp.Metrics {
+		names[m.MetricName] = true
+	}
+	for _, name := range []string{"requests_total", "go_goroutines", "go_memstats_alloc_bytes"} {
+		if !names[name] {
+			t.Errorf("Expected %s to be exported, got %v", name, names)
+		}
+	}
+}
+
+func BenchmarkCounterInc(b *testing.B) {
+	c := NewRegistry().Counter("requests_total", "Requests.", "method").WithLabelValues("Get")
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			c.Inc()
+		}
+	})
+}
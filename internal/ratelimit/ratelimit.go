@@ -0,0 +1,138 @@
+package ratelimit
+
+import (
+	"context"
+	"math"
+	"net"
+	"strconv"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	"github.com/sairamkiran9/asset-telemetry-monitor/internal/auth"
+)
+
+// RetryAfterKey is the trailer metadata key telling throttled callers how many
+// whole seconds to wait before retrying.
+const RetryAfterKey = "retry-after"
+
+// minSweepInterval bounds how often idle buckets are looked for.
+const minSweepInterval = time.Minute
+
+// Limiter keeps a token bucket per key, such as a tenant, client or asset, all
+// refilled at the same rate. A nil Limiter allows everything.
+type Limiter struct {
+	rate  float64 // Tokens added per second
+	burst float64 // Bucket capacity
+	now   func() time.Time
+
+	// Buckets idle this long are full again, so they're dropped instead of
+	// kept for keys that may never come back
+	idle      time.Duration
+	lastSweep time.Time
+
+	mu      sync.Mutex
+	buckets map[string]*bucket
+}
+
+type bucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// New returns a limiter allowing rate events per second per key with bursts
+// of up to burst events, or nil when rate isn't positive. A burst below one
+// is raised to one.
+func New(rate float64, burst int) *Limiter {
+	if rate <= 0 {
+		return nil
+	}
+	l := &Limiter{
+		rate:    rate,
+		burst:   math.Max(float64(burst), 1),
+		now:     time.Now,
+		buckets: make(map[string]*bucket),
+	}
+	l.idle = time.Duration(math.Ceil(l.burst / rate * float64(time.Second)))
+	return l
+}
+
+// Allow takes a token from key's bucket. When the bucket is empty it returns
+// false and how long until the next token.
+func (l *Limiter) Allow(key string) (bool, time.Duration) {
+	if l == nil {
+		return true, 0
+	}
+	now := l.now()
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.sweep(now)
+	b, exists := l.buckets[key]
+	if !exists {
+		b = &bucket{tokens: l.burst, last: now}
+		l.buckets[key] = b
+	}
+	if elapsed := now.Sub(b.last).Seconds(); elapsed > 0 {
+		b.tokens = math.Min(l.burst, b.tokens+elapsed*l.rate)
+		b.last = now
+	}
+
+	if b.tokens >= 1 {
+		b.tokens--
+		return true, 0
+	}
+	wait := (1 - b.tokens) / l.rate
+	return false, time.Duration(math.Ceil(wait * float64(time.Second)))
+}
+
+// sweep drops idle buckets, at most once per idle period. Callers must hold
+// l.mu.
+func (l *Limiter) sweep(now time.Time) {
+	interval := l.idle
+	if interval < minSweepInterval {
+		interval = minSweepInterval
+	}
+	if now.Sub(l.lastSweep) < interval {
+		return
+	}
+	l.lastSweep = now
+	for key, b := range l.buckets {
+		if now.Sub(b.last) >= l.idle {
+			delete(l.buckets, key)
+		}
+	}
+}
+
+// Exhausted returns the ResourceExhausted error for a throttled call, setting
+// RetryAfterKey in its trailer to retryAfter rounded up to whole seconds.
+func Exhausted(ctx context.Context, retryAfter time.Duration, format string, args ...interface{}) error {
+	seconds := int64(math.Ceil(retryAfter.Seconds()))
+	if seconds < 1 {
+		seconds = 1
+	}
+	grpc.SetTrailer(ctx, metadata.Pairs(RetryAfterKey, strconv.FormatInt(seconds, 10)))
+	return status.Errorf(codes.ResourceExhausted, format, args...)
+}
+
+// ClientID identifies the caller for per-client limits: the authenticated
+// subject, or the peer's IP address when authentication is disabled.
+func ClientID(ctx context.Context) string {
+	if p := auth.PrincipalFromContext(ctx); p != nil {
+		return "subject:" + p.Subject
+	}
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		host, _, err := net.SplitHostPort(p.Addr.String())
+		if err != nil {
+			host = p.Addr.String()
+		}
+		return "ip:" + host
+	}
+	return "unknown"
+}
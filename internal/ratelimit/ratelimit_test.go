@@ -0,0 +1,154 @@
+package ratelimit
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	"github.com/sairamkiran9/asset-telemetry-monitor/internal/auth"
+)
+
+func newTestLimiter(rate float64, burst int) (*Limiter, *time.Time) {
+	l := New(rate, burst)
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	l.now = func() time.Time { return now }
+	return l, &now
+}
+
+func TestLimiterBurstAndRefill(t *testing.T) {
+	l, now := newTestLimiter(2, 3)
+
+	for i := 0; i < 3; i++ {
+		if ok, _ := l.Allow("tenant-a"); !ok {
+			t.Fatalf("Expected event %d of the burst to be allowed", i+1)
+		}
+	}
+	ok, retryAfter := l.Allow("tenant-a")
+	if ok {
+		t.Fatal("Expected event beyond the burst to be rejected")
+	}
+	if retryAfter != 500*time.Millisecond {
+		t.Errorf("Expected retry after 500ms, got %v", retryAfter)
+	}
+
+	*now = now.Add(500 * time.Millisecond)
+	if ok, _ := l.Allow("tenant-a"); !ok {
+		t.Error("Expected a token after refilling")
+	}
+	if ok, _ := l.Allow("tenant-a"); ok {
+		t.Error("Expected a single token after 500ms")
+	}
+
+	// Idle time refills up to the burst only
+	*now = now.Add(time.Hour)
+	allowed := 0
+	for i := 0; i < 10; i++ {
+		if ok, _ := l.Allow("tenant-a"); ok {
+			allowed++
+		}
+	}
+	if allowed != 3 {
+		t.Errorf("Expected 3 events after idling, got %d", allowed)
+	}
+}
+
+func TestLimiterKeysAreIndependent(t *testing.T) {
+	l, _ := newTestLimiter(1, 1)
+
+	if ok, _ := l.Allow("tenant-a"); !ok {
+		t.Fatal("Expected first tenant-a event to be allowed")
+	}
+	if ok, _ := l.Allow("tenant-a"); ok {
+		t.Error("Expected second tenant-a event to be rejected")
+	}
+	if ok, _ := l.Allow("tenant-b"); !ok {
+		t.Error("Expected tenant-b to have its own bucket")
+	}
+}
+
+func TestDisabledLimiter(t *testing.T) {
+	l := New(0, 10)
+	if l != nil {
+		t.Fatal("Expected nil limiter for a zero rate")
+	}
+	for i := 0; i < 100; i++ {
+		if ok, _ := l.Allow("tenant-a"); !ok {
+			t.Fatal("Expected nil limiter to allow everything")
+		}
+	}
+}
+
+func TestLimiterDropsIdleBuckets(t *testing.T) {
+	l, now := newTestLimiter(10, 5)
+
+	l.Allow("asset-1")
+	l.Allow("asset-2")
+	*now = now.Add(30 * time.Second)
+	l.Allow("asset-2")
+	if len(l.buckets) != 2 {
+		t.Fatalf("Expected 2 buckets before the sweep, got %d", len(l.buckets))
+	}
+
+	*now = now.Add(40 * time.Second)
+	l.Allow("asset-3")
+	if _, exists := l.buckets["asset-1"]; exists {
+		t.Error("Expected idle bucket to be dropped")
+	}
+	if len(l.buckets) != 1 {
+		t.Errorf("Expected only the new bucket, got %d", len(l.buckets))
+	}
+}
+
+// trailerStream records the trailer set by a handler.
+type trailerStream struct {
+	grpc.ServerTransportStream
+	trailer metadata.MD
+}
+
+func (s *trailerStream) SetTrailer(md metadata.MD) error {
+	s.trailer = metadata.Join(s.trailer, md)
+	return nil
+}
+
+func TestExhausted(t *testing.T) {
+	tests := []struct {
+		retryAfter time.Duration
+		expected   string
+	}{
+		{10 * time.Millisecond, "1"},
+		{time.Second, "1"},
+		{1500 * time.Millisecond, "2"},
+	}
+
+	for _, tt := range tests {
+		stream := &trailerStream{}
+		ctx := grpc.NewContextWithServerTransportStream(context.Background(), stream)
+		err := Exhausted(ctx, tt.retryAfter, "client %s is throttled", "gateway-7")
+		if status.Code(err) != codes.ResourceExhausted || status.Convert(err).Message() != "client gateway-7 is throttled" {
+			t.Errorf("Expected ResourceExhausted error, got %v", err)
+		}
+		if got := stream.trailer.Get(RetryAfterKey); len(got) != 1 || got[0] != tt.expected {
+			t.Errorf("Retry after %v: expected trailer %q, got %v", tt.retryAfter, tt.expected, got)
+		}
+	}
+}
+
+func TestClientID(t *testing.T) {
+	addr := &net.TCPAddr{IP: net.ParseIP("10.0.0.7"), Port: 41234}
+	ctx := peer.NewContext(context.Background(), &peer.Peer{Addr: addr})
+
+	if id := ClientID(ctx); id != "ip:10.0.0.7" {
+		t.Errorf("Expected peer IP without port, got %q", id)
+	}
+	ctx = auth.ContextWithPrincipal(ctx, &auth.Principal{Subject: "gateway-7"})
+	if id := ClientID(ctx); id != "subject:gateway-7" {
+		t.Errorf("Expected authenticated subject, got %q", id)
+	}
+}
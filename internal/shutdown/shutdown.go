@@ -0,0 +1,206 @@
+package shutdown
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// DefaultGracePeriod bounds how long a service takes to exit after SIGTERM.
+// Docker sends SIGKILL 10s after SIGTERM unless stop_grace_period is raised.
+const DefaultGracePeriod = 10 * time.Second
+
+// TrailerKey is set in the trailer of streams ended by a shutdown, so clients
+// can tell a drain from a failure and reconnect.
+const TrailerKey = "x-server-shutdown"
+
+// ErrShuttingDown is the cancellation cause of stream contexts ended by a
+// shutdown.
+var ErrShuttingDown = errors.New("server is shutting down")
+
+// IsShuttingDown reports whether ctx was cancelled because the server is
+// shutting down, as opposed to the client going away.
+func IsShuttingDown(ctx context.Context) bool {
+	return errors.Is(context.Cause(ctx), ErrShuttingDown)
+}
+
+type cleanup struct {
+	name string
+	fn   func(context.Context) error
+}
+
+// Coordinator drains a gRPC server when its context is done:
+//  1. OnShutdown hooks run, e.g. to report NOT_SERVING to health checks.
+//  2. The server stops accepting connections and RPCs, and open streams are
+//     cancelled so they end with codes.Unavailable and a shutdown trailer.
+//  3. In-flight RPCs get three quarters of the grace period to finish, after
+//     which remaining connections are closed.
+//  4. Cleanups run in the order they were added, within the grace period.
+type Coordinator struct {
+	grace time.Duration
+
+	draining chan struct{}
+	once     sync.Once
+
+	mu         sync.Mutex
+	onShutdown []func()
+	cleanups   []cleanup
+}
+
+// New returns a coordinator that shuts down within grace.
+func New(grace time.Duration) *Coordinator {
+	if grace <= 0 {
+		grace = DefaultGracePeriod
+	}
+	return &Coordinator{
+		grace:    grace,
+		draining: make(chan struct{}),
+	}
+}
+
+// OnShutdown adds a hook that runs as soon as shutdown begins.
+func (c *Coordinator) OnShutdown(fn func()) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.onShutdown = append(c.onShutdown, fn)
+}
+
+// AddCleanup adds a step that runs after the server has stopped, such as
+// stopping background goroutines or flushing buffered data.
+func (c *Coordinator) AddCleanup(name string, fn func(context.Context) error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.cleanups = append(c.cleanups, cleanup{name: name, fn: fn})
+}
+
+// Draining is closed once shutdown begins.
+func (c *Coordinator) Draining() <-chan struct{} {
+	return c.draining
+}
+
+// drainStream cancels the stream's context when shutdown begins.
+type drainStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *drainStream) Context() context.Context {
+	return s.ctx
+}
+
+// StreamServerInterceptor ends open streams when shutdown begins. Handlers see
+// their context cancelled with ErrShuttingDown as the cause, may send a final
+// message, and the stream then ends with codes.Unavailable and TrailerKey set.
+func (c *Coordinator) StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, cancel := context.WithCancelCause(ss.Context())
+		defer cancel(nil)
+
+		go func() {
+			select {
+			case <-c.draining:
+				cancel(ErrShuttingDown)
+			case <-ctx.Done():
+			}
+		}()
+
+		err := handler(srv, &drainStream{ServerStream: ss, ctx: ctx})
+		if !IsShuttingDown(ctx) {
+			return err
+		}
+
+		ss.SetTrailer(metadata.Pairs(TrailerKey, "true"))
+		if code := status.Code(err); code == codes.OK || code == codes.Canceled {
+			return status.Error(codes.Unavailable, ErrShuttingDown.Error())
+		}
+		return err
+	}
+}
+
+// Serve serves lis until ctx is done, typically on SIGTERM, then drains the
+// server and runs the cleanups. It returns an error only if serving fails;
+// failed cleanups are logged.
+func (c *Coordinator) Serve(ctx context.Context, srv *grpc.Server, lis net.Listener) error {
+	serveErr := make(chan error, 1)
+	go func() {
+		serveErr <- srv.Serve(lis)
+	}()
+
+	select {
+	case err := <-serveErr:
+		return err
+	case <-ctx.Done():
+	}
+
+	start := time.Now()
+	log.Printf("Shutting down, grace period %v", c.grace)
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), c.grace)
+	defer cancel()
+
+	c.mu.Lock()
+	onShutdown := append([]func(){}, c.onShutdown...)
+	cleanups := append([]cleanup{}, c.cleanups...)
+	c.mu.Unlock()
+
+	for _, fn := range onShutdown {
+		fn()
+	}
+	c.once.Do(func() { close(c.draining) })
+
+	// Leave a quarter of the grace period for cleanups
+	drainCtx, cancelDrain := context.WithTimeout(shutdownCtx, c.grace*3/4)
+	defer cancelDrain()
+
+	stopped := make(chan struct{})
+	go func() {
+		srv.GracefulStop()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+	case <-drainCtx.Done():
+		log.Printf("RPCs still in flight after %v, closing connections", time.Since(start).Round(time.Millisecond))
+		srv.Stop()
+
+		// Stop doesn't wait for handlers that ignore cancellation
+		select {
+		case <-stopped:
+		case <-shutdownCtx.Done():
+			log.Printf("RPC handlers still running after %v", c.grace)
+		}
+	}
+	<-serveErr
+
+	for _, step := range cleanups {
+		if err := step.fn(shutdownCtx); err != nil {
+			log.Printf("Shutdown step %s failed: %v", step.name, err)
+		}
+	}
+
+	log.Printf("Shutdown complete in %v", time.Since(start).Round(time.Millisecond))
+	return nil
+}
+
+// Wait waits for wg, giving up when ctx is done.
+func Wait(ctx context.Context, wg *sync.WaitGroup) error {
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
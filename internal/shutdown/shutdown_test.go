@@ -0,0 +1,189 @@
+package shutdown
+
+import (
+	"context"
+	"errors"
+	"net"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+)
+
+// startServer serves a health server through c until the returned cancel is
+// called. unaryDelay holds each unary RPC before it is handled.
+func startServer(t *testing.T, c *Coordinator, unaryDelay time.Duration) (healthpb.HealthClient, context.CancelFunc, <-chan error) {
+	t.Helper()
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+
+	delay := func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		time.Sleep(unaryDelay)
+		return handler(ctx, req)
+	}
+	srv := grpc.NewServer(grpc.UnaryInterceptor(delay), grpc.StreamInterceptor(c.StreamServerInterceptor()))
+	healthpb.RegisterHealthServer(srv, health.NewServer())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	served := make(chan error, 1)
+	go func() { served <- c.Serve(ctx, srv, lis) }()
+
+	conn, err := grpc.NewClient(lis.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("Failed to dial: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return healthpb.NewHealthClient(conn), cancel, served
+}
+
+func TestStreamEndsWithShutdownTrailer(t *testing.T) {
+	c := New(time.Second)
+	client, stop, served := startServer(t, c, 0)
+
+	stream, err := client.Watch(context.Background(), &healthpb.HealthCheckRequest{})
+	if err != nil {
+		t.Fatalf("Watch failed: %v", err)
+	}
+	if _, err := stream.Recv(); err != nil {
+		t.Fatalf("Expected initial status, got %v", err)
+	}
+
+	stop()
+	_, err = stream.Recv()
+	if status.Code(err) != codes.Unavailable {
+		t.Errorf("Expected Unavailable when draining, got %v", err)
+	}
+	if got := stream.Trailer().Get(TrailerKey); len(got) != 1 || got[0] != "true" {
+		t.Errorf("Expected %s trailer, got %v", TrailerKey, stream.Trailer())
+	}
+	if err := <-served; err != nil {
+		t.Errorf("Expected clean shutdown, got %v", err)
+	}
+}
+
+func TestInFlightUnaryCompletes(t *testing.T) {
+	c := New(2 * time.Second)
+	client, stop, served := startServer(t, c, 200*time.Millisecond)
+
+	result := make(chan error, 1)
+	go func() {
+		_, err := client.Check(context.Background(), &healthpb.HealthCheckRequest{})
+		result <- err
+	}()
+
+	// Let the call reach the server before draining
+	time.Sleep(50 * time.Millisecond)
+	stop()
+
+	if err := <-result; err != nil {
+		t.Errorf("Expected in-flight call to complete, got %v", err)
+	}
+	<-served
+}
+
+func TestCleanupsRunInOrder(t *testing.T) {
+	c := New(time.Second)
+
+	var mu sync.Mutex
+	var steps []string
+	record := func(name string) {
+		mu.Lock()
+		defer mu.Unlock()
+		steps = append(steps, name)
+	}
+
+	c.OnShutdown(func() { record("hook") })
+	c.AddCleanup("first", func(ctx context.Context) error {
+		record("first")
+		return errors.New("failed")
+	})
+	c.AddCleanup("second", func(ctx context.Context) error {
+		record("second")
+		return nil
+	})
+
+	_, stop, served := startServer(t, c, 0)
+	stop()
+	if err := <-served; err != nil {
+		t.Errorf("Expected cleanup failures not to fail Serve, got %v", err)
+	}
+
+	select {
+	case <-c.Draining():
+	default:
+		t.Error("Expected Draining to be closed")
+	}
+	if got := strings.Join(steps, ","); got != "hook,first,second" {
+		t.Errorf("Expected hook,first,second, got %s", got)
+	}
+}
+
+func TestGracePeriodEnforced(t *testing.T) {
+	grace := 400 * time.Millisecond
+	c := New(grace)
+
+	var cleanupCtx context.Context
+	c.AddCleanup("slow", func(ctx context.Context) error {
+		cleanupCtx = ctx
+		<-ctx.Done()
+		return ctx.Err()
+	})
+
+	// The unary call outlives the drain window and is cut off
+	client, stop, served := startServer(t, c, 5*time.Second)
+	go client.Check(context.Background(), &healthpb.HealthCheckRequest{})
+	time.Sleep(50 * time.Millisecond)
+
+	start := time.Now()
+	stop()
+	<-served
+
+	if elapsed := time.Since(start); elapsed > grace+200*time.Millisecond {
+		t.Errorf("Expected shutdown within %v, took %v", grace, elapsed)
+	}
+	if cleanupCtx == nil || cleanupCtx.Err() == nil {
+		t.Error("Expected cleanup context to expire with the grace period")
+	}
+}
+
+func TestIsShuttingDown(t *testing.T) {
+	ctx, cancel := context.WithCancelCause(context.Background())
+	cancel(ErrShuttingDown)
+	if !IsShuttingDown(ctx) {
+		t.Error("Expected IsShuttingDown after shutdown cancellation")
+	}
+
+	ctx, cancel2 := context.WithCancel(context.Background())
+	cancel2()
+	if IsShuttingDown(ctx) {
+		t.Error("Expected client cancellation not to count as shutdown")
+	}
+	if IsShuttingDown(context.Background()) {
+		t.Error("Expected live context not to count as shutdown")
+	}
+}
+
+func TestWait(t *testing.T) {
+	var wg sync.WaitGroup
+	wg.Add(1)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if err := Wait(ctx, &wg); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected DeadlineExceeded while goroutines run, got %v", err)
+	}
+
+	wg.Done()
+	if err := Wait(context.Background(), &wg); err != nil {
+		t.Errorf("Expected nil once goroutines exit, got %v", err)
+	}
+}
@@ -0,0 +1,78 @@
+package tracing
+
+import (
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// Attribute keys recorded on spans. The rpc.* keys follow the OpenTelemetry
+// semantic conventions for gRPC.
+const (
+	AttrAssetID        = "asset.id"
+	AttrMetricName     = "telemetry.metric_name"
+	AttrRPCSystem      = "rpc.system"
+	AttrRPCService     = "rpc.service"
+	AttrRPCMethod      = "rpc.method"
+	AttrRPCStatusCode  = "rpc.grpc.status_code"
+	AttrRequestID      = "request.id"
+	AttrPeerAddress    = "net.peer.address"
+	AttrServiceName    = "service.name"
+	instrumentationLib = "github.com/sairamkiran9/asset-telemetry-monitor/internal/tracing"
+)
+
+// Attribute is a span attribute. Value is a string, int64, float64 or bool.
+type Attribute struct {
+	Key   string
+	Value interface{}
+}
+
+// String returns a string attribute.
+func String(key, value string) Attribute { return Attribute{Key: key, Value: value} }
+
+// Int returns an integer attribute.
+func Int(key string, value int64) Attribute { return Attribute{Key: key, Value: value} }
+
+// Float returns a floating point attribute.
+func Float(key string, value float64) Attribute { return Attribute{Key: key, Value: value} }
+
+// Bool returns a boolean attribute.
+func Bool(key string, value bool) Attribute { return Attribute{Key: key, Value: value} }
+
+// AssetID returns the asset.id attribute.
+func AssetID(id string) Attribute { return String(AttrAssetID, id) }
+
+// MetricName returns the telemetry.metric_name attribute.
+func MetricName(name string) Attribute { return String(AttrMetricName, name) }
+
+// requestFields maps request message fields to the attributes recorded for
+// them, so every RPC that names an asset or metric is searchable by it.
+var requestFields = []struct {
+	name protoreflect.Name
+	key  string
+}{
+	{"asset_id", AttrAssetID},
+	{"metric_name", AttrMetricName},
+}
+
+// requestAttributes returns the asset and metric attributes of a request
+// message.
+func requestAttributes(req interface{}) []Attribute {
+	msg, ok := req.(proto.Message)
+	if !ok {
+		return nil
+	}
+	m := msg.ProtoReflect()
+	fields := m.Descriptor().Fields()
+
+	var attrs []Attribute
+	for _, f := range requestFields {
+		fd := fields.ByName(f.name)
+		if fd == nil || fd.Kind() != protoreflect.StringKind || fd.IsList() {
+			continue
+		}
+		if v := m.Get(fd).String(); v != "" {
+			attrs = append(attrs, String(f.key, v))
+		}
+	}
+	return attrs
+}
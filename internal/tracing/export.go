@@ -0,0 +1,325 @@
+package tracing
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Exporter sends finished spans somewhere. ExportSpans is called from a single
+// goroutine.
+type Exporter interface {
+	ExportSpans(ctx context.Context, spans []SpanData) error
+	Shutdown(ctx context.Context) error
+}
+
+// jsonSpan is the line format written by WriterExporter.
+type jsonSpan struct {
+	TraceID      string                 `json:"trace_id"`
+	SpanID       string                 `json:"span_id"`
+	ParentSpanID string                 `json:"parent_span_id,omitempty"`
+	Service      string                 `json:"service"`
+	Name         string                 `json:"name"`
+	Kind         string                 `json:"kind"`
+	Start        time.Time              `json:"start"`
+	DurationMs   float64                `json:"duration_ms"`
+	Attributes   map[string]interface{} `json:"attributes,omitempty"`
+	Status       string                 `json:"status"`
+	Message      string                 `json:"message,omitempty"`
+}
+
+// WriterExporter writes each span as a JSON line.
+type WriterExporter struct {
+	mu     sync.Mutex
+	w      io.Writer
+	closer io.Closer
+}
+
+// NewWriterExporter writes spans to w, e.g. os.Stdout.
+func NewWriterExporter(w io.Writer) *WriterExporter {
+	return &WriterExporter{w: w}
+}
+
+// NewFileExporter appends spans to the file at path.
+func NewFileExporter(path string) (*WriterExporter, error) {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open trace file: %w", err)
+	}
+	return &WriterExporter{w: f, closer: f}, nil
+}
+
+func (e *WriterExporter) ExportSpans(ctx context.Context, spans []SpanData) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	enc := json.NewEncoder(e.w)
+	for _, s := range spans {
+		line := jsonSpan{
+			TraceID:    s.TraceID.String(),
+			SpanID:     s.SpanID.String(),
+			Service:    s.Service,
+			Name:       s.Name,
+			Kind:       s.Kind.String(),
+			Start:      s.Start,
+			DurationMs: float64(s.End.Sub(s.Start).Microseconds()) / 1000,
+			Status:     s.StatusCode.String(),
+			Message:    s.StatusMessage,
+		}
+		if s.ParentSpanID.IsValid() {
+			line.ParentSpanID = s.ParentSpanID.String()
+		}
+		if len(s.Attributes) > 0 {
+			line.Attributes = make(map[string]interface{}, len(s.Attributes))
+			for _, a := range s.Attributes {
+				line.Attributes[a.Key] = a.Value
+			}
+		}
+		if err := enc.Encode(line); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (e *WriterExporter) Shutdown(ctx context.Context) error {
+	if e.closer != nil {
+		return e.closer.Close()
+	}
+	return nil
+}
+
+// OTLPExporter posts spans to an OTLP/HTTP collector using the JSON encoding,
+// e.g. an OpenTelemetry Collector or the bundled trace-collector stand-in.
+type OTLPExporter struct {
+	endpoint string
+	client   *http.Client
+}
+
+// NewOTLPExporter exports to endpoint, the collector's base URL such as
+// http://localhost:4318. Spans are posted to endpoint + "/v1/traces".
+func NewOTLPExporter(endpoint string) *OTLPExporter {
+	return &OTLPExporter{
+		endpoint: strings.TrimSuffix(endpoint, "/") + "/v1/traces",
+		client:   &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+func (e *OTLPExporter) ExportSpans(ctx context.Context, spans []SpanData) error {
+	body, err := json.Marshal(ToOTLP(spans))
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, e.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := e.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode/100 != 2 {
+		return fmt.Errorf("collector returned %s", resp.Status)
+	}
+	return nil
+}
+
+func (e *OTLPExporter) Shutdown(ctx context.Context) error {
+	e.client.CloseIdleConnections()
+	return nil
+}
+
+// OTLP/JSON request types, following opentelemetry-proto's
+// ExportTraceServiceRequest. IDs are hex encoded and timestamps are decimal
+// strings, as the JSON mapping requires.
+type (
+	OTLPTraces struct {
+		ResourceSpans []OTLPResourceSpans `json:"resourceSpans"`
+	}
+	OTLPResourceSpans struct {
+		Resource   OTLPResource     `json:"resource"`
+		ScopeSpans []OTLPScopeSpans `json:"scopeSpans"`
+	}
+	OTLPResource struct {
+		Attributes []OTLPKeyValue `json:"attributes"`
+	}
+	OTLPScopeSpans struct {
+		Scope OTLPScope  `json:"scope"`
+		Spans []OTLPSpan `json:"spans"`
+	}
+	OTLPScope struct {
+		Name string `json:"name"`
+	}
+	OTLPSpan struct {
+		TraceID           string         `json:"traceId"`
+		SpanID            string         `json:"spanId"`
+		ParentSpanID      string         `json:"parentSpanId,omitempty"`
+		Name              string         `json:"name"`
+		Kind              int            `json:"kind"`
+		StartTimeUnixNano string         `json:"startTimeUnixNano"`
+		EndTimeUnixNano   string         `json:"endTimeUnixNano"`
+		Attributes        []OTLPKeyValue `json:"attributes,omitempty"`
+		Status            OTLPStatus     `json:"status"`
+	}
+	OTLPStatus struct {
+		Code    int    `json:"code,omitempty"`
+		Message string `json:"message,omitempty"`
+	}
+	OTLPKeyValue struct {
+		Key   string    `json:"key"`
+		Value OTLPValue `json:"value"`
+	}
+	OTLPValue struct {
+		StringValue *string  `json:"stringValue,omitempty"`
+		IntValue    *string  `json:"intValue,omitempty"`
+		DoubleValue *float64 `json:"doubleValue,omitempty"`
+		BoolValue   *bool    `json:"boolValue,omitempty"`
+	}
+)
+
+// String renders the value whatever its type.
+func (v OTLPValue) String() string {
+	switch {
+	case v.StringValue != nil:
+		return *v.StringValue
+	case v.IntValue != nil:
+		return *v.IntValue
+	case v.DoubleValue != nil:
+		return strconv.FormatFloat(*v.DoubleValue, 'g', -1, 64)
+	case v.BoolValue != nil:
+		return strconv.FormatBool(*v.BoolValue)
+	default:
+		return ""
+	}
+}
+
+func otlpValue(value interface{}) OTLPValue {
+	switch v := value.(type) {
+	case string:
+		return OTLPValue{StringValue: &v}
+	case int64:
+		s := strconv.FormatInt(v, 10)
+		return OTLPValue{IntValue: &s}
+	case float64:
+		return OTLPValue{DoubleValue: &v}
+	case bool:
+		return OTLPValue{BoolValue: &v}
+	default:
+		s := fmt.Sprint(v)
+		return OTLPValue{StringValue: &s}
+	}
+}
+
+func otlpAttributes(attrs []Attribute) []OTLPKeyValue {
+	kvs := make([]OTLPKeyValue, 0, len(attrs))
+	for _, a := range attrs {
+		kvs = append(kvs, OTLPKeyValue{Key: a.Key, Value: otlpValue(a.Value)})
+	}
+	return kvs
+}
+
+// ToOTLP groups spans by service into an OTLP export request.
+func ToOTLP(spans []SpanData) OTLPTraces {
+	var req OTLPTraces
+	index := make(map[string]int)
+
+	for _, s := range spans {
+		i, ok := index[s.Service]
+		if !ok {
+			i = len(req.ResourceSpans)
+			index[s.Service] = i
+			req.ResourceSpans = append(req.ResourceSpans, OTLPResourceSpans{
+				Resource:   OTLPResource{Attributes: otlpAttributes([]Attribute{String(AttrServiceName, s.Service)})},
+				ScopeSpans: []OTLPScopeSpans{{Scope: OTLPScope{Name: instrumentationLib}}},
+			})
+		}
+
+		span := OTLPSpan{
+			TraceID:           s.TraceID.String(),
+			SpanID:            s.SpanID.String(),
+			Name:              s.Name,
+			Kind:              int(s.Kind),
+			StartTimeUnixNano: strconv.FormatInt(s.Start.UnixNano(), 10),
+			EndTimeUnixNano:   strconv.FormatInt(s.End.UnixNano(), 10),
+			Attributes:        otlpAttributes(s.Attributes),
+			Status:            OTLPStatus{Code: int(s.StatusCode), Message: s.StatusMessage},
+		}
+		if s.ParentSpanID.IsValid() {
+			span.ParentSpanID = s.ParentSpanID.String()
+		}
+
+		scope := &req.ResourceSpans[i].ScopeSpans[0]
+		scope.Spans = append(scope.Spans, span)
+	}
+	return req
+}
+
+// DefaultOTLPEndpoint is the OTLP/HTTP collector address used unless
+// configured otherwise.
+const DefaultOTLPEndpoint = "http://localhost:4318"
+
+// Config selects the exporter.
+type Config struct {
+	Exporter     string `config:"exporter" env:"TRACING_EXPORTER" usage:"span exporter: none, stdout, file or otlp"`
+	FilePath     string `config:"file_path" env:"TRACING_FILE_PATH" usage:"file the file exporter appends spans to"`
+	OTLPEndpoint string `config:"otlp_endpoint" env:"OTEL_EXPORTER_OTLP_ENDPOINT" usage:"OTLP/HTTP collector base URL"`
+}
+
+// DefaultConfig disables tracing.
+func DefaultConfig() Config {
+	return Config{
+		Exporter:     "none",
+		FilePath:     "traces.jsonl",
+		OTLPEndpoint: DefaultOTLPEndpoint,
+	}
+}
+
+// Validate checks the exporter name.
+func (c Config) Validate() error {
+	switch c.Exporter {
+	case "none", "stdout", "file", "otlp":
+		return nil
+	default:
+		return fmt.Errorf("unknown tracing exporter %q, expected none, stdout, file or otlp", c.Exporter)
+	}
+}
+
+// New returns the tracer selected by cfg, or nil when tracing is disabled:
+//   - "none" - tracing disabled
+//   - "stdout" - JSON lines on standard output
+//   - "file" - JSON lines appended to cfg.FilePath
+//   - "otlp" - OTLP/HTTP JSON to cfg.OTLPEndpoint
+func New(service string, cfg Config) (*Tracer, error) {
+	var exporter Exporter
+	switch cfg.Exporter {
+	case "", "none":
+		return nil, nil
+	case "stdout":
+		exporter = NewWriterExporter(os.Stdout)
+	case "file":
+		fe, err := NewFileExporter(cfg.FilePath)
+		if err != nil {
+			return nil, err
+		}
+		exporter = fe
+	case "otlp":
+		exporter = NewOTLPExporter(cfg.OTLPEndpoint)
+	default:
+		return nil, cfg.Validate()
+	}
+	return NewTracer(service, exporter), nil
+}
@@ -0,0 +1,152 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// rpcAttributes splits "/package.Service/Method" into the rpc.* attributes.
+func rpcAttributes(fullMethod string) []Attribute {
+	attrs := []Attribute{String(AttrRPCSystem, "grpc")}
+	name := strings.TrimPrefix(fullMethod, "/")
+	if i := strings.LastIndex(name, "/"); i >= 0 {
+		attrs = append(attrs, String(AttrRPCService, name[:i]), String(AttrRPCMethod, name[i+1:]))
+	}
+	return attrs
+}
+
+func peerAttributes(ctx context.Context) []Attribute {
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		return []Attribute{String(AttrPeerAddress, p.Addr.String())}
+	}
+	return nil
+}
+
+// endRPC records the gRPC status of err and ends the span.
+func endRPC(span *Span, err error) {
+	if span == nil {
+		return
+	}
+	st := status.Convert(err)
+	span.SetAttributes(Int(AttrRPCStatusCode, int64(st.Code())))
+	if st.Code() == codes.OK {
+		span.SetStatus(StatusOK, "")
+	} else {
+		span.SetStatus(StatusError, st.Code().String()+": "+st.Message())
+	}
+	span.End()
+}
+
+// UnaryServerInterceptor records a server span per unary RPC, continuing the
+// caller's trace when the request carries a traceparent.
+func UnaryServerInterceptor(t *Tracer) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		ctx, span := t.Start(Extract(ctx), info.FullMethod, SpanKindServer, rpcAttributes(info.FullMethod)...)
+		span.SetAttributes(peerAttributes(ctx)...)
+		span.SetAttributes(requestAttributes(req)...)
+
+		resp, err := handler(ctx, req)
+		endRPC(span, err)
+		return resp, err
+	}
+}
+
+// tracedServerStream tags the span with the attributes of the first request
+// received on the stream.
+type tracedServerStream struct {
+	grpc.ServerStream
+	ctx      context.Context
+	span     *Span
+	received bool
+}
+
+func (s *tracedServerStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *tracedServerStream) RecvMsg(m interface{}) error {
+	err := s.ServerStream.RecvMsg(m)
+	if err == nil && !s.received {
+		s.received = true
+		s.span.SetAttributes(requestAttributes(m)...)
+	}
+	return err
+}
+
+// StreamServerInterceptor records a server span per streaming RPC, lasting
+// until the handler returns.
+func StreamServerInterceptor(t *Tracer) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, span := t.Start(Extract(ss.Context()), info.FullMethod, SpanKindServer, rpcAttributes(info.FullMethod)...)
+		span.SetAttributes(peerAttributes(ctx)...)
+
+		err := handler(srv, &tracedServerStream{ServerStream: ss, ctx: ctx, span: span})
+		endRPC(span, err)
+		return err
+	}
+}
+
+// UnaryClientInterceptor records a client span per outgoing unary call and
+// propagates it to the callee.
+func UnaryClientInterceptor(t *Tracer) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		ctx, span := t.Start(ctx, method, SpanKindClient, rpcAttributes(method)...)
+		span.SetAttributes(requestAttributes(req)...)
+
+		err := invoker(Inject(ctx), method, req, reply, cc, opts...)
+		endRPC(span, err)
+		return err
+	}
+}
+
+// tracedClientStream ends the span when the stream reports its final status.
+type tracedClientStream struct {
+	grpc.ClientStream
+	span *Span
+}
+
+func (s *tracedClientStream) SendMsg(m interface{}) error {
+	s.span.SetAttributes(requestAttributes(m)...)
+	return s.ClientStream.SendMsg(m)
+}
+
+func (s *tracedClientStream) RecvMsg(m interface{}) error {
+	err := s.ClientStream.RecvMsg(m)
+	if errors.Is(err, io.EOF) {
+		endRPC(s.span, nil)
+	} else if err != nil {
+		endRPC(s.span, err)
+	}
+	return err
+}
+
+// StreamClientInterceptor records a client span per outgoing streaming call.
+// The span ends with the stream's status, or when its context is done if the
+// caller stops reading first.
+func StreamClientInterceptor(t *Tracer) grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		ctx, span := t.Start(ctx, method, SpanKindClient, rpcAttributes(method)...)
+
+		cs, err := streamer(Inject(ctx), desc, cc, method, opts...)
+		if err != nil {
+			endRPC(span, err)
+			return nil, err
+		}
+		if span == nil {
+			return cs, nil
+		}
+
+		go func() {
+			<-cs.Context().Done()
+			endRPC(span, cs.Context().Err())
+		}()
+		return &tracedClientStream{ClientStream: cs, span: span}, nil
+	}
+}
@@ -0,0 +1,74 @@
+package tracing
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"google.golang.org/grpc/metadata"
+)
+
+// TraceparentKey is the W3C Trace Context header, carried as gRPC metadata so
+// spans link up with any OpenTelemetry-instrumented peer.
+const TraceparentKey = "traceparent"
+
+// FormatTraceparent encodes sc as a W3C traceparent value. Every span this
+// package records is sampled.
+func FormatTraceparent(sc SpanContext) string {
+	return "00-" + sc.TraceID.String() + "-" + sc.SpanID.String() + "-01"
+}
+
+// ParseTraceparent decodes a W3C traceparent value.
+func ParseTraceparent(value string) (SpanContext, error) {
+	parts := strings.Split(strings.TrimSpace(value), "-")
+	if len(parts) < 4 || len(parts[0]) != 2 || parts[0] == "ff" {
+		return SpanContext{}, fmt.Errorf("malformed traceparent %q", value)
+	}
+	if parts[0] == "00" && len(parts) != 4 {
+		return SpanContext{}, fmt.Errorf("malformed traceparent %q", value)
+	}
+
+	var sc SpanContext
+	if len(parts[1]) != 32 || len(parts[2]) != 16 {
+		return SpanContext{}, fmt.Errorf("malformed traceparent %q", value)
+	}
+	if _, err := hex.Decode(sc.TraceID[:], []byte(parts[1])); err != nil {
+		return SpanContext{}, fmt.Errorf("malformed trace id in %q", value)
+	}
+	if _, err := hex.Decode(sc.SpanID[:], []byte(parts[2])); err != nil {
+		return SpanContext{}, fmt.Errorf("malformed span id in %q", value)
+	}
+	if !sc.IsValid() {
+		return SpanContext{}, fmt.Errorf("zero ids in traceparent %q", value)
+	}
+	return sc, nil
+}
+
+// Inject adds the span context in ctx to the outgoing gRPC metadata.
+func Inject(ctx context.Context) context.Context {
+	sc := SpanContextFromContext(ctx)
+	if !sc.IsValid() {
+		return ctx
+	}
+	return metadata.AppendToOutgoingContext(ctx, TraceparentKey, FormatTraceparent(sc))
+}
+
+// Extract records the caller's span context from incoming gRPC metadata as the
+// remote parent of spans started from the returned context. Missing or
+// malformed headers start a new trace.
+func Extract(ctx context.Context) context.Context {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ctx
+	}
+	values := md.Get(TraceparentKey)
+	if len(values) == 0 {
+		return ctx
+	}
+	sc, err := ParseTraceparent(values[0])
+	if err != nil {
+		return ctx
+	}
+	return ContextWithRemoteSpanContext(ctx, sc)
+}
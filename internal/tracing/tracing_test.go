@@ -0,0 +1,282 @@
+package tracing
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+)
+
+// recordingExporter keeps exported spans for inspection.
+type recordingExporter struct {
+	mu    sync.Mutex
+	spans []SpanData
+}
+
+func (e *recordingExporter) ExportSpans(ctx context.Context, spans []SpanData) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.spans = append(e.spans, spans...)
+	return nil
+}
+
+func (e *recordingExporter) Shutdown(ctx context.Context) error { return nil }
+
+func attribute(s SpanData, key string) interface{} {
+	for _, a := range s.Attributes {
+		if a.Key == key {
+			return a.Value
+		}
+	}
+	return nil
+}
+
+func TestTraceparentRoundTrip(t *testing.T) {
+	sc := SpanContext{TraceID: newTraceID(), SpanID: newSpanID()}
+
+	parsed, err := ParseTraceparent(FormatTraceparent(sc))
+	if err != nil {
+		t.Fatalf("ParseTraceparent failed: %v", err)
+	}
+	if parsed != sc {
+		t.Errorf("Expected %v, got %v", sc, parsed)
+	}
+}
+
+func TestParseTraceparentRejectsMalformed(t *testing.T) {
+	tests := []string{
+		"",
+		"00-abc-def-01",
+		"00-00000000000000000000000000000000-00f067aa0ba902b7-01",
+		"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01-extra",
+		"ff-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+		"00-4bf92f3577b34da6a3ce929d0e0e473g-00f067aa0ba902b7-01",
+	}
+
+	for _, value := range tests {
+		if _, err := ParseTraceparent(value); err == nil {
+			t.Errorf("Expected %q to be rejected", value)
+		}
+	}
+}
+
+func TestStartParentsSpans(t *testing.T) {
+	exporter := &recordingExporter{}
+	tracer := NewTracer("test", exporter)
+
+	ctx, parent := tracer.Start(context.Background(), "parent", SpanKindInternal)
+	_, child := tracer.Start(ctx, "child", SpanKindInternal, AssetID("asset-1"))
+	child.End()
+	parent.End()
+	tracer.Shutdown(context.Background())
+
+	if len(exporter.spans) != 2 {
+		t.Fatalf("Expected 2 spans, got %d", len(exporter.spans))
+	}
+	c, p := exporter.spans[0], exporter.spans[1]
+	if c.TraceID != p.TraceID {
+		t.Errorf("Expected child in parent's trace %s, got %s", p.TraceID, c.TraceID)
+	}
+	if c.ParentSpanID != p.SpanID {
+		t.Errorf("Expected child's parent %s, got %s", p.SpanID, c.ParentSpanID)
+	}
+	if p.ParentSpanID.IsValid() {
+		t.Errorf("Expected root span without parent, got %s", p.ParentSpanID)
+	}
+	if got := attribute(c, AttrAssetID); got != "asset-1" {
+		t.Errorf("Expected asset.id asset-1, got %v", got)
+	}
+}
+
+func TestNilTracerIsNoop(t *testing.T) {
+	var tracer *Tracer
+
+	ctx, span := tracer.Start(context.Background(), "noop", SpanKindInternal)
+	span.SetAttributes(AssetID("asset-1"))
+	span.SetStatus(StatusError, "ignored")
+	span.End()
+
+	if span != nil || SpanFromContext(ctx) != nil {
+		t.Error("Expected nil tracer to start no span")
+	}
+	if err := tracer.Shutdown(context.Background()); err != nil {
+		t.Errorf("Expected nil tracer shutdown to succeed, got %v", err)
+	}
+}
+
+// nestedHealthServer answers health checks by asking another server, so one
+// request produces a trace across two services.
+type nestedHealthServer struct {
+	healthpb.UnimplementedHealthServer
+	next healthpb.HealthClient
+}
+
+func (s *nestedHealthServer) Check(ctx context.Context, req *healthpb.HealthCheckRequest) (*healthpb.HealthCheckResponse, error) {
+	SpanFromContext(ctx).SetAttributes(AssetID("asset-1"))
+	return s.next.Check(ctx, req)
+}
+
+func serve(t *testing.T, tracer *Tracer, register func(*grpc.Server)) string {
+	t.Helper()
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+	s := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(UnaryServerInterceptor(tracer)),
+		grpc.ChainStreamInterceptor(StreamServerInterceptor(tracer)),
+	)
+	register(s)
+	go s.Serve(lis)
+	t.Cleanup(s.Stop)
+	return lis.Addr().String()
+}
+
+func dial(t *testing.T, tracer *Tracer, addr string) *grpc.ClientConn {
+	t.Helper()
+	conn, err := grpc.NewClient(addr,
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithChainUnaryInterceptor(UnaryClientInterceptor(tracer)),
+		grpc.WithChainStreamInterceptor(StreamClientInterceptor(tracer)),
+	)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return conn
+}
+
+func TestTracePropagatesAcrossServices(t *testing.T) {
+	exporter := &recordingExporter{}
+	frontTracer := NewTracer("front", exporter)
+	backTracer := NewTracer("back", exporter)
+
+	backAddr := serve(t, backTracer, func(s *grpc.Server) {
+		healthpb.RegisterHealthServer(s, health.NewServer())
+	})
+	frontAddr := serve(t, frontTracer, func(s *grpc.Server) {
+		healthpb.RegisterHealthServer(s, &nestedHealthServer{next: healthpb.NewHealthClient(dial(t, frontTracer, backAddr))})
+	})
+
+	// The test client continues a trace started by an upstream caller
+	upstream := SpanContext{TraceID: newTraceID(), SpanID: newSpanID()}
+	ctx := metadata.AppendToOutgoingContext(context.Background(), TraceparentKey, FormatTraceparent(upstream))
+	conn, err := grpc.NewClient(frontAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	defer conn.Close()
+	if _, err := healthpb.NewHealthClient(conn).Check(ctx, &healthpb.HealthCheckRequest{}); err != nil {
+		t.Fatalf("Check failed: %v", err)
+	}
+
+	frontTracer.Shutdown(context.Background())
+	backTracer.Shutdown(context.Background())
+
+	byKind := make(map[string]SpanData)
+	for _, s := range exporter.spans {
+		byKind[s.Service+"/"+s.Kind.String()] = s
+	}
+	frontServer, frontClient, backServer := byKind["front/server"], byKind["front/client"], byKind["back/server"]
+
+	if len(exporter.spans) != 3 {
+		t.Fatalf("Expected 3 spans, got %d", len(exporter.spans))
+	}
+	for _, s := range exporter.spans {
+		if s.TraceID != upstream.TraceID {
+			t.Errorf("Expected %s span in trace %s, got %s", s.Name, upstream.TraceID, s.TraceID)
+		}
+		if s.StatusCode != StatusOK {
+			t.Errorf("Expected %s span status OK, got %v", s.Name, s.StatusCode)
+		}
+	}
+	if frontServer.ParentSpanID != upstream.SpanID {
+		t.Errorf("Expected front server span parented by upstream caller")
+	}
+	if frontClient.ParentSpanID != frontServer.SpanID {
+		t.Errorf("Expected nested call parented by front server span")
+	}
+	if backServer.ParentSpanID != frontClient.SpanID {
+		t.Errorf("Expected back server span parented by the nested client span")
+	}
+	if got := attribute(frontServer, AttrAssetID); got != "asset-1" {
+		t.Errorf("Expected asset.id on front server span, got %v", got)
+	}
+	if got := attribute(backServer, AttrRPCMethod); got != "Check" {
+		t.Errorf("Expected rpc.method Check, got %v", got)
+	}
+}
+
+func TestWriterExporter(t *testing.T) {
+	var b bytes.Buffer
+	tracer := NewTracer("telemetry", NewWriterExporter(&b))
+	_, span := tracer.Start(context.Background(), "SubmitTelemetry", SpanKindServer, AssetID("asset-1"), MetricName("temperature"))
+	span.SetStatus(StatusError, "boom")
+	span.End()
+	tracer.Shutdown(context.Background())
+
+	var line jsonSpan
+	if err := json.Unmarshal(b.Bytes(), &line); err != nil {
+		t.Fatalf("Expected one JSON line, got %q: %v", b.String(), err)
+	}
+	if line.Service != "telemetry" || line.Kind != "server" || line.Status != "error" || line.Message != "boom" {
+		t.Errorf("Unexpected span line: %+v", line)
+	}
+	if line.Attributes[AttrMetricName] != "temperature" {
+		t.Errorf("Expected metric name attribute, got %v", line.Attributes)
+	}
+}
+
+func TestToOTLPGroupsByService(t *testing.T) {
+	tracer := NewTracer("telemetry", &recordingExporter{})
+	_, span := tracer.Start(context.Background(), "SubmitTelemetry", SpanKindServer, Int("count", 3))
+	span.End()
+
+	req := ToOTLP([]SpanData{span.data})
+	body, _ := json.Marshal(req)
+
+	for _, want := range []string{
+		`"key":"service.name","value":{"stringValue":"telemetry"}`,
+		`"key":"count","value":{"intValue":"3"}`,
+		`"kind":2`,
+		`"traceId":"` + span.data.TraceID.String() + `"`,
+	} {
+		if !strings.Contains(string(body), want) {
+			t.Errorf("Expected %s in OTLP JSON:\n%s", want, body)
+		}
+	}
+}
+
+func TestNew(t *testing.T) {
+	if tracer, err := New("test", DefaultConfig()); err != nil || tracer != nil {
+		t.Errorf("Expected tracing disabled by default, got %v, %v", tracer, err)
+	}
+
+	if _, err := New("test", Config{Exporter: "jaeger"}); err == nil {
+		t.Error("Expected unknown exporter to be rejected")
+	}
+
+	path := filepath.Join(t.TempDir(), "traces.jsonl")
+	tracer, err := New("test", Config{Exporter: "file", FilePath: path})
+	if err != nil {
+		t.Fatalf("Failed to create file tracer: %v", err)
+	}
+	_, span := tracer.Start(context.Background(), "op", SpanKindInternal)
+	span.End()
+	tracer.Shutdown(context.Background())
+
+	if data, _ := os.ReadFile(path); !strings.Contains(string(data), `"name":"op"`) {
+		t.Errorf("Expected span in trace file, got %q", data)
+	}
+}
@@ -29,5 +29,14 @@ echo "=== Asset Monitoring Service Tests ==="
 cd services/asset-monitoring && go test -v
 cd "$PROJECT_ROOT"
 
+echo ""
+echo "=== Alerting Service Tests ==="
+cd services/alerting && go test -v
+cd "$PROJECT_ROOT"
+
+echo ""
+echo "=== Shared Package Tests ==="
+go test -v ./internal/...
+
 echo ""
 echo "All tests completed!"
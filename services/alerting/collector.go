@@ -0,0 +1,93 @@
+package main
+
+import (
+	"context"
+	"log"
+	"time"
+
+	assetpb "github.com/sairamkiran9/asset-telemetry-monitor/gen/go/proto/asset"
+	monitoringpb "github.com/sairamkiran9/asset-telemetry-monitor/gen/go/proto/asset_monitoring"
+	telemetrypb "github.com/sairamkiran9/asset-telemetry-monitor/gen/go/proto/telemetry"
+)
+
+func (s *server) collect(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		s.collectOnce(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// collectOnce pulls the latest telemetry for every registered asset and makes
+// sure each asset has a status subscription. Listing every tenant's assets
+// needs credentials for any tenant.
+func (s *server) collectOnce(ctx context.Context) {
+	assetsResp, err := s.assetClient.ListAssets(ctx, &assetpb.ListAssetsRequest{})
+	if err != nil {
+		log.Printf("Failed to list assets: %v", err)
+		return
+	}
+
+	for _, asset := range assetsResp.Assets {
+		s.observeTenant(asset.Id, asset.Tenant)
+		s.collectTelemetry(ctx, asset.Id)
+		s.ensureStatusWatch(ctx, asset.Id)
+	}
+}
+
+func (s *server) collectTelemetry(ctx context.Context, assetID string) {
+	resp, err := s.telemetryClient.GetTelemetryData(ctx, &telemetrypb.GetTelemetryDataRequest{AssetId: assetID})
+	if err != nil {
+		log.Printf("Failed to get telemetry for asset %s: %v", assetID, err)
+		return
+	}
+
+	for _, data := range resp.Data {
+		s.observeMetric(assetID, data.MetricName, data.Value, data.Timestamp.AsTime())
+	}
+}
+
+// ensureStatusWatch starts a StreamAssetStatus subscription for the asset
+// unless one is already open.
+func (s *server) ensureStatusWatch(ctx context.Context, assetID string) {
+	s.watchingMu.Lock()
+	defer s.watchingMu.Unlock()
+
+	if s.watching[assetID] {
+		return
+	}
+	s.watching[assetID] = true
+	go s.watchStatus(ctx, assetID)
+}
+
+func (s *server) watchStatus(ctx context.Context, assetID string) {
+	defer func() {
+		s.watchingMu.Lock()
+		delete(s.watching, assetID)
+		s.watchingMu.Unlock()
+	}()
+
+	stream, err := s.monitoringClient.StreamAssetStatus(ctx, &monitoringpb.StreamAssetStatusRequest{AssetId: assetID})
+	if err != nil {
+		log.Printf("Failed to watch status of asset %s: %v", assetID, err)
+		return
+	}
+
+	for {
+		update, err := stream.Recv()
+		if err != nil {
+			if ctx.Err() == nil {
+				log.Printf("Status stream for asset %s ended: %v", assetID, err)
+			}
+			return
+		}
+		s.observeStatus(assetID, update.Status.String())
+	}
+}
@@ -0,0 +1,76 @@
+package main
+
+import (
+	"errors"
+	"net/url"
+	"time"
+
+	"github.com/sairamkiran9/asset-telemetry-monitor/internal/config"
+)
+
+// Config holds the alerting service's settings.
+type Config struct {
+	config.Common
+	AssetRegistryAddr   string             `config:"asset_registry_addr" env:"ASSET_REGISTRY_ADDR" usage:"asset registry address, or comma-separated addresses of its replicas"`
+	TelemetryAddr       string             `config:"telemetry_addr" env:"TELEMETRY_ADDR" usage:"telemetry service address"`
+	AssetMonitoringAddr string             `config:"asset_monitoring_addr" env:"ASSET_MONITORING_ADDR" usage:"asset monitoring service address"`
+	EvaluationInterval  time.Duration      `config:"evaluation_interval" env:"EVALUATION_INTERVAL" usage:"time between rule evaluations"`
+	CollectionInterval  time.Duration      `config:"collection_interval" env:"COLLECTION_INTERVAL" usage:"time between telemetry collections"`
+	Notifications       NotificationConfig `config:"notifications"`
+}
+
+// NotificationConfig selects the notification sinks. Each sink is enabled by
+// setting its address or path.
+type NotificationConfig struct {
+	WebhookURL string     `config:"webhook_url" env:"ALERT_WEBHOOK_URL" usage:"URL alerts are POSTed to as JSON"`
+	SMTP       SMTPConfig `config:"smtp"`
+	FilePath   string     `config:"file_path" env:"ALERT_FILE_PATH" usage:"file alerts are appended to as JSON lines"`
+}
+
+// SMTPConfig configures email notifications.
+type SMTPConfig struct {
+	Addr     string   `config:"addr" env:"ALERT_SMTP_ADDR" usage:"SMTP server host:port"`
+	From     string   `config:"from" env:"ALERT_SMTP_FROM" usage:"sender address"`
+	To       []string `config:"to" env:"ALERT_SMTP_TO" usage:"comma separated recipients"`
+	Username string   `config:"username" env:"ALERT_SMTP_USERNAME" usage:"SMTP username"`
+	Password string   `config:"password" env:"ALERT_SMTP_PASSWORD" usage:"SMTP password" secret:"true"`
+}
+
+func defaultConfig() Config {
+	return Config{
+		Common:              config.DefaultCommon(":50055"),
+		AssetRegistryAddr:   "asset-registry:50051",
+		TelemetryAddr:       "telemetry:50052",
+		AssetMonitoringAddr: "asset-monitoring:50054",
+		EvaluationInterval:  10 * time.Second,
+		CollectionInterval:  10 * time.Second,
+	}
+}
+
+func (c *Config) Validate() error {
+	errs := []error{
+		c.Common.Validate(),
+		config.CheckAddrs("asset_registry_addr", c.AssetRegistryAddr),
+		config.CheckAddr("telemetry_addr", c.TelemetryAddr),
+		config.CheckAddr("asset_monitoring_addr", c.AssetMonitoringAddr),
+		config.CheckPositive("evaluation_interval", c.EvaluationInterval),
+		config.CheckPositive("collection_interval", c.CollectionInterval),
+	}
+
+	n := c.Notifications
+	if n.WebhookURL != "" {
+		if u, err := url.Parse(n.WebhookURL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			errs = append(errs, errors.New("notifications.webhook_url must be an http or https URL"))
+		}
+	}
+	if n.SMTP.Addr != "" {
+		errs = append(errs, config.CheckAddr("notifications.smtp.addr", n.SMTP.Addr))
+		if n.SMTP.From == "" {
+			errs = append(errs, errors.New("notifications.smtp.from is required when notifications.smtp.addr is set"))
+		}
+		if len(n.SMTP.To) == 0 {
+			errs = append(errs, errors.New("notifications.smtp.to is required when notifications.smtp.addr is set"))
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,322 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	pb "github.com/sairamkiran9/asset-telemetry-monitor/gen/go/proto/alerting"
+	"github.com/sairamkiran9/asset-telemetry-monitor/internal/auth"
+)
+
+// observeMetric records the latest value of a telemetry metric for an asset.
+func (s *server) observeMetric(assetID, metricName string, value float64, at time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	metrics, exists := s.metrics[assetID]
+	if !exists {
+		metrics = make(map[string]metricSample)
+		s.metrics[assetID] = metrics
+	}
+	if prev, ok := metrics[metricName]; ok && prev.at.After(at) {
+		return
+	}
+	metrics[metricName] = metricSample{value: value, at: at}
+}
+
+// observeStatus records the latest status name reported for an asset.
+func (s *server) observeStatus(assetID, assetStatus string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.statuses[assetID] = assetStatus
+}
+
+// observeTenant records the tenant an asset belongs to. Rules only apply to
+// assets of their own tenant.
+func (s *server) observeTenant(assetID, tenant string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.assetTenants[assetID] = ownerTenant(tenant)
+}
+
+func (s *server) runEvaluation(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			s.dispatch(ctx, s.evaluate(now))
+		}
+	}
+}
+
+// evaluate runs every rule against the latest observations, advances alert
+// lifecycles and returns the notifications to send, grouped by group key.
+func (s *server) evaluate(now time.Time) []*notification {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var changed []*pb.Alert
+	seen := make(map[string]bool)
+
+	for _, rule := range s.sortedRules() {
+		for _, assetID := range s.ruleTargets(rule) {
+			firing, value, message := s.check(rule, assetID)
+			fingerprint := rule.Id + "/" + assetID
+			seen[fingerprint] = true
+
+			alert, active := s.alerts[fingerprint]
+			switch {
+			case firing && !active:
+				alert = s.newAlert(rule, assetID, fingerprint, now)
+				s.alerts[fingerprint] = alert
+				fallthrough
+			case firing:
+				alert.Value = value
+				alert.Message = message
+				if alert.State == pb.AlertState_PENDING && now.Sub(alert.StartedAt.AsTime()) >= time.Duration(rule.ForSeconds)*time.Second {
+					alert.State = pb.AlertState_FIRING
+					alert.FiredAt = timestamppb.New(now)
+					log.Printf("Alert %s firing: %s", alert.Id, alert.Message)
+				}
+				s.applySilences(alert, now)
+				if s.shouldNotify(alert, now) {
+					changed = append(changed, alert)
+				}
+			case active:
+				if s.resolve(fingerprint, now) {
+					changed = append(changed, alert)
+				}
+			}
+		}
+	}
+
+	// Alerts whose rule was deleted resolve on their own
+	for fingerprint, alert := range s.alerts {
+		if _, exists := s.rules[alert.RuleId]; !exists && !seen[fingerprint] {
+			if s.resolve(fingerprint, now) {
+				changed = append(changed, alert)
+			}
+		}
+	}
+
+	return groupNotifications(changed)
+}
+
+// sortedRules returns rules in ID order. Callers must hold s.mu.
+func (s *server) sortedRules() []*pb.AlertRule {
+	rules := make([]*pb.AlertRule, 0, len(s.rules))
+	for _, rule := range s.rules {
+		rules = append(rules, rule)
+	}
+	sort.Slice(rules, func(i, j int) bool {
+		return rules[i].Id < rules[j].Id
+	})
+	return rules
+}
+
+// ownerTenant is the tenant owning data stamped with tenant, which is
+// DefaultTenant for data without one.
+func ownerTenant(tenant string) string {
+	if tenant == "" {
+		return auth.DefaultTenant
+	}
+	return tenant
+}
+
+// ruleTargets returns the assets of the rule's tenant that it has data for.
+// Callers must hold s.mu.
+func (s *server) ruleTargets(rule *pb.AlertRule) []string {
+	var targets []string
+	switch rule.Kind {
+	case pb.RuleKind_METRIC_THRESHOLD:
+		for assetID, metrics := range s.metrics {
+			if _, ok := metrics[rule.MetricName]; ok {
+				targets = append(targets, assetID)
+			}
+		}
+	case pb.RuleKind_ASSET_STATUS:
+		for assetID := range s.statuses {
+			targets = append(targets, assetID)
+		}
+	}
+
+	filtered := targets[:0]
+	for _, assetID := range targets {
+		if ownerTenant(s.assetTenants[assetID]) != ownerTenant(rule.Tenant) {
+			continue
+		}
+		if rule.AssetId == "" || rule.AssetId == assetID {
+			filtered = append(filtered, assetID)
+		}
+	}
+	sort.Strings(filtered)
+	return filtered
+}
+
+// check reports whether the rule's condition holds for an asset. Callers must
+// hold s.mu.
+func (s *server) check(rule *pb.AlertRule, assetID string) (bool, float64, string) {
+	switch rule.Kind {
+	case pb.RuleKind_METRIC_THRESHOLD:
+		sample := s.metrics[assetID][rule.MetricName]
+		holds := compare(sample.value, rule.Comparison, rule.Threshold)
+		message := fmt.Sprintf("%s on asset %s is %.2f (%s %.2f)",
+			rule.MetricName, assetID, sample.value, comparisonSymbol(rule.Comparison), rule.Threshold)
+		return holds, sample.value, message
+	case pb.RuleKind_ASSET_STATUS:
+		assetStatus := s.statuses[assetID]
+		return assetStatus == rule.Status, 0, fmt.Sprintf("Asset %s is %s", assetID, assetStatus)
+	default:
+		return false, 0, ""
+	}
+}
+
+// newAlert creates a pending alert. Callers must hold s.mu.
+func (s *server) newAlert(rule *pb.AlertRule, assetID, fingerprint string, now time.Time) *pb.Alert {
+	s.alertCounter++
+
+	labels := map[string]string{
+		"alertname": rule.Name,
+		"asset_id":  assetID,
+		"severity":  strings.ToLower(rule.Severity.String()),
+	}
+	for k, v := range rule.Labels {
+		labels[k] = v
+	}
+
+	return &pb.Alert{
+		Id:          fmt.Sprintf("alert-%d", s.alertCounter),
+		RuleId:      rule.Id,
+		RuleName:    rule.Name,
+		AssetId:     assetID,
+		State:       pb.AlertState_PENDING,
+		Severity:    rule.Severity,
+		Labels:      labels,
+		Fingerprint: fingerprint,
+		GroupKey:    groupKey(rule.GroupBy, labels),
+		StartedAt:   timestamppb.New(now),
+		Tenant:      ownerTenant(s.assetTenants[assetID]),
+	}
+}
+
+// resolve ends an active alert and reports whether anyone was notified about
+// it, i.e. whether the resolution needs a notification. Pending alerts are
+// dropped silently. Callers must hold s.mu.
+func (s *server) resolve(fingerprint string, now time.Time) bool {
+	alert := s.alerts[fingerprint]
+	delete(s.alerts, fingerprint)
+	_, notified := s.lastNotified[fingerprint]
+	delete(s.lastNotified, fingerprint)
+
+	if alert.State == pb.AlertState_PENDING {
+		return false
+	}
+
+	alert.State = pb.AlertState_RESOLVED
+	alert.ResolvedAt = timestamppb.New(now)
+	s.resolved = append(s.resolved, alert)
+	if len(s.resolved) > maxResolvedAlerts {
+		s.resolved = s.resolved[len(s.resolved)-maxResolvedAlerts:]
+	}
+	log.Printf("Alert %s resolved", alert.Id)
+	return notified
+}
+
+// shouldNotify deduplicates notifications for a firing alert: it is sent once
+// when it starts firing and again every repeatInterval until acknowledged or
+// silenced.
+// Callers must hold s.mu.
+func (s *server) shouldNotify(alert *pb.Alert, now time.Time) bool {
+	if alert.State != pb.AlertState_FIRING {
+		return false
+	}
+	if last, ok := s.lastNotified[alert.Fingerprint]; ok && now.Sub(last) < repeatInterval {
+		return false
+	}
+	s.lastNotified[alert.Fingerprint] = now
+	return true
+}
+
+// groupKey builds the notification group for an alert from the rule's
+// group_by labels, defaulting to the alert name.
+func groupKey(groupBy []string, labels map[string]string) string {
+	if len(groupBy) == 0 {
+		groupBy = []string{"alertname"}
+	}
+	parts := make([]string, 0, len(groupBy))
+	for _, name := range groupBy {
+		parts = append(parts, name+"="+labels[name])
+	}
+	return strings.Join(parts, ",")
+}
+
+// groupNotifications batches alerts of one tenant that share a group key into
+// a single notification, preserving the order groups were first seen in.
+func groupNotifications(alerts []*pb.Alert) []*notification {
+	var notifications []*notification
+	byKey := make(map[[2]string]*notification)
+
+	for _, alert := range alerts {
+		key := [2]string{alert.Tenant, alert.GroupKey}
+		n, exists := byKey[key]
+		if !exists {
+			n = &notification{GroupKey: alert.GroupKey, Status: "resolved"}
+			byKey[key] = n
+			notifications = append(notifications, n)
+		}
+		if alert.State != pb.AlertState_RESOLVED {
+			n.Status = "firing"
+		}
+		n.Alerts = append(n.Alerts, proto.Clone(alert).(*pb.Alert))
+	}
+	return notifications
+}
+
+func compare(value float64, comparison pb.Comparison, threshold float64) bool {
+	switch comparison {
+	case pb.Comparison_GREATER_THAN:
+		return value > threshold
+	case pb.Comparison_GREATER_THAN_OR_EQUAL:
+		return value >= threshold
+	case pb.Comparison_LESS_THAN:
+		return value < threshold
+	case pb.Comparison_LESS_THAN_OR_EQUAL:
+		return value <= threshold
+	case pb.Comparison_EQUAL:
+		return value == threshold
+	case pb.Comparison_NOT_EQUAL:
+		return value != threshold
+	default:
+		return false
+	}
+}
+
+func comparisonSymbol(comparison pb.Comparison) string {
+	switch comparison {
+	case pb.Comparison_GREATER_THAN:
+		return ">"
+	case pb.Comparison_GREATER_THAN_OR_EQUAL:
+		return ">="
+	case pb.Comparison_LESS_THAN:
+		return "<"
+	case pb.Comparison_LESS_THAN_OR_EQUAL:
+		return "<="
+	case pb.Comparison_EQUAL:
+		return "=="
+	case pb.Comparison_NOT_EQUAL:
+		return "!="
+	default:
+		return "?"
+	}
+}
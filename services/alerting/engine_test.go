@@ -0,0 +1,187 @@
+package main
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	pb "github.com/sairamkiran9/asset-telemetry-monitor/gen/go/proto/alerting"
+)
+
+// Recording notifier for assertions
+type recordingNotifier struct {
+	mu            sync.Mutex
+	notifications []*notification
+}
+
+func (r *recordingNotifier) Name() string {
+	return "recording"
+}
+
+func (r *recordingNotifier) Notify(ctx context.Context, n *notification) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.notifications = append(r.notifications, n)
+	return nil
+}
+
+func createRule(t *testing.T, s *server, rule *pb.AlertRule) *pb.AlertRule {
+	t.Helper()
+	resp, err := s.CreateRule(context.Background(), &pb.CreateRuleRequest{Rule: rule})
+	if err != nil {
+		t.Fatalf("CreateRule failed: %v", err)
+	}
+	return resp.Rule
+}
+
+func TestAlertLifecycle(t *testing.T) {
+	s := newTestServer()
+	now := time.Now()
+
+	createRule(t, s, &pb.AlertRule{
+		Name:       "High power",
+		Kind:       pb.RuleKind_METRIC_THRESHOLD,
+		MetricName: "power",
+		Comparison: pb.Comparison_GREATER_THAN,
+		Threshold:  900,
+		ForSeconds: 60,
+	})
+
+	s.observeMetric("asset-1", "power", 950, now)
+	if notifications := s.evaluate(now); len(notifications) != 0 {
+		t.Errorf("Expected no notifications while pending, got %d", len(notifications))
+	}
+
+	alert := s.alerts["rule-1/asset-1"]
+	if alert == nil || alert.State != pb.AlertState_PENDING {
+		t.Fatalf("Expected PENDING alert, got %v", alert)
+	}
+
+	notifications := s.evaluate(now.Add(time.Minute))
+	if alert.State != pb.AlertState_FIRING {
+		t.Errorf("Expected FIRING after for duration, got %v", alert.State)
+	}
+	if len(notifications) != 1 || notifications[0].Status != "firing" {
+		t.Fatalf("Expected one firing notification, got %v", notifications)
+	}
+
+	s.observeMetric("asset-1", "power", 500, now.Add(2*time.Minute))
+	notifications = s.evaluate(now.Add(2 * time.Minute))
+	if alert.State != pb.AlertState_RESOLVED {
+		t.Errorf("Expected RESOLVED, got %v", alert.State)
+	}
+	if len(notifications) != 1 || notifications[0].Status != "resolved" {
+		t.Fatalf("Expected one resolved notification, got %v", notifications)
+	}
+}
+
+func TestPendingAlertDroppedWhenConditionClears(t *testing.T) {
+	s := newTestServer()
+	now := time.Now()
+
+	createRule(t, s, &pb.AlertRule{
+		Name:       "Low flow",
+		Kind:       pb.RuleKind_METRIC_THRESHOLD,
+		MetricName: "flow_rate",
+		Comparison: pb.Comparison_LESS_THAN,
+		Threshold:  1000,
+		ForSeconds: 300,
+	})
+
+	s.observeMetric("asset-1", "flow_rate", 500, now)
+	s.evaluate(now)
+	s.observeMetric("asset-1", "flow_rate", 2000, now.Add(time.Second))
+
+	if notifications := s.evaluate(now.Add(time.Second)); len(notifications) != 0 {
+		t.Errorf("Expected no notifications for a pending alert, got %d", len(notifications))
+	}
+	if len(s.alerts) != 0 || len(s.resolved) != 0 {
+		t.Errorf("Expected pending alert to be dropped, got %d active and %d resolved", len(s.alerts), len(s.resolved))
+	}
+}
+
+func TestAlertDeduplication(t *testing.T) {
+	s := newTestServer()
+	now := time.Now()
+
+	createRule(t, s, &pb.AlertRule{Name: "Offline", Kind: pb.RuleKind_ASSET_STATUS, Status: "OFFLINE"})
+	s.observeStatus("asset-1", "OFFLINE")
+
+	if notifications := s.evaluate(now); len(notifications) != 1 {
+		t.Fatalf("Expected 1 notification, got %d", len(notifications))
+	}
+	for i := 1; i <= 5; i++ {
+		if notifications := s.evaluate(now.Add(time.Duration(i) * time.Minute)); len(notifications) != 0 {
+			t.Errorf("Expected duplicate notification to be suppressed, got %d", len(notifications))
+		}
+	}
+	if len(s.alerts) != 1 {
+		t.Errorf("Expected a single active alert, got %d", len(s.alerts))
+	}
+
+	if notifications := s.evaluate(now.Add(repeatInterval)); len(notifications) != 1 {
+		t.Errorf("Expected a repeat notification after %v, got %d", repeatInterval, len(notifications))
+	}
+}
+
+func TestAlertGrouping(t *testing.T) {
+	s := newTestServer()
+	now := time.Now()
+
+	createRule(t, s, &pb.AlertRule{Name: "Offline", Kind: pb.RuleKind_ASSET_STATUS, Status: "OFFLINE"})
+	createRule(t, s, &pb.AlertRule{
+		Name:    "Degraded",
+		Kind:    pb.RuleKind_ASSET_STATUS,
+		Status:  "DEGRADED",
+		GroupBy: []string{"asset_id"},
+	})
+
+	s.observeStatus("asset-1", "OFFLINE")
+	s.observeStatus("asset-2", "OFFLINE")
+	s.observeStatus("asset-3", "DEGRADED")
+
+	notifications := s.evaluate(now)
+	if len(notifications) != 2 {
+		t.Fatalf("Expected 2 notification groups, got %d", len(notifications))
+	}
+
+	if notifications[0].GroupKey != "alertname=Offline" || len(notifications[0].Alerts) != 2 {
+		t.Errorf("Expected both offline assets grouped, got %s with %d alerts", notifications[0].GroupKey, len(notifications[0].Alerts))
+	}
+	if notifications[1].GroupKey != "asset_id=asset-3" {
+		t.Errorf("Expected group by asset_id, got %s", notifications[1].GroupKey)
+	}
+}
+
+func TestDeletedRuleResolvesAlerts(t *testing.T) {
+	s := newTestServer()
+	now := time.Now()
+
+	rule := createRule(t, s, &pb.AlertRule{Name: "Offline", Kind: pb.RuleKind_ASSET_STATUS, Status: "OFFLINE"})
+	s.observeStatus("asset-1", "OFFLINE")
+	s.evaluate(now)
+
+	s.DeleteRule(context.Background(), &pb.DeleteRuleRequest{Id: rule.Id})
+	notifications := s.evaluate(now.Add(time.Second))
+
+	if len(notifications) != 1 || notifications[0].Status != "resolved" {
+		t.Errorf("Expected a resolved notification, got %v", notifications)
+	}
+}
+
+func TestDispatch(t *testing.T) {
+	recorder := &recordingNotifier{}
+	s := newTestServer(recorder)
+
+	createRule(t, s, &pb.AlertRule{Name: "Offline", Kind: pb.RuleKind_ASSET_STATUS, Status: "OFFLINE"})
+	s.observeStatus("asset-1", "OFFLINE")
+	s.dispatch(context.Background(), s.evaluate(time.Now()))
+
+	if len(recorder.notifications) != 1 {
+		t.Fatalf("Expected 1 delivered notification, got %d", len(recorder.notifications))
+	}
+	if recorder.notifications[0].Alerts[0].Message != "Asset asset-1 is OFFLINE" {
+		t.Errorf("Unexpected alert message %q", recorder.notifications[0].Alerts[0].Message)
+	}
+}
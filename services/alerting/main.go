@@ -0,0 +1,401 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net"
+	"os/signal"
+	"sort"
+	"sync"
+	"syscall"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	pb "github.com/sairamkiran9/asset-telemetry-monitor/gen/go/proto/alerting"
+	assetpb "github.com/sairamkiran9/asset-telemetry-monitor/gen/go/proto/asset"
+	monitoringpb "github.com/sairamkiran9/asset-telemetry-monitor/gen/go/proto/asset_monitoring"
+	metricspb "github.com/sairamkiran9/asset-telemetry-monitor/gen/go/proto/monitoring"
+	telemetrypb "github.com/sairamkiran9/asset-telemetry-monitor/gen/go/proto/telemetry"
+	"github.com/sairamkiran9/asset-telemetry-monitor/internal/auth"
+	"github.com/sairamkiran9/asset-telemetry-monitor/internal/certs"
+	"github.com/sairamkiran9/asset-telemetry-monitor/internal/cluster"
+	"github.com/sairamkiran9/asset-telemetry-monitor/internal/config"
+	"github.com/sairamkiran9/asset-telemetry-monitor/internal/health"
+	"github.com/sairamkiran9/asset-telemetry-monitor/internal/interceptors"
+	"github.com/sairamkiran9/asset-telemetry-monitor/internal/metrics"
+	"github.com/sairamkiran9/asset-telemetry-monitor/internal/shutdown"
+	"github.com/sairamkiran9/asset-telemetry-monitor/internal/tracing"
+)
+
+const (
+	repeatInterval    = 4 * time.Hour
+	maxResolvedAlerts = 1000
+)
+
+type metricSample struct {
+	value float64
+	at    time.Time
+}
+
+type server struct {
+	pb.UnimplementedAlertingServiceServer
+
+	mu          sync.RWMutex
+	rules       map[string]*pb.AlertRule
+	ruleCounter int
+
+	// Latest observations per asset, and the tenant each asset belongs to
+	metrics      map[string]map[string]metricSample
+	statuses     map[string]string
+	assetTenants map[string]string
+
+	// Active alerts keyed by fingerprint, resolved alerts oldest first
+	alerts       map[string]*pb.Alert
+	resolved     []*pb.Alert
+	alertCounter int
+	lastNotified map[string]time.Time
+
+	silences       map[string]*pb.Silence
+	silenceCounter int
+
+	notifiers []notifier
+
+	// Service clients
+	assetClient      assetpb.AssetRegistryClient
+	telemetryClient  telemetrypb.TelemetryServiceClient
+	monitoringClient monitoringpb.AssetMonitoringServiceClient
+
+	// Assets with an open StreamAssetStatus subscription
+	watching   map[string]bool
+	watchingMu sync.Mutex
+
+	registry *metrics.Registry
+}
+
+func newServer(assetClient assetpb.AssetRegistryClient, telemetryClient telemetrypb.TelemetryServiceClient, monitoringClient monitoringpb.AssetMonitoringServiceClient, notifiers ...notifier) *server {
+	registry := metrics.NewRegistry()
+	metrics.RegisterRuntime(registry)
+
+	s := &server{
+		rules:            make(map[string]*pb.AlertRule),
+		metrics:          make(map[string]map[string]metricSample),
+		statuses:         make(map[string]string),
+		assetTenants:     make(map[string]string),
+		alerts:           make(map[string]*pb.Alert),
+		lastNotified:     make(map[string]time.Time),
+		silences:         make(map[string]*pb.Silence),
+		notifiers:        notifiers,
+		assetClient:      assetClient,
+		telemetryClient:  telemetryClient,
+		monitoringClient: monitoringClient,
+		watching:         make(map[string]bool),
+		registry:         registry,
+	}
+
+	registry.GaugeFunc("alerting_rules", "Configured alert rules.", func() float64 {
+		s.mu.RLock()
+		defer s.mu.RUnlock()
+		return float64(len(s.rules))
+	})
+	registry.GaugeFunc("alerting_active_alerts", "Alerts that are pending, firing, acknowledged or silenced.", func() float64 {
+		s.mu.RLock()
+		defer s.mu.RUnlock()
+		return float64(len(s.alerts))
+	})
+	return s
+}
+
+func (s *server) CreateRule(ctx context.Context, req *pb.CreateRuleRequest) (*pb.CreateRuleResponse, error) {
+	rule := req.Rule
+	if rule == nil {
+		return nil, status.Error(codes.InvalidArgument, "rule is required")
+	}
+	if rule.Name == "" {
+		return nil, status.Error(codes.InvalidArgument, "rule name is required")
+	}
+	if rule.ForSeconds < 0 {
+		return nil, status.Error(codes.InvalidArgument, "for_seconds must not be negative")
+	}
+
+	switch rule.Kind {
+	case pb.RuleKind_METRIC_THRESHOLD:
+		if rule.MetricName == "" {
+			return nil, status.Error(codes.InvalidArgument, "metric_name is required for METRIC_THRESHOLD rules")
+		}
+		if rule.Comparison == pb.Comparison_COMPARISON_UNKNOWN {
+			return nil, status.Error(codes.InvalidArgument, "comparison is required for METRIC_THRESHOLD rules")
+		}
+	case pb.RuleKind_ASSET_STATUS:
+		if _, ok := monitoringpb.AssetStatus_value[rule.Status]; !ok {
+			return nil, status.Errorf(codes.InvalidArgument, "unknown asset status %q", rule.Status)
+		}
+	default:
+		return nil, status.Error(codes.InvalidArgument, "rule kind is required")
+	}
+	tenant, err := auth.RequireTenant(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rule = proto.Clone(rule).(*pb.AlertRule)
+	rule.Tenant = tenant
+	if rule.Severity == pb.Severity_SEVERITY_UNKNOWN {
+		rule.Severity = pb.Severity_WARNING
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.ruleCounter++
+	rule.Id = fmt.Sprintf("rule-%d", s.ruleCounter)
+	s.rules[rule.Id] = rule
+	log.Printf("Created alert rule: %s (ID: %s, tenant: %s)", rule.Name, rule.Id, rule.Tenant)
+
+	return &pb.CreateRuleResponse{
+		Rule:    rule,
+		Success: true,
+		Message: "Rule created successfully",
+	}, nil
+}
+
+func (s *server) ListRules(ctx context.Context, req *pb.ListRulesRequest) (*pb.ListRulesResponse, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	rules := make([]*pb.AlertRule, 0, len(s.rules))
+	for _, rule := range s.sortedRules() {
+		if auth.CanAccessTenant(ctx, rule.Tenant) {
+			rules = append(rules, rule)
+		}
+	}
+
+	return &pb.ListRulesResponse{
+		Rules: rules,
+	}, nil
+}
+
+func (s *server) DeleteRule(ctx context.Context, req *pb.DeleteRuleRequest) (*pb.DeleteRuleResponse, error) {
+	if req.Id == "" {
+		return nil, status.Error(codes.InvalidArgument, "rule ID is required")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if rule, exists := s.rules[req.Id]; !exists || !auth.CanAccessTenant(ctx, rule.Tenant) {
+		return nil, status.Errorf(codes.NotFound, "rule %s not found", req.Id)
+	}
+	delete(s.rules, req.Id)
+	log.Printf("Deleted alert rule %s", req.Id)
+
+	return &pb.DeleteRuleResponse{
+		Success: true,
+		Message: "Rule deleted successfully",
+	}, nil
+}
+
+func (s *server) ListAlerts(ctx context.Context, req *pb.ListAlertsRequest) (*pb.ListAlertsResponse, error) {
+	states := make(map[pb.AlertState]bool)
+	for _, state := range req.States {
+		states[state] = true
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	alerts := make([]*pb.Alert, 0, len(s.alerts)+len(s.resolved))
+	for _, alert := range s.allAlerts() {
+		if !auth.CanAccessTenant(ctx, alert.Tenant) || (req.AssetId != "" && alert.AssetId != req.AssetId) {
+			continue
+		}
+		if len(states) > 0 && !states[alert.State] {
+			continue
+		}
+		alerts = append(alerts, proto.Clone(alert).(*pb.Alert))
+	}
+
+	return &pb.ListAlertsResponse{
+		Alerts: alerts,
+	}, nil
+}
+
+func (s *server) AcknowledgeAlert(ctx context.Context, req *pb.AcknowledgeAlertRequest) (*pb.AcknowledgeAlertResponse, error) {
+	if req.AlertId == "" {
+		return nil, status.Error(codes.InvalidArgument, "alert_id is required")
+	}
+	owner, err := callerOwner(ctx, req.Owner)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	alert := s.findActiveAlert(ctx, req.AlertId)
+	if alert == nil {
+		return nil, status.Errorf(codes.NotFound, "active alert %s not found", req.AlertId)
+	}
+	if alert.State != pb.AlertState_FIRING && (alert.State != pb.AlertState_SILENCED || alert.AcknowledgedAt != nil) {
+		return nil, status.Errorf(codes.FailedPrecondition, "alert %s is %s, only FIRING or SILENCED alerts can be acknowledged", alert.Id, alert.State)
+	}
+
+	// Silenced alerts stay silenced and come back as ACKNOWLEDGED
+	if alert.State == pb.AlertState_FIRING {
+		alert.State = pb.AlertState_ACKNOWLEDGED
+	}
+	alert.AcknowledgedAt = timestamppb.Now()
+	alert.AcknowledgedBy = owner
+	alert.Comment = req.Comment
+	log.Printf("Acknowledged alert %s by %s", alert.Id, owner)
+
+	return &pb.AcknowledgeAlertResponse{
+		Alert:   proto.Clone(alert).(*pb.Alert),
+		Success: true,
+		Message: "Alert acknowledged",
+	}, nil
+}
+
+// allAlerts returns active alerts by start time followed by resolved alerts.
+// Callers must hold s.mu.
+func (s *server) allAlerts() []*pb.Alert {
+	active := make([]*pb.Alert, 0, len(s.alerts))
+	for _, alert := range s.alerts {
+		active = append(active, alert)
+	}
+	sort.Slice(active, func(i, j int) bool {
+		return active[i].StartedAt.AsTime().Before(active[j].StartedAt.AsTime())
+	})
+	return append(active, s.resolved...)
+}
+
+// callerOwner returns who is acting on alerts: the authenticated caller, or
+// the owner the request names when authentication is disabled.
+func callerOwner(ctx context.Context, requested string) (string, error) {
+	if p := auth.PrincipalFromContext(ctx); p != nil {
+		return p.Subject, nil
+	}
+	if requested == "" {
+		return "", status.Error(codes.InvalidArgument, "owner is required")
+	}
+	return requested, nil
+}
+
+// findActiveAlert looks up an active alert by ID among those the caller's
+// tenant may see. Callers must hold s.mu.
+func (s *server) findActiveAlert(ctx context.Context, id string) *pb.Alert {
+	for _, alert := range s.alerts {
+		if alert.Id == id && auth.CanAccessTenant(ctx, alert.Tenant) {
+			return alert
+		}
+	}
+	return nil
+}
+
+func main() {
+	cfg := defaultConfig()
+	config.Load("alerting", &cfg)
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+	drain := shutdown.New(cfg.ShutdownGracePeriod)
+
+	tracer, err := tracing.New("alerting", cfg.Tracing)
+	if err != nil {
+		log.Fatalf("Failed to set up tracing: %v", err)
+	}
+
+	tlsCerts, err := certs.New(cfg.TLS)
+	if err != nil {
+		log.Fatalf("Failed to load TLS certificates: %v", err)
+	}
+	go tlsCerts.Run(ctx)
+
+	authenticator, err := auth.New(cfg.Auth, auth.CommonPolicy.With(policy))
+	if err != nil {
+		log.Fatalf("Failed to set up authentication: %v", err)
+	}
+
+	dialOpts := append(interceptors.DialOptions(interceptors.Options{Tracer: tracer, Token: cfg.Auth.Token}), grpc.WithTransportCredentials(tlsCerts.Credentials()))
+
+	// Connect to Asset Registry
+	assetConn, err := cluster.Dial(cfg.AssetRegistryAddr, dialOpts...)
+	if err != nil {
+		log.Fatalf("Failed to connect to asset registry: %v", err)
+	}
+	defer assetConn.Close()
+
+	// Connect to Telemetry Service
+	telemetryConn, err := grpc.Dial(cfg.TelemetryAddr, dialOpts...)
+	if err != nil {
+		log.Fatalf("Failed to connect to telemetry service: %v", err)
+	}
+	defer telemetryConn.Close()
+
+	// Connect to Asset Monitoring Service
+	monitoringConn, err := grpc.Dial(cfg.AssetMonitoringAddr, dialOpts...)
+	if err != nil {
+		log.Fatalf("Failed to connect to asset monitoring service: %v", err)
+	}
+	defer monitoringConn.Close()
+
+	s := newServer(
+		assetpb.NewAssetRegistryClient(assetConn),
+		telemetrypb.NewTelemetryServiceClient(telemetryConn),
+		monitoringpb.NewAssetMonitoringServiceClient(monitoringConn),
+		notifiersFromConfig(cfg.Notifications)...,
+	)
+
+	checker := health.NewChecker(pb.AlertingService_ServiceDesc.ServiceName)
+	checker.AddLivenessCheck("alert-store", health.LockAcquirable(&s.mu))
+	checker.AddReadinessCheck("asset-registry", health.ConnReady(assetConn))
+	checker.AddReadinessCheck("telemetry", health.ConnReady(telemetryConn))
+	checker.AddReadinessCheck("asset-monitoring", health.ConnReady(monitoringConn))
+
+	// Collection and evaluation stop once RPCs have drained, so rules are not
+	// evaluated against a half-stopped service
+	background, stopBackground := context.WithCancel(context.Background())
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		s.collect(background, cfg.CollectionInterval)
+	}()
+	go func() {
+		defer wg.Done()
+		s.runEvaluation(background, cfg.EvaluationInterval)
+	}()
+	go checker.Run(ctx, health.DefaultInterval)
+
+	lis, err := net.Listen("tcp", cfg.ListenAddr)
+	if err != nil {
+		log.Fatalf("Failed to listen: %v", err)
+	}
+
+	grpcServer := grpc.NewServer(append(interceptors.ServerOptions(interceptors.Options{Registry: s.registry, Tracer: tracer, MaxTimeout: cfg.MaxRPCTimeout, Shutdown: drain, Auth: authenticator, TLS: tlsCerts}), grpc.Creds(tlsCerts.Credentials()))...)
+	pb.RegisterAlertingServiceServer(grpcServer, s)
+	metricspb.RegisterMetricsExporterServer(grpcServer, metrics.NewExporter(s.registry))
+	checker.Register(grpcServer)
+	reflection.Register(grpcServer)
+
+	metricsServer := metrics.Serve(cfg.MetricsAddr, s.registry)
+
+	drain.OnShutdown(checker.Shutdown)
+	drain.AddCleanup("rule evaluation", func(ctx context.Context) error {
+		stopBackground()
+		return shutdown.Wait(ctx, &wg)
+	})
+	drain.AddCleanup("metrics endpoint", metricsServer.Shutdown)
+	drain.AddCleanup("tracing", tracer.Shutdown)
+
+	log.Printf("Alerting Service listening on %s", cfg.ListenAddr)
+	if err := drain.Serve(ctx, grpcServer, lis); err != nil {
+		log.Fatalf("Failed to serve: %v", err)
+	}
+}
@@ -0,0 +1,451 @@
+package main
+
+import (
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	pb "github.com/sairamkiran9/asset-telemetry-monitor/gen/go/proto/alerting"
+	assetpb "github.com/sairamkiran9/asset-telemetry-monitor/gen/go/proto/asset"
+	monitoringpb "github.com/sairamkiran9/asset-telemetry-monitor/gen/go/proto/asset_monitoring"
+	telemetrypb "github.com/sairamkiran9/asset-telemetry-monitor/gen/go/proto/telemetry"
+	"github.com/sairamkiran9/asset-telemetry-monitor/internal/auth"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Mock asset client
+type mockAssetClient struct {
+	assets []*assetpb.Asset
+}
+
+func (m *mockAssetClient) RegisterAsset(ctx context.Context, req *assetpb.RegisterAssetRequest, opts ...grpc.CallOption) (*assetpb.RegisterAssetResponse, error) {
+	return nil, nil
+}
+
+func (m *mockAssetClient) GetAsset(ctx context.Context, req *assetpb.GetAssetRequest, opts ...grpc.CallOption) (*assetpb.GetAssetResponse, error) {
+	return nil, nil
+}
+
+func (m *mockAssetClient) ListAssets(ctx context.Context, req *assetpb.ListAssetsRequest, opts ...grpc.CallOption) (*assetpb.ListAssetsResponse, error) {
+	return &assetpb.ListAssetsResponse{Assets: m.assets}, nil
+}
+
+func (m *mockAssetClient) UpdateAsset(ctx context.Context, req *assetpb.UpdateAssetRequest, opts ...grpc.CallOption) (*assetpb.UpdateAssetResponse, error) {
+	return nil, nil
+}
+
+func (m *mockAssetClient) DeleteAsset(ctx context.Context, req *assetpb.DeleteAssetRequest, opts ...grpc.CallOption) (*assetpb.DeleteAssetResponse, error) {
+	return nil, nil
+}
+
+func (m *mockAssetClient) WatchAssets(ctx context.Context, req *assetpb.WatchAssetsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[assetpb.AssetEvent], error) {
+	return nil, status.Error(codes.Unimplemented, "not implemented")
+}
+
+func (m *mockAssetClient) SetParent(ctx context.Context, req *assetpb.SetParentRequest, opts ...grpc.CallOption) (*assetpb.SetParentResponse, error) {
+	return nil, nil
+}
+
+func (m *mockAssetClient) ConnectAssets(ctx context.Context, req *assetpb.ConnectAssetsRequest, opts ...grpc.CallOption) (*assetpb.ConnectAssetsResponse, error) {
+	return nil, nil
+}
+
+func (m *mockAssetClient) DisconnectAssets(ctx context.Context, req *assetpb.DisconnectAssetsRequest, opts ...grpc.CallOption) (*assetpb.DisconnectAssetsResponse, error) {
+	return nil, nil
+}
+
+func (m *mockAssetClient) GetSubtree(ctx context.Context, req *assetpb.GetSubtreeRequest, opts ...grpc.CallOption) (*assetpb.GetSubtreeResponse, error) {
+	return nil, nil
+}
+
+func (m *mockAssetClient) GetAncestors(ctx context.Context, req *assetpb.GetAncestorsRequest, opts ...grpc.CallOption) (*assetpb.GetAncestorsResponse, error) {
+	return nil, nil
+}
+
+func (m *mockAssetClient) GetConnected(ctx context.Context, req *assetpb.GetConnectedRequest, opts ...grpc.CallOption) (*assetpb.GetConnectedResponse, error) {
+	return nil, nil
+}
+
+func (m *mockAssetClient) ListAssetTypes(ctx context.Context, req *assetpb.ListAssetTypesRequest, opts ...grpc.CallOption) (*assetpb.ListAssetTypesResponse, error) {
+	return nil, nil
+}
+
+func (m *mockAssetClient) ImportAssets(ctx context.Context, req *assetpb.ImportAssetsRequest, opts ...grpc.CallOption) (*assetpb.ImportAssetsResponse, error) {
+	return nil, nil
+}
+
+func (m *mockAssetClient) ExportAssets(ctx context.Context, req *assetpb.ExportAssetsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[assetpb.Asset], error) {
+	return nil, status.Error(codes.Unimplemented, "not implemented")
+}
+
+// Mock telemetry client
+type mockTelemetryClient struct {
+	data map[string][]*telemetrypb.TelemetryData
+}
+
+func (m *mockTelemetryClient) SubmitTelemetry(ctx context.Context, req *telemetrypb.SubmitTelemetryRequest, opts ...grpc.CallOption) (*telemetrypb.SubmitTelemetryResponse, error) {
+	return nil, nil
+}
+
+func (m *mockTelemetryClient) GetTelemetryData(ctx context.Context, req *telemetrypb.GetTelemetryDataRequest, opts ...grpc.CallOption) (*telemetrypb.GetTelemetryDataResponse, error) {
+	return &telemetrypb.GetTelemetryDataResponse{Data: m.data[req.AssetId]}, nil
+}
+
+func (m *mockTelemetryClient) GetEnergyIntervals(ctx context.Context, req *telemetrypb.GetEnergyIntervalsRequest, opts ...grpc.CallOption) (*telemetrypb.GetEnergyIntervalsResponse, error) {
+	return nil, nil
+}
+
+// Mock asset monitoring client
+type mockMonitoringClient struct {
+	updates map[string][]*monitoringpb.AssetStatusUpdate
+}
+
+func (m *mockMonitoringClient) StreamAssetStatus(ctx context.Context, req *monitoringpb.StreamAssetStatusRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[monitoringpb.AssetStatusUpdate], error) {
+	return &mockStatusStream{updates: m.updates[req.AssetId]}, nil
+}
+
+func (m *mockMonitoringClient) SubscribeToReadings(ctx context.Context, req *monitoringpb.SubscribeRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[monitoringpb.ReadingUpdate], error) {
+	return nil, nil
+}
+
+func (m *mockMonitoringClient) GetCurrentStatus(ctx context.Context, req *monitoringpb.GetStatusRequest, opts ...grpc.CallOption) (*monitoringpb.AssetStatusResponse, error) {
+	return nil, nil
+}
+
+func (m *mockMonitoringClient) ListPowerQualityEvents(ctx context.Context, req *monitoringpb.ListPowerQualityEventsRequest, opts ...grpc.CallOption) (*monitoringpb.ListPowerQualityEventsResponse, error) {
+	return nil, nil
+}
+
+func (m *mockMonitoringClient) ScheduleMaintenance(ctx context.Context, req *monitoringpb.ScheduleMaintenanceRequest, opts ...grpc.CallOption) (*monitoringpb.ScheduleMaintenanceResponse, error) {
+	return nil, nil
+}
+
+func (m *mockMonitoringClient) ListMaintenanceWindows(ctx context.Context, req *monitoringpb.ListMaintenanceWindowsRequest, opts ...grpc.CallOption) (*monitoringpb.ListMaintenanceWindowsResponse, error) {
+	return nil, nil
+}
+
+func (m *mockMonitoringClient) CancelMaintenance(ctx context.Context, req *monitoringpb.CancelMaintenanceRequest, opts ...grpc.CallOption) (*monitoringpb.CancelMaintenanceResponse, error) {
+	return nil, nil
+}
+
+// Mock status stream that replays updates and then ends
+type mockStatusStream struct {
+	grpc.ClientStream
+	updates []*monitoringpb.AssetStatusUpdate
+}
+
+func (m *mockStatusStream) Recv() (*monitoringpb.AssetStatusUpdate, error) {
+	if len(m.updates) == 0 {
+		return nil, io.EOF
+	}
+	update := m.updates[0]
+	m.updates = m.updates[1:]
+	return update, nil
+}
+
+func newTestServer(notifiers ...notifier) *server {
+	return newServer(&mockAssetClient{}, &mockTelemetryClient{}, &mockMonitoringClient{}, notifiers...)
+}
+
+func TestCreateRule(t *testing.T) {
+	s := newTestServer()
+
+	resp, err := s.CreateRule(context.Background(), &pb.CreateRuleRequest{
+		Rule: &pb.AlertRule{
+			Name:       "High power",
+			Kind:       pb.RuleKind_METRIC_THRESHOLD,
+			MetricName: "power",
+			Comparison: pb.Comparison_GREATER_THAN,
+			Threshold:  900,
+		},
+	})
+	if err != nil {
+		t.Fatalf("CreateRule failed: %v", err)
+	}
+
+	if !resp.Success {
+		t.Errorf("Expected success=true, got %v", resp.Success)
+	}
+
+	if resp.Rule.Id == "" {
+		t.Error("Expected rule ID to be generated")
+	}
+
+	if resp.Rule.Severity != pb.Severity_WARNING {
+		t.Errorf("Expected default severity WARNING, got %v", resp.Rule.Severity)
+	}
+}
+
+func TestCreateRuleValidation(t *testing.T) {
+	s := newTestServer()
+
+	tests := []struct {
+		name string
+		rule *pb.AlertRule
+	}{
+		{"Missing rule", nil},
+		{"Missing name", &pb.AlertRule{Kind: pb.RuleKind_ASSET_STATUS, Status: "OFFLINE"}},
+		{"Missing kind", &pb.AlertRule{Name: "rule"}},
+		{"Missing metric", &pb.AlertRule{Name: "rule", Kind: pb.RuleKind_METRIC_THRESHOLD, Comparison: pb.Comparison_GREATER_THAN}},
+		{"Missing comparison", &pb.AlertRule{Name: "rule", Kind: pb.RuleKind_METRIC_THRESHOLD, MetricName: "power"}},
+		{"Unknown status", &pb.AlertRule{Name: "rule", Kind: pb.RuleKind_ASSET_STATUS, Status: "ON_FIRE"}},
+		{"Negative for", &pb.AlertRule{Name: "rule", Kind: pb.RuleKind_ASSET_STATUS, Status: "OFFLINE", ForSeconds: -1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := s.CreateRule(context.Background(), &pb.CreateRuleRequest{Rule: tt.rule})
+			if status.Code(err) != codes.InvalidArgument {
+				t.Errorf("Expected InvalidArgument error, got %v", err)
+			}
+		})
+	}
+}
+
+func TestListAndDeleteRules(t *testing.T) {
+	s := newTestServer()
+
+	for i := 0; i < 2; i++ {
+		s.CreateRule(context.Background(), &pb.CreateRuleRequest{
+			Rule: &pb.AlertRule{Name: "Offline", Kind: pb.RuleKind_ASSET_STATUS, Status: "OFFLINE"},
+		})
+	}
+
+	listResp, err := s.ListRules(context.Background(), &pb.ListRulesRequest{})
+	if err != nil {
+		t.Fatalf("ListRules failed: %v", err)
+	}
+	if len(listResp.Rules) != 2 {
+		t.Fatalf("Expected 2 rules, got %d", len(listResp.Rules))
+	}
+
+	if _, err := s.DeleteRule(context.Background(), &pb.DeleteRuleRequest{Id: listResp.Rules[0].Id}); err != nil {
+		t.Fatalf("DeleteRule failed: %v", err)
+	}
+
+	_, err = s.DeleteRule(context.Background(), &pb.DeleteRuleRequest{Id: listResp.Rules[0].Id})
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("Expected NotFound error, got %v", err)
+	}
+}
+
+func TestAcknowledgeAlert(t *testing.T) {
+	s := newTestServer()
+	now := time.Now()
+
+	s.CreateRule(context.Background(), &pb.CreateRuleRequest{
+		Rule: &pb.AlertRule{Name: "Offline", Kind: pb.RuleKind_ASSET_STATUS, Status: "OFFLINE"},
+	})
+	s.observeStatus("asset-1", "OFFLINE")
+	s.evaluate(now)
+
+	listResp, _ := s.ListAlerts(context.Background(), &pb.ListAlertsRequest{})
+	if len(listResp.Alerts) != 1 {
+		t.Fatalf("Expected 1 alert, got %d", len(listResp.Alerts))
+	}
+	alertID := listResp.Alerts[0].Id
+
+	ackResp, err := s.AcknowledgeAlert(context.Background(), &pb.AcknowledgeAlertRequest{AlertId: alertID, Owner: "alice", Comment: "Replacing the breaker"})
+	if err != nil {
+		t.Fatalf("AcknowledgeAlert failed: %v", err)
+	}
+	if ackResp.Alert.State != pb.AlertState_ACKNOWLEDGED {
+		t.Errorf("Expected ACKNOWLEDGED state, got %v", ackResp.Alert.State)
+	}
+	if ackResp.Alert.AcknowledgedBy != "alice" || ackResp.Alert.Comment != "Replacing the breaker" {
+		t.Errorf("Expected owner and comment to be recorded, got %q/%q", ackResp.Alert.AcknowledgedBy, ackResp.Alert.Comment)
+	}
+
+	_, err = s.AcknowledgeAlert(context.Background(), &pb.AcknowledgeAlertRequest{AlertId: alertID, Owner: "alice"})
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("Expected FailedPrecondition error, got %v", err)
+	}
+
+	_, err = s.AcknowledgeAlert(context.Background(), &pb.AcknowledgeAlertRequest{AlertId: "alert-404", Owner: "alice"})
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("Expected NotFound error, got %v", err)
+	}
+
+	_, err = s.AcknowledgeAlert(context.Background(), &pb.AcknowledgeAlertRequest{AlertId: alertID})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Expected InvalidArgument error without owner, got %v", err)
+	}
+}
+
+func TestListAlertsFilters(t *testing.T) {
+	s := newTestServer()
+	now := time.Now()
+
+	s.CreateRule(context.Background(), &pb.CreateRuleRequest{
+		Rule: &pb.AlertRule{Name: "Offline", Kind: pb.RuleKind_ASSET_STATUS, Status: "OFFLINE"},
+	})
+	s.observeStatus("asset-1", "OFFLINE")
+	s.observeStatus("asset-2", "OFFLINE")
+	s.evaluate(now)
+	s.observeStatus("asset-2", "ONLINE")
+	s.evaluate(now.Add(time.Second))
+
+	resp, _ := s.ListAlerts(context.Background(), &pb.ListAlertsRequest{AssetId: "asset-2"})
+	if len(resp.Alerts) != 1 || resp.Alerts[0].State != pb.AlertState_RESOLVED {
+		t.Errorf("Expected one resolved alert for asset-2, got %v", resp.Alerts)
+	}
+
+	resp, _ = s.ListAlerts(context.Background(), &pb.ListAlertsRequest{States: []pb.AlertState{pb.AlertState_FIRING}})
+	if len(resp.Alerts) != 1 || resp.Alerts[0].AssetId != "asset-1" {
+		t.Errorf("Expected one firing alert for asset-1, got %v", resp.Alerts)
+	}
+}
+
+func TestCollectOnce(t *testing.T) {
+	now := time.Now()
+	s := newServer(
+		&mockAssetClient{assets: []*assetpb.Asset{{Id: "asset-1"}}},
+		&mockTelemetryClient{data: map[string][]*telemetrypb.TelemetryData{
+			"asset-1": {
+				{AssetId: "asset-1", MetricName: "power", Value: 950, Timestamp: timestamppb.New(now)},
+				{AssetId: "asset-1", MetricName: "power", Value: 100, Timestamp: timestamppb.New(now.Add(-time.Minute))},
+			},
+		}},
+		&mockMonitoringClient{updates: map[string][]*monitoringpb.AssetStatusUpdate{
+			"asset-1": {{AssetId: "asset-1", Status: monitoringpb.AssetStatus_OFFLINE}},
+		}},
+	)
+
+	s.collectOnce(context.Background())
+
+	s.mu.RLock()
+	sample := s.metrics["asset-1"]["power"]
+	s.mu.RUnlock()
+	if sample.value != 950 {
+		t.Errorf("Expected latest power=950, got %f", sample.value)
+	}
+
+	deadline := time.Now().Add(time.Second)
+	for {
+		s.mu.RLock()
+		assetStatus := s.statuses["asset-1"]
+		s.mu.RUnlock()
+		if assetStatus == "OFFLINE" {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("Expected status OFFLINE from stream, got %q", assetStatus)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestTenantIsolation(t *testing.T) {
+	s := newTestServer()
+	now := time.Now()
+	north := auth.ContextWithTenant(context.Background(), "north-campus")
+	south := auth.ContextWithTenant(context.Background(), "south-campus")
+	s.observeTenant("chiller-1", "north-campus")
+	s.observeTenant("boiler-1", "south-campus")
+
+	rules := make(map[context.Context]*pb.AlertRule)
+	for _, ctx := range []context.Context{north, south} {
+		resp, err := s.CreateRule(ctx, &pb.CreateRuleRequest{
+			Rule: &pb.AlertRule{Name: "Offline", Kind: pb.RuleKind_ASSET_STATUS, Status: "OFFLINE"},
+		})
+		if err != nil {
+			t.Fatalf("CreateRule failed: %v", err)
+		}
+		rules[ctx] = resp.Rule
+	}
+	s.observeStatus("chiller-1", "OFFLINE")
+	s.observeStatus("boiler-1", "OFFLINE")
+	notifications := s.evaluate(now)
+
+	// Each rule only sees its own tenant's assets
+	if len(s.alerts) != 2 {
+		t.Errorf("Expected one alert per tenant, got %d", len(s.alerts))
+	}
+	if len(notifications) != 2 {
+		t.Errorf("Expected tenants' alerts to be notified apart, got %d notifications", len(notifications))
+	}
+	listResp, _ := s.ListAlerts(north, &pb.ListAlertsRequest{})
+	if len(listResp.Alerts) != 1 || listResp.Alerts[0].AssetId != "chiller-1" || listResp.Alerts[0].Tenant != "north-campus" {
+		t.Fatalf("Expected only north-campus's alert, got %v", listResp.Alerts)
+	}
+	southResp, _ := s.ListAlerts(south, &pb.ListAlertsRequest{})
+	if len(southResp.Alerts) != 1 {
+		t.Fatalf("Expected 1 alert for south-campus, got %d", len(southResp.Alerts))
+	}
+	southAlert := southResp.Alerts[0].Id
+
+	if rulesResp, _ := s.ListRules(north, &pb.ListRulesRequest{}); len(rulesResp.Rules) != 1 || rulesResp.Rules[0].Id != rules[north].Id {
+		t.Errorf("Expected only north-campus's rule, got %v", rulesResp.Rules)
+	}
+	if _, err := s.DeleteRule(north, &pb.DeleteRuleRequest{Id: rules[south].Id}); status.Code(err) != codes.NotFound {
+		t.Errorf("Expected NotFound deleting another tenant's rule, got %v", err)
+	}
+	if _, err := s.AcknowledgeAlert(north, &pb.AcknowledgeAlertRequest{AlertId: southAlert, Owner: "alice"}); status.Code(err) != codes.NotFound {
+		t.Errorf("Expected NotFound acknowledging another tenant's alert, got %v", err)
+	}
+	if _, err := s.CreateSilence(north, &pb.CreateSilenceRequest{AlertId: southAlert, Owner: "alice", DurationSeconds: 3600}); status.Code(err) != codes.NotFound {
+		t.Errorf("Expected NotFound silencing another tenant's alert, got %v", err)
+	}
+
+	// Matchers only silence the silencing tenant's alerts
+	silenceResp, err := s.CreateSilence(north, &pb.CreateSilenceRequest{Matchers: map[string]string{"alertname": "Offline"}, Owner: "alice", DurationSeconds: 3600})
+	if err != nil {
+		t.Fatalf("CreateSilence failed: %v", err)
+	}
+	s.evaluate(now.Add(time.Second))
+	for _, alert := range s.alerts {
+		if silenced := alert.State == pb.AlertState_SILENCED; silenced != (alert.Tenant == "north-campus") {
+			t.Errorf("Expected only north-campus's alert silenced, got %s %v", alert.Tenant, alert.State)
+		}
+	}
+	if resp, _ := s.ListSilences(south, &pb.ListSilencesRequest{}); len(resp.Silences) != 0 {
+		t.Errorf("Expected south-campus to see no silences, got %d", len(resp.Silences))
+	}
+	if _, err := s.ExpireSilence(south, &pb.ExpireSilenceRequest{Id: silenceResp.Silence.Id}); status.Code(err) != codes.NotFound {
+		t.Errorf("Expected NotFound expiring another tenant's silence, got %v", err)
+	}
+
+	service := auth.ContextWithTenant(context.Background(), auth.AnyTenant)
+	if resp, _ := s.ListAlerts(service, &pb.ListAlertsRequest{}); len(resp.Alerts) != 2 {
+		t.Errorf("Expected credentials for any tenant to see every alert, got %d", len(resp.Alerts))
+	}
+	if _, err := s.CreateRule(service, &pb.CreateRuleRequest{
+		Rule: &pb.AlertRule{Name: "Offline", Kind: pb.RuleKind_ASSET_STATUS, Status: "OFFLINE"},
+	}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Expected InvalidArgument creating a rule without a tenant, got %v", err)
+	}
+}
+
+func TestOwnerFromCredentials(t *testing.T) {
+	s := newTestServer()
+	s.CreateRule(context.Background(), &pb.CreateRuleRequest{
+		Rule: &pb.AlertRule{Name: "Offline", Kind: pb.RuleKind_ASSET_STATUS, Status: "OFFLINE"},
+	})
+	s.observeStatus("asset-1", "OFFLINE")
+	s.evaluate(time.Now())
+	listResp, _ := s.ListAlerts(context.Background(), &pb.ListAlertsRequest{})
+	if len(listResp.Alerts) != 1 {
+		t.Fatalf("Expected 1 alert, got %d", len(listResp.Alerts))
+	}
+
+	// The named owner is ignored, or may be left out, once callers authenticate
+	ctx := auth.ContextWithPrincipal(context.Background(), &auth.Principal{Subject: "alice", Tenant: auth.DefaultTenant})
+	silenceResp, err := s.CreateSilence(ctx, &pb.CreateSilenceRequest{AlertId: listResp.Alerts[0].Id, DurationSeconds: 3600})
+	if err != nil {
+		t.Fatalf("CreateSilence failed: %v", err)
+	}
+	if silenceResp.Silence.Owner != "alice" {
+		t.Errorf("Expected silence owner alice, got %q", silenceResp.Silence.Owner)
+	}
+	ackResp, err := s.AcknowledgeAlert(ctx, &pb.AcknowledgeAlertRequest{AlertId: listResp.Alerts[0].Id, Owner: "bob"})
+	if err != nil {
+		t.Fatalf("AcknowledgeAlert failed: %v", err)
+	}
+	if ackResp.Alert.AcknowledgedBy != "alice" {
+		t.Errorf("Expected acknowledgement by alice, got %q", ackResp.Alert.AcknowledgedBy)
+	}
+}
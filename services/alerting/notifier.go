@@ -0,0 +1,198 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"net/smtp"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/protobuf/encoding/protojson"
+
+	pb "github.com/sairamkiran9/asset-telemetry-monitor/gen/go/proto/alerting"
+)
+
+// notification is a batch of alerts sharing a group key. Status is "firing"
+// if any alert in the batch is still active, otherwise "resolved".
+type notification struct {
+	GroupKey string
+	Status   string
+	Alerts   []*pb.Alert
+}
+
+// MarshalJSON renders alerts with protojson so sinks see the API field names.
+func (n *notification) MarshalJSON() ([]byte, error) {
+	alerts := make([]json.RawMessage, 0, len(n.Alerts))
+	for _, alert := range n.Alerts {
+		data, err := protojson.Marshal(alert)
+		if err != nil {
+			return nil, err
+		}
+		alerts = append(alerts, data)
+	}
+	return json.Marshal(struct {
+		GroupKey string            `json:"group_key"`
+		Status   string            `json:"status"`
+		Alerts   []json.RawMessage `json:"alerts"`
+	}{n.GroupKey, n.Status, alerts})
+}
+
+// summary is a one-line description used as a mail subject.
+func (n *notification) summary() string {
+	return fmt.Sprintf("[%s:%d] %s", strings.ToUpper(n.Status), len(n.Alerts), n.GroupKey)
+}
+
+// notifier delivers notifications to an external sink.
+type notifier interface {
+	Name() string
+	Notify(ctx context.Context, n *notification) error
+}
+
+// dispatch sends every notification to every sink. Failures are logged and do
+// not stop delivery to the remaining sinks.
+func (s *server) dispatch(ctx context.Context, notifications []*notification) {
+	for _, n := range notifications {
+		for _, sink := range s.notifiers {
+			if err := sink.Notify(ctx, n); err != nil {
+				log.Printf("Failed to deliver notification %s via %s: %v", n.GroupKey, sink.Name(), err)
+			}
+		}
+	}
+}
+
+// webhookNotifier POSTs notifications as JSON.
+type webhookNotifier struct {
+	url    string
+	client *http.Client
+}
+
+func newWebhookNotifier(url string) *webhookNotifier {
+	return &webhookNotifier{
+		url:    url,
+		client: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+func (w *webhookNotifier) Name() string {
+	return "webhook"
+}
+
+func (w *webhookNotifier) Notify(ctx context.Context, n *notification) error {
+	body, err := json.Marshal(n)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook returned %s", resp.Status)
+	}
+	return nil
+}
+
+// smtpNotifier sends a plain text mail per notification.
+type smtpNotifier struct {
+	addr string
+	from string
+	to   []string
+	auth smtp.Auth
+}
+
+func newSMTPNotifier(addr, from string, to []string, username, password string) *smtpNotifier {
+	n := &smtpNotifier{addr: addr, from: from, to: to}
+	if username != "" {
+		host, _, _ := net.SplitHostPort(addr)
+		n.auth = smtp.PlainAuth("", username, password, host)
+	}
+	return n
+}
+
+func (m *smtpNotifier) Name() string {
+	return "smtp"
+}
+
+func (m *smtpNotifier) Notify(ctx context.Context, n *notification) error {
+	var body strings.Builder
+	fmt.Fprintf(&body, "From: %s\r\n", m.from)
+	fmt.Fprintf(&body, "To: %s\r\n", strings.Join(m.to, ", "))
+	fmt.Fprintf(&body, "Subject: %s\r\n", n.summary())
+	body.WriteString("Content-Type: text/plain; charset=utf-8\r\n\r\n")
+	for _, alert := range n.Alerts {
+		fmt.Fprintf(&body, "%s [%s] %s\r\n", alert.State, alert.Severity, alert.Message)
+	}
+
+	return smtp.SendMail(m.addr, m.auth, m.from, m.to, []byte(body.String()))
+}
+
+// fileNotifier appends notifications to a file as JSON lines.
+type fileNotifier struct {
+	mu   sync.Mutex
+	path string
+}
+
+func newFileNotifier(path string) *fileNotifier {
+	return &fileNotifier{path: path}
+}
+
+func (f *fileNotifier) Name() string {
+	return "file"
+}
+
+func (f *fileNotifier) Notify(ctx context.Context, n *notification) error {
+	line, err := json.Marshal(n)
+	if err != nil {
+		return err
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	file, err := os.OpenFile(f.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	_, err = file.Write(append(line, '\n'))
+	return err
+}
+
+// notifiersFromConfig builds the configured sinks: a webhook, SMTP email and
+// a JSON lines file.
+func notifiersFromConfig(cfg NotificationConfig) []notifier {
+	var notifiers []notifier
+
+	if cfg.WebhookURL != "" {
+		notifiers = append(notifiers, newWebhookNotifier(cfg.WebhookURL))
+	}
+	if cfg.SMTP.Addr != "" {
+		notifiers = append(notifiers, newSMTPNotifier(cfg.SMTP.Addr, cfg.SMTP.From, cfg.SMTP.To,
+			cfg.SMTP.Username, cfg.SMTP.Password))
+	}
+	if cfg.FilePath != "" {
+		notifiers = append(notifiers, newFileNotifier(cfg.FilePath))
+	}
+
+	for _, n := range notifiers {
+		log.Printf("Alert notifications enabled via %s", n.Name())
+	}
+	return notifiers
+}
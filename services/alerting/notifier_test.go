@@ -0,0 +1,170 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	pb "github.com/sairamkiran9/asset-telemetry-monitor/gen/go/proto/alerting"
+)
+
+func testNotification() *notification {
+	return &notification{
+		GroupKey: "alertname=Offline",
+		Status:   "firing",
+		Alerts: []*pb.Alert{
+			{Id: "alert-1", AssetId: "asset-1", State: pb.AlertState_FIRING, Severity: pb.Severity_CRITICAL, Message: "Asset asset-1 is OFFLINE"},
+		},
+	}
+}
+
+func TestWebhookNotifier(t *testing.T) {
+	var received map[string]interface{}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Content-Type") != "application/json" {
+			t.Errorf("Expected JSON content type, got %s", r.Header.Get("Content-Type"))
+		}
+		json.NewDecoder(r.Body).Decode(&received)
+	}))
+	defer ts.Close()
+
+	if err := newWebhookNotifier(ts.URL).Notify(context.Background(), testNotification()); err != nil {
+		t.Fatalf("Notify failed: %v", err)
+	}
+
+	if received["group_key"] != "alertname=Offline" {
+		t.Errorf("Expected group_key in payload, got %v", received)
+	}
+	alerts, _ := received["alerts"].([]interface{})
+	if len(alerts) != 1 || alerts[0].(map[string]interface{})["assetId"] != "asset-1" {
+		t.Errorf("Expected alert in payload, got %v", received["alerts"])
+	}
+}
+
+func TestWebhookNotifierErrorStatus(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "boom", http.StatusInternalServerError)
+	}))
+	defer ts.Close()
+
+	if err := newWebhookNotifier(ts.URL).Notify(context.Background(), testNotification()); err == nil {
+		t.Error("Expected error for non-2xx response")
+	}
+}
+
+// fakeSMTPServer accepts a single mail and returns its DATA section.
+func fakeSMTPServer(t *testing.T) (string, <-chan string) {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+	messages := make(chan string, 1)
+
+	go func() {
+		defer lis.Close()
+		conn, err := lis.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		reader := bufio.NewReader(conn)
+		io.WriteString(conn, "220 localhost ESMTP\r\n")
+		for {
+			line, err := reader.ReadString('\n')
+			if err != nil {
+				return
+			}
+			switch cmd := strings.ToUpper(strings.TrimSpace(line)); {
+			case strings.HasPrefix(cmd, "EHLO"), strings.HasPrefix(cmd, "HELO"):
+				io.WriteString(conn, "250 localhost\r\n")
+			case strings.HasPrefix(cmd, "DATA"):
+				io.WriteString(conn, "354 Go ahead\r\n")
+				var data strings.Builder
+				for {
+					dataLine, err := reader.ReadString('\n')
+					if err != nil || dataLine == ".\r\n" {
+						break
+					}
+					data.WriteString(dataLine)
+				}
+				messages <- data.String()
+				io.WriteString(conn, "250 OK\r\n")
+			case strings.HasPrefix(cmd, "QUIT"):
+				io.WriteString(conn, "221 Bye\r\n")
+				return
+			default:
+				io.WriteString(conn, "250 OK\r\n")
+			}
+		}
+	}()
+
+	return lis.Addr().String(), messages
+}
+
+func TestSMTPNotifier(t *testing.T) {
+	addr, messages := fakeSMTPServer(t)
+
+	n := newSMTPNotifier(addr, "alerts@example.com", []string{"ops@example.com"}, "", "")
+	if err := n.Notify(context.Background(), testNotification()); err != nil {
+		t.Fatalf("Notify failed: %v", err)
+	}
+
+	message := <-messages
+	if !strings.Contains(message, "Subject: [FIRING:1] alertname=Offline") {
+		t.Errorf("Expected subject in message, got %q", message)
+	}
+	if !strings.Contains(message, "Asset asset-1 is OFFLINE") {
+		t.Errorf("Expected alert message in body, got %q", message)
+	}
+}
+
+func TestFileNotifier(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "alerts.jsonl")
+	n := newFileNotifier(path)
+
+	for i := 0; i < 2; i++ {
+		if err := n.Notify(context.Background(), testNotification()); err != nil {
+			t.Fatalf("Notify failed: %v", err)
+		}
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read notifications file: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("Expected 2 JSON lines, got %d", len(lines))
+	}
+
+	var decoded map[string]interface{}
+	if err := json.Unmarshal([]byte(lines[0]), &decoded); err != nil {
+		t.Errorf("Expected valid JSON, got %v", err)
+	}
+}
+
+func TestNotifiersFromConfig(t *testing.T) {
+	notifiers := notifiersFromConfig(NotificationConfig{
+		WebhookURL: "http://localhost:9999/hook",
+		SMTP:       SMTPConfig{Addr: "localhost:2525"},
+		FilePath:   filepath.Join(t.TempDir(), "alerts.jsonl"),
+	})
+	if len(notifiers) != 3 {
+		t.Fatalf("Expected 3 notifiers, got %d", len(notifiers))
+	}
+
+	names := []string{notifiers[0].Name(), notifiers[1].Name(), notifiers[2].Name()}
+	if strings.Join(names, ",") != "webhook,smtp,file" {
+		t.Errorf("Unexpected notifiers %v", names)
+	}
+}
@@ -0,0 +1,19 @@
+package main
+
+import (
+	pb "github.com/sairamkiran9/asset-telemetry-monitor/gen/go/proto/alerting"
+	"github.com/sairamkiran9/asset-telemetry-monitor/internal/auth"
+)
+
+// policy lists the roles allowed to call each RPC of the alerting service.
+// None names an asset, so credentials scoped to assets can't call them.
+var policy = auth.Policy{
+	pb.AlertingService_CreateRule_FullMethodName:       {Roles: []auth.Role{auth.RoleOperator}},
+	pb.AlertingService_ListRules_FullMethodName:        {Roles: []auth.Role{auth.RoleViewer}},
+	pb.AlertingService_DeleteRule_FullMethodName:       {Roles: []auth.Role{auth.RoleOperator}},
+	pb.AlertingService_ListAlerts_FullMethodName:       {Roles: []auth.Role{auth.RoleViewer}},
+	pb.AlertingService_AcknowledgeAlert_FullMethodName: {Roles: []auth.Role{auth.RoleOperator}},
+	pb.AlertingService_CreateSilence_FullMethodName:    {Roles: []auth.Role{auth.RoleOperator}},
+	pb.AlertingService_ListSilences_FullMethodName:     {Roles: []auth.Role{auth.RoleViewer}},
+	pb.AlertingService_ExpireSilence_FullMethodName:    {Roles: []auth.Role{auth.RoleOperator}},
+}
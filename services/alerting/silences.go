@@ -0,0 +1,197 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	pb "github.com/sairamkiran9/asset-telemetry-monitor/gen/go/proto/alerting"
+	"github.com/sairamkiran9/asset-telemetry-monitor/internal/auth"
+)
+
+// maintenanceSilence is reported as the silencer of alerts on assets that
+// asset-monitoring reports as under maintenance.
+const maintenanceSilence = "maintenance"
+
+// silenceActiveAt reports whether the silence covers t.
+func silenceActiveAt(silence *pb.Silence, t time.Time) bool {
+	return !t.Before(silence.StartsAt.AsTime()) && t.Before(silence.EndsAt.AsTime())
+}
+
+// silenceMatches reports whether the silence targets the alert, either by
+// fingerprint or by all matchers equalling the alert's labels. Silences only
+// cover alerts of their own tenant.
+func silenceMatches(silence *pb.Silence, alert *pb.Alert) bool {
+	if ownerTenant(silence.Tenant) != ownerTenant(alert.Tenant) {
+		return false
+	}
+	if silence.Fingerprint != "" {
+		return silence.Fingerprint == alert.Fingerprint
+	}
+	for key, value := range silence.Matchers {
+		if alert.Labels[key] != value {
+			return false
+		}
+	}
+	return true
+}
+
+// silencedBy returns what silences an alert at now: a silence ID, the
+// maintenance marker, or "" if nothing does. Callers must hold s.mu.
+func (s *server) silencedBy(alert *pb.Alert, now time.Time) string {
+	if s.statuses[alert.AssetId] == "MAINTENANCE" {
+		return maintenanceSilence
+	}
+	for _, silence := range s.sortedSilences() {
+		if silenceActiveAt(silence, now) && silenceMatches(silence, alert) {
+			return silence.Id
+		}
+	}
+	return ""
+}
+
+// applySilences moves a firing or acknowledged alert to SILENCED while a
+// silence or maintenance covers it, and restores its previous state once it
+// no longer does. Callers must hold s.mu.
+func (s *server) applySilences(alert *pb.Alert, now time.Time) {
+	if alert.State == pb.AlertState_PENDING {
+		return
+	}
+
+	silencer := s.silencedBy(alert, now)
+	switch {
+	case silencer != "" && alert.State != pb.AlertState_SILENCED:
+		alert.State = pb.AlertState_SILENCED
+		// Forget the last notification so the alert is announced again
+		// when the silence ends
+		delete(s.lastNotified, alert.Fingerprint)
+		log.Printf("Alert %s silenced by %s", alert.Id, silencer)
+	case silencer == "" && alert.State == pb.AlertState_SILENCED:
+		alert.State = pb.AlertState_FIRING
+		if alert.AcknowledgedAt != nil {
+			alert.State = pb.AlertState_ACKNOWLEDGED
+		}
+		log.Printf("Alert %s no longer silenced", alert.Id)
+	}
+	alert.SilencedBy = silencer
+}
+
+// sortedSilences returns silences in ID order. Callers must hold s.mu.
+func (s *server) sortedSilences() []*pb.Silence {
+	silences := make([]*pb.Silence, 0, len(s.silences))
+	for _, silence := range s.silences {
+		silences = append(silences, silence)
+	}
+	sort.Slice(silences, func(i, j int) bool {
+		return silences[i].CreatedAt.AsTime().Before(silences[j].CreatedAt.AsTime()) ||
+			(silences[i].CreatedAt.AsTime().Equal(silences[j].CreatedAt.AsTime()) && silences[i].Id < silences[j].Id)
+	})
+	return silences
+}
+
+func (s *server) CreateSilence(ctx context.Context, req *pb.CreateSilenceRequest) (*pb.CreateSilenceResponse, error) {
+	owner, err := callerOwner(ctx, req.Owner)
+	if err != nil {
+		return nil, err
+	}
+	if req.AlertId == "" && len(req.Matchers) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "alert_id or matchers is required")
+	}
+	if req.DurationSeconds <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "duration_seconds must be positive")
+	}
+	tenant, err := auth.RequireTenant(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	startsAt := now
+	if req.StartsAt != nil {
+		startsAt = req.StartsAt.AsTime()
+	}
+
+	silence := &pb.Silence{
+		Matchers:  req.Matchers,
+		Owner:     owner,
+		Comment:   req.Comment,
+		StartsAt:  timestamppb.New(startsAt),
+		EndsAt:    timestamppb.New(startsAt.Add(time.Duration(req.DurationSeconds) * time.Second)),
+		CreatedAt: timestamppb.New(now),
+		Tenant:    tenant,
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if req.AlertId != "" {
+		alert := s.findActiveAlert(ctx, req.AlertId)
+		if alert == nil {
+			return nil, status.Errorf(codes.NotFound, "active alert %s not found", req.AlertId)
+		}
+		silence.Fingerprint = alert.Fingerprint
+		silence.Matchers = nil
+	}
+
+	s.silenceCounter++
+	silence.Id = fmt.Sprintf("silence-%d", s.silenceCounter)
+	s.silences[silence.Id] = silence
+	log.Printf("Created silence %s by %s until %v", silence.Id, silence.Owner, silence.EndsAt.AsTime())
+
+	return &pb.CreateSilenceResponse{
+		Silence: proto.Clone(silence).(*pb.Silence),
+		Success: true,
+		Message: "Silence created",
+	}, nil
+}
+
+func (s *server) ListSilences(ctx context.Context, req *pb.ListSilencesRequest) (*pb.ListSilencesResponse, error) {
+	now := time.Now()
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	silences := make([]*pb.Silence, 0, len(s.silences))
+	for _, silence := range s.sortedSilences() {
+		if !auth.CanAccessTenant(ctx, silence.Tenant) || (req.ActiveOnly && !silenceActiveAt(silence, now)) {
+			continue
+		}
+		silences = append(silences, proto.Clone(silence).(*pb.Silence))
+	}
+
+	return &pb.ListSilencesResponse{
+		Silences: silences,
+	}, nil
+}
+
+func (s *server) ExpireSilence(ctx context.Context, req *pb.ExpireSilenceRequest) (*pb.ExpireSilenceResponse, error) {
+	if req.Id == "" {
+		return nil, status.Error(codes.InvalidArgument, "silence ID is required")
+	}
+
+	now := time.Now()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	silence, exists := s.silences[req.Id]
+	if !exists || !auth.CanAccessTenant(ctx, silence.Tenant) {
+		return nil, status.Errorf(codes.NotFound, "silence %s not found", req.Id)
+	}
+	if silence.EndsAt.AsTime().After(now) {
+		silence.EndsAt = timestamppb.New(now)
+	}
+	log.Printf("Expired silence %s", req.Id)
+
+	return &pb.ExpireSilenceResponse{
+		Success: true,
+		Message: "Silence expired",
+	}, nil
+}
@@ -0,0 +1,172 @@
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	pb "github.com/sairamkiran9/asset-telemetry-monitor/gen/go/proto/alerting"
+)
+
+func TestSilenceSuppressesNotifications(t *testing.T) {
+	s := newTestServer()
+	now := time.Now()
+
+	createRule(t, s, &pb.AlertRule{Name: "Offline", Kind: pb.RuleKind_ASSET_STATUS, Status: "OFFLINE"})
+	s.observeStatus("asset-1", "OFFLINE")
+	s.evaluate(now)
+	alert := s.alerts["rule-1/asset-1"]
+
+	resp, err := s.CreateSilence(context.Background(), &pb.CreateSilenceRequest{
+		AlertId:         alert.Id,
+		Owner:           "alice",
+		Comment:         "Known outage",
+		StartsAt:        timestamppb.New(now),
+		DurationSeconds: 3600,
+	})
+	if err != nil {
+		t.Fatalf("CreateSilence failed: %v", err)
+	}
+	if resp.Silence.Fingerprint != alert.Fingerprint {
+		t.Errorf("Expected silence on fingerprint %s, got %q", alert.Fingerprint, resp.Silence.Fingerprint)
+	}
+
+	if notifications := s.evaluate(now.Add(time.Minute)); len(notifications) != 0 {
+		t.Errorf("Expected no notifications while silenced, got %d", len(notifications))
+	}
+	if alert.State != pb.AlertState_SILENCED || alert.SilencedBy != resp.Silence.Id {
+		t.Errorf("Expected SILENCED by %s, got %v by %q", resp.Silence.Id, alert.State, alert.SilencedBy)
+	}
+
+	notifications := s.evaluate(now.Add(time.Hour))
+	if alert.State != pb.AlertState_FIRING || alert.SilencedBy != "" {
+		t.Errorf("Expected FIRING after silence ends, got %v by %q", alert.State, alert.SilencedBy)
+	}
+	if len(notifications) != 1 {
+		t.Errorf("Expected a notification once the silence ends, got %d", len(notifications))
+	}
+}
+
+func TestSilenceByMatchers(t *testing.T) {
+	s := newTestServer()
+	now := time.Now()
+
+	createRule(t, s, &pb.AlertRule{Name: "Offline", Kind: pb.RuleKind_ASSET_STATUS, Status: "OFFLINE"})
+	s.CreateSilence(context.Background(), &pb.CreateSilenceRequest{
+		Matchers:        map[string]string{"asset_id": "asset-1"},
+		Owner:           "alice",
+		StartsAt:        timestamppb.New(now),
+		DurationSeconds: 3600,
+	})
+
+	s.observeStatus("asset-1", "OFFLINE")
+	s.observeStatus("asset-2", "OFFLINE")
+	notifications := s.evaluate(now)
+
+	if len(notifications) != 1 || len(notifications[0].Alerts) != 1 || notifications[0].Alerts[0].AssetId != "asset-2" {
+		t.Fatalf("Expected only asset-2 to notify, got %v", notifications)
+	}
+	if s.alerts["rule-1/asset-1"].State != pb.AlertState_SILENCED {
+		t.Errorf("Expected asset-1 alert to be SILENCED, got %v", s.alerts["rule-1/asset-1"].State)
+	}
+}
+
+func TestMaintenanceSilencesAlerts(t *testing.T) {
+	s := newTestServer()
+	now := time.Now()
+
+	createRule(t, s, &pb.AlertRule{
+		Name:       "High power",
+		Kind:       pb.RuleKind_METRIC_THRESHOLD,
+		MetricName: "power",
+		Comparison: pb.Comparison_GREATER_THAN,
+		Threshold:  900,
+	})
+	s.observeMetric("asset-1", "power", 950, now)
+	s.evaluate(now)
+
+	s.observeStatus("asset-1", "MAINTENANCE")
+	if notifications := s.evaluate(now.Add(repeatInterval)); len(notifications) != 0 {
+		t.Errorf("Expected no notifications during maintenance, got %d", len(notifications))
+	}
+	alert := s.alerts["rule-1/asset-1"]
+	if alert.State != pb.AlertState_SILENCED || alert.SilencedBy != maintenanceSilence {
+		t.Errorf("Expected SILENCED by maintenance, got %v by %q", alert.State, alert.SilencedBy)
+	}
+
+	// Alerts acknowledged during maintenance come back acknowledged
+	if _, err := s.AcknowledgeAlert(context.Background(), &pb.AcknowledgeAlertRequest{AlertId: alert.Id, Owner: "bob"}); err != nil {
+		t.Fatalf("AcknowledgeAlert failed: %v", err)
+	}
+	s.observeStatus("asset-1", "ONLINE")
+	if notifications := s.evaluate(now.Add(repeatInterval + time.Minute)); len(notifications) != 0 {
+		t.Errorf("Expected no notifications for an acknowledged alert, got %d", len(notifications))
+	}
+	if alert.State != pb.AlertState_ACKNOWLEDGED {
+		t.Errorf("Expected ACKNOWLEDGED after maintenance, got %v", alert.State)
+	}
+}
+
+func TestListAndExpireSilences(t *testing.T) {
+	s := newTestServer()
+
+	created, err := s.CreateSilence(context.Background(), &pb.CreateSilenceRequest{
+		Matchers:        map[string]string{"alertname": "Offline"},
+		Owner:           "alice",
+		DurationSeconds: 3600,
+	})
+	if err != nil {
+		t.Fatalf("CreateSilence failed: %v", err)
+	}
+
+	resp, _ := s.ListSilences(context.Background(), &pb.ListSilencesRequest{ActiveOnly: true})
+	if len(resp.Silences) != 1 {
+		t.Fatalf("Expected 1 active silence, got %d", len(resp.Silences))
+	}
+
+	if _, err := s.ExpireSilence(context.Background(), &pb.ExpireSilenceRequest{Id: created.Silence.Id}); err != nil {
+		t.Fatalf("ExpireSilence failed: %v", err)
+	}
+
+	resp, _ = s.ListSilences(context.Background(), &pb.ListSilencesRequest{ActiveOnly: true})
+	if len(resp.Silences) != 0 {
+		t.Errorf("Expected no active silences after expiry, got %d", len(resp.Silences))
+	}
+	resp, _ = s.ListSilences(context.Background(), &pb.ListSilencesRequest{})
+	if len(resp.Silences) != 1 {
+		t.Errorf("Expected expired silence to be listed, got %d", len(resp.Silences))
+	}
+
+	_, err = s.ExpireSilence(context.Background(), &pb.ExpireSilenceRequest{Id: "silence-404"})
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("Expected NotFound error, got %v", err)
+	}
+}
+
+func TestCreateSilenceValidation(t *testing.T) {
+	s := newTestServer()
+
+	tests := []struct {
+		name string
+		req  *pb.CreateSilenceRequest
+		code codes.Code
+	}{
+		{"Missing owner", &pb.CreateSilenceRequest{AlertId: "alert-1", DurationSeconds: 60}, codes.InvalidArgument},
+		{"Missing target", &pb.CreateSilenceRequest{Owner: "alice", DurationSeconds: 60}, codes.InvalidArgument},
+		{"Missing duration", &pb.CreateSilenceRequest{AlertId: "alert-1", Owner: "alice"}, codes.InvalidArgument},
+		{"Unknown alert", &pb.CreateSilenceRequest{AlertId: "alert-404", Owner: "alice", DurationSeconds: 60}, codes.NotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := s.CreateSilence(context.Background(), tt.req)
+			if status.Code(err) != tt.code {
+				t.Errorf("Expected %v error, got %v", tt.code, err)
+			}
+		})
+	}
+}
@@ -2,9 +2,11 @@ package main
 
 import (
 	"context"
+	"fmt"
 	"io"
 	"log"
 	"os"
+	"sync"
 	"testing"
 	"time"
 
@@ -144,6 +146,97 @@ func BenchmarkBroadcastUpdate(b *testing.B) {
 	}
 }
 
+// subscribeConsumers registers n subscribers to assetID, each drained by its
+// own goroutine, and returns a function that stops them.
+func subscribeConsumers(s *server, assetID string, n int) func() {
+	channels := make([]chan *pb.AssetStatusUpdate, n)
+	var wg sync.WaitGroup
+	for i := range channels {
+		ch := make(chan *pb.AssetStatusUpdate, 1000)
+		channels[i] = ch
+		s.registerUpdateChannel(assetID, ch)
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range ch {
+			}
+		}()
+	}
+	return func() {
+		for _, ch := range channels {
+			s.fanout.unsubscribe(assetID, ch)
+			close(ch)
+		}
+		wg.Wait()
+	}
+}
+
+// Benchmark one producer broadcasting to growing numbers of subscribers
+func BenchmarkBroadcastUpdateSubscribers(b *testing.B) {
+	log.SetOutput(io.Discard)
+	defer log.SetOutput(os.Stderr)
+
+	update := &pb.AssetStatusUpdate{
+		AssetId:   "asset-1",
+		Status:    pb.AssetStatus_ONLINE,
+		Timestamp: timestamppb.Now(),
+		Message:   "Benchmark update",
+	}
+	for _, n := range []int{1, 100, 10000} {
+		b.Run(fmt.Sprintf("subscribers=%d", n), func(b *testing.B) {
+			s := newServer(&mockAssetClient{assets: map[string]*assetpb.Asset{}}, &mockTelemetryClient{})
+			stop := subscribeConsumers(s, "asset-1", n)
+			defer stop()
+
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				s.broadcastUpdate("asset-1", update)
+			}
+			b.StopTimer()
+		})
+	}
+}
+
+// Benchmark broadcasting while subscribers of the same asset come and go,
+// which no longer blocks the producer
+func BenchmarkBroadcastUpdateWithChurn(b *testing.B) {
+	log.SetOutput(io.Discard)
+	defer log.SetOutput(os.Stderr)
+
+	s := newServer(&mockAssetClient{assets: map[string]*assetpb.Asset{}}, &mockTelemetryClient{})
+	stop := subscribeConsumers(s, "asset-1", 100)
+	defer stop()
+
+	done := make(chan struct{})
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for {
+			select {
+			case <-done:
+				return
+			default:
+			}
+			ch := make(chan *pb.AssetStatusUpdate, 10)
+			s.fanout.subscribe("asset-1", ch)
+			s.fanout.unsubscribe("asset-1", ch)
+		}
+	}()
+
+	update := &pb.AssetStatusUpdate{AssetId: "asset-1", Status: pb.AssetStatus_ONLINE}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		s.broadcastUpdate("asset-1", update)
+	}
+	b.StopTimer()
+
+	close(done)
+	wg.Wait()
+}
+
 // Benchmark channel registration/unregistration
 func BenchmarkRegisterUpdateChannel(b *testing.B) {
 	mockAsset := &mockAssetClient{assets: map[string]*assetpb.Asset{}}
@@ -195,7 +288,7 @@ func BenchmarkStartMonitoringConcurrent(b *testing.B) {
 	b.ResetTimer()
 	b.RunParallel(func(p *testing.PB) {
 		for p.Next() {
-			_ = s.startMonitoring(ctx, "asset-1", pb.AssetType_ELECTRIC)
+			_ = s.startMonitoring(ctx, "asset-1", "electric")
 		}
 	})
 }
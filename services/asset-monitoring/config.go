@@ -0,0 +1,47 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/sairamkiran9/asset-telemetry-monitor/internal/cluster"
+	"github.com/sairamkiran9/asset-telemetry-monitor/internal/config"
+)
+
+// Config holds the asset monitoring service's settings.
+type Config struct {
+	config.Common
+	AssetRegistryAddr  string         `config:"asset_registry_addr" env:"ASSET_REGISTRY_ADDR" usage:"asset registry address, or comma-separated addresses of its replicas"`
+	TelemetryAddr      string         `config:"telemetry_addr" env:"TELEMETRY_ADDR" usage:"telemetry service address"`
+	MonitoringInterval time.Duration  `config:"monitoring_interval" env:"MONITORING_INTERVAL" usage:"time between status updates; bare numbers are seconds"`
+	FanoutBuffer       int            `config:"fanout_buffer" env:"FANOUT_BUFFER" usage:"updates buffered per subscriber before they are dropped"`
+	AssetTypesFile     string         `config:"asset_types_file" env:"ASSET_TYPES_FILE" usage:"JSON file of asset types besides the built-in ones, whose reading channels monitors report; use the asset registry's file"`
+	Cluster            cluster.Config `config:"cluster"`
+}
+
+func defaultConfig() Config {
+	return Config{
+		Common:             config.DefaultCommon(":50054"),
+		AssetRegistryAddr:  "asset-registry:50051",
+		TelemetryAddr:      "telemetry:50052",
+		MonitoringInterval: defaultMonitoringInterval,
+		FanoutBuffer:       defaultFanoutBuffer,
+		Cluster:            cluster.DefaultConfig(),
+	}
+}
+
+func (c *Config) Validate() error {
+	var fanoutErr error
+	if c.FanoutBuffer < 1 {
+		fanoutErr = fmt.Errorf("fanout_buffer must be at least 1, got %d", c.FanoutBuffer)
+	}
+	return errors.Join(
+		c.Common.Validate(),
+		config.CheckAddrs("asset_registry_addr", c.AssetRegistryAddr),
+		config.CheckAddr("telemetry_addr", c.TelemetryAddr),
+		config.CheckPositive("monitoring_interval", c.MonitoringInterval),
+		fanoutErr,
+		c.Cluster.Validate(),
+	)
+}
@@ -0,0 +1,51 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sairamkiran9/asset-telemetry-monitor/internal/config"
+)
+
+func TestConfigFromComposeEnv(t *testing.T) {
+	env := map[string]string{
+		"ASSET_REGISTRY_ADDR": "registry.local:6001",
+		"TELEMETRY_ADDR":      "telemetry.local:6002",
+		"MONITORING_INTERVAL": "1",
+		"FANOUT_BUFFER":       "16",
+	}
+
+	cfg := defaultConfig()
+	err := config.Loader{Env: func(key string) (string, bool) {
+		v, ok := env[key]
+		return v, ok
+	}}.Load("asset-monitoring", &cfg)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+
+	if cfg.AssetRegistryAddr != "registry.local:6001" || cfg.TelemetryAddr != "telemetry.local:6002" {
+		t.Errorf("Expected upstream addresses from env, got %s and %s", cfg.AssetRegistryAddr, cfg.TelemetryAddr)
+	}
+	if cfg.MonitoringInterval != time.Second {
+		t.Errorf("Expected 1s interval, got %v", cfg.MonitoringInterval)
+	}
+	if cfg.FanoutBuffer != 16 {
+		t.Errorf("Expected fanout buffer 16, got %d", cfg.FanoutBuffer)
+	}
+}
+
+func TestConfigValidation(t *testing.T) {
+	cfg := defaultConfig()
+	err := config.Loader{Args: []string{"-fanout-buffer", "0", "-monitoring-interval", "0"}}.Load("asset-monitoring", &cfg)
+	if err == nil {
+		t.Fatal("Expected invalid config to be rejected")
+	}
+
+	for _, want := range []string{"fanout_buffer must be at least 1", "monitoring_interval must be positive"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("Expected %q in %v", want, err)
+		}
+	}
+}
@@ -0,0 +1,109 @@
+package main
+
+import (
+	"sync"
+	"sync/atomic"
+
+	pb "github.com/sairamkiran9/asset-telemetry-monitor/gen/go/proto/asset_monitoring"
+)
+
+// fanout tracks the update channels subscribed to each asset. Broadcasts read
+// an immutable snapshot of an asset's channels without taking any lock, so a
+// producer serving thousands of subscribers never waits on, or delays,
+// subscribers coming and going.
+type fanout struct {
+	mu     sync.Mutex // Serializes subscribe and unsubscribe
+	assets sync.Map   // Asset ID to *subscriberSet
+	total  atomic.Int64
+}
+
+type subscriberSet struct {
+	chans   atomic.Pointer[[]chan *pb.AssetStatusUpdate]
+	removed chan struct{} // Closed when the asset is deleted
+}
+
+// subscribe adds ch to the asset's channels and returns a channel closed if
+// the asset is deleted. New channels are appended past the end of the current
+// snapshot, which its readers never look at, so the backing array is only
+// copied when it's full.
+func (f *fanout) subscribe(assetID string, ch chan *pb.AssetStatusUpdate) <-chan struct{} {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	set, _ := f.assets.Load(assetID)
+	if set == nil {
+		set = &subscriberSet{removed: make(chan struct{})}
+		f.assets.Store(assetID, set)
+	}
+	var chans []chan *pb.AssetStatusUpdate
+	if current := set.(*subscriberSet).chans.Load(); current != nil {
+		chans = *current
+	}
+	chans = append(chans, ch)
+	set.(*subscriberSet).chans.Store(&chans)
+	f.total.Add(1)
+	return set.(*subscriberSet).removed
+}
+
+// unsubscribe removes ch from the asset's channels and returns how many
+// remain. Broadcasts still holding the previous snapshot may send to ch
+// afterwards, so it's never closed here.
+func (f *fanout) unsubscribe(assetID string, ch chan *pb.AssetStatusUpdate) int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	set, _ := f.assets.Load(assetID)
+	if set == nil {
+		return 0
+	}
+	current := *set.(*subscriberSet).chans.Load()
+	for i, c := range current {
+		if c != ch {
+			continue
+		}
+		// Copy rather than shift in place, which readers of the current
+		// snapshot would see
+		chans := make([]chan *pb.AssetStatusUpdate, 0, len(current)-1)
+		chans = append(append(chans, current[:i]...), current[i+1:]...)
+		set.(*subscriberSet).chans.Store(&chans)
+		f.total.Add(-1)
+		current = chans
+		break
+	}
+	if len(current) == 0 {
+		f.assets.Delete(assetID)
+	}
+	return len(current)
+}
+
+// remove drops the asset's channels and tells their subscribers the asset was
+// deleted. Their later unsubscribe calls find nothing to remove.
+func (f *fanout) remove(assetID string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if set, _ := f.assets.LoadAndDelete(assetID); set != nil {
+		close(set.(*subscriberSet).removed)
+		if chans := set.(*subscriberSet).chans.Load(); chans != nil {
+			f.total.Add(-int64(len(*chans)))
+		}
+	}
+}
+
+// snapshot returns the asset's channels. The slice must not be modified.
+func (f *fanout) snapshot(assetID string) []chan *pb.AssetStatusUpdate {
+	set, _ := f.assets.Load(assetID)
+	if set == nil {
+		return nil
+	}
+	// Nil while the asset's first subscriber is being added
+	if chans := set.(*subscriberSet).chans.Load(); chans != nil {
+		return *chans
+	}
+	return nil
+}
+
+// subscribers returns the number of subscribed channels across all assets.
+func (f *fanout) subscribers() int {
+	return int(f.total.Load())
+}
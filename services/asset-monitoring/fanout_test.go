@@ -0,0 +1,93 @@
+package main
+
+import (
+	"sync"
+	"testing"
+
+	pb "github.com/sairamkiran9/asset-telemetry-monitor/gen/go/proto/asset_monitoring"
+)
+
+func TestFanoutSnapshotsAreImmutable(t *testing.T) {
+	var f fanout
+	ch1 := make(chan *pb.AssetStatusUpdate)
+	ch2 := make(chan *pb.AssetStatusUpdate)
+	ch3 := make(chan *pb.AssetStatusUpdate)
+
+	f.subscribe("asset-1", ch1)
+	f.subscribe("asset-1", ch2)
+	before := f.snapshot("asset-1")
+
+	f.subscribe("asset-1", ch3)
+	f.unsubscribe("asset-1", ch1)
+
+	if len(before) != 2 || before[0] != ch1 || before[1] != ch2 {
+		t.Errorf("Expected earlier snapshot to keep ch1 and ch2, got %v", before)
+	}
+	after := f.snapshot("asset-1")
+	if len(after) != 2 || after[0] != ch2 || after[1] != ch3 {
+		t.Errorf("Expected ch2 and ch3 after changes, got %v", after)
+	}
+	if f.subscribers() != 2 {
+		t.Errorf("Expected 2 subscribers, got %d", f.subscribers())
+	}
+}
+
+func TestFanoutUnsubscribeLast(t *testing.T) {
+	var f fanout
+	ch := make(chan *pb.AssetStatusUpdate)
+
+	f.subscribe("asset-1", ch)
+	if remaining := f.unsubscribe("asset-1", ch); remaining != 0 {
+		t.Errorf("Expected no remaining subscribers, got %d", remaining)
+	}
+	if remaining := f.unsubscribe("asset-1", ch); remaining != 0 {
+		t.Errorf("Expected unsubscribing twice to be harmless, got %d", remaining)
+	}
+	if snapshot := f.snapshot("asset-1"); snapshot != nil {
+		t.Errorf("Expected no channels, got %v", snapshot)
+	}
+	if f.subscribers() != 0 {
+		t.Errorf("Expected 0 subscribers, got %d", f.subscribers())
+	}
+}
+
+func TestFanoutConcurrentBroadcasts(t *testing.T) {
+	var f fanout
+	var wg sync.WaitGroup
+	done := make(chan struct{})
+
+	// Readers sending to every channel of their snapshot while others change
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-done:
+					return
+				default:
+				}
+				for _, ch := range f.snapshot("asset-1") {
+					select {
+					case ch <- &pb.AssetStatusUpdate{}:
+					default:
+					}
+				}
+			}
+		}()
+	}
+
+	for i := 0; i < 1000; i++ {
+		ch := make(chan *pb.AssetStatusUpdate, 1)
+		f.subscribe("asset-1", ch)
+		if i%2 == 0 {
+			f.unsubscribe("asset-1", ch)
+		}
+	}
+	close(done)
+	wg.Wait()
+
+	if f.subscribers() != 500 || len(f.snapshot("asset-1")) != 500 {
+		t.Errorf("Expected 500 subscribers, got %d (%d in snapshot)", f.subscribers(), len(f.snapshot("asset-1")))
+	}
+}
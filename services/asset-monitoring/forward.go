@@ -0,0 +1,103 @@
+package main
+
+import (
+	"context"
+	"io"
+	"log"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/sairamkiran9/asset-telemetry-monitor/gen/go/proto/asset_monitoring"
+	"github.com/sairamkiran9/asset-telemetry-monitor/internal/cluster"
+)
+
+// forwardRetryDelay is how long a stream whose owner became unreachable waits
+// for the cluster to notice before trying the owner again.
+const forwardRetryDelay = time.Second
+
+// streamFromOwner serves a stream from the instance owning the asset, here or
+// by forwarding it, so only the owner runs the asset's monitor. The stream
+// moves whenever the asset changes owner as instances join or leave.
+func (s *server) streamFromOwner(ctx context.Context, req *pb.StreamAssetStatusRequest, stream pb.AssetMonitoringService_StreamAssetStatusServer) error {
+	for {
+		changed := s.cluster.Changed()
+		owner, local := s.cluster.Owner(req.AssetId)
+
+		routeCtx, cancel := context.WithCancel(ctx)
+		go func() {
+			s.awaitNewOwner(routeCtx, req.AssetId, owner)
+			cancel()
+		}()
+		var err error
+		if local {
+			err = s.streamLocal(routeCtx, req, stream)
+		} else {
+			err = s.forwardAssetStatus(routeCtx, owner, req, stream)
+		}
+		moved := routeCtx.Err() != nil
+		cancel()
+
+		switch {
+		case ctx.Err() != nil:
+			return err
+		case moved:
+			s.movedStreams.Inc()
+			log.Printf("Moving stream for asset %s: owner was %s", req.AssetId, owner)
+		case !local && status.Code(err) == codes.Unavailable:
+			// Wait for the cluster to drop the owner, or retry it
+			log.Printf("Owner %s of asset %s unavailable: %v", owner, req.AssetId, err)
+			select {
+			case <-ctx.Done():
+				return nil
+			case <-changed:
+			case <-time.After(forwardRetryDelay):
+			}
+		default:
+			return err
+		}
+	}
+}
+
+// awaitNewOwner returns once the asset is owned by someone other than owner
+// or ctx is done.
+func (s *server) awaitNewOwner(ctx context.Context, assetID, owner string) {
+	for {
+		changed := s.cluster.Changed()
+		if current, _ := s.cluster.Owner(assetID); current != owner {
+			return
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-changed:
+		}
+	}
+}
+
+// forwardAssetStatus relays the owner's stream of the asset until ctx is done
+// or the owner ends it.
+func (s *server) forwardAssetStatus(ctx context.Context, owner string, req *pb.StreamAssetStatusRequest, stream pb.AssetMonitoringService_StreamAssetStatusServer) error {
+	client := pb.NewAssetMonitoringServiceClient(s.cluster.Conn(owner))
+	upstream, err := client.StreamAssetStatus(cluster.ForwardContext(ctx, s.cluster.Self()), req)
+	if err != nil {
+		return err
+	}
+	s.forwardedStreams.Inc()
+	defer s.forwardedStreams.Dec()
+
+	for {
+		update, err := upstream.Recv()
+		if err == io.EOF {
+			// Owners only end forwarded streams early when they stop
+			return status.Errorf(codes.Unavailable, "owner %s ended the stream", owner)
+		}
+		if err != nil {
+			return err
+		}
+		if err := stream.Send(update); err != nil {
+			return err
+		}
+	}
+}
@@ -0,0 +1,391 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	assetpb "github.com/sairamkiran9/asset-telemetry-monitor/gen/go/proto/asset"
+	pb "github.com/sairamkiran9/asset-telemetry-monitor/gen/go/proto/asset_monitoring"
+	"github.com/sairamkiran9/asset-telemetry-monitor/internal/auth"
+	"github.com/sairamkiran9/asset-telemetry-monitor/internal/cluster"
+	"github.com/sairamkiran9/asset-telemetry-monitor/internal/health"
+	"github.com/sairamkiran9/asset-telemetry-monitor/internal/interceptors"
+)
+
+// testInstance is an asset monitoring instance serving on localhost.
+type testInstance struct {
+	*server
+	addr    string
+	grpc    *grpc.Server
+	checker *health.Checker
+}
+
+func startInstances(t *testing.T, n int, assets *mockAssetClient) []*testInstance {
+	t.Helper()
+	instances := make([]*testInstance, n)
+	addrs := make([]string, n)
+	for i := range instances {
+		lis, err := net.Listen("tcp", "127.0.0.1:0")
+		if err != nil {
+			t.Fatalf("Failed to listen: %v", err)
+		}
+		s := newServer(assets, &mockTelemetryClient{})
+		s.monitoringInterval = 10 * time.Millisecond
+
+		checker := health.NewChecker(pb.AssetMonitoringService_ServiceDesc.ServiceName)
+		checker.Update(context.Background())
+		srv := grpc.NewServer()
+		pb.RegisterAssetMonitoringServiceServer(srv, s)
+		checker.Register(srv)
+		go srv.Serve(lis)
+		t.Cleanup(srv.Stop)
+
+		instances[i] = &testInstance{server: s, addr: lis.Addr().String(), grpc: srv, checker: checker}
+		addrs[i] = instances[i].addr
+	}
+
+	for _, inst := range instances {
+		c, err := cluster.New(cluster.Config{Self: inst.addr, Peers: addrs, ProbeInterval: cluster.DefaultProbeInterval},
+			pb.AssetMonitoringService_ServiceDesc.ServiceName, grpc.WithTransportCredentials(insecure.NewCredentials()))
+		if err != nil {
+			t.Fatalf("Failed to create cluster: %v", err)
+		}
+		t.Cleanup(func() { c.Close() })
+		inst.cluster = c
+	}
+	for _, inst := range instances {
+		inst.cluster.Update(context.Background())
+	}
+	return instances
+}
+
+func (inst *testInstance) monitoring(assetID string) bool {
+	inst.mu.RLock()
+	defer inst.mu.RUnlock()
+	_, exists := inst.monitors[assetID]
+	return exists
+}
+
+func TestStreamAssetStatusForwardsToOwner(t *testing.T) {
+	assets := &mockAssetClient{assets: map[string]*assetpb.Asset{}}
+	for i := 0; i < 50; i++ {
+		id := fmt.Sprintf("asset-%d", i)
+		assets.assets[id] = &assetpb.Asset{Id: id, Name: "Test Asset", Type: "electric"}
+	}
+	instances := startInstances(t, 3, assets)
+	entry, owner := instances[0], instances[1]
+
+	// Pick an asset the second instance owns
+	assetID := ""
+	for id := range assets.assets {
+		if addr, _ := entry.cluster.Owner(id); addr == owner.addr {
+			assetID = id
+			break
+		}
+	}
+	if assetID == "" {
+		t.Fatal("Expected the second instance to own some asset")
+	}
+
+	conn, err := grpc.NewClient(entry.addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("Failed to dial: %v", err)
+	}
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	stream, err := pb.NewAssetMonitoringServiceClient(conn).StreamAssetStatus(ctx, &pb.StreamAssetStatusRequest{AssetId: assetID})
+	if err != nil {
+		t.Fatalf("Failed to open stream: %v", err)
+	}
+	if update, err := stream.Recv(); err != nil || update.AssetId != assetID {
+		t.Fatalf("Expected an update for %s, got %v, %v", assetID, update, err)
+	}
+
+	if !owner.monitoring(assetID) {
+		t.Error("Expected the owner to monitor the asset")
+	}
+	if entry.monitoring(assetID) || instances[2].monitoring(assetID) {
+		t.Error("Expected only the owner to monitor the asset")
+	}
+	if entry.forwardedStreams.Value() != 1 {
+		t.Errorf("Expected 1 forwarded stream, got %v", entry.forwardedStreams.Value())
+	}
+
+	// The owner leaves; the stream carries on from the asset's new owner
+	owner.checker.Shutdown()
+	owner.grpc.Stop()
+	for _, inst := range []*testInstance{entry, instances[2]} {
+		inst.cluster.Update(context.Background())
+	}
+	newOwnerAddr, _ := entry.cluster.Owner(assetID)
+	if newOwnerAddr == owner.addr {
+		t.Fatal("Expected the asset to move off the stopped instance")
+	}
+
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		if _, err := stream.Recv(); err != nil {
+			t.Fatalf("Expected the stream to survive the owner leaving, got %v", err)
+		}
+		newOwner := entry
+		if newOwnerAddr == instances[2].addr {
+			newOwner = instances[2]
+		}
+		if newOwner.monitoring(assetID) {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("Expected the new owner to monitor the asset")
+		}
+	}
+}
+
+func TestStreamAssetStatusServesForwardedLocally(t *testing.T) {
+	assets := &mockAssetClient{assets: map[string]*assetpb.Asset{
+		"asset-1": {Id: "asset-1", Name: "Test Asset", Type: "electric"},
+	}}
+	instances := startInstances(t, 2, assets)
+
+	// Whichever instance doesn't own the asset serves a forwarded stream itself
+	other := instances[0]
+	if _, local := other.cluster.Owner("asset-1"); local {
+		other = instances[1]
+	}
+
+	conn, err := grpc.NewClient(other.addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("Failed to dial: %v", err)
+	}
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	ctx = cluster.ForwardContext(ctx, "127.0.0.1:1")
+	stream, err := pb.NewAssetMonitoringServiceClient(conn).StreamAssetStatus(ctx, &pb.StreamAssetStatusRequest{AssetId: "asset-1"})
+	if err != nil {
+		t.Fatalf("Failed to open stream: %v", err)
+	}
+	if _, err := stream.Recv(); err != nil {
+		t.Fatalf("Expected an update, got %v", err)
+	}
+	if !other.monitoring("asset-1") {
+		t.Error("Expected a forwarded stream to be served where it arrived")
+	}
+}
+
+func TestMaintenanceAppliesAcrossInstances(t *testing.T) {
+	assets := &mockAssetClient{assets: map[string]*assetpb.Asset{}}
+	for i := 0; i < 50; i++ {
+		id := fmt.Sprintf("asset-%d", i)
+		assets.assets[id] = &assetpb.Asset{Id: id, Name: "Test Asset", Type: "electric", Metadata: map[string]string{"building": "plant-1"}}
+	}
+	instances := startInstances(t, 3, assets)
+	byAddr := make(map[string]*testInstance)
+	for _, inst := range instances {
+		byAddr[inst.addr] = inst
+	}
+
+	// Schedule on the instance that neither numbers windows nor owns the asset
+	coordinator, _ := instances[0].cluster.Owner(maintenanceKey)
+	var assetID string
+	var owner, entry *testInstance
+	for id := range assets.assets {
+		if addr, _ := instances[0].cluster.Owner(id); addr != coordinator {
+			assetID, owner = id, byAddr[addr]
+			break
+		}
+	}
+	if owner == nil {
+		t.Fatal("Expected an asset owned by another instance than the coordinator")
+	}
+	for _, inst := range instances {
+		if inst.addr != coordinator && inst != owner {
+			entry = inst
+		}
+	}
+
+	clients := make(map[*testInstance]pb.AssetMonitoringServiceClient)
+	for _, inst := range instances {
+		conn, err := grpc.NewClient(inst.addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+		if err != nil {
+			t.Fatalf("Failed to dial: %v", err)
+		}
+		defer conn.Close()
+		clients[inst] = pb.NewAssetMonitoringServiceClient(conn)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	resp, err := clients[entry].ScheduleMaintenance(ctx, &pb.ScheduleMaintenanceRequest{Window: &pb.MaintenanceWindow{
+		Selector:        map[string]string{"building": "plant-1"},
+		StartTime:       timestamppb.New(time.Now().Add(-time.Minute)),
+		DurationSeconds: 3600,
+	}})
+	if err != nil {
+		t.Fatalf("ScheduleMaintenance failed: %v", err)
+	}
+	for _, inst := range instances {
+		list, err := clients[inst].ListMaintenanceWindows(ctx, &pb.ListMaintenanceWindowsRequest{})
+		if err != nil || len(list.Windows) != 1 || list.Windows[0].Id != resp.Window.Id {
+			t.Errorf("Expected %s to list window %s, got %v, %v", inst.addr, resp.Window.Id, list, err)
+		}
+	}
+
+	stream, err := clients[entry].StreamAssetStatus(ctx, &pb.StreamAssetStatusRequest{AssetId: assetID})
+	if err != nil {
+		t.Fatalf("Failed to open stream: %v", err)
+	}
+	if update, err := stream.Recv(); err != nil || update.Status != pb.AssetStatus_MAINTENANCE {
+		t.Fatalf("Expected the forwarded stream to report MAINTENANCE, got %v, %v", update, err)
+	}
+	if !owner.monitoring(assetID) {
+		t.Error("Expected the owner to monitor the asset")
+	}
+
+	// Cancelling on any instance ends the window everywhere
+	if _, err := clients[owner].CancelMaintenance(ctx, &pb.CancelMaintenanceRequest{Id: resp.Window.Id}); err != nil {
+		t.Fatalf("CancelMaintenance failed: %v", err)
+	}
+	for _, inst := range instances {
+		if list, err := clients[inst].ListMaintenanceWindows(ctx, &pb.ListMaintenanceWindowsRequest{}); err != nil || len(list.Windows) != 0 {
+			t.Errorf("Expected %s to list no windows, got %v, %v", inst.addr, list, err)
+		}
+	}
+	for {
+		update, err := stream.Recv()
+		if err != nil {
+			t.Fatalf("Expected the stream to leave MAINTENANCE, got %v", err)
+		}
+		if update.Status != pb.AssetStatus_MAINTENANCE {
+			break
+		}
+	}
+}
+
+func TestMaintenanceCopiedToRejoiningInstance(t *testing.T) {
+	instances := startInstances(t, 2, &mockAssetClient{})
+	a, b := instances[0], instances[1]
+
+	// Windows a kept while b was away, one of which b cancelled meanwhile
+	for _, id := range []string{"maintenance-7", "maintenance-8"} {
+		a.maintenance[id] = &maintenanceWindow{
+			window:   &pb.MaintenanceWindow{Id: id, AssetId: "asset-1", StartTime: timestamppb.Now(), DurationSeconds: 60, CreatedAt: timestamppb.Now()},
+			location: time.UTC,
+			tenant:   auth.DefaultTenant,
+		}
+	}
+	b.cancelledMaintenance["maintenance-8"] = true
+
+	a.copyMaintenanceTo(context.Background(), b.addr)
+
+	b.maintenanceMu.RLock()
+	_, copied := b.maintenance["maintenance-7"]
+	_, revived := b.maintenance["maintenance-8"]
+	counter := b.maintenanceCounter
+	b.maintenanceMu.RUnlock()
+	if !copied || revived {
+		t.Errorf("Expected b to take maintenance-7 but not maintenance-8, got %t, %t", copied, revived)
+	}
+	if counter != 7 {
+		t.Errorf("Expected b to number windows after maintenance-7, got counter %d", counter)
+	}
+
+	a.maintenanceMu.RLock()
+	_, kept := a.maintenance["maintenance-8"]
+	a.maintenanceMu.RUnlock()
+	if kept {
+		t.Error("Expected a to drop the window b cancelled")
+	}
+}
+
+func TestForgedForwardingMarkerCannotCancel(t *testing.T) {
+	keys := filepath.Join(t.TempDir(), "keys.yaml")
+	if err := os.WriteFile(keys, []byte(`
+keys:
+  - subject: north-ops
+    key: north-key
+    roles: [operator]
+    tenant: north-campus
+  - subject: south-ops
+    key: south-key
+    roles: [operator]
+    tenant: south-campus
+`), 0600); err != nil {
+		t.Fatalf("Failed to write keys: %v", err)
+	}
+	authenticator, err := auth.New(auth.Config{APIKeysFile: keys}, auth.CommonPolicy.With(policy))
+	if err != nil {
+		t.Fatalf("Failed to set up authentication: %v", err)
+	}
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+	s := newMaintenanceTestServer()
+	srv := grpc.NewServer(interceptors.ServerOptions(interceptors.Options{Auth: authenticator})...)
+	pb.RegisterAssetMonitoringServiceServer(srv, s)
+	go srv.Serve(lis)
+	defer srv.Stop()
+
+	conn, err := grpc.NewClient(lis.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("Failed to dial: %v", err)
+	}
+	defer conn.Close()
+	client := pb.NewAssetMonitoringServiceClient(conn)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	north := metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer north-key")
+	resp, err := client.ScheduleMaintenance(north, &pb.ScheduleMaintenanceRequest{Window: &pb.MaintenanceWindow{
+		AssetId: "chiller-1", StartTime: timestamppb.Now(), DurationSeconds: 3600,
+	}})
+	if err != nil {
+		t.Fatalf("ScheduleMaintenance failed: %v", err)
+	}
+
+	// South claims to be a peer to cancel north's window and one yet to come
+	south := cluster.ForwardContext(metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer south-key"), "10.0.0.9:50053")
+	for _, id := range []string{resp.Window.Id, "maintenance-99"} {
+		if _, err := client.CancelMaintenance(south, &pb.CancelMaintenanceRequest{Id: id}); status.Code(err) != codes.NotFound {
+			t.Errorf("Expected NotFound cancelling %s, got %v", id, err)
+		}
+	}
+
+	s.maintenanceMu.RLock()
+	_, kept := s.maintenance[resp.Window.Id]
+	tombstones := len(s.cancelledMaintenance)
+	s.maintenanceMu.RUnlock()
+	if !kept {
+		t.Error("Expected north's window to survive")
+	}
+	if tombstones != 0 {
+		t.Errorf("Expected no windows recorded as cancelled, got %d", tombstones)
+	}
+
+	// Nor can south plant a window under an ID of its choosing
+	planted, err := client.ScheduleMaintenance(south, &pb.ScheduleMaintenanceRequest{Window: &pb.MaintenanceWindow{
+		Id: "maintenance-99", AssetId: "boiler-1", StartTime: timestamppb.Now(), DurationSeconds: 3600,
+	}})
+	if err != nil {
+		t.Fatalf("ScheduleMaintenance failed: %v", err)
+	}
+	if planted.Window.Id == "maintenance-99" {
+		t.Error("Expected the window to be numbered here, not take the caller's ID")
+	}
+}
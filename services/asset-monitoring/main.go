@@ -6,27 +6,56 @@ import (
 	"log"
 	"math/rand"
 	"net"
+	"os/signal"
+	"strings"
 	"sync"
+	"sync/atomic"
+	"syscall"
 	"time"
 
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/credentials/insecure"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
 	assetpb "github.com/sairamkiran9/asset-telemetry-monitor/gen/go/proto/asset"
 	pb "github.com/sairamkiran9/asset-telemetry-monitor/gen/go/proto/asset_monitoring"
+	monitoringpb "github.com/sairamkiran9/asset-telemetry-monitor/gen/go/proto/monitoring"
 	telemetrypb "github.com/sairamkiran9/asset-telemetry-monitor/gen/go/proto/telemetry"
+	"github.com/sairamkiran9/asset-telemetry-monitor/internal/assetcache"
+	"github.com/sairamkiran9/asset-telemetry-monitor/internal/assettypes"
+	"github.com/sairamkiran9/asset-telemetry-monitor/internal/auth"
+	"github.com/sairamkiran9/asset-telemetry-monitor/internal/certs"
+	"github.com/sairamkiran9/asset-telemetry-monitor/internal/cluster"
+	"github.com/sairamkiran9/asset-telemetry-monitor/internal/config"
+	"github.com/sairamkiran9/asset-telemetry-monitor/internal/energy"
+	"github.com/sairamkiran9/asset-telemetry-monitor/internal/health"
+	"github.com/sairamkiran9/asset-telemetry-monitor/internal/interceptors"
+	"github.com/sairamkiran9/asset-telemetry-monitor/internal/metrics"
+	"github.com/sairamkiran9/asset-telemetry-monitor/internal/shutdown"
+	"github.com/sairamkiran9/asset-telemetry-monitor/internal/tracing"
+)
+
+const (
+	defaultMonitoringInterval = time.Second
+	defaultFanoutBuffer       = 10
 )
 
 type assetMonitor struct {
-	assetID     string
-	assetType   pb.AssetType
-	status      pb.AssetStatus
-	lastUpdate  time.Time
-	cancel      context.CancelFunc
-	subscribers int
+	assetID      string
+	assetType    pb.AssetType
+	schema       *assetpb.AssetTypeSchema // Registry type, nil if unknown
+	status       pb.AssetStatus
+	lastUpdate   time.Time
+	cancel       context.CancelFunc
+	subscribers  int
+	energy       *energy.Accumulator
+	powerQuality *powerQualityDetector
+
+	// Type the registry changed the asset to, taken up by the next update
+	retyped atomic.Pointer[string]
 }
 
 type server struct {
@@ -36,22 +65,88 @@ type server struct {
 	mu       sync.RWMutex
 	monitors map[string]*assetMonitor
 
+	// Running monitorAsset goroutines, waited on at shutdown
+	monitorsWG sync.WaitGroup
+
+	// Energy accumulators and power quality detectors outlive monitors so
+	// totals and event history survive restarts
+	energy       map[string]*energy.Accumulator
+	powerQuality map[string]*powerQualityDetector
+
+	// Maintenance windows, the IDs of cancelled ones, the asset metadata
+	// their selectors match on and the tenant owning each asset
+	maintenance          map[string]*maintenanceWindow
+	cancelledMaintenance map[string]bool
+	maintenanceCounter   int
+	assetMetadata        map[string]map[string]string
+	assetTenants         map[string]string
+	maintenanceMu        sync.RWMutex
+
 	// Service clients
 	assetClient     assetpb.AssetRegistryClient
 	telemetryClient telemetrypb.TelemetryServiceClient
 
-	// Broadcast channels for updates
-	updateChans   map[string][]chan *pb.AssetStatusUpdate
-	updateChansMu sync.RWMutex
+	// Watched copy of the registry's assets, which keeps running monitors
+	// up to date; nil looks assets up once per stream
+	assets *assetcache.Cache
+
+	// Instances sharing the monitors; nil runs every monitor here
+	cluster *cluster.Cluster
+
+	// Asset types and the reading channels monitors report for them
+	types *assettypes.Registry
+
+	// Update channels of each asset's subscribers
+	fanout fanout
+
+	// Time between status updates and the updates buffered per subscriber
+	monitoringInterval time.Duration
+	fanoutBuffer       int
+
+	registry         *metrics.Registry
+	droppedUpdates   *metrics.Counter
+	forwardedStreams *metrics.Gauge
+	movedStreams     *metrics.Counter
 }
 
 func newServer(assetClient assetpb.AssetRegistryClient, telemetryClient telemetrypb.TelemetryServiceClient) *server {
-	return &server{
-		monitors:        make(map[string]*assetMonitor),
-		assetClient:     assetClient,
-		telemetryClient: telemetryClient,
-		updateChans:     make(map[string][]chan *pb.AssetStatusUpdate),
+	registry := metrics.NewRegistry()
+	metrics.RegisterRuntime(registry)
+
+	s := &server{
+		monitors:             make(map[string]*assetMonitor),
+		energy:               make(map[string]*energy.Accumulator),
+		powerQuality:         make(map[string]*powerQualityDetector),
+		maintenance:          make(map[string]*maintenanceWindow),
+		cancelledMaintenance: make(map[string]bool),
+		assetMetadata:        make(map[string]map[string]string),
+		assetTenants:         make(map[string]string),
+		assetClient:          assetClient,
+		telemetryClient:      telemetryClient,
+		types:                assettypes.Builtin(),
+		monitoringInterval:   defaultMonitoringInterval,
+		fanoutBuffer:         defaultFanoutBuffer,
+		registry:             registry,
+		droppedUpdates:       registry.Counter("asset_monitoring_dropped_updates_total", "Status updates dropped because a subscriber's channel was full.").WithLabelValues(),
+		forwardedStreams:     registry.Gauge("asset_monitoring_forwarded_streams", "Open StreamAssetStatus subscriptions forwarded to the asset's owner.").WithLabelValues(),
+		movedStreams:         registry.Counter("asset_monitoring_moved_streams_total", "StreamAssetStatus subscriptions moved after their asset changed owner.").WithLabelValues(),
 	}
+
+	registry.GaugeFunc("asset_monitoring_monitors", "Assets currently being monitored.", func() float64 {
+		s.mu.RLock()
+		defer s.mu.RUnlock()
+		return float64(len(s.monitors))
+	})
+	registry.GaugeFunc("asset_monitoring_subscribers", "Open StreamAssetStatus subscriptions.", func() float64 {
+		return float64(s.fanout.subscribers())
+	})
+	registry.GaugeFunc("asset_monitoring_cluster_members", "Instances currently sharing the monitors, including this one.", func() float64 {
+		if s.cluster == nil {
+			return 1
+		}
+		return float64(len(s.cluster.Members()))
+	})
+	return s
 }
 
 func (s *server) StreamAssetStatus(req *pb.StreamAssetStatusRequest, stream pb.AssetMonitoringService_StreamAssetStatusServer) error {
@@ -59,35 +154,66 @@ func (s *server) StreamAssetStatus(req *pb.StreamAssetStatusRequest, stream pb.A
 		return status.Error(codes.InvalidArgument, "asset_id is required")
 	}
 
-	// Validate asset exists
+	// Peers forward streams for assets they think this instance owns
 	ctx := stream.Context()
-	assetResp, err := s.assetClient.GetAsset(ctx, &assetpb.GetAssetRequest{Id: req.AssetId})
+	if s.cluster == nil || cluster.IsForwarded(ctx) {
+		return s.streamLocal(ctx, req, stream)
+	}
+	return s.streamFromOwner(ctx, req, stream)
+}
+
+// streamLocal streams updates from this instance's monitor of the asset until
+// ctx is done.
+func (s *server) streamLocal(ctx context.Context, req *pb.StreamAssetStatusRequest, stream pb.AssetMonitoringService_StreamAssetStatusServer) error {
+	// Validate asset exists
+	asset, found, err := s.lookupAsset(ctx, req.AssetId)
 	if err != nil {
 		return status.Errorf(codes.Internal, "failed to validate asset: %v", err)
 	}
-	if !assetResp.Found {
+	if !found || !auth.CanAccessTenant(ctx, asset.Tenant) {
 		return status.Errorf(codes.NotFound, "asset %s not found", req.AssetId)
 	}
 
-	// Determine asset type
-	assetType := getAssetType(assetResp.Asset.Type)
+	s.setAssetTenant(req.AssetId, asset.Tenant)
+	s.setAssetMetadata(req.AssetId, asset.Metadata)
 
 	// Start monitoring if not already running
-	if err := s.startMonitoring(ctx, req.AssetId, assetType); err != nil {
+	if err := s.startMonitoring(ctx, req.AssetId, asset.Type); err != nil {
 		return err
 	}
+	s.configurePowerQuality(req.AssetId, asset.Metadata)
 
 	// Create update channel for this client
-	updateChan := make(chan *pb.AssetStatusUpdate, 10)
-	s.registerUpdateChannel(req.AssetId, updateChan)
+	updateChan := make(chan *pb.AssetStatusUpdate, s.fanoutBuffer)
+	removed := s.registerUpdateChannel(req.AssetId, updateChan)
 	defer s.unregisterUpdateChannel(req.AssetId, updateChan)
 
 	// Stream updates to client
 	for {
 		select {
 		case <-ctx.Done():
-			log.Printf("Client disconnected from asset %s", req.AssetId)
+			if shutdown.IsShuttingDown(ctx) {
+				// The forwarding peer moves the stream once it notices
+				if cluster.IsForwarded(ctx) {
+					return status.Error(codes.Unavailable, "service shutting down")
+				}
+				// Tell the client why the stream is ending; it may reconnect
+				// to another replica
+				log.Printf("Closing stream for asset %s: service shutting down", req.AssetId)
+				return stream.Send(&pb.AssetStatusUpdate{
+					AssetId:   req.AssetId,
+					Status:    pb.AssetStatus_UNKNOWN,
+					Message:   "service shutting down",
+					Timestamp: timestamppb.Now(),
+				})
+			}
+			if stream.Context().Err() != nil {
+				log.Printf("Client disconnected from asset %s", req.AssetId)
+			}
 			return nil
+		case <-removed:
+			log.Printf("Closing stream for asset %s: asset deleted", req.AssetId)
+			return status.Errorf(codes.NotFound, "asset %s was deleted", req.AssetId)
 		case update := <-updateChan:
 			if err := stream.Send(update); err != nil {
 				return err
@@ -96,7 +222,50 @@ func (s *server) StreamAssetStatus(req *pb.StreamAssetStatusRequest, stream pb.A
 	}
 }
 
-func (s *server) startMonitoring(ctx context.Context, assetID string, assetType pb.AssetType) error {
+// lookupAsset returns the asset with id from the cache, or else from the
+// registry, which only finds the caller's own assets.
+func (s *server) lookupAsset(ctx context.Context, id string) (*assetpb.Asset, bool, error) {
+	if asset, found := s.assets.Get(id); found {
+		return asset, true, nil
+	}
+	resp, err := s.assetClient.GetAsset(ctx, &assetpb.GetAssetRequest{Id: id})
+	if err != nil {
+		return nil, false, err
+	}
+	return resp.Asset, resp.Found, nil
+}
+
+// assetChanged applies a change reported by the registry to the asset's
+// monitor, if it has one: updates retype and reconfigure it, and deletions
+// stop it, end its streams and drop what's kept about the asset.
+func (s *server) assetChanged(event *assetpb.AssetEvent) {
+	asset := event.Asset
+	switch event.Type {
+	case assetpb.AssetEventType_UPDATED:
+		typeName := asset.Type
+		s.mu.RLock()
+		monitor, exists := s.monitors[asset.Id]
+		if exists {
+			monitor.retyped.Store(&typeName)
+		}
+		s.mu.RUnlock()
+		if !exists {
+			return
+		}
+		s.setAssetTenant(asset.Id, asset.Tenant)
+		s.setAssetMetadata(asset.Id, asset.Metadata)
+		s.configurePowerQuality(asset.Id, asset.Metadata)
+		log.Printf("Reconfigured monitor of asset %s (type: %s)", asset.Id, typeName)
+	case assetpb.AssetEventType_DELETED:
+		s.fanout.remove(asset.Id)
+		s.stopMonitoring(asset.Id)
+		s.forgetAsset(asset.Id)
+	}
+}
+
+func (s *server) startMonitoring(ctx context.Context, assetID string, typeName string) error {
+	assetType, schema := s.resolveType(typeName)
+
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
@@ -107,27 +276,49 @@ func (s *server) startMonitoring(ctx context.Context, assetID string, assetType
 		return nil
 	}
 
+	// Resume the asset's energy total, but don't integrate across the restart
+	acc, exists := s.energy[assetID]
+	if !exists {
+		acc = energy.NewAccumulator(energy.DefaultMaxGap, time.UTC)
+		s.energy[assetID] = acc
+	}
+	acc.Restart()
+
+	detector, exists := s.powerQuality[assetID]
+	if !exists {
+		detector = newPowerQualityDetector(assetID)
+		s.powerQuality[assetID] = detector
+	}
+	detector.reset()
+
 	// Create new monitor
 	monitorCtx, cancel := context.WithCancel(context.Background())
 	monitor := &assetMonitor{
-		assetID:     assetID,
-		assetType:   assetType,
-		status:      pb.AssetStatus_ONLINE,
-		lastUpdate:  time.Now(),
-		cancel:      cancel,
-		subscribers: 1,
+		assetID:      assetID,
+		assetType:    assetType,
+		schema:       schema,
+		status:       pb.AssetStatus_ONLINE,
+		lastUpdate:   time.Now(),
+		cancel:       cancel,
+		subscribers:  1,
+		energy:       acc,
+		powerQuality: detector,
 	}
 	s.monitors[assetID] = monitor
 
 	// Start monitoring goroutine
-	go s.monitorAsset(monitorCtx, monitor)
+	s.monitorsWG.Add(1)
+	go func() {
+		defer s.monitorsWG.Done()
+		s.monitorAsset(monitorCtx, monitor)
+	}()
 
-	log.Printf("Started monitoring asset %s (type: %v)", assetID, assetType)
+	log.Printf("Started monitoring asset %s (type: %s)", assetID, typeName)
 	return nil
 }
 
 func (s *server) monitorAsset(ctx context.Context, monitor *assetMonitor) {
-	ticker := time.NewTicker(1 * time.Second)
+	ticker := time.NewTicker(s.monitoringInterval)
 	defer ticker.Stop()
 	defer s.cleanupMonitor(monitor.assetID)
 
@@ -144,6 +335,14 @@ func (s *server) monitorAsset(ctx context.Context, monitor *assetMonitor) {
 }
 
 func (s *server) generateAssetUpdate(monitor *assetMonitor) *pb.AssetStatusUpdate {
+	if typeName := monitor.retyped.Swap(nil); typeName != nil {
+		monitor.assetType, monitor.schema = s.resolveType(*typeName)
+	}
+	// Monitors made without a schema report the channels of their built-in type
+	if monitor.schema == nil && monitor.assetType != pb.AssetType_ASSET_TYPE_UNKNOWN {
+		monitor.schema, _ = s.types.Lookup(strings.ToLower(monitor.assetType.String()))
+	}
+
 	update := &pb.AssetStatusUpdate{
 		AssetId:   monitor.assetID,
 		Status:    monitor.status,
@@ -151,36 +350,68 @@ func (s *server) generateAssetUpdate(monitor *assetMonitor) *pb.AssetStatusUpdat
 		Message:   fmt.Sprintf("Asset %s is %s", monitor.assetID, monitor.status.String()),
 	}
 
+	// Planned maintenance takes precedence over the observed status
+	if window := s.activeMaintenance(monitor.assetID, update.Timestamp.AsTime()); window != nil {
+		update.Status = pb.AssetStatus_MAINTENANCE
+		update.Message = fmt.Sprintf("Asset %s is under maintenance (%s)", monitor.assetID, window.Id)
+	}
+
 	// Generate type-specific readings
+	var readings proto.Message
 	switch monitor.assetType {
 	case pb.AssetType_ELECTRIC:
-		update.Readings = &pb.AssetStatusUpdate_Electric{
-			Electric: &pb.ElectricReadings{
-				Voltage:     randomFloat(200, 240),
-				Current:     randomFloat(10, 100),
-				Power:       randomFloat(100, 1000),
-				Frequency:   randomFloat(49.5, 50.5),
-				PowerFactor: randomFloat(0.85, 0.99),
-			},
+		if monitor.energy == nil {
+			monitor.energy = energy.NewAccumulator(energy.DefaultMaxGap, time.UTC)
+		}
+		if monitor.powerQuality == nil {
+			monitor.powerQuality = newPowerQualityDetector(monitor.assetID)
+		}
+		power := randomFloat(100, 1000)
+		monitor.energy.AddPower(update.Timestamp.AsTime(), power)
+
+		voltage := randomFloat(200, 240)
+		current := randomFloat(10, 100)
+		electric := &pb.ElectricReadings{
+			Voltage:     voltage,
+			Current:     current,
+			Power:       power,
+			Frequency:   randomFloat(49.5, 50.5),
+			PowerFactor: randomFloat(0.85, 0.99),
+			Energy:      monitor.energy.Total(),
+			Demand:      monitor.energy.CurrentDemand(),
+			VoltageA:    voltage * randomFloat(0.995, 1.005),
+			VoltageB:    voltage * randomFloat(0.995, 1.005),
+			VoltageC:    voltage * randomFloat(0.995, 1.005),
+			CurrentA:    current * randomFloat(0.95, 1.05),
+			CurrentB:    current * randomFloat(0.95, 1.05),
+			CurrentC:    current * randomFloat(0.95, 1.05),
 		}
+		monitor.powerQuality.observe(update.Timestamp.AsTime(), electric)
+		readings = electric
+
+		update.Readings = &pb.AssetStatusUpdate_Electric{Electric: electric}
 	case pb.AssetType_CHILLWATER:
-		update.Readings = &pb.AssetStatusUpdate_Chillwater{
-			Chillwater: &pb.ChillWaterReadings{
-				SupplyTemp: randomFloat(6, 8),
-				ReturnTemp: randomFloat(12, 15),
-				Pressure:   randomFloat(3, 5),
-				FlowRate:   randomFloat(1000, 5000),
-			},
+		chillwater := &pb.ChillWaterReadings{
+			SupplyTemp: randomFloat(6, 8),
+			ReturnTemp: randomFloat(12, 15),
+			Pressure:   randomFloat(3, 5),
+			FlowRate:   randomFloat(1000, 5000),
 		}
+		update.Readings = &pb.AssetStatusUpdate_Chillwater{Chillwater: chillwater}
+		readings = chillwater
 	case pb.AssetType_STEAM:
-		update.Readings = &pb.AssetStatusUpdate_Steam{
-			Steam: &pb.SteamReadings{
-				Pressure:    randomFloat(10, 50),
-				Temperature: randomFloat(150, 250),
-				Quality:     randomFloat(95, 99.5),
-				Enthalpy:    randomFloat(2500, 2800),
-			},
+		steam := &pb.SteamReadings{
+			Pressure:    randomFloat(10, 50),
+			Temperature: randomFloat(150, 250),
+			Quality:     randomFloat(95, 99.5),
+			Enthalpy:    randomFloat(2500, 2800),
 		}
+		update.Readings = &pb.AssetStatusUpdate_Steam{Steam: steam}
+		readings = steam
+	}
+	if monitor.schema != nil {
+		update.AssetType = monitor.schema.Name
+		update.Channels = channelReadings(monitor.schema, readings)
 	}
 
 	// Randomly change status occasionally
@@ -201,43 +432,42 @@ func randomFloat(min, max float64) float64 {
 	return min + rand.Float64()*(max-min)
 }
 
-func (s *server) registerUpdateChannel(assetID string, ch chan *pb.AssetStatusUpdate) {
-	s.updateChansMu.Lock()
-	defer s.updateChansMu.Unlock()
-	s.updateChans[assetID] = append(s.updateChans[assetID], ch)
+// channelReadings returns a reading for each channel of schema. Channels
+// named after a field of the typed readings take its value; the rest, which
+// are all of them for types without typed readings, are simulated within the
+// channel's range.
+func channelReadings(schema *assetpb.AssetTypeSchema, readings proto.Message) []*pb.ChannelReading {
+	var fields protoreflect.FieldDescriptors
+	if readings != nil {
+		fields = readings.ProtoReflect().Descriptor().Fields()
+	}
+	channels := make([]*pb.ChannelReading, len(schema.Channels))
+	for i, c := range schema.Channels {
+		value := randomFloat(c.Min, c.Max)
+		if fields != nil {
+			if fd := fields.ByName(protoreflect.Name(c.Name)); fd != nil && fd.Kind() == protoreflect.DoubleKind {
+				value = readings.ProtoReflect().Get(fd).Float()
+			}
+		}
+		channels[i] = &pb.ChannelReading{Name: c.Name, Value: value, Unit: c.Unit}
+	}
+	return channels
+}
+
+func (s *server) registerUpdateChannel(assetID string, ch chan *pb.AssetStatusUpdate) <-chan struct{} {
+	return s.fanout.subscribe(assetID, ch)
 }
 
 func (s *server) unregisterUpdateChannel(assetID string, ch chan *pb.AssetStatusUpdate) {
-	s.updateChansMu.Lock()
-	channels := s.updateChans[assetID]
-	for i, c := range channels {
-		if c == ch {
-			s.updateChans[assetID] = append(channels[:i], channels[i+1:]...)
-			break
-		}
-	}
-	close(ch)
-	shouldStop := len(s.updateChans[assetID]) == 0
-	s.updateChansMu.Unlock()
-
-	// Stop monitoring if no more subscribers (outside the lock)
-	if shouldStop {
-		s.mu.Lock()
-		if monitor, exists := s.monitors[assetID]; exists {
-			monitor.cancel()
-			delete(s.monitors, assetID)
-			log.Printf("Stopped monitoring asset %s (no subscribers)", assetID)
-		}
-		s.mu.Unlock()
+	// Stop monitoring if no more subscribers
+	if s.fanout.unsubscribe(assetID, ch) == 0 {
+		s.stopMonitoring(assetID)
 	}
 }
 
 func (s *server) broadcastUpdate(assetID string, update *pb.AssetStatusUpdate) {
-	s.updateChansMu.RLock()
-	defer s.updateChansMu.RUnlock()
-
 	dropped := 0
-	for _, ch := range s.updateChans[assetID] {
+	for _, ch := range s.fanout.snapshot(assetID) {
 		select {
 		case ch <- update:
 		default:
@@ -248,54 +478,128 @@ func (s *server) broadcastUpdate(assetID string, update *pb.AssetStatusUpdate) {
 
 	// Only log if updates were dropped (reduces spam)
 	if dropped > 0 {
+		s.droppedUpdates.Add(float64(dropped))
 		log.Printf("Warning: Dropped %d updates for asset %s (channel full)", dropped, assetID)
 	}
 }
 
 func (s *server) checkStopMonitoring(assetID string) {
-	if len(s.updateChans[assetID]) == 0 {
-		s.mu.Lock()
-		defer s.mu.Unlock()
-
-		if monitor, exists := s.monitors[assetID]; exists {
-			monitor.cancel()
-			delete(s.monitors, assetID)
-			log.Printf("Stopped monitoring asset %s (no subscribers)", assetID)
-		}
+	if len(s.fanout.snapshot(assetID)) == 0 {
+		s.stopMonitoring(assetID)
+	}
+}
+
+func (s *server) stopMonitoring(assetID string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if monitor, exists := s.monitors[assetID]; exists {
+		monitor.cancel()
+		delete(s.monitors, assetID)
+		log.Printf("Stopped monitoring asset %s (no subscribers)", assetID)
+	}
+}
+
+// forgetAsset drops the energy total, power quality history, metadata and
+// tenant kept for a deleted asset.
+func (s *server) forgetAsset(assetID string) {
+	s.mu.Lock()
+	delete(s.energy, assetID)
+	delete(s.powerQuality, assetID)
+	s.mu.Unlock()
+
+	s.maintenanceMu.Lock()
+	delete(s.assetMetadata, assetID)
+	delete(s.assetTenants, assetID)
+	s.maintenanceMu.Unlock()
+}
+
+func (s *server) configurePowerQuality(assetID string, metadata map[string]string) {
+	s.mu.RLock()
+	detector, exists := s.powerQuality[assetID]
+	s.mu.RUnlock()
+
+	if exists {
+		detector.configure(metadata)
 	}
 }
 
+// stopMonitors cancels every monitor and waits for their goroutines to exit.
+func (s *server) stopMonitors(ctx context.Context) error {
+	s.mu.Lock()
+	for assetID, monitor := range s.monitors {
+		monitor.cancel()
+		delete(s.monitors, assetID)
+	}
+	s.mu.Unlock()
+
+	return shutdown.Wait(ctx, &s.monitorsWG)
+}
+
 func (s *server) cleanupMonitor(assetID string) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 	delete(s.monitors, assetID)
 }
 
+// assetTypes resolves the registry's type names, including aliases accepted
+// from assets registered before the registry checked types.
+var assetTypes = assettypes.Builtin()
+
+// resolveType returns the monitored type of a registry type and its schema,
+// which is nil for types this instance doesn't know.
+func (s *server) resolveType(typeName string) (pb.AssetType, *assetpb.AssetTypeSchema) {
+	schema, _ := s.types.Lookup(typeName)
+	return getAssetType(typeName), schema
+}
+
+// getAssetType maps a registry asset type to the monitored type named the
+// same in upper case; unmonitored types are unknown.
 func getAssetType(typeStr string) pb.AssetType {
-	switch typeStr {
-	case "electric":
-		return pb.AssetType_ELECTRIC
-	case "chillwater":
-		return pb.AssetType_CHILLWATER
-	case "steam":
-		return pb.AssetType_STEAM
-	default:
+	t, found := assetTypes.Lookup(typeStr)
+	if !found {
 		return pb.AssetType_ASSET_TYPE_UNKNOWN
 	}
+	return pb.AssetType(pb.AssetType_value[strings.ToUpper(t.Name)])
 }
 
 func main() {
+	cfg := defaultConfig()
+	config.Load("asset-monitoring", &cfg)
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+	drain := shutdown.New(cfg.ShutdownGracePeriod)
+
+	tracer, err := tracing.New("asset-monitoring", cfg.Tracing)
+	if err != nil {
+		log.Fatalf("Failed to set up tracing: %v", err)
+	}
+
+	tlsCerts, err := certs.New(cfg.TLS)
+	if err != nil {
+		log.Fatalf("Failed to load TLS certificates: %v", err)
+	}
+	go tlsCerts.Run(ctx)
+
+	authenticator, err := auth.New(cfg.Auth, auth.CommonPolicy.With(policy))
+	if err != nil {
+		log.Fatalf("Failed to set up authentication: %v", err)
+	}
+
 	rand.Seed(time.Now().UnixNano())
 
+	dialOpts := append(interceptors.DialOptions(interceptors.Options{Tracer: tracer, Token: cfg.Auth.Token}), grpc.WithTransportCredentials(tlsCerts.Credentials()))
+
 	// Connect to Asset Registry
-	assetConn, err := grpc.Dial("asset-registry:50051", grpc.WithTransportCredentials(insecure.NewCredentials()))
+	assetConn, err := cluster.Dial(cfg.AssetRegistryAddr, dialOpts...)
 	if err != nil {
 		log.Fatalf("Failed to connect to asset registry: %v", err)
 	}
 	defer assetConn.Close()
 
 	// Connect to Telemetry Service
-	telemetryConn, err := grpc.Dial("telemetry:50052", grpc.WithTransportCredentials(insecure.NewCredentials()))
+	telemetryConn, err := grpc.Dial(cfg.TelemetryAddr, dialOpts...)
 	if err != nil {
 		log.Fatalf("Failed to connect to telemetry service: %v", err)
 	}
@@ -303,17 +607,51 @@ func main() {
 
 	assetClient := assetpb.NewAssetRegistryClient(assetConn)
 	telemetryClient := telemetrypb.NewTelemetryServiceClient(telemetryConn)
-
-	lis, err := net.Listen("tcp", ":50054")
+	s := newServer(assetClient, telemetryClient)
+	if s.types, err = assettypes.Load(cfg.AssetTypesFile); err != nil {
+		log.Fatalf("Failed to load asset types: %v", err)
+	}
+	s.monitoringInterval = cfg.MonitoringInterval
+	s.fanoutBuffer = cfg.FanoutBuffer
+	s.assets = assetcache.New(assetClient)
+	s.assets.OnChange(s.assetChanged)
+	go s.assets.Run(ctx)
+
+	// Peers are probed for readiness of this same service
+	peers, err := cluster.New(cfg.Cluster, pb.AssetMonitoringService_ServiceDesc.ServiceName, dialOpts...)
+	if err != nil {
+		log.Fatalf("Failed to set up clustering: %v", err)
+	}
+	defer peers.Close()
+	s.cluster = peers
+	go peers.Run(ctx)
+	go s.syncMaintenance(ctx)
+
+	checker := health.NewChecker(pb.AssetMonitoringService_ServiceDesc.ServiceName)
+	checker.AddLivenessCheck("monitor-store", health.LockAcquirable(&s.mu))
+	checker.AddReadinessCheck("asset-registry", health.ConnReady(assetConn))
+	checker.AddReadinessCheck("telemetry", health.ConnReady(telemetryConn))
+	go checker.Run(ctx, health.DefaultInterval)
+
+	lis, err := net.Listen("tcp", cfg.ListenAddr)
 	if err != nil {
 		log.Fatalf("Failed to listen: %v", err)
 	}
 
-	grpcServer := grpc.NewServer()
-	pb.RegisterAssetMonitoringServiceServer(grpcServer, newServer(assetClient, telemetryClient))
+	grpcServer := grpc.NewServer(append(interceptors.ServerOptions(interceptors.Options{Registry: s.registry, Tracer: tracer, MaxTimeout: cfg.MaxRPCTimeout, Shutdown: drain, Auth: authenticator, TLS: tlsCerts}), grpc.Creds(tlsCerts.Credentials()))...)
+	pb.RegisterAssetMonitoringServiceServer(grpcServer, s)
+	monitoringpb.RegisterMetricsExporterServer(grpcServer, metrics.NewExporter(s.registry))
+	checker.Register(grpcServer)
+
+	metricsServer := metrics.Serve(cfg.MetricsAddr, s.registry)
+
+	drain.OnShutdown(checker.Shutdown)
+	drain.AddCleanup("asset monitors", s.stopMonitors)
+	drain.AddCleanup("metrics endpoint", metricsServer.Shutdown)
+	drain.AddCleanup("tracing", tracer.Shutdown)
 
-	log.Println("Asset Monitoring Service listening on :50054")
-	if err := grpcServer.Serve(lis); err != nil {
+	log.Printf("Asset Monitoring Service listening on %s", cfg.ListenAddr)
+	if err := drain.Serve(ctx, grpcServer, lis); err != nil {
 		log.Fatalf("Failed to serve: %v", err)
 	}
 }
@@ -2,12 +2,18 @@ package main
 
 import (
 	"context"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
 	assetpb "github.com/sairamkiran9/asset-telemetry-monitor/gen/go/proto/asset"
 	pb "github.com/sairamkiran9/asset-telemetry-monitor/gen/go/proto/asset_monitoring"
 	telemetrypb "github.com/sairamkiran9/asset-telemetry-monitor/gen/go/proto/telemetry"
+	"github.com/sairamkiran9/asset-telemetry-monitor/internal/assettypes"
+	"github.com/sairamkiran9/asset-telemetry-monitor/internal/auth"
+	"github.com/sairamkiran9/asset-telemetry-monitor/internal/shutdown"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
@@ -35,6 +41,54 @@ func (m *mockAssetClient) ListAssets(ctx context.Context, req *assetpb.ListAsset
 	return nil, nil
 }
 
+func (m *mockAssetClient) UpdateAsset(ctx context.Context, req *assetpb.UpdateAssetRequest, opts ...grpc.CallOption) (*assetpb.UpdateAssetResponse, error) {
+	return nil, nil
+}
+
+func (m *mockAssetClient) DeleteAsset(ctx context.Context, req *assetpb.DeleteAssetRequest, opts ...grpc.CallOption) (*assetpb.DeleteAssetResponse, error) {
+	return nil, nil
+}
+
+func (m *mockAssetClient) WatchAssets(ctx context.Context, req *assetpb.WatchAssetsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[assetpb.AssetEvent], error) {
+	return nil, status.Error(codes.Unimplemented, "not implemented")
+}
+
+func (m *mockAssetClient) SetParent(ctx context.Context, req *assetpb.SetParentRequest, opts ...grpc.CallOption) (*assetpb.SetParentResponse, error) {
+	return nil, nil
+}
+
+func (m *mockAssetClient) ConnectAssets(ctx context.Context, req *assetpb.ConnectAssetsRequest, opts ...grpc.CallOption) (*assetpb.ConnectAssetsResponse, error) {
+	return nil, nil
+}
+
+func (m *mockAssetClient) DisconnectAssets(ctx context.Context, req *assetpb.DisconnectAssetsRequest, opts ...grpc.CallOption) (*assetpb.DisconnectAssetsResponse, error) {
+	return nil, nil
+}
+
+func (m *mockAssetClient) GetSubtree(ctx context.Context, req *assetpb.GetSubtreeRequest, opts ...grpc.CallOption) (*assetpb.GetSubtreeResponse, error) {
+	return nil, nil
+}
+
+func (m *mockAssetClient) GetAncestors(ctx context.Context, req *assetpb.GetAncestorsRequest, opts ...grpc.CallOption) (*assetpb.GetAncestorsResponse, error) {
+	return nil, nil
+}
+
+func (m *mockAssetClient) GetConnected(ctx context.Context, req *assetpb.GetConnectedRequest, opts ...grpc.CallOption) (*assetpb.GetConnectedResponse, error) {
+	return nil, nil
+}
+
+func (m *mockAssetClient) ListAssetTypes(ctx context.Context, req *assetpb.ListAssetTypesRequest, opts ...grpc.CallOption) (*assetpb.ListAssetTypesResponse, error) {
+	return nil, nil
+}
+
+func (m *mockAssetClient) ImportAssets(ctx context.Context, req *assetpb.ImportAssetsRequest, opts ...grpc.CallOption) (*assetpb.ImportAssetsResponse, error) {
+	return nil, nil
+}
+
+func (m *mockAssetClient) ExportAssets(ctx context.Context, req *assetpb.ExportAssetsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[assetpb.Asset], error) {
+	return nil, status.Error(codes.Unimplemented, "not implemented")
+}
+
 // Mock telemetry client
 type mockTelemetryClient struct{}
 
@@ -46,6 +100,10 @@ func (m *mockTelemetryClient) GetTelemetryData(ctx context.Context, req *telemet
 	return nil, nil
 }
 
+func (m *mockTelemetryClient) GetEnergyIntervals(ctx context.Context, req *telemetrypb.GetEnergyIntervalsRequest, opts ...grpc.CallOption) (*telemetrypb.GetEnergyIntervalsResponse, error) {
+	return nil, nil
+}
+
 // Mock stream for testing
 type mockStream struct {
 	grpc.ServerStream
@@ -75,6 +133,9 @@ func TestGetAssetType(t *testing.T) {
 		{"Electric", "electric", pb.AssetType_ELECTRIC},
 		{"ChillWater", "chillwater", pb.AssetType_CHILLWATER},
 		{"Steam", "steam", pb.AssetType_STEAM},
+		{"Capitalized", "Electric", pb.AssetType_ELECTRIC},
+		{"Alias", "chilled-water", pb.AssetType_CHILLWATER},
+		{"Unmonitored", "building", pb.AssetType_ASSET_TYPE_UNKNOWN},
 		{"Unknown", "unknown", pb.AssetType_ASSET_TYPE_UNKNOWN},
 		{"Empty", "", pb.AssetType_ASSET_TYPE_UNKNOWN},
 	}
@@ -89,6 +150,19 @@ func TestGetAssetType(t *testing.T) {
 	}
 }
 
+// Every monitored type must be one the registry accepts, or no asset could
+// have it.
+func TestAssetTypesAreRegistryTypes(t *testing.T) {
+	for value, name := range pb.AssetType_name {
+		if pb.AssetType(value) == pb.AssetType_ASSET_TYPE_UNKNOWN {
+			continue
+		}
+		if got := getAssetType(strings.ToLower(name)); got != pb.AssetType(value) {
+			t.Errorf("Expected registry type %q to be monitored as %s, got %v", strings.ToLower(name), name, got)
+		}
+	}
+}
+
 func TestRandomFloat(t *testing.T) {
 	min, max := 10.0, 20.0
 
@@ -178,6 +252,68 @@ func TestGenerateAssetUpdate(t *testing.T) {
 	}
 }
 
+func TestGenerateAssetUpdateChannels(t *testing.T) {
+	s := newServer(&mockAssetClient{}, &mockTelemetryClient{})
+
+	// Built-in types report their typed readings as channels too
+	monitor := &assetMonitor{assetID: "asset-1", assetType: pb.AssetType_CHILLWATER, status: pb.AssetStatus_ONLINE}
+	update := s.generateAssetUpdate(monitor)
+	chillwater := update.GetChillwater()
+	channels := make(map[string]*pb.ChannelReading)
+	for _, c := range update.Channels {
+		channels[c.Name] = c
+	}
+	if update.AssetType != "chillwater" || len(channels) != 4 {
+		t.Fatalf("Expected four chillwater channels, got %s %v", update.AssetType, update.Channels)
+	}
+	if c := channels["supply_temp"]; c.Value != chillwater.SupplyTemp || c.Unit != "°C" {
+		t.Errorf("Expected supply_temp %v in °C, got %v", chillwater.SupplyTemp, c)
+	}
+	if c := channels["flow_rate"]; c.Value != chillwater.FlowRate || c.Unit != "L/min" {
+		t.Errorf("Expected flow_rate %v in L/min, got %v", chillwater.FlowRate, c)
+	}
+
+	// Types from a definition file report simulated channels only
+	path := filepath.Join(t.TempDir(), "types.json")
+	os.WriteFile(path, []byte(`{"types": [{"name": "boiler", "aliases": ["hot-water-boiler"], "channels": [
+		{"name": "flow_temp", "unit": "°C", "min": 60, "max": 80},
+		{"name": "gas_flow", "unit": "m³/h", "min": 5, "max": 5}
+	]}]}`), 0o600)
+	var err error
+	if s.types, err = assettypes.Load(path); err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if err := s.startMonitoring(context.Background(), "asset-2", "Hot Water Boiler"); err != nil {
+		t.Fatalf("startMonitoring failed: %v", err)
+	}
+	defer s.stopMonitoring("asset-2")
+	s.mu.RLock()
+	monitor = s.monitors["asset-2"]
+	s.mu.RUnlock()
+	if monitor.assetType != pb.AssetType_ASSET_TYPE_UNKNOWN || monitor.schema.GetName() != "boiler" {
+		t.Fatalf("Expected an unknown monitored type with the boiler schema, got %v %v", monitor.assetType, monitor.schema)
+	}
+
+	update = s.generateAssetUpdate(&assetMonitor{assetID: "asset-2", assetType: monitor.assetType, schema: monitor.schema})
+	if update.Readings != nil || update.AssetType != "boiler" || len(update.Channels) != 2 {
+		t.Fatalf("Expected two boiler channels and no typed readings, got %v", update)
+	}
+	if c := update.Channels[0]; c.Name != "flow_temp" || c.Unit != "°C" || c.Value < 60 || c.Value > 80 {
+		t.Errorf("Expected flow_temp between 60 and 80 °C, got %v", c)
+	}
+	if c := update.Channels[1]; c.Name != "gas_flow" || c.Value != 5 {
+		t.Errorf("Expected gas_flow of 5, got %v", c)
+	}
+
+	// Retyping to a type the instance doesn't know drops the channels
+	typeName := "hvac"
+	monitor = &assetMonitor{assetID: "asset-3", assetType: pb.AssetType_STEAM}
+	monitor.retyped.Store(&typeName)
+	if update := s.generateAssetUpdate(monitor); update.Readings != nil || update.Channels != nil {
+		t.Errorf("Expected no readings for an unknown type, got %v", update)
+	}
+}
+
 func TestStreamAssetStatusAssetNotFound(t *testing.T) {
 	mockAsset := &mockAssetClient{
 		assets: map[string]*assetpb.Asset{},
@@ -213,6 +349,24 @@ func TestStreamAssetStatusAssetNotFound(t *testing.T) {
 	}
 }
 
+func TestStreamAssetStatusOtherTenant(t *testing.T) {
+	mockAsset := &mockAssetClient{
+		assets: map[string]*assetpb.Asset{
+			"asset-1": {Id: "asset-1", Type: "electric", Tenant: "north-campus"},
+		},
+	}
+	s := newServer(mockAsset, &mockTelemetryClient{})
+
+	ctx := auth.ContextWithTenant(context.Background(), "south-campus")
+	err := s.StreamAssetStatus(&pb.StreamAssetStatusRequest{AssetId: "asset-1"}, &mockStream{ctx: ctx})
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("Expected NotFound for another tenant's asset, got %v", err)
+	}
+	if len(s.monitors) != 0 {
+		t.Error("Expected no monitor for another tenant's asset")
+	}
+}
+
 func TestStreamAssetStatusMissingAssetID(t *testing.T) {
 	mockAsset := &mockAssetClient{
 		assets: map[string]*assetpb.Asset{},
@@ -261,7 +415,7 @@ func TestStartMonitoring(t *testing.T) {
 	assetType := pb.AssetType_ELECTRIC
 
 	// Start monitoring
-	err := s.startMonitoring(ctx, assetID, assetType)
+	err := s.startMonitoring(ctx, assetID, "electric")
 	if err != nil {
 		t.Fatalf("startMonitoring failed: %v", err)
 	}
@@ -288,7 +442,7 @@ func TestStartMonitoring(t *testing.T) {
 	}
 
 	// Start monitoring again (should increment subscribers)
-	err = s.startMonitoring(ctx, assetID, assetType)
+	err = s.startMonitoring(ctx, assetID, "electric")
 	if err != nil {
 		t.Fatalf("startMonitoring failed on second call: %v", err)
 	}
@@ -305,6 +459,137 @@ func TestStartMonitoring(t *testing.T) {
 	monitor.cancel()
 }
 
+func TestStreamAssetStatusShutdown(t *testing.T) {
+	mockAsset := &mockAssetClient{
+		assets: map[string]*assetpb.Asset{
+			"asset-1": {Id: "asset-1", Name: "Test Asset", Type: "electric"},
+		},
+	}
+	s := newServer(mockAsset, &mockTelemetryClient{})
+
+	ctx, cancel := context.WithCancelCause(context.Background())
+	stream := &mockStream{ctx: ctx}
+	time.AfterFunc(50*time.Millisecond, func() { cancel(shutdown.ErrShuttingDown) })
+
+	if err := s.StreamAssetStatus(&pb.StreamAssetStatusRequest{AssetId: "asset-1"}, stream); err != nil {
+		t.Fatalf("Expected nil error on shutdown, got %v", err)
+	}
+
+	if len(stream.updates) == 0 {
+		t.Fatal("Expected a final update before the stream ends")
+	}
+	last := stream.updates[len(stream.updates)-1]
+	if last.Status != pb.AssetStatus_UNKNOWN || last.Message != "service shutting down" {
+		t.Errorf("Expected final shutdown update, got %v", last)
+	}
+}
+
+func TestAssetChanged(t *testing.T) {
+	mockAsset := &mockAssetClient{
+		assets: map[string]*assetpb.Asset{
+			"asset-1": {Id: "asset-1", Name: "AHU-1", Type: "electric"},
+		},
+	}
+	s := newServer(mockAsset, &mockTelemetryClient{})
+
+	stream := &mockStream{ctx: context.Background()}
+	done := make(chan error, 1)
+	go func() { done <- s.StreamAssetStatus(&pb.StreamAssetStatusRequest{AssetId: "asset-1"}, stream) }()
+
+	var monitor *assetMonitor
+	for deadline := time.Now().Add(5 * time.Second); monitor == nil && time.Now().Before(deadline); time.Sleep(time.Millisecond) {
+		s.mu.RLock()
+		monitor = s.monitors["asset-1"]
+		s.mu.RUnlock()
+	}
+	if monitor == nil {
+		t.Fatal("Expected the asset to be monitored")
+	}
+
+	// Updates retype the running monitor and refresh its metadata
+	s.assetChanged(&assetpb.AssetEvent{
+		Type:  assetpb.AssetEventType_UPDATED,
+		Asset: &assetpb.Asset{Id: "asset-1", Type: "steam", Metadata: map[string]string{"building": "plant"}},
+	})
+	if retyped := monitor.retyped.Load(); retyped == nil || *retyped != "steam" {
+		t.Errorf("Expected the monitor to be retyped to steam, got %v", retyped)
+	}
+	s.maintenanceMu.RLock()
+	building := s.assetMetadata["asset-1"]["building"]
+	s.maintenanceMu.RUnlock()
+	if building != "plant" {
+		t.Errorf("Expected updated metadata, got building=%q", building)
+	}
+
+	// Deletions end the asset's streams and stop its monitor
+	s.assetChanged(&assetpb.AssetEvent{Type: assetpb.AssetEventType_DELETED, Asset: &assetpb.Asset{Id: "asset-1"}})
+	select {
+	case err := <-done:
+		if status.Code(err) != codes.NotFound {
+			t.Errorf("Expected NotFound after deletion, got %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Expected the stream to end after deletion")
+	}
+	s.mu.RLock()
+	_, exists := s.monitors["asset-1"]
+	s.mu.RUnlock()
+	if exists {
+		t.Error("Expected the monitor to stop after deletion")
+	}
+
+	// Nothing is kept about the deleted asset
+	s.mu.RLock()
+	_, hasEnergy := s.energy["asset-1"]
+	_, hasPowerQuality := s.powerQuality["asset-1"]
+	s.mu.RUnlock()
+	s.maintenanceMu.RLock()
+	_, hasMetadata := s.assetMetadata["asset-1"]
+	_, hasTenant := s.assetTenants["asset-1"]
+	s.maintenanceMu.RUnlock()
+	if hasEnergy || hasPowerQuality || hasMetadata || hasTenant {
+		t.Errorf("Expected the asset's state to be dropped, got energy=%t power quality=%t metadata=%t tenant=%t",
+			hasEnergy, hasPowerQuality, hasMetadata, hasTenant)
+	}
+}
+
+func TestGenerateAssetUpdateTakesNewType(t *testing.T) {
+	s := newServer(&mockAssetClient{}, &mockTelemetryClient{})
+	monitor := &assetMonitor{assetID: "asset-1", assetType: pb.AssetType_ELECTRIC, status: pb.AssetStatus_ONLINE}
+	typeName := "chillwater"
+	monitor.retyped.Store(&typeName)
+
+	update := s.generateAssetUpdate(monitor)
+	if update.GetChillwater() == nil {
+		t.Errorf("Expected chilled water readings after retyping, got %v", update.Readings)
+	}
+	if monitor.retyped.Load() != nil {
+		t.Error("Expected the new type to be taken up")
+	}
+}
+
+func TestStopMonitors(t *testing.T) {
+	s := newServer(&mockAssetClient{}, &mockTelemetryClient{})
+
+	for _, id := range []string{"asset-1", "asset-2"} {
+		if err := s.startMonitoring(context.Background(), id, "electric"); err != nil {
+			t.Fatalf("startMonitoring failed: %v", err)
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if err := s.stopMonitors(ctx); err != nil {
+		t.Fatalf("Expected monitors to stop, got %v", err)
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if len(s.monitors) != 0 {
+		t.Errorf("Expected no monitors after stop, got %d", len(s.monitors))
+	}
+}
+
 func TestRegisterAndUnregisterUpdateChannel(t *testing.T) {
 	mockAsset := &mockAssetClient{
 		assets: map[string]*assetpb.Asset{},
@@ -321,9 +606,7 @@ func TestRegisterAndUnregisterUpdateChannel(t *testing.T) {
 	s.registerUpdateChannel(assetID, ch1)
 	s.registerUpdateChannel(assetID, ch2)
 
-	s.updateChansMu.RLock()
-	channelCount := len(s.updateChans[assetID])
-	s.updateChansMu.RUnlock()
+	channelCount := len(s.fanout.snapshot(assetID))
 
 	if channelCount != 2 {
 		t.Errorf("Expected 2 channels, got %d", channelCount)
@@ -332,9 +615,7 @@ func TestRegisterAndUnregisterUpdateChannel(t *testing.T) {
 	// Unregister one channel
 	s.unregisterUpdateChannel(assetID, ch1)
 
-	s.updateChansMu.RLock()
-	channelCount = len(s.updateChans[assetID])
-	s.updateChansMu.RUnlock()
+	channelCount = len(s.fanout.snapshot(assetID))
 
 	if channelCount != 1 {
 		t.Errorf("Expected 1 channel after unregister, got %d", channelCount)
@@ -390,6 +671,21 @@ func TestBroadcastUpdate(t *testing.T) {
 	close(ch2)
 }
 
+func TestBroadcastUpdateCountsDrops(t *testing.T) {
+	s := newServer(&mockAssetClient{assets: map[string]*assetpb.Asset{}}, &mockTelemetryClient{})
+
+	full := make(chan *pb.AssetStatusUpdate, 1)
+	s.registerUpdateChannel("asset-1", full)
+
+	for i := 0; i < 3; i++ {
+		s.broadcastUpdate("asset-1", &pb.AssetStatusUpdate{AssetId: "asset-1"})
+	}
+
+	if s.droppedUpdates.Value() != 2 {
+		t.Errorf("Expected 2 dropped updates, got %v", s.droppedUpdates.Value())
+	}
+}
+
 func TestElectricReadingsRange(t *testing.T) {
 	mockAsset := &mockAssetClient{
 		assets: map[string]*assetpb.Asset{},
@@ -0,0 +1,415 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"sync"
+	"time"
+	_ "time/tzdata" // The alpine runtime image ships without zone info
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	pb "github.com/sairamkiran9/asset-telemetry-monitor/gen/go/proto/asset_monitoring"
+	"github.com/sairamkiran9/asset-telemetry-monitor/internal/auth"
+	"github.com/sairamkiran9/asset-telemetry-monitor/internal/cluster"
+)
+
+const (
+	// maintenanceKey is the cluster key whose owner numbers new maintenance
+	// windows
+	maintenanceKey = "maintenance"
+
+	// maintenanceReplicationTimeout bounds copying a maintenance change to
+	// one peer
+	maintenanceReplicationTimeout = 5 * time.Second
+)
+
+// maintenanceWindow pairs a window with its resolved time zone and the tenant
+// that scheduled it.
+type maintenanceWindow struct {
+	window   *pb.MaintenanceWindow
+	location *time.Location
+	tenant   string
+}
+
+// appliesTo reports whether the window targets the asset, either by ID or by
+// all selector entries matching the asset's metadata. Windows only apply to
+// assets of their own tenant.
+func (w *maintenanceWindow) appliesTo(assetID, tenant string, metadata map[string]string) bool {
+	if w.tenant != tenant {
+		return false
+	}
+	if w.window.AssetId != "" {
+		return w.window.AssetId == assetID
+	}
+	for key, value := range w.window.Selector {
+		if metadata[key] != value {
+			return false
+		}
+	}
+	return true
+}
+
+// activeAt reports whether any occurrence of the window covers t. Recurring
+// windows repeat at the same local wall-clock time, so a 02:00 window stays at
+// 02:00 across daylight saving changes.
+func (w *maintenanceWindow) activeAt(t time.Time) bool {
+	start := w.window.StartTime.AsTime().In(w.location)
+	duration := time.Duration(w.window.DurationSeconds) * time.Second
+	if t.Before(start) {
+		return false
+	}
+
+	var stepDays int
+	switch w.window.Recurrence {
+	case pb.Recurrence_DAILY:
+		stepDays = 1
+	case pb.Recurrence_WEEKLY:
+		stepDays = 7
+	default:
+		return t.Before(start.Add(duration))
+	}
+
+	// Walk back from the latest occurrence that could have started by t
+	elapsed := calendarDays(start, t.In(w.location))
+	latest := elapsed - elapsed%stepDays
+	lookback := int(duration/(time.Duration(stepDays)*24*time.Hour)) + 1
+
+	for i := 0; i <= lookback; i++ {
+		offset := latest - i*stepDays
+		if offset < 0 {
+			break
+		}
+		occurrence := time.Date(start.Year(), start.Month(), start.Day()+offset,
+			start.Hour(), start.Minute(), start.Second(), 0, w.location)
+		if w.window.RecurrenceEnd != nil && occurrence.After(w.window.RecurrenceEnd.AsTime()) {
+			continue
+		}
+		if !t.Before(occurrence) && t.Before(occurrence.Add(duration)) {
+			return true
+		}
+	}
+	return false
+}
+
+// calendarDays counts local calendar days from a to b, ignoring DST shifts.
+func calendarDays(a, b time.Time) int {
+	dayA := time.Date(a.Year(), a.Month(), a.Day(), 12, 0, 0, 0, time.UTC)
+	dayB := time.Date(b.Year(), b.Month(), b.Day(), 12, 0, 0, 0, time.UTC)
+	return int(dayB.Sub(dayA).Hours() / 24)
+}
+
+// activeMaintenance returns the first maintenance window covering the asset at
+// t, or nil.
+func (s *server) activeMaintenance(assetID string, t time.Time) *pb.MaintenanceWindow {
+	s.maintenanceMu.RLock()
+	defer s.maintenanceMu.RUnlock()
+
+	metadata := s.assetMetadata[assetID]
+	tenant := s.assetTenant(assetID)
+	for _, id := range s.sortedMaintenanceIDs() {
+		w := s.maintenance[id]
+		if w.appliesTo(assetID, tenant, metadata) && w.activeAt(t) {
+			return w.window
+		}
+	}
+	return nil
+}
+
+// setAssetMetadata remembers asset metadata for selector matching.
+func (s *server) setAssetMetadata(assetID string, metadata map[string]string) {
+	s.maintenanceMu.Lock()
+	defer s.maintenanceMu.Unlock()
+	s.assetMetadata[assetID] = metadata
+}
+
+// setAssetTenant remembers the tenant owning an asset, as reported by the
+// asset registry.
+func (s *server) setAssetTenant(assetID, tenant string) {
+	s.maintenanceMu.Lock()
+	defer s.maintenanceMu.Unlock()
+	s.assetTenants[assetID] = tenant
+}
+
+// assetTenant returns the tenant owning an asset, DefaultTenant for assets
+// the registry hasn't reported. Callers must hold s.maintenanceMu.
+func (s *server) assetTenant(assetID string) string {
+	if tenant := s.assetTenants[assetID]; tenant != "" {
+		return tenant
+	}
+	return auth.DefaultTenant
+}
+
+// canAccessAsset reports whether the caller's tenant owns the asset.
+func (s *server) canAccessAsset(ctx context.Context, assetID string) bool {
+	s.maintenanceMu.RLock()
+	defer s.maintenanceMu.RUnlock()
+	return auth.CanAccessTenant(ctx, s.assetTenant(assetID))
+}
+
+// sortedMaintenanceIDs returns window IDs in creation order. Callers must hold
+// s.maintenanceMu.
+func (s *server) sortedMaintenanceIDs() []string {
+	ids := make([]string, 0, len(s.maintenance))
+	for id := range s.maintenance {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		return s.maintenance[ids[i]].window.CreatedAt.AsTime().Before(s.maintenance[ids[j]].window.CreatedAt.AsTime())
+	})
+	return ids
+}
+
+func (s *server) ScheduleMaintenance(ctx context.Context, req *pb.ScheduleMaintenanceRequest) (*pb.ScheduleMaintenanceResponse, error) {
+	window := req.Window
+	if window == nil {
+		return nil, status.Error(codes.InvalidArgument, "window is required")
+	}
+	if window.AssetId == "" && len(window.Selector) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "asset_id or selector is required")
+	}
+	if window.StartTime == nil {
+		return nil, status.Error(codes.InvalidArgument, "start_time is required")
+	}
+	if window.DurationSeconds <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "duration_seconds must be positive")
+	}
+	tenant, err := auth.RequireTenant(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	location := time.UTC
+	if window.Timezone != "" {
+		var err error
+		if location, err = time.LoadLocation(window.Timezone); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "unknown timezone %q", window.Timezone)
+		}
+	}
+
+	// Peers copy the windows they schedule here with their ID set
+	window = proto.Clone(window).(*pb.MaintenanceWindow)
+	if window.Id != "" && cluster.IsForwarded(ctx) {
+		return s.copyMaintenanceWindow(window, location, tenant)
+	}
+	window.Id = ""
+
+	// Selectors match assets any instance may own, so every instance keeps
+	// every window. One instance numbers them, keeping IDs unique.
+	if owner, local := s.cluster.Owner(maintenanceKey); !local && !cluster.IsForwarded(ctx) {
+		client := pb.NewAssetMonitoringServiceClient(s.cluster.Conn(owner))
+		return client.ScheduleMaintenance(s.peerContext(ctx, tenant), &pb.ScheduleMaintenanceRequest{Window: window})
+	}
+
+	window.CreatedAt = timestamppb.Now()
+	s.maintenanceMu.Lock()
+	window.Id = s.nextMaintenanceID()
+	s.maintenance[window.Id] = &maintenanceWindow{window: window, location: location, tenant: tenant}
+	s.maintenanceMu.Unlock()
+	log.Printf("Scheduled maintenance window %s (tenant: %s, asset: %q, selector: %v)", window.Id, tenant, window.AssetId, window.Selector)
+
+	s.replicateMaintenance(ctx, tenant, func(ctx context.Context, client pb.AssetMonitoringServiceClient) error {
+		_, err := client.ScheduleMaintenance(ctx, &pb.ScheduleMaintenanceRequest{Window: window})
+		return err
+	})
+
+	return &pb.ScheduleMaintenanceResponse{
+		Window:  window,
+		Success: true,
+		Message: "Maintenance window scheduled",
+	}, nil
+}
+
+// nextMaintenanceID returns an ID no window here has had. Callers must hold
+// s.maintenanceMu.
+func (s *server) nextMaintenanceID() string {
+	for {
+		s.maintenanceCounter++
+		id := fmt.Sprintf("maintenance-%d", s.maintenanceCounter)
+		if _, exists := s.maintenance[id]; !exists && !s.cancelledMaintenance[id] {
+			return id
+		}
+	}
+}
+
+// copyMaintenanceWindow keeps a window a peer scheduled. Windows cancelled
+// here are refused, so the peer drops them too.
+func (s *server) copyMaintenanceWindow(window *pb.MaintenanceWindow, location *time.Location, tenant string) (*pb.ScheduleMaintenanceResponse, error) {
+	s.maintenanceMu.Lock()
+	defer s.maintenanceMu.Unlock()
+
+	if s.cancelledMaintenance[window.Id] {
+		return nil, status.Errorf(codes.FailedPrecondition, "maintenance window %s was cancelled", window.Id)
+	}
+	if _, exists := s.maintenance[window.Id]; !exists {
+		s.maintenance[window.Id] = &maintenanceWindow{window: window, location: location, tenant: tenant}
+		// Numbering carries on from the peer's windows should it fall here
+		var n int
+		if _, err := fmt.Sscanf(window.Id, "maintenance-%d", &n); err == nil && n > s.maintenanceCounter {
+			s.maintenanceCounter = n
+		}
+	}
+
+	return &pb.ScheduleMaintenanceResponse{
+		Window:  window,
+		Success: true,
+		Message: "Maintenance window copied",
+	}, nil
+}
+
+func (s *server) ListMaintenanceWindows(ctx context.Context, req *pb.ListMaintenanceWindowsRequest) (*pb.ListMaintenanceWindowsResponse, error) {
+	// Selectors match the metadata the asset's owner keeps
+	if req.AssetId != "" {
+		if owner, local := s.cluster.Owner(req.AssetId); !local && !cluster.IsForwarded(ctx) {
+			client := pb.NewAssetMonitoringServiceClient(s.cluster.Conn(owner))
+			return client.ListMaintenanceWindows(cluster.ForwardContext(ctx, s.cluster.Self()), req)
+		}
+	}
+
+	now := time.Now()
+
+	s.maintenanceMu.RLock()
+	defer s.maintenanceMu.RUnlock()
+
+	windows := make([]*pb.MaintenanceWindow, 0, len(s.maintenance))
+	for _, id := range s.sortedMaintenanceIDs() {
+		w := s.maintenance[id]
+		if !auth.CanAccessTenant(ctx, w.tenant) {
+			continue
+		}
+		if req.AssetId != "" && !w.appliesTo(req.AssetId, s.assetTenant(req.AssetId), s.assetMetadata[req.AssetId]) {
+			continue
+		}
+		if req.ActiveOnly && !w.activeAt(now) {
+			continue
+		}
+		windows = append(windows, w.window)
+	}
+
+	return &pb.ListMaintenanceWindowsResponse{
+		Windows: windows,
+	}, nil
+}
+
+func (s *server) CancelMaintenance(ctx context.Context, req *pb.CancelMaintenanceRequest) (*pb.CancelMaintenanceResponse, error) {
+	if req.Id == "" {
+		return nil, status.Error(codes.InvalidArgument, "maintenance window ID is required")
+	}
+
+	s.maintenanceMu.Lock()
+	w, exists := s.maintenance[req.Id]
+	if !exists || !auth.CanAccessTenant(ctx, w.tenant) {
+		s.maintenanceMu.Unlock()
+		return nil, status.Errorf(codes.NotFound, "maintenance window %s not found", req.Id)
+	}
+	delete(s.maintenance, req.Id)
+	s.cancelledMaintenance[req.Id] = true
+	s.maintenanceMu.Unlock()
+	log.Printf("Cancelled maintenance window %s", req.Id)
+
+	if !cluster.IsForwarded(ctx) {
+		s.replicateMaintenance(ctx, w.tenant, func(ctx context.Context, client pb.AssetMonitoringServiceClient) error {
+			_, err := client.CancelMaintenance(ctx, req)
+			return err
+		})
+	}
+
+	return &pb.CancelMaintenanceResponse{
+		Success: true,
+		Message: "Maintenance window cancelled",
+	}, nil
+}
+
+// replicateMaintenance makes a maintenance change on every other instance
+// that's up. Those it misses catch up when they rejoin.
+func (s *server) replicateMaintenance(ctx context.Context, tenant string, change func(context.Context, pb.AssetMonitoringServiceClient) error) {
+	var wg sync.WaitGroup
+	for _, peer := range s.cluster.Members() {
+		if peer == s.cluster.Self() {
+			continue
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			// Peers that never got a window can't cancel it
+			if err := s.changeMaintenance(ctx, peer, tenant, change); err != nil && status.Code(err) != codes.NotFound {
+				log.Printf("Failed to copy maintenance change to %s: %v", peer, err)
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+// changeMaintenance makes a maintenance change on peer, acting for tenant. The
+// change goes ahead even if the caller goes away.
+func (s *server) changeMaintenance(ctx context.Context, peer, tenant string, change func(context.Context, pb.AssetMonitoringServiceClient) error) error {
+	ctx, cancel := context.WithTimeout(s.peerContext(context.WithoutCancel(ctx), tenant), maintenanceReplicationTimeout)
+	defer cancel()
+	return change(ctx, pb.NewAssetMonitoringServiceClient(s.cluster.Conn(peer)))
+}
+
+// peerContext marks calls made with ctx as forwarded and acting for tenant,
+// whatever credentials the peer connections carry.
+func (s *server) peerContext(ctx context.Context, tenant string) context.Context {
+	return metadata.AppendToOutgoingContext(cluster.ForwardContext(ctx, s.cluster.Self()), auth.TenantHeader, tenant)
+}
+
+// syncMaintenance copies this instance's windows to instances as they join,
+// so restarted instances and those that were cut off catch up, until ctx is
+// done.
+func (s *server) syncMaintenance(ctx context.Context) {
+	known := map[string]bool{s.cluster.Self(): true}
+	for {
+		changed := s.cluster.Changed()
+		members := s.cluster.Members()
+		for _, peer := range members {
+			if !known[peer] {
+				s.copyMaintenanceTo(ctx, peer)
+			}
+		}
+		known = make(map[string]bool, len(members))
+		for _, peer := range members {
+			known[peer] = true
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-changed:
+		}
+	}
+}
+
+// copyMaintenanceTo copies this instance's windows to peer, dropping those
+// the peer cancelled while the two were apart.
+func (s *server) copyMaintenanceTo(ctx context.Context, peer string) {
+	s.maintenanceMu.RLock()
+	windows := make([]*maintenanceWindow, 0, len(s.maintenance))
+	for _, id := range s.sortedMaintenanceIDs() {
+		windows = append(windows, s.maintenance[id])
+	}
+	s.maintenanceMu.RUnlock()
+
+	for _, w := range windows {
+		err := s.changeMaintenance(ctx, peer, w.tenant, func(ctx context.Context, client pb.AssetMonitoringServiceClient) error {
+			_, err := client.ScheduleMaintenance(ctx, &pb.ScheduleMaintenanceRequest{Window: w.window})
+			return err
+		})
+		switch {
+		case status.Code(err) == codes.FailedPrecondition:
+			s.maintenanceMu.Lock()
+			delete(s.maintenance, w.window.Id)
+			s.cancelledMaintenance[w.window.Id] = true
+			s.maintenanceMu.Unlock()
+			log.Printf("Dropped maintenance window %s cancelled by %s", w.window.Id, peer)
+		case err != nil:
+			log.Printf("Failed to copy maintenance window %s to %s: %v", w.window.Id, peer, err)
+		}
+	}
+}
@@ -0,0 +1,255 @@
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	assetpb "github.com/sairamkiran9/asset-telemetry-monitor/gen/go/proto/asset"
+	pb "github.com/sairamkiran9/asset-telemetry-monitor/gen/go/proto/asset_monitoring"
+	"github.com/sairamkiran9/asset-telemetry-monitor/internal/auth"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func newMaintenanceTestServer() *server {
+	return newServer(&mockAssetClient{assets: map[string]*assetpb.Asset{}}, &mockTelemetryClient{})
+}
+
+func scheduleWindow(t *testing.T, s *server, window *pb.MaintenanceWindow) *pb.MaintenanceWindow {
+	t.Helper()
+	resp, err := s.ScheduleMaintenance(context.Background(), &pb.ScheduleMaintenanceRequest{Window: window})
+	if err != nil {
+		t.Fatalf("ScheduleMaintenance failed: %v", err)
+	}
+	return resp.Window
+}
+
+func TestOneOffMaintenanceWindow(t *testing.T) {
+	s := newMaintenanceTestServer()
+	start := time.Date(2024, 6, 1, 8, 0, 0, 0, time.UTC)
+
+	scheduleWindow(t, s, &pb.MaintenanceWindow{
+		AssetId:         "asset-1",
+		StartTime:       timestamppb.New(start),
+		DurationSeconds: 3600,
+	})
+
+	tests := []struct {
+		name     string
+		at       time.Time
+		expected bool
+	}{
+		{"Before", start.Add(-time.Minute), false},
+		{"Start", start, true},
+		{"During", start.Add(30 * time.Minute), true},
+		{"End", start.Add(time.Hour), false},
+		{"Next day", start.Add(24 * time.Hour), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if active := s.activeMaintenance("asset-1", tt.at) != nil; active != tt.expected {
+				t.Errorf("activeMaintenance at %v = %v, want %v", tt.at, active, tt.expected)
+			}
+		})
+	}
+
+	if s.activeMaintenance("asset-2", start) != nil {
+		t.Error("Expected window not to apply to other assets")
+	}
+}
+
+func TestDailyMaintenanceWindowAcrossDST(t *testing.T) {
+	s := newMaintenanceTestServer()
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skipf("Time zone data unavailable: %v", err)
+	}
+
+	// 01:00-02:00 local every day, starting before the March DST change
+	scheduleWindow(t, s, &pb.MaintenanceWindow{
+		AssetId:         "asset-1",
+		StartTime:       timestamppb.New(time.Date(2024, 3, 8, 1, 0, 0, 0, newYork)),
+		DurationSeconds: 3600,
+		Recurrence:      pb.Recurrence_DAILY,
+		Timezone:        "America/New_York",
+	})
+
+	tests := []struct {
+		name     string
+		at       time.Time
+		expected bool
+	}{
+		{"First occurrence", time.Date(2024, 3, 8, 1, 30, 0, 0, newYork), true},
+		{"After DST change", time.Date(2024, 3, 11, 1, 30, 0, 0, newYork), true},
+		{"Outside window after DST change", time.Date(2024, 3, 11, 2, 30, 0, 0, newYork), false},
+		{"Midday", time.Date(2024, 3, 12, 12, 0, 0, 0, newYork), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if active := s.activeMaintenance("asset-1", tt.at) != nil; active != tt.expected {
+				t.Errorf("activeMaintenance at %v = %v, want %v", tt.at, active, tt.expected)
+			}
+		})
+	}
+}
+
+func TestWeeklyMaintenanceWindowWithSelector(t *testing.T) {
+	s := newMaintenanceTestServer()
+	start := time.Date(2024, 6, 3, 22, 0, 0, 0, time.UTC) // Monday
+
+	scheduleWindow(t, s, &pb.MaintenanceWindow{
+		Selector:        map[string]string{"building": "plant-1"},
+		StartTime:       timestamppb.New(start),
+		DurationSeconds: 4 * 3600, // Runs past midnight
+		Recurrence:      pb.Recurrence_WEEKLY,
+		RecurrenceEnd:   timestamppb.New(start.AddDate(0, 0, 7)),
+	})
+	s.setAssetMetadata("chiller-1", map[string]string{"building": "plant-1"})
+	s.setAssetMetadata("chiller-2", map[string]string{"building": "plant-2"})
+
+	if s.activeMaintenance("chiller-1", start.AddDate(0, 0, 8).Add(-21*time.Hour)) == nil {
+		t.Error("Expected window to cover the second Tuesday after midnight")
+	}
+	if s.activeMaintenance("chiller-1", start.AddDate(0, 0, 1)) != nil {
+		t.Error("Expected window to be inactive on Tuesday night")
+	}
+	if s.activeMaintenance("chiller-1", start.AddDate(0, 0, 14)) != nil {
+		t.Error("Expected no occurrences after recurrence_end")
+	}
+	if s.activeMaintenance("chiller-2", start) != nil {
+		t.Error("Expected selector not to match other buildings")
+	}
+}
+
+func TestGenerateAssetUpdateDuringMaintenance(t *testing.T) {
+	s := newMaintenanceTestServer()
+
+	window := scheduleWindow(t, s, &pb.MaintenanceWindow{
+		AssetId:         "asset-1",
+		StartTime:       timestamppb.New(time.Now().Add(-time.Minute)),
+		DurationSeconds: 3600,
+	})
+
+	monitor := &assetMonitor{
+		assetID:    "asset-1",
+		assetType:  pb.AssetType_CHILLWATER,
+		status:     pb.AssetStatus_OFFLINE,
+		lastUpdate: time.Now(),
+	}
+
+	update := s.generateAssetUpdate(monitor)
+	if update.Status != pb.AssetStatus_MAINTENANCE {
+		t.Errorf("Expected MAINTENANCE status, got %v", update.Status)
+	}
+
+	if _, err := s.CancelMaintenance(context.Background(), &pb.CancelMaintenanceRequest{Id: window.Id}); err != nil {
+		t.Fatalf("CancelMaintenance failed: %v", err)
+	}
+
+	update = s.generateAssetUpdate(monitor)
+	if update.Status == pb.AssetStatus_MAINTENANCE {
+		t.Error("Expected normal status after cancelling maintenance")
+	}
+}
+
+func TestScheduleMaintenanceValidation(t *testing.T) {
+	s := newMaintenanceTestServer()
+	now := timestamppb.Now()
+
+	tests := []struct {
+		name   string
+		window *pb.MaintenanceWindow
+	}{
+		{"Missing window", nil},
+		{"Missing target", &pb.MaintenanceWindow{StartTime: now, DurationSeconds: 60}},
+		{"Missing start", &pb.MaintenanceWindow{AssetId: "asset-1", DurationSeconds: 60}},
+		{"Missing duration", &pb.MaintenanceWindow{AssetId: "asset-1", StartTime: now}},
+		{"Unknown timezone", &pb.MaintenanceWindow{AssetId: "asset-1", StartTime: now, DurationSeconds: 60, Timezone: "Mars/Olympus"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := s.ScheduleMaintenance(context.Background(), &pb.ScheduleMaintenanceRequest{Window: tt.window})
+			if status.Code(err) != codes.InvalidArgument {
+				t.Errorf("Expected InvalidArgument error, got %v", err)
+			}
+		})
+	}
+}
+
+func TestListMaintenanceWindows(t *testing.T) {
+	s := newMaintenanceTestServer()
+	now := time.Now()
+
+	scheduleWindow(t, s, &pb.MaintenanceWindow{AssetId: "asset-1", StartTime: timestamppb.New(now.Add(-time.Minute)), DurationSeconds: 3600})
+	scheduleWindow(t, s, &pb.MaintenanceWindow{AssetId: "asset-1", StartTime: timestamppb.New(now.Add(time.Hour)), DurationSeconds: 3600})
+	scheduleWindow(t, s, &pb.MaintenanceWindow{AssetId: "asset-2", StartTime: timestamppb.New(now), DurationSeconds: 3600})
+
+	resp, _ := s.ListMaintenanceWindows(context.Background(), &pb.ListMaintenanceWindowsRequest{AssetId: "asset-1"})
+	if len(resp.Windows) != 2 {
+		t.Errorf("Expected 2 windows for asset-1, got %d", len(resp.Windows))
+	}
+
+	resp, _ = s.ListMaintenanceWindows(context.Background(), &pb.ListMaintenanceWindowsRequest{AssetId: "asset-1", ActiveOnly: true})
+	if len(resp.Windows) != 1 {
+		t.Errorf("Expected 1 active window for asset-1, got %d", len(resp.Windows))
+	}
+
+	_, err := s.CancelMaintenance(context.Background(), &pb.CancelMaintenanceRequest{Id: "maintenance-404"})
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("Expected NotFound error, got %v", err)
+	}
+}
+
+func TestMaintenanceTenantIsolation(t *testing.T) {
+	s := newMaintenanceTestServer()
+	north := auth.ContextWithTenant(context.Background(), "north-campus")
+	south := auth.ContextWithTenant(context.Background(), "south-campus")
+	s.setAssetTenant("chiller-1", "north-campus")
+	s.setAssetTenant("boiler-1", "south-campus")
+	s.setAssetMetadata("chiller-1", map[string]string{"building": "plant-1"})
+	s.setAssetMetadata("boiler-1", map[string]string{"building": "plant-1"})
+	now := time.Now()
+
+	// South can name north's asset, but its windows never apply to it
+	resp, err := s.ScheduleMaintenance(south, &pb.ScheduleMaintenanceRequest{Window: &pb.MaintenanceWindow{
+		AssetId: "chiller-1", StartTime: timestamppb.New(now.Add(-time.Minute)), DurationSeconds: 3600,
+	}})
+	if err != nil {
+		t.Fatalf("ScheduleMaintenance failed: %v", err)
+	}
+	if s.activeMaintenance("chiller-1", now) != nil {
+		t.Error("Expected another tenant's window not to apply")
+	}
+
+	// Selectors only match the scheduling tenant's assets
+	if _, err := s.ScheduleMaintenance(north, &pb.ScheduleMaintenanceRequest{Window: &pb.MaintenanceWindow{
+		Selector: map[string]string{"building": "plant-1"}, StartTime: timestamppb.New(now.Add(-time.Minute)), DurationSeconds: 3600,
+	}}); err != nil {
+		t.Fatalf("ScheduleMaintenance failed: %v", err)
+	}
+	if s.activeMaintenance("chiller-1", now) == nil {
+		t.Error("Expected selector to match own asset")
+	}
+	if s.activeMaintenance("boiler-1", now) != nil {
+		t.Error("Expected selector not to match another tenant's asset")
+	}
+
+	if listResp, _ := s.ListMaintenanceWindows(north, &pb.ListMaintenanceWindowsRequest{}); len(listResp.Windows) != 1 {
+		t.Errorf("Expected 1 window for north-campus, got %d", len(listResp.Windows))
+	}
+	if _, err := s.CancelMaintenance(north, &pb.CancelMaintenanceRequest{Id: resp.Window.Id}); status.Code(err) != codes.NotFound {
+		t.Errorf("Expected NotFound cancelling another tenant's window, got %v", err)
+	}
+
+	service := auth.ContextWithTenant(context.Background(), auth.AnyTenant)
+	if _, err := s.ScheduleMaintenance(service, &pb.ScheduleMaintenanceRequest{Window: &pb.MaintenanceWindow{
+		AssetId: "chiller-1", StartTime: timestamppb.New(now), DurationSeconds: 60,
+	}}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Expected InvalidArgument without a tenant, got %v", err)
+	}
+}
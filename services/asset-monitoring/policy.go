@@ -0,0 +1,19 @@
+package main
+
+import (
+	pb "github.com/sairamkiran9/asset-telemetry-monitor/gen/go/proto/asset_monitoring"
+	"github.com/sairamkiran9/asset-telemetry-monitor/internal/auth"
+)
+
+// policy lists the roles allowed to call each RPC of the asset monitoring
+// service. RPCs naming an asset are open to credentials scoped to that
+// asset.
+var policy = auth.Policy{
+	pb.AssetMonitoringService_StreamAssetStatus_FullMethodName:      {Roles: []auth.Role{auth.RoleViewer}, AssetField: "asset_id"},
+	pb.AssetMonitoringService_SubscribeToReadings_FullMethodName:    {Roles: []auth.Role{auth.RoleViewer}, AssetField: "asset_ids"},
+	pb.AssetMonitoringService_GetCurrentStatus_FullMethodName:       {Roles: []auth.Role{auth.RoleViewer}, AssetField: "asset_id"},
+	pb.AssetMonitoringService_ListPowerQualityEvents_FullMethodName: {Roles: []auth.Role{auth.RoleViewer}, AssetField: "asset_id"},
+	pb.AssetMonitoringService_ScheduleMaintenance_FullMethodName:    {Roles: []auth.Role{auth.RoleOperator}},
+	pb.AssetMonitoringService_ListMaintenanceWindows_FullMethodName: {Roles: []auth.Role{auth.RoleViewer}},
+	pb.AssetMonitoringService_CancelMaintenance_FullMethodName:      {Roles: []auth.Role{auth.RoleOperator}},
+}
@@ -0,0 +1,327 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	pb "github.com/sairamkiran9/asset-telemetry-monitor/gen/go/proto/asset_monitoring"
+	"github.com/sairamkiran9/asset-telemetry-monitor/internal/cluster"
+)
+
+const (
+	// IEEE 1159 voltage variation thresholds, per-unit of nominal voltage
+	interruptionThreshold = 0.1
+	sagThreshold          = 0.9
+	swellThreshold        = 1.1
+
+	defaultNominalVoltage   = 220.0 // Volts
+	defaultNominalFrequency = 50.0  // Hertz
+
+	frequencyTolerance        = 0.5 // Hertz
+	lowPowerFactorThreshold   = 0.9
+	lowPowerFactorMinDuration = time.Minute
+	imbalanceThreshold        = 2.0 // Percent, NEMA MG1 definition
+
+	// Recorded events kept per asset; the oldest are dropped first
+	maxPowerQualityEvents = 1000
+)
+
+// pqCondition is an abnormal condition observed in a single reading.
+type pqCondition struct {
+	eventType   pb.PowerQualityEventType
+	phase       string
+	magnitude   float64
+	minDuration time.Duration
+}
+
+// activeEvent tracks a condition that has not cleared yet. Conditions with a
+// minimum duration are only recorded once they have lasted long enough.
+type activeEvent struct {
+	event    *pb.PowerQualityEvent
+	recorded bool
+	minimum  time.Duration
+}
+
+type powerQualityDetector struct {
+	mu               sync.Mutex
+	assetID          string
+	nominalVoltage   float64
+	nominalFrequency float64
+	phases           string // "1", "3" or empty to go by the readings
+	active           map[string]*activeEvent
+	events           []*pb.PowerQualityEvent
+	eventCounter     int
+	lastSeen         time.Time
+}
+
+func newPowerQualityDetector(assetID string) *powerQualityDetector {
+	return &powerQualityDetector{
+		assetID:          assetID,
+		nominalVoltage:   defaultNominalVoltage,
+		nominalFrequency: defaultNominalFrequency,
+		active:           make(map[string]*activeEvent),
+	}
+}
+
+// configure applies nominal_voltage, nominal_frequency and phases from asset
+// metadata.
+func (d *powerQualityDetector) configure(metadata map[string]string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if v, err := strconv.ParseFloat(metadata["nominal_voltage"], 64); err == nil && v > 0 {
+		d.nominalVoltage = v
+	}
+	if f, err := strconv.ParseFloat(metadata["nominal_frequency"], 64); err == nil && f > 0 {
+		d.nominalFrequency = f
+	}
+	d.phases = metadata["phases"]
+}
+
+// observe evaluates one reading, opening, extending and closing events.
+func (d *powerQualityDetector) observe(at time.Time, readings *pb.ElectricReadings) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	conditions := d.evaluate(readings)
+
+	// Close events whose condition cleared or changed type
+	for key, active := range d.active {
+		if cond, ok := conditions[key]; ok && cond.eventType == active.event.Type {
+			continue
+		}
+		d.close(key, at)
+	}
+
+	for key, cond := range conditions {
+		active, exists := d.active[key]
+		if !exists {
+			d.eventCounter++
+			active = &activeEvent{
+				event: &pb.PowerQualityEvent{
+					Id:        fmt.Sprintf("%s-pq-%d", d.assetID, d.eventCounter),
+					AssetId:   d.assetID,
+					Type:      cond.eventType,
+					Phase:     cond.phase,
+					StartTime: timestamppb.New(at),
+					Magnitude: cond.magnitude,
+				},
+				minimum: cond.minDuration,
+			}
+			d.active[key] = active
+		} else if isWorse(cond.eventType, cond.magnitude, active.event.Magnitude) {
+			active.event.Magnitude = cond.magnitude
+		}
+
+		duration := at.Sub(active.event.StartTime.AsTime())
+		active.event.Classification = d.classify(active.event.Type, duration)
+		if !active.recorded && duration >= active.minimum {
+			active.recorded = true
+			d.events = append(d.events, active.event)
+			if len(d.events) > maxPowerQualityEvents {
+				d.events = slices.Delete(d.events, 0, len(d.events)-maxPowerQualityEvents)
+			}
+			log.Printf("Power quality event on asset %s: %s (phase %q, magnitude %.3f)",
+				d.assetID, active.event.Type, active.event.Phase, active.event.Magnitude)
+		}
+	}
+
+	d.lastSeen = at
+}
+
+// evaluate returns the abnormal conditions present in a reading, keyed by the
+// quantity and phase they apply to.
+func (d *powerQualityDetector) evaluate(r *pb.ElectricReadings) map[string]pqCondition {
+	conditions := make(map[string]pqCondition)
+
+	// A phase reading 0 V is lost, not absent, so any per-phase voltage makes
+	// the reading three-phase unless the metadata says otherwise
+	threePhase := d.phases == "3" || (d.phases != "1" && (r.VoltageA > 0 || r.VoltageB > 0 || r.VoltageC > 0))
+	phases := map[string]float64{"": r.Voltage}
+	if threePhase {
+		phases = map[string]float64{"A": r.VoltageA, "B": r.VoltageB, "C": r.VoltageC}
+	}
+	for phase, voltage := range phases {
+		pu := voltage / d.nominalVoltage
+		var eventType pb.PowerQualityEventType
+		switch {
+		case pu < interruptionThreshold:
+			eventType = pb.PowerQualityEventType_INTERRUPTION
+		case pu < sagThreshold:
+			eventType = pb.PowerQualityEventType_VOLTAGE_SAG
+		case pu > swellThreshold:
+			eventType = pb.PowerQualityEventType_VOLTAGE_SWELL
+		default:
+			continue
+		}
+		conditions["voltage:"+phase] = pqCondition{eventType: eventType, phase: phase, magnitude: pu}
+	}
+
+	if deviation := r.Frequency - d.nominalFrequency; r.Frequency > 0 && math.Abs(deviation) > frequencyTolerance {
+		conditions["frequency"] = pqCondition{
+			eventType: pb.PowerQualityEventType_FREQUENCY_EXCURSION,
+			magnitude: deviation,
+		}
+	}
+
+	if r.PowerFactor > 0 && r.PowerFactor < lowPowerFactorThreshold {
+		conditions["power_factor"] = pqCondition{
+			eventType:   pb.PowerQualityEventType_LOW_POWER_FACTOR,
+			magnitude:   r.PowerFactor,
+			minDuration: lowPowerFactorMinDuration,
+		}
+	}
+
+	if threePhase {
+		if imbalance := phaseImbalance(r.VoltageA, r.VoltageB, r.VoltageC); imbalance > imbalanceThreshold {
+			conditions["imbalance"] = pqCondition{
+				eventType: pb.PowerQualityEventType_PHASE_IMBALANCE,
+				magnitude: imbalance,
+			}
+		}
+	}
+
+	return conditions
+}
+
+// close ends the active event for key. Events that never reached their
+// minimum duration are discarded.
+func (d *powerQualityDetector) close(key string, at time.Time) {
+	active := d.active[key]
+	delete(d.active, key)
+	if !active.recorded {
+		return
+	}
+	active.event.EndTime = timestamppb.New(at)
+	active.event.Classification = d.classify(active.event.Type, at.Sub(active.event.StartTime.AsTime()))
+}
+
+// reset closes all ongoing events at the last observed reading, so a monitor
+// restart doesn't stretch them over the time nobody was watching.
+func (d *powerQualityDetector) reset() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	for key := range d.active {
+		d.close(key, d.lastSeen)
+	}
+}
+
+// classify maps a voltage event duration to its IEEE 1159 category.
+func (d *powerQualityDetector) classify(eventType pb.PowerQualityEventType, duration time.Duration) string {
+	cycles := duration.Seconds() * d.nominalFrequency
+
+	switch eventType {
+	case pb.PowerQualityEventType_INTERRUPTION:
+		switch {
+		case duration <= 3*time.Second:
+			return "momentary"
+		case duration <= time.Minute:
+			return "temporary"
+		default:
+			return "sustained"
+		}
+	case pb.PowerQualityEventType_VOLTAGE_SAG, pb.PowerQualityEventType_VOLTAGE_SWELL:
+		switch {
+		case cycles <= 30:
+			return "instantaneous"
+		case duration <= 3*time.Second:
+			return "momentary"
+		case duration <= time.Minute:
+			return "temporary"
+		case eventType == pb.PowerQualityEventType_VOLTAGE_SAG:
+			return "undervoltage"
+		default:
+			return "overvoltage"
+		}
+	default:
+		return ""
+	}
+}
+
+// list returns copies of the events overlapping [start, end). A zero start or
+// end leaves that side of the range open.
+func (d *powerQualityDetector) list(start, end time.Time) []*pb.PowerQualityEvent {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	events := make([]*pb.PowerQualityEvent, 0, len(d.events))
+	for _, event := range d.events {
+		if !end.IsZero() && !event.StartTime.AsTime().Before(end) {
+			continue
+		}
+		if !start.IsZero() && event.EndTime != nil && event.EndTime.AsTime().Before(start) {
+			continue
+		}
+		events = append(events, proto.Clone(event).(*pb.PowerQualityEvent))
+	}
+	return events
+}
+
+// isWorse reports whether magnitude is more severe than current for the type.
+func isWorse(eventType pb.PowerQualityEventType, magnitude, current float64) bool {
+	switch eventType {
+	case pb.PowerQualityEventType_VOLTAGE_SAG, pb.PowerQualityEventType_INTERRUPTION,
+		pb.PowerQualityEventType_LOW_POWER_FACTOR:
+		return magnitude < current
+	case pb.PowerQualityEventType_FREQUENCY_EXCURSION:
+		return math.Abs(magnitude) > math.Abs(current)
+	default:
+		return magnitude > current
+	}
+}
+
+// phaseImbalance returns the maximum deviation from the phase average as a
+// percentage of the average.
+func phaseImbalance(a, b, c float64) float64 {
+	avg := (a + b + c) / 3
+	if avg == 0 {
+		return 0
+	}
+	maxDeviation := math.Max(math.Abs(a-avg), math.Max(math.Abs(b-avg), math.Abs(c-avg)))
+	return maxDeviation / avg * 100
+}
+
+func (s *server) ListPowerQualityEvents(ctx context.Context, req *pb.ListPowerQualityEventsRequest) (*pb.ListPowerQualityEventsResponse, error) {
+	if req.AssetId == "" {
+		return nil, status.Error(codes.InvalidArgument, "asset_id is required")
+	}
+
+	// Events are detected by the asset's owner
+	if owner, local := s.cluster.Owner(req.AssetId); !local && !cluster.IsForwarded(ctx) {
+		client := pb.NewAssetMonitoringServiceClient(s.cluster.Conn(owner))
+		return client.ListPowerQualityEvents(cluster.ForwardContext(ctx, s.cluster.Self()), req)
+	}
+
+	s.mu.RLock()
+	detector, exists := s.powerQuality[req.AssetId]
+	s.mu.RUnlock()
+
+	// Other tenants' assets read as having no events
+	if !exists || !s.canAccessAsset(ctx, req.AssetId) {
+		return &pb.ListPowerQualityEventsResponse{}, nil
+	}
+
+	var start, end time.Time
+	if req.StartTime != nil {
+		start = req.StartTime.AsTime()
+	}
+	if req.EndTime != nil {
+		end = req.EndTime.AsTime()
+	}
+
+	return &pb.ListPowerQualityEventsResponse{
+		Events: detector.list(start, end),
+	}, nil
+}
@@ -0,0 +1,244 @@
+package main
+
+import (
+	"context"
+	"math"
+	"testing"
+	"time"
+
+	assetpb "github.com/sairamkiran9/asset-telemetry-monitor/gen/go/proto/asset"
+	pb "github.com/sairamkiran9/asset-telemetry-monitor/gen/go/proto/asset_monitoring"
+	"github.com/sairamkiran9/asset-telemetry-monitor/internal/auth"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func normalReadings() *pb.ElectricReadings {
+	return &pb.ElectricReadings{
+		Voltage:     220,
+		Frequency:   50,
+		PowerFactor: 0.95,
+	}
+}
+
+func TestPowerQualityVoltageSag(t *testing.T) {
+	d := newPowerQualityDetector("asset-1")
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	d.observe(start, normalReadings())
+
+	sag := normalReadings()
+	sag.Voltage = 176 // 0.8 pu
+	d.observe(start.Add(time.Second), sag)
+	sag.Voltage = 154 // 0.7 pu
+	d.observe(start.Add(2*time.Second), sag)
+
+	d.observe(start.Add(3*time.Second), normalReadings())
+
+	events := d.list(time.Time{}, time.Time{})
+	if len(events) != 1 {
+		t.Fatalf("Expected 1 event, got %d", len(events))
+	}
+
+	event := events[0]
+	if event.Type != pb.PowerQualityEventType_VOLTAGE_SAG {
+		t.Errorf("Expected VOLTAGE_SAG, got %v", event.Type)
+	}
+	if math.Abs(event.Magnitude-0.7) > 1e-9 {
+		t.Errorf("Expected magnitude=0.7 pu, got %f", event.Magnitude)
+	}
+	if event.EndTime == nil || !event.EndTime.AsTime().Equal(start.Add(3*time.Second)) {
+		t.Errorf("Expected event to end at %v, got %v", start.Add(3*time.Second), event.EndTime)
+	}
+	if event.Classification != "momentary" {
+		t.Errorf("Expected classification=momentary, got %s", event.Classification)
+	}
+}
+
+func TestPowerQualityPerPhaseSwell(t *testing.T) {
+	d := newPowerQualityDetector("asset-1")
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	readings := normalReadings()
+	readings.VoltageA = 220
+	readings.VoltageB = 250 // 1.14 pu
+	readings.VoltageC = 220
+	d.observe(start, readings)
+
+	events := d.list(time.Time{}, time.Time{})
+
+	var swell, imbalance *pb.PowerQualityEvent
+	for _, event := range events {
+		switch event.Type {
+		case pb.PowerQualityEventType_VOLTAGE_SWELL:
+			swell = event
+		case pb.PowerQualityEventType_PHASE_IMBALANCE:
+			imbalance = event
+		}
+	}
+
+	if swell == nil || swell.Phase != "B" {
+		t.Errorf("Expected swell on phase B, got %v", swell)
+	}
+	if imbalance == nil {
+		t.Fatal("Expected phase imbalance event")
+	}
+	if imbalance.EndTime != nil {
+		t.Error("Expected imbalance event to be ongoing")
+	}
+}
+
+func TestPowerQualityLostPhase(t *testing.T) {
+	d := newPowerQualityDetector("asset-1")
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	readings := normalReadings()
+	readings.VoltageA = 176 // 0.8 pu
+	readings.VoltageB = 220
+	readings.VoltageC = 220
+	d.observe(start, readings)
+
+	// Losing the sagging phase deepens its event rather than closing it
+	readings.VoltageA = 0
+	d.observe(start.Add(time.Second), readings)
+
+	found := make(map[pb.PowerQualityEventType]*pb.PowerQualityEvent)
+	for _, event := range d.list(time.Time{}, time.Time{}) {
+		found[event.Type] = event
+	}
+	if sag := found[pb.PowerQualityEventType_VOLTAGE_SAG]; sag == nil || sag.Phase != "A" || sag.EndTime == nil {
+		t.Errorf("Expected a closed sag on phase A, got %v", sag)
+	}
+	if lost := found[pb.PowerQualityEventType_INTERRUPTION]; lost == nil || lost.Phase != "A" || lost.EndTime != nil {
+		t.Errorf("Expected an ongoing interruption on phase A, got %v", lost)
+	}
+	if found[pb.PowerQualityEventType_PHASE_IMBALANCE] == nil {
+		t.Error("Expected phase imbalance event")
+	}
+
+	// Single-phase assets go by the aggregate voltage
+	d = newPowerQualityDetector("asset-2")
+	d.configure(map[string]string{"phases": "1"})
+	d.observe(start, readings)
+	if events := d.list(time.Time{}, time.Time{}); len(events) != 0 {
+		t.Errorf("Expected no events for a single-phase asset, got %v", events)
+	}
+}
+
+func TestPowerQualityEventHistoryCapped(t *testing.T) {
+	d := newPowerQualityDetector("asset-1")
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	sag := normalReadings()
+	sag.Voltage = 176
+	for i := 0; i < maxPowerQualityEvents+10; i++ {
+		d.observe(start.Add(time.Duration(2*i)*time.Second), sag)
+		d.observe(start.Add(time.Duration(2*i+1)*time.Second), normalReadings())
+	}
+
+	events := d.list(time.Time{}, time.Time{})
+	if len(events) != maxPowerQualityEvents {
+		t.Fatalf("Expected %d events, got %d", maxPowerQualityEvents, len(events))
+	}
+	if !events[0].StartTime.AsTime().Equal(start.Add(20 * time.Second)) {
+		t.Errorf("Expected the oldest events dropped, first starts at %v", events[0].StartTime.AsTime())
+	}
+}
+
+func TestPowerQualityFrequencyExcursion(t *testing.T) {
+	d := newPowerQualityDetector("asset-1")
+	d.configure(map[string]string{"nominal_frequency": "60"})
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	readings := normalReadings()
+	readings.Frequency = 59.2
+	d.observe(start, readings)
+
+	events := d.list(time.Time{}, time.Time{})
+	if len(events) != 1 || events[0].Type != pb.PowerQualityEventType_FREQUENCY_EXCURSION {
+		t.Fatalf("Expected a frequency excursion, got %v", events)
+	}
+	if math.Abs(events[0].Magnitude+0.8) > 1e-9 {
+		t.Errorf("Expected magnitude=-0.8 Hz, got %f", events[0].Magnitude)
+	}
+}
+
+func TestPowerQualitySustainedLowPowerFactor(t *testing.T) {
+	d := newPowerQualityDetector("asset-1")
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	lowPF := normalReadings()
+	lowPF.PowerFactor = 0.8
+
+	// A short dip is not sustained
+	d.observe(start, lowPF)
+	d.observe(start.Add(10*time.Second), normalReadings())
+	if events := d.list(time.Time{}, time.Time{}); len(events) != 0 {
+		t.Fatalf("Expected no events for a short dip, got %d", len(events))
+	}
+
+	for i := 0; i <= 60; i++ {
+		d.observe(start.Add(time.Minute+time.Duration(i)*time.Second), lowPF)
+	}
+
+	events := d.list(time.Time{}, time.Time{})
+	if len(events) != 1 || events[0].Type != pb.PowerQualityEventType_LOW_POWER_FACTOR {
+		t.Fatalf("Expected a low power factor event, got %v", events)
+	}
+}
+
+func TestPowerQualityListTimeRange(t *testing.T) {
+	d := newPowerQualityDetector("asset-1")
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	sag := normalReadings()
+	sag.Voltage = 100
+	d.observe(start, sag)
+	d.observe(start.Add(time.Second), normalReadings())
+
+	if events := d.list(start.Add(time.Hour), time.Time{}); len(events) != 0 {
+		t.Errorf("Expected no events after the range start, got %d", len(events))
+	}
+	if events := d.list(time.Time{}, start.Add(-time.Hour)); len(events) != 0 {
+		t.Errorf("Expected no events before the range end, got %d", len(events))
+	}
+}
+
+func TestListPowerQualityEvents(t *testing.T) {
+	mockAsset := &mockAssetClient{
+		assets: map[string]*assetpb.Asset{
+			"asset-1": {Id: "asset-1", Name: "Test Asset", Type: "electric"},
+		},
+	}
+	s := newServer(mockAsset, &mockTelemetryClient{})
+
+	if err := s.startMonitoring(context.Background(), "asset-1", "electric"); err != nil {
+		t.Fatalf("startMonitoring failed: %v", err)
+	}
+	defer s.monitors["asset-1"].cancel()
+
+	sag := normalReadings()
+	sag.Voltage = 10
+	s.powerQuality["asset-1"].observe(time.Now(), sag)
+
+	resp, err := s.ListPowerQualityEvents(context.Background(), &pb.ListPowerQualityEventsRequest{
+		AssetId:   "asset-1",
+		StartTime: timestamppb.New(time.Now().Add(-time.Hour)),
+	})
+	if err != nil {
+		t.Fatalf("ListPowerQualityEvents failed: %v", err)
+	}
+
+	if len(resp.Events) != 1 || resp.Events[0].Type != pb.PowerQualityEventType_INTERRUPTION {
+		t.Errorf("Expected one interruption event, got %v", resp.Events)
+	}
+
+	s.setAssetTenant("asset-1", "north-campus")
+	resp, _ = s.ListPowerQualityEvents(auth.ContextWithTenant(context.Background(), "south-campus"), &pb.ListPowerQualityEventsRequest{AssetId: "asset-1"})
+	if len(resp.Events) != 0 {
+		t.Errorf("Expected no events for another tenant, got %d", len(resp.Events))
+	}
+
+	if _, err := s.ListPowerQualityEvents(context.Background(), &pb.ListPowerQualityEventsRequest{}); err == nil {
+		t.Error("Expected error for missing asset_id")
+	}
+}
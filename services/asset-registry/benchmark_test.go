@@ -16,7 +16,7 @@ func BenchmarkRegisterAsset(b *testing.B) {
 	for i := 0; i < b.N; i++ {
 		req := &pb.RegisterAssetRequest{
 			Name:        fmt.Sprintf("Asset-%d", i),
-			Type:        "temperature",
+			Type:        "sensor",
 			Description: "Benchmark asset",
 		}
 		_, _ = s.RegisterAsset(ctx, req)
@@ -31,7 +31,7 @@ func BenchmarkGetAsset(b *testing.B) {
 	for i := 0; i < 1000; i++ {
 		req := &pb.RegisterAssetRequest{
 			Name: fmt.Sprintf("Asset-%d", i),
-			Type: "temperature",
+			Type: "sensor",
 		}
 		s.RegisterAsset(ctx, req)
 	}
@@ -52,7 +52,7 @@ func BenchmarkListAssets(b *testing.B) {
 	for i := 0; i < 100; i++ {
 		req := &pb.RegisterAssetRequest{
 			Name: fmt.Sprintf("Asset-%d", i),
-			Type: "temperature",
+			Type: "sensor",
 		}
 		s.RegisterAsset(ctx, req)
 	}
@@ -74,7 +74,7 @@ func BenchmarkRegisterAssetConcurrent(b *testing.B) {
 		for p.Next() {
 			req := &pb.RegisterAssetRequest{
 				Name: fmt.Sprintf("Asset-%d", i),
-				Type: "temperature",
+				Type: "sensor",
 			}
 			_, _ = s.RegisterAsset(ctx, req)
 			i++
@@ -90,7 +90,7 @@ func BenchmarkGetAssetConcurrent(b *testing.B) {
 	for i := 0; i < 1000; i++ {
 		req := &pb.RegisterAssetRequest{
 			Name: fmt.Sprintf("Asset-%d", i),
-			Type: "temperature",
+			Type: "sensor",
 		}
 		s.RegisterAsset(ctx, req)
 	}
@@ -116,7 +116,7 @@ func BenchmarkRegisterAssetAllocs(b *testing.B) {
 	for i := 0; i < b.N; i++ {
 		req := &pb.RegisterAssetRequest{
 			Name:        "Test Asset",
-			Type:        "temperature",
+			Type:        "sensor",
 			Description: "Benchmark",
 		}
 		_, _ = s.RegisterAsset(ctx, req)
@@ -0,0 +1,325 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"maps"
+	"sort"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/sairamkiran9/asset-telemetry-monitor/gen/go/proto/asset"
+	"github.com/sairamkiran9/asset-telemetry-monitor/internal/auth"
+)
+
+const (
+	defaultExternalIDKey = "external_id"
+
+	// Records accepted per ImportAssets call
+	maxImportRecords = 10000
+)
+
+// externalIDs indexes a tenant's assets by the external ID in their key
+// metadata. IDs several assets share map to nil.
+func (s *server) externalIDs(tenant, key string) map[string]*pb.Asset {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	index := make(map[string]*pb.Asset)
+	for _, asset := range s.assets {
+		id := asset.Metadata[key]
+		if asset.Tenant != tenant || id == "" {
+			continue
+		}
+		if _, taken := index[id]; taken {
+			index[id] = nil
+			continue
+		}
+		index[id] = asset
+	}
+	return index
+}
+
+// ImportAssets upserts each record through the same handlers single changes
+// go through, so records are validated, replicated and watched alike. A
+// failed record doesn't stop the others; a dry run reports what each record
+// would do, taking the assets earlier batches of it would create as created.
+func (s *server) ImportAssets(ctx context.Context, req *pb.ImportAssetsRequest) (*pb.ImportAssetsResponse, error) {
+	if len(req.Records) > maxImportRecords {
+		return nil, status.Errorf(codes.InvalidArgument, "at most %d records can be imported at once, got %d", maxImportRecords, len(req.Records))
+	}
+	tenant, err := auth.RequireTenant(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	leader, leaderCtx, err := s.repl.route(ctx, !req.DryRun)
+	if err != nil {
+		return nil, err
+	}
+	if leader != nil {
+		return leader.ImportAssets(leaderCtx, req)
+	}
+
+	key := req.ExternalIdKey
+	if key == "" {
+		key = defaultExternalIDKey
+	}
+	imp := &importer{
+		s:       s,
+		ctx:     ctx,
+		tenant:  tenant,
+		key:     key,
+		dryRun:  req.DryRun,
+		index:   s.externalIDs(tenant, key),
+		seen:    make(map[string]bool),
+		planned: make(map[string]bool),
+	}
+	if req.DryRun {
+		s.mu.RLock()
+		imp.assets = s.tenantAssets[tenant]
+		s.mu.RUnlock()
+		for _, id := range req.PlannedExternalIds {
+			imp.planned[id] = true
+		}
+	}
+
+	resp := &pb.ImportAssetsResponse{Results: make([]*pb.ImportResult, len(req.Records))}
+	for i, record := range req.Records {
+		result := &pb.ImportResult{ExternalId: record.ExternalId}
+		result.Action, result.AssetId, err = imp.upsert(record)
+		if err != nil {
+			result.Action = pb.ImportAction_FAILED
+			result.Error = status.Convert(err).Message()
+		}
+		resp.Results[i] = result
+
+		switch result.Action {
+		case pb.ImportAction_CREATE:
+			resp.Created++
+		case pb.ImportAction_UPDATE:
+			resp.Updated++
+		case pb.ImportAction_UNCHANGED:
+			resp.Unchanged++
+		case pb.ImportAction_FAILED:
+			resp.Failed++
+		}
+	}
+
+	log.Printf("Imported %d assets for tenant %s (dry run: %t): %d created, %d updated, %d unchanged, %d failed",
+		len(req.Records), tenant, req.DryRun, resp.Created, resp.Updated, resp.Unchanged, resp.Failed)
+	return resp, nil
+}
+
+// importer upserts the records of one ImportAssets call.
+type importer struct {
+	s      *server
+	ctx    context.Context
+	tenant string
+	key    string
+	dryRun bool
+	assets int // The tenant's assets before a dry run, for its quota
+
+	// Assets by external ID, including those the import created, the
+	// external IDs of records done so far and those a dry run would create
+	index   map[string]*pb.Asset
+	seen    map[string]bool
+	planned map[string]bool
+}
+
+func (imp *importer) upsert(record *pb.AssetRecord) (pb.ImportAction, string, error) {
+	switch {
+	case record.ExternalId == "":
+		return 0, "", errors.New("external_id is required")
+	case imp.seen[record.ExternalId]:
+		return 0, "", fmt.Errorf("external_id %s appears in an earlier record", record.ExternalId)
+	}
+	imp.seen[record.ExternalId] = true
+	if record.Name == "" {
+		return 0, "", errors.New("name is required")
+	}
+
+	metadata := maps.Clone(record.Metadata)
+	if metadata == nil {
+		metadata = make(map[string]string)
+	}
+	metadata[imp.key] = record.ExternalId
+
+	var parentID string
+	if record.ParentExternalId != "" {
+		parent, found := imp.index[record.ParentExternalId]
+		switch {
+		case found && parent == nil:
+			return 0, "", fmt.Errorf("parent %s is the external_id of several assets", record.ParentExternalId)
+		case found:
+			parentID = parent.Id
+		case !imp.planned[record.ParentExternalId]:
+			return 0, "", fmt.Errorf("parent %s not found; parents must be imported before their children", record.ParentExternalId)
+		}
+	}
+
+	current, found := imp.index[record.ExternalId]
+	if found && current == nil {
+		return 0, "", fmt.Errorf("external_id %s matches several assets", record.ExternalId)
+	}
+	if !found {
+		return imp.create(record, metadata, parentID)
+	}
+	return imp.update(current, record, metadata, parentID)
+}
+
+func (imp *importer) create(record *pb.AssetRecord, metadata map[string]string, parentID string) (pb.ImportAction, string, error) {
+	if imp.dryRun {
+		if _, err := imp.s.checkType(record.Type, metadata); err != nil {
+			return 0, "", err
+		}
+		if quota := imp.s.maxAssetsPerTenant; quota > 0 && imp.assets+len(imp.planned) >= quota {
+			return 0, "", status.Errorf(codes.ResourceExhausted, "tenant %s has reached its quota of %d assets", imp.tenant, quota)
+		}
+		imp.planned[record.ExternalId] = true
+		return pb.ImportAction_CREATE, "", nil
+	}
+
+	resp, err := imp.s.RegisterAsset(imp.ctx, &pb.RegisterAssetRequest{
+		Name:        record.Name,
+		Type:        record.Type,
+		Description: record.Description,
+		Metadata:    metadata,
+		ParentId:    parentID,
+	})
+	if err != nil {
+		return 0, "", err
+	}
+	imp.index[record.ExternalId] = resp.Asset
+	return pb.ImportAction_CREATE, resp.Asset.Id, nil
+}
+
+// update changes what the record sets of an existing asset. parentID is
+// empty for parents a dry run would create.
+func (imp *importer) update(current *pb.Asset, record *pb.AssetRecord, metadata map[string]string, parentID string) (pb.ImportAction, string, error) {
+	typeName := record.Type
+	if typeName == "" {
+		typeName = current.Type
+	}
+	typeName, err := imp.s.checkType(typeName, metadata)
+	if err != nil {
+		return 0, "", err
+	}
+
+	changed := record.Name != current.Name ||
+		typeName != current.Type ||
+		(record.Description != "" && record.Description != current.Description) ||
+		!maps.Equal(metadata, current.Metadata)
+	moved := record.ParentExternalId != "" && (parentID == "" || parentID != current.ParentId)
+	if !changed && !moved {
+		return pb.ImportAction_UNCHANGED, current.Id, nil
+	}
+	if imp.dryRun {
+		return pb.ImportAction_UPDATE, current.Id, nil
+	}
+
+	asset := current
+	if changed {
+		resp, err := imp.s.UpdateAsset(imp.ctx, &pb.UpdateAssetRequest{
+			Id:          current.Id,
+			Name:        record.Name,
+			Type:        typeName,
+			Description: record.Description,
+			Metadata:    metadata,
+		})
+		if err != nil {
+			return 0, "", err
+		}
+		asset = resp.Asset
+	}
+	if moved {
+		resp, err := imp.s.SetParent(imp.ctx, &pb.SetParentRequest{AssetId: current.Id, ParentId: parentID})
+		if err != nil {
+			return 0, "", err
+		}
+		asset = resp.Asset
+	}
+	imp.index[record.ExternalId] = asset
+	return pb.ImportAction_UPDATE, asset.Id, nil
+}
+
+// ExportAssets streams the caller's assets with parents before their
+// children, so the export can be imported again in the order it was written.
+func (s *server) ExportAssets(req *pb.ExportAssetsRequest, stream pb.AssetRegistry_ExportAssetsServer) error {
+	ctx := stream.Context()
+	leader, leaderCtx, err := s.repl.route(ctx, false)
+	if err != nil {
+		return err
+	}
+	if leader != nil {
+		return forwardExport(leaderCtx, leader, req, stream)
+	}
+
+	typeName := req.Type
+	if typeName != "" {
+		t, found := s.types.Lookup(typeName)
+		if !found {
+			return status.Errorf(codes.InvalidArgument, "unknown asset type %q", typeName)
+		}
+		typeName = t.Name
+	}
+
+	type exported struct {
+		asset *pb.Asset
+		depth int
+	}
+	s.mu.RLock()
+	var assets []exported
+	for _, asset := range s.assets {
+		if !auth.CanAccessTenant(ctx, asset.Tenant) || (typeName != "" && asset.Type != typeName) {
+			continue
+		}
+		depth := 0
+		for parent := s.assets[asset.ParentId]; parent != nil; parent = s.assets[parent.ParentId] {
+			depth++
+		}
+		assets = append(assets, exported{asset, depth})
+	}
+	s.mu.RUnlock()
+
+	sort.Slice(assets, func(i, j int) bool {
+		if assets[i].depth != assets[j].depth {
+			return assets[i].depth < assets[j].depth
+		}
+		a, b := assets[i].asset.CreatedAt.AsTime(), assets[j].asset.CreatedAt.AsTime()
+		if !a.Equal(b) {
+			return a.Before(b)
+		}
+		return assets[i].asset.Id < assets[j].asset.Id
+	})
+	for _, e := range assets {
+		if err := stream.Send(e.asset); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// forwardExport relays the leader's export of the assets.
+func forwardExport(ctx context.Context, leader pb.AssetRegistryClient, req *pb.ExportAssetsRequest, stream pb.AssetRegistry_ExportAssetsServer) error {
+	export, err := leader.ExportAssets(ctx, req)
+	if err != nil {
+		return err
+	}
+	for {
+		asset, err := export.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if err := stream.Send(asset); err != nil {
+			return err
+		}
+	}
+}
@@ -0,0 +1,249 @@
+package main
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"testing"
+
+	"google.golang.org/grpc"
+
+	pb "github.com/sairamkiran9/asset-telemetry-monitor/gen/go/proto/asset"
+	"github.com/sairamkiran9/asset-telemetry-monitor/internal/auth"
+)
+
+// exportStream collects what ExportAssets sends.
+type exportStream struct {
+	grpc.ServerStream
+	ctx    context.Context
+	assets []*pb.Asset
+}
+
+func (e *exportStream) Context() context.Context {
+	return e.ctx
+}
+
+func (e *exportStream) Send(asset *pb.Asset) error {
+	e.assets = append(e.assets, asset)
+	return nil
+}
+
+func actions(resp *pb.ImportAssetsResponse) []pb.ImportAction {
+	actions := make([]pb.ImportAction, len(resp.Results))
+	for i, result := range resp.Results {
+		actions[i] = result.Action
+	}
+	return actions
+}
+
+// building is a building with two floors and a meter on the first floor.
+func building() []*pb.AssetRecord {
+	return []*pb.AssetRecord{
+		{ExternalId: "B1", Name: "Building 1", Type: "building", Metadata: map[string]string{"floor_area": "12000"}},
+		{ExternalId: "B1-F1", Name: "Floor 1", Type: "floor", ParentExternalId: "B1"},
+		{ExternalId: "B1-F2", Name: "Floor 2", Type: "floor", ParentExternalId: "B1"},
+		{ExternalId: "B1-M1", Name: "Main meter", Type: "Electric", Description: "Incoming supply", ParentExternalId: "B1-F1", Metadata: map[string]string{"rated_kw": "500"}},
+	}
+}
+
+func TestImportAssets(t *testing.T) {
+	s := newServer()
+	ctx := context.Background()
+	create := []pb.ImportAction{pb.ImportAction_CREATE, pb.ImportAction_CREATE, pb.ImportAction_CREATE, pb.ImportAction_CREATE}
+
+	// A dry run reports what would happen without changing anything
+	resp, err := s.ImportAssets(ctx, &pb.ImportAssetsRequest{Records: building(), DryRun: true})
+	if err != nil {
+		t.Fatalf("ImportAssets failed: %v", err)
+	}
+	if !slices.Equal(actions(resp), create) || resp.Created != 4 || resp.Results[0].AssetId != "" {
+		t.Errorf("Expected a dry run to create four assets, got %v", resp)
+	}
+	if len(s.assets) != 0 {
+		t.Fatalf("Expected a dry run to register nothing, got %d assets", len(s.assets))
+	}
+
+	resp, err = s.ImportAssets(ctx, &pb.ImportAssetsRequest{Records: building()})
+	if err != nil {
+		t.Fatalf("ImportAssets failed: %v", err)
+	}
+	if !slices.Equal(actions(resp), create) || resp.Results[3].AssetId != "asset-4" {
+		t.Fatalf("Expected four assets to be created, got %v", resp)
+	}
+	meter := s.assets["asset-4"]
+	if meter.ParentId != "asset-2" || meter.Type != "electric" || meter.Metadata["external_id"] != "B1-M1" || meter.Metadata["rated_kw"] != "500" {
+		t.Errorf("Expected the meter on floor 1 with its external ID, got %v", meter)
+	}
+
+	// Importing again is idempotent
+	resp, _ = s.ImportAssets(ctx, &pb.ImportAssetsRequest{Records: building()})
+	if resp.Unchanged != 4 || len(s.assets) != 4 {
+		t.Errorf("Expected a repeated import to change nothing, got %v", resp)
+	}
+
+	// Changed records update their assets, keeping what they leave empty
+	records := building()
+	records[3] = &pb.AssetRecord{ExternalId: "B1-M1", Name: "Main meter", ParentExternalId: "B1-F2", Metadata: map[string]string{"rated_kw": "750"}}
+	resp, _ = s.ImportAssets(ctx, &pb.ImportAssetsRequest{Records: records})
+	if resp.Updated != 1 || resp.Results[3].Action != pb.ImportAction_UPDATE || resp.Results[3].AssetId != "asset-4" {
+		t.Fatalf("Expected the meter to be updated, got %v", resp)
+	}
+	meter = s.assets["asset-4"]
+	if meter.ParentId != "asset-3" || meter.Type != "electric" || meter.Description != "Incoming supply" || meter.Metadata["rated_kw"] != "750" {
+		t.Errorf("Expected the meter moved to floor 2 with its new rating, got %v", meter)
+	}
+}
+
+func TestImportAssetsErrors(t *testing.T) {
+	s := newServer()
+	ctx := context.Background()
+	s.ImportAssets(ctx, &pb.ImportAssetsRequest{Records: building()[:1]})
+	// Assets sharing an external ID can't be told apart
+	s.RegisterAsset(ctx, &pb.RegisterAssetRequest{Name: "Copy", Metadata: map[string]string{"external_id": "DUP"}})
+	s.RegisterAsset(ctx, &pb.RegisterAssetRequest{Name: "Copy", Metadata: map[string]string{"external_id": "DUP"}})
+
+	records := []*pb.AssetRecord{
+		{Name: "No ID"},
+		{ExternalId: "A1"},
+		{ExternalId: "A2", Name: "Boiler", Type: "boiler"},
+		{ExternalId: "A3", Name: "Feeder", Type: "electric", Metadata: map[string]string{"phases": "2"}},
+		{ExternalId: "A4", Name: "Orphan", ParentExternalId: "B9"},
+		{ExternalId: "A5", Name: "Child first", ParentExternalId: "A6"},
+		{ExternalId: "A6", Name: "Parent later"},
+		{ExternalId: "A6", Name: "Parent again"},
+		{ExternalId: "DUP", Name: "Copy"},
+		{ExternalId: "A7", Name: "Floor 3", Type: "floor", ParentExternalId: "B1"},
+	}
+	want := []string{
+		"external_id is required",
+		"name is required",
+		"unknown asset type",
+		"metadata phases",
+		"parent B9 not found",
+		"parent A6 not found",
+		"",
+		"appears in an earlier record",
+		"matches several assets",
+		"",
+	}
+	for _, dryRun := range []bool{true, false} {
+		resp, err := s.ImportAssets(ctx, &pb.ImportAssetsRequest{Records: records, DryRun: dryRun})
+		if err != nil {
+			t.Fatalf("ImportAssets failed: %v", err)
+		}
+		for i, result := range resp.Results {
+			if want[i] == "" && result.Action != pb.ImportAction_CREATE {
+				t.Errorf("Expected record %d to be created (dry run: %t), got %v", i, dryRun, result)
+			}
+			if want[i] != "" && (result.Action != pb.ImportAction_FAILED || !strings.Contains(result.Error, want[i])) {
+				t.Errorf("Expected record %d to fail with %q (dry run: %t), got %v", i, want[i], dryRun, result)
+			}
+		}
+		if resp.Failed != 8 || resp.Created != 2 {
+			t.Errorf("Expected 8 failures and 2 creations, got %v", resp)
+		}
+	}
+	if asset := s.assets["asset-5"]; asset.GetParentId() != "asset-1" {
+		t.Errorf("Expected floor 3 in building 1, got %v", asset)
+	}
+
+	// Credentials for any tenant have to pick one
+	if _, err := s.ImportAssets(auth.ContextWithTenant(ctx, auth.AnyTenant), &pb.ImportAssetsRequest{Records: building()}); err == nil {
+		t.Error("Expected importing for any tenant to fail")
+	}
+}
+
+func TestImportAssetsDryRunBatches(t *testing.T) {
+	s := newServer()
+	ctx := context.Background()
+
+	// Later batches of a dry run find the parents earlier ones would create
+	resp, err := s.ImportAssets(ctx, &pb.ImportAssetsRequest{Records: building()[1:], DryRun: true, PlannedExternalIds: []string{"B1"}})
+	if err != nil {
+		t.Fatalf("ImportAssets failed: %v", err)
+	}
+	if resp.Created != 3 {
+		t.Errorf("Expected the floors and meter to be created under planned B1, got %v", resp)
+	}
+
+	// Dry runs count what they would create against the quota, as imports do
+	s.maxAssetsPerTenant = 3
+	s.RegisterAsset(ctx, &pb.RegisterAssetRequest{Name: "Existing"})
+	created, failed := pb.ImportAction_CREATE, pb.ImportAction_FAILED
+	tests := []struct {
+		name string
+		req  *pb.ImportAssetsRequest
+		want []pb.ImportAction
+	}{
+		{"dry run", &pb.ImportAssetsRequest{Records: building(), DryRun: true}, []pb.ImportAction{created, created, failed, failed}},
+		{"later batch", &pb.ImportAssetsRequest{Records: building()[1:], DryRun: true, PlannedExternalIds: []string{"B1"}}, []pb.ImportAction{created, failed, failed}},
+		{"import", &pb.ImportAssetsRequest{Records: building()}, []pb.ImportAction{created, created, failed, failed}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := s.ImportAssets(ctx, tt.req)
+			if err != nil {
+				t.Fatalf("ImportAssets failed: %v", err)
+			}
+			if !slices.Equal(actions(resp), tt.want) || !strings.Contains(resp.Results[len(tt.want)-1].Error, "quota of 3 assets") {
+				t.Errorf("Expected %v with quota errors, got %v", tt.want, resp)
+			}
+		})
+	}
+}
+
+func TestImportAssetsTenants(t *testing.T) {
+	s := newServer()
+	north := auth.ContextWithTenant(context.Background(), "north-campus")
+	south := auth.ContextWithTenant(context.Background(), "south-campus")
+	s.ImportAssets(north, &pb.ImportAssetsRequest{Records: building()})
+
+	// External IDs are per tenant
+	resp, _ := s.ImportAssets(south, &pb.ImportAssetsRequest{Records: building()})
+	if resp.Created != 4 || s.assets["asset-8"].GetTenant() != "south-campus" {
+		t.Errorf("Expected south campus to get its own assets, got %v", resp)
+	}
+
+	// The external ID key is configurable
+	resp, _ = s.ImportAssets(north, &pb.ImportAssetsRequest{Records: building()[:1], ExternalIdKey: "asset_tag"})
+	if resp.Created != 1 || s.assets["asset-9"].Metadata["asset_tag"] != "B1" {
+		t.Errorf("Expected a new asset keyed on asset_tag, got %v", resp)
+	}
+}
+
+func TestExportAssets(t *testing.T) {
+	s := newServer()
+	north := auth.ContextWithTenant(context.Background(), "north-campus")
+	south := auth.ContextWithTenant(context.Background(), "south-campus")
+	s.ImportAssets(north, &pb.ImportAssetsRequest{Records: building()})
+	s.ImportAssets(south, &pb.ImportAssetsRequest{Records: building()[:1]})
+	// A new parent of older assets still comes first
+	s.RegisterAsset(north, &pb.RegisterAssetRequest{Name: "Campus", Type: "site"})
+	s.SetParent(north, &pb.SetParentRequest{AssetId: "asset-1", ParentId: "asset-6"})
+
+	tests := []struct {
+		name string
+		ctx  context.Context
+		req  *pb.ExportAssetsRequest
+		want []string
+	}{
+		{"parents first", north, &pb.ExportAssetsRequest{}, []string{"asset-6", "asset-1", "asset-2", "asset-3", "asset-4"}},
+		{"by type", north, &pb.ExportAssetsRequest{Type: "Floor"}, []string{"asset-2", "asset-3"}},
+		{"other tenant", south, &pb.ExportAssetsRequest{}, []string{"asset-5"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stream := &exportStream{ctx: tt.ctx}
+			if err := s.ExportAssets(tt.req, stream); err != nil {
+				t.Fatalf("ExportAssets failed: %v", err)
+			}
+			if got := assetIDs(stream.assets); !slices.Equal(got, tt.want) {
+				t.Errorf("Expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+
+	if err := s.ExportAssets(&pb.ExportAssetsRequest{Type: "boiler"}, &exportStream{ctx: north}); err == nil {
+		t.Error("Expected exporting an unknown type to fail")
+	}
+}
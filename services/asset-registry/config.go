@@ -0,0 +1,40 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/sairamkiran9/asset-telemetry-monitor/internal/config"
+)
+
+// Config holds the asset registry's settings.
+type Config struct {
+	config.Common
+	MaxAssetsPerTenant int               `config:"max_assets_per_tenant" env:"MAX_ASSETS_PER_TENANT" usage:"assets each tenant may register; 0 is unlimited"`
+	WatchHistory       int               `config:"watch_history" env:"WATCH_HISTORY" usage:"recent asset changes kept for WatchAssets calls resuming from a version"`
+	AssetTypesFile     string            `config:"asset_types_file" env:"ASSET_TYPES_FILE" usage:"JSON file of asset types to accept besides the built-in ones; every replica needs the same file"`
+	Replication        ReplicationConfig `config:"raft"`
+}
+
+func defaultConfig() Config {
+	return Config{
+		Common:       config.DefaultCommon(":50051"),
+		WatchHistory: defaultWatchHistory,
+		Replication:  defaultReplicationConfig(),
+	}
+}
+
+func (c *Config) Validate() error {
+	var errs []error
+	if c.MaxAssetsPerTenant < 0 {
+		errs = append(errs, fmt.Errorf("max_assets_per_tenant must not be negative, got %d", c.MaxAssetsPerTenant))
+	}
+	if c.WatchHistory < 0 {
+		errs = append(errs, fmt.Errorf("watch_history must not be negative, got %d", c.WatchHistory))
+	}
+	// Raft peers are only authenticated by their certificates
+	if c.Replication.NodeID != "" && !c.TLS.ClientAuth {
+		errs = append(errs, errors.New("raft.node_id requires tls.client_auth"))
+	}
+	return errors.Join(append(errs, c.Common.Validate(), c.Replication.Validate())...)
+}
@@ -5,16 +5,29 @@ import (
 	"fmt"
 	"log"
 	"net"
+	"os/signal"
 	"sync"
+	"syscall"
 	"time"
 
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/reflection"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
 	pb "github.com/sairamkiran9/asset-telemetry-monitor/gen/go/proto/asset"
+	monitoringpb "github.com/sairamkiran9/asset-telemetry-monitor/gen/go/proto/monitoring"
+	"github.com/sairamkiran9/asset-telemetry-monitor/internal/assettypes"
+	"github.com/sairamkiran9/asset-telemetry-monitor/internal/auth"
+	"github.com/sairamkiran9/asset-telemetry-monitor/internal/certs"
+	"github.com/sairamkiran9/asset-telemetry-monitor/internal/config"
+	"github.com/sairamkiran9/asset-telemetry-monitor/internal/health"
+	"github.com/sairamkiran9/asset-telemetry-monitor/internal/interceptors"
+	"github.com/sairamkiran9/asset-telemetry-monitor/internal/metrics"
+	"github.com/sairamkiran9/asset-telemetry-monitor/internal/shutdown"
+	"github.com/sairamkiran9/asset-telemetry-monitor/internal/tracing"
 )
 
 type server struct {
@@ -22,54 +35,336 @@ type server struct {
 	mu        sync.RWMutex
 	assets    map[string]*pb.Asset
 	idCounter int
+	registry  *metrics.Registry
+
+	// Version of the latest change to the assets, and the recent changes
+	// watchers can resume from
+	version uint64
+	events  *assetEvents
+
+	// Children and incoming connections of each asset
+	topology *topology
+
+	// Asset types and the metadata they expect
+	types *assettypes.Registry
+
+	// Assets registered per tenant, capped by maxAssetsPerTenant unless zero
+	tenantAssets       map[string]int
+	maxAssetsPerTenant int
+	quotaRejections    *metrics.CounterVec
+
+	// Raft replication of the assets; nil runs a single registry. Node
+	// addresses are the replicated gRPC addresses of the nodes, by node ID.
+	repl      *replication
+	nodeAddrs map[string]string
 }
 
 func newServer() *server {
-	return &server{
-		assets: make(map[string]*pb.Asset),
+	registry := metrics.NewRegistry()
+	metrics.RegisterRuntime(registry)
+
+	s := &server{
+		assets:          make(map[string]*pb.Asset),
+		registry:        registry,
+		tenantAssets:    make(map[string]int),
+		events:          newAssetEvents(defaultWatchHistory),
+		topology:        newTopology(),
+		types:           assettypes.Builtin(),
+		nodeAddrs:       make(map[string]string),
+		quotaRejections: registry.Counter("asset_registry_quota_rejections_total", "Registrations rejected because the tenant reached its asset quota.", "tenant"),
 	}
+	registry.GaugeFunc("asset_registry_assets", "Registered assets.", func() float64 {
+		s.mu.RLock()
+		defer s.mu.RUnlock()
+		return float64(len(s.assets))
+	})
+	registry.GaugeFunc("asset_registry_watchers", "Open WatchAssets calls.", func() float64 {
+		return float64(s.events.watching())
+	})
+	return s
 }
 
 func (s *server) RegisterAsset(ctx context.Context, req *pb.RegisterAssetRequest) (*pb.RegisterAssetResponse, error) {
 	if req.Name == "" {
 		return nil, status.Error(codes.InvalidArgument, "asset name is required")
 	}
+	tenant, err := auth.RequireTenant(ctx)
+	if err != nil {
+		return nil, err
+	}
 
+	// Followers forward registrations to the leader
+	leader, leaderCtx, err := s.repl.route(ctx, true)
+	if err != nil {
+		return nil, err
+	}
+	if leader != nil {
+		return leader.RegisterAsset(leaderCtx, req)
+	}
+
+	assetType, err := s.checkType(req.Type, req.Metadata)
+	if err != nil {
+		return nil, err
+	}
+
+	reg := registration{
+		Name:        req.Name,
+		Type:        assetType,
+		Description: req.Description,
+		Metadata:    req.Metadata,
+		ParentID:    req.ParentId,
+		Tenant:      tenant,
+		CreatedAt:   time.Now(),
+		MaxAssets:   s.maxAssetsPerTenant,
+	}
+	var asset *pb.Asset
+	if s.repl == nil {
+		asset, err = s.register(reg)
+	} else {
+		asset, err = s.repl.apply(command{Op: opRegister, Register: &reg})
+	}
+	if status.Code(err) == codes.ResourceExhausted {
+		s.quotaRejections.WithLabelValues(tenant).Inc()
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	tracing.SpanFromContext(ctx).SetAttributes(tracing.AssetID(asset.Id))
+	log.Printf("Registered asset: %s (ID: %s, tenant: %s)", asset.Name, asset.Id, tenant)
+
+	return &pb.RegisterAssetResponse{
+		Asset:   asset,
+		Success: true,
+		Message: "Asset registered successfully",
+	}, nil
+}
+
+// registration is an asset to register. Replicated registries apply it on
+// every node, so it carries everything that decides the outcome.
+type registration struct {
+	Name        string            `json:"name"`
+	Type        string            `json:"type"`
+	Description string            `json:"description"`
+	Metadata    map[string]string `json:"metadata,omitempty"`
+	ParentID    string            `json:"parent_id,omitempty"`
+	Tenant      string            `json:"tenant"`
+	CreatedAt   time.Time         `json:"created_at"`
+	MaxAssets   int               `json:"max_assets"` // Tenant's quota; 0 is unlimited
+}
+
+// register stores a new asset with the next ID, unless its tenant has reached
+// the quota.
+func (s *server) register(reg registration) (*pb.Asset, error) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
+	if reg.MaxAssets > 0 && s.tenantAssets[reg.Tenant] >= reg.MaxAssets {
+		return nil, status.Errorf(codes.ResourceExhausted, "tenant %s has reached its quota of %d assets", reg.Tenant, reg.MaxAssets)
+	}
+	if reg.ParentID != "" {
+		if _, err := s.lookup(assetChange{ID: reg.ParentID, Tenant: reg.Tenant}); err != nil {
+			return nil, status.Errorf(codes.NotFound, "parent asset %s not found", reg.ParentID)
+		}
+	}
+
 	s.idCounter++
 	assetID := fmt.Sprintf("asset-%d", s.idCounter)
 
 	asset := &pb.Asset{
 		Id:          assetID,
+		Name:        reg.Name,
+		Type:        reg.Type,
+		Description: reg.Description,
+		CreatedAt:   timestamppb.New(reg.CreatedAt),
+		Metadata:    reg.Metadata,
+		Tenant:      reg.Tenant,
+		ParentId:    reg.ParentID,
+	}
+
+	s.assets[assetID] = asset
+	s.topology.replace(nil, asset)
+	s.tenantAssets[reg.Tenant]++
+	s.recordChange(pb.AssetEventType_CREATED, asset)
+	return asset, nil
+}
+
+// recordChange stamps asset with the next version and records the change for
+// watchers. The caller holds s.mu.
+func (s *server) recordChange(eventType pb.AssetEventType, asset *pb.Asset) {
+	s.version++
+	asset.ResourceVersion = s.version
+	s.events.record(&pb.AssetEvent{Type: eventType, Asset: asset, ResourceVersion: s.version})
+}
+
+func (s *server) UpdateAsset(ctx context.Context, req *pb.UpdateAssetRequest) (*pb.UpdateAssetResponse, error) {
+	if req.Id == "" {
+		return nil, status.Error(codes.InvalidArgument, "asset ID is required")
+	}
+	tracing.SpanFromContext(ctx).SetAttributes(tracing.AssetID(req.Id))
+
+	leader, leaderCtx, err := s.repl.route(ctx, true)
+	if err != nil {
+		return nil, err
+	}
+	if leader != nil {
+		return leader.UpdateAsset(leaderCtx, req)
+	}
+
+	change := assetChange{
+		ID:          req.Id,
 		Name:        req.Name,
 		Type:        req.Type,
 		Description: req.Description,
-		CreatedAt:   timestamppb.New(time.Now()),
 		Metadata:    req.Metadata,
+		Tenant:      auth.TenantFromContext(ctx),
+	}
+	if err := s.checkUpdate(&change); err != nil {
+		return nil, err
+	}
+	var asset *pb.Asset
+	if s.repl == nil {
+		asset, err = s.update(change)
+	} else {
+		asset, err = s.repl.apply(command{Op: opUpdate, Change: &change})
+	}
+	if err != nil {
+		return nil, err
 	}
 
-	s.assets[assetID] = asset
-	log.Printf("Registered asset: %s (ID: %s)", asset.Name, assetID)
+	log.Printf("Updated asset: %s (ID: %s)", asset.Name, asset.Id)
+	return &pb.UpdateAssetResponse{Asset: asset}, nil
+}
 
-	return &pb.RegisterAssetResponse{
-		Asset:   asset,
-		Success: true,
-		Message: "Asset registered successfully",
-	}, nil
+func (s *server) DeleteAsset(ctx context.Context, req *pb.DeleteAssetRequest) (*pb.DeleteAssetResponse, error) {
+	if req.Id == "" {
+		return nil, status.Error(codes.InvalidArgument, "asset ID is required")
+	}
+	tracing.SpanFromContext(ctx).SetAttributes(tracing.AssetID(req.Id))
+
+	leader, leaderCtx, err := s.repl.route(ctx, true)
+	if err != nil {
+		return nil, err
+	}
+	if leader != nil {
+		return leader.DeleteAsset(leaderCtx, req)
+	}
+
+	change := assetChange{ID: req.Id, Tenant: auth.TenantFromContext(ctx)}
+	var asset *pb.Asset
+	if s.repl == nil {
+		asset, err = s.delete(change)
+	} else {
+		asset, err = s.repl.apply(command{Op: opDelete, Change: &change})
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	log.Printf("Deleted asset: %s (ID: %s)", asset.Name, asset.Id)
+	return &pb.DeleteAssetResponse{Asset: asset}, nil
+}
+
+// assetChange is an update or deletion of an asset, made by a caller acting
+// for Tenant.
+type assetChange struct {
+	ID          string            `json:"id"`
+	Name        string            `json:"name,omitempty"`
+	Type        string            `json:"type,omitempty"`
+	Description string            `json:"description,omitempty"`
+	Metadata    map[string]string `json:"metadata,omitempty"`
+	Tenant      string            `json:"tenant"`
+	Version     uint64            `json:"version,omitempty"` // Version the change was checked against; 0 skips the check
+}
+
+// lookup returns the asset a change applies to. Other tenants' assets are
+// indistinguishable from missing ones. The caller holds s.mu.
+func (s *server) lookup(change assetChange) (*pb.Asset, error) {
+	asset, found := s.assets[change.ID]
+	if !found || !canAccess(change.Tenant, asset.Tenant) {
+		return nil, status.Errorf(codes.NotFound, "asset %s not found", change.ID)
+	}
+	return asset, nil
+}
+
+// update replaces the asset with a copy carrying the change, since stored
+// assets are shared with callers and never modified.
+func (s *server) update(change assetChange) (*pb.Asset, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	current, err := s.lookup(change)
+	if err != nil {
+		return nil, err
+	}
+	if change.Version != 0 && current.ResourceVersion != change.Version {
+		return nil, status.Errorf(codes.Aborted, "asset %s changed while being updated; retry", change.ID)
+	}
+	asset := proto.Clone(current).(*pb.Asset)
+	if change.Name != "" {
+		asset.Name = change.Name
+	}
+	if change.Type != "" {
+		asset.Type = change.Type
+	}
+	if change.Description != "" {
+		asset.Description = change.Description
+	}
+	if len(change.Metadata) > 0 {
+		asset.Metadata = change.Metadata
+	}
+
+	s.assets[asset.Id] = asset
+	s.topology.replace(current, asset)
+	s.recordChange(pb.AssetEventType_UPDATED, asset)
+	return asset, nil
+}
+
+func (s *server) delete(change assetChange) (*pb.Asset, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	current, err := s.lookup(change)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkUnlinked(current); err != nil {
+		return nil, err
+	}
+	asset := proto.Clone(current).(*pb.Asset)
+
+	delete(s.assets, asset.Id)
+	s.topology.replace(current, nil)
+	s.tenantAssets[asset.Tenant]--
+	s.recordChange(pb.AssetEventType_DELETED, asset)
+	return asset, nil
+}
+
+// canAccess is auth.CanAccessTenant for a caller acting for tenant.
+func canAccess(tenant, owner string) bool {
+	return auth.CanAccessTenant(auth.ContextWithTenant(context.Background(), tenant), owner)
 }
 
 func (s *server) GetAsset(ctx context.Context, req *pb.GetAssetRequest) (*pb.GetAssetResponse, error) {
 	if req.Id == "" {
 		return nil, status.Error(codes.InvalidArgument, "asset ID is required")
 	}
+	tracing.SpanFromContext(ctx).SetAttributes(tracing.AssetID(req.Id))
+
+	leader, leaderCtx, err := s.repl.route(ctx, false)
+	if err != nil {
+		return nil, err
+	}
+	if leader != nil {
+		return leader.GetAsset(leaderCtx, req)
+	}
 
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
+	// Other tenants' assets are indistinguishable from missing ones
 	asset, found := s.assets[req.Id]
-	if !found {
+	if !found || !auth.CanAccessTenant(ctx, asset.Tenant) {
 		return &pb.GetAssetResponse{
 			Found: false,
 		}, nil
@@ -82,31 +377,97 @@ func (s *server) GetAsset(ctx context.Context, req *pb.GetAssetRequest) (*pb.Get
 }
 
 func (s *server) ListAssets(ctx context.Context, req *pb.ListAssetsRequest) (*pb.ListAssetsResponse, error) {
+	leader, leaderCtx, err := s.repl.route(ctx, false)
+	if err != nil {
+		return nil, err
+	}
+	if leader != nil {
+		return leader.ListAssets(leaderCtx, req)
+	}
+
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
 	assets := make([]*pb.Asset, 0, len(s.assets))
 	for _, asset := range s.assets {
-		assets = append(assets, asset)
+		if auth.CanAccessTenant(ctx, asset.Tenant) {
+			assets = append(assets, asset)
+		}
 	}
 
 	return &pb.ListAssetsResponse{
-		Assets: assets,
+		Assets:          assets,
+		ResourceVersion: s.version,
 	}, nil
 }
 
 func main() {
-	lis, err := net.Listen("tcp", ":50051")
+	cfg := defaultConfig()
+	config.Load("asset-registry", &cfg)
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+	drain := shutdown.New(cfg.ShutdownGracePeriod)
+
+	tracer, err := tracing.New("asset-registry", cfg.Tracing)
+	if err != nil {
+		log.Fatalf("Failed to set up tracing: %v", err)
+	}
+
+	tlsCerts, err := certs.New(cfg.TLS)
+	if err != nil {
+		log.Fatalf("Failed to load TLS certificates: %v", err)
+	}
+	go tlsCerts.Run(ctx)
+
+	authenticator, err := auth.New(cfg.Auth, auth.CommonPolicy.With(policy))
+	if err != nil {
+		log.Fatalf("Failed to set up authentication: %v", err)
+	}
+
+	lis, err := net.Listen("tcp", cfg.ListenAddr)
 	if err != nil {
 		log.Fatalf("Failed to listen: %v", err)
 	}
 
-	grpcServer := grpc.NewServer()
-	pb.RegisterAssetRegistryServer(grpcServer, newServer())
+	s := newServer()
+	s.maxAssetsPerTenant = cfg.MaxAssetsPerTenant
+	s.events = newAssetEvents(cfg.WatchHistory)
+	if s.types, err = assettypes.Load(cfg.AssetTypesFile); err != nil {
+		log.Fatalf("Failed to load asset types: %v", err)
+	}
+	checker := health.NewChecker(pb.AssetRegistry_ServiceDesc.ServiceName)
+	checker.AddLivenessCheck("asset-store", health.LockAcquirable(&s.mu))
+
+	if cfg.Replication.NodeID != "" {
+		dialOpts := append(interceptors.DialOptions(interceptors.Options{Tracer: tracer, Token: cfg.Auth.Token}), grpc.WithTransportCredentials(tlsCerts.Credentials()))
+		s.repl, err = newReplication(cfg.Replication, s, tlsCerts, dialOpts)
+		if err != nil {
+			log.Fatalf("Failed to set up replication: %v", err)
+		}
+		s.registerReplicationMetrics()
+		checker.AddReadinessCheck("raft-leader", s.repl.ready)
+		log.Printf("Replicating as Raft node %s", cfg.Replication.NodeID)
+	}
+	go checker.Run(ctx, health.DefaultInterval)
+
+	grpcServer := grpc.NewServer(append(interceptors.ServerOptions(interceptors.Options{Registry: s.registry, Tracer: tracer, MaxTimeout: cfg.MaxRPCTimeout, Shutdown: drain, Auth: authenticator, TLS: tlsCerts}), grpc.Creds(tlsCerts.Credentials()))...)
+	pb.RegisterAssetRegistryServer(grpcServer, s)
+	monitoringpb.RegisterMetricsExporterServer(grpcServer, metrics.NewExporter(s.registry))
+	checker.Register(grpcServer)
 	reflection.Register(grpcServer)
 
-	log.Println("Asset Registry Service listening on :50051")
-	if err := grpcServer.Serve(lis); err != nil {
+	metricsServer := metrics.Serve(cfg.MetricsAddr, s.registry)
+
+	drain.OnShutdown(checker.Shutdown)
+	if s.repl != nil {
+		drain.AddCleanup("raft", s.repl.close)
+	}
+	drain.AddCleanup("metrics endpoint", metricsServer.Shutdown)
+	drain.AddCleanup("tracing", tracer.Shutdown)
+
+	log.Printf("Asset Registry Service listening on %s", cfg.ListenAddr)
+	if err := drain.Serve(ctx, grpcServer, lis); err != nil {
 		log.Fatalf("Failed to serve: %v", err)
 	}
 }
@@ -2,9 +2,17 @@ package main
 
 import (
 	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
 
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
 	pb "github.com/sairamkiran9/asset-telemetry-monitor/gen/go/proto/asset"
+	"github.com/sairamkiran9/asset-telemetry-monitor/internal/auth"
+	"github.com/sairamkiran9/asset-telemetry-monitor/internal/metrics"
 )
 
 func TestRegisterAsset(t *testing.T) {
@@ -12,7 +20,7 @@ func TestRegisterAsset(t *testing.T) {
 
 	req := &pb.RegisterAssetRequest{
 		Name:        "Test Sensor",
-		Type:        "temperature",
+		Type:        "sensor",
 		Description: "A test temperature sensor",
 		Metadata:    map[string]string{"location": "room1"},
 	}
@@ -41,7 +49,7 @@ func TestGetAsset(t *testing.T) {
 	// First register an asset
 	registerReq := &pb.RegisterAssetRequest{
 		Name: "Sensor-001",
-		Type: "humidity",
+		Type: "sensor",
 	}
 	registerResp, _ := s.RegisterAsset(context.Background(), registerReq)
 	assetID := registerResp.Asset.Id
@@ -85,7 +93,7 @@ func TestListAssets(t *testing.T) {
 	for i := 1; i <= 3; i++ {
 		req := &pb.RegisterAssetRequest{
 			Name: "Sensor-" + string(rune('0'+i)),
-			Type: "test",
+			Type: "sensor",
 		}
 		s.RegisterAsset(context.Background(), req)
 	}
@@ -102,3 +110,133 @@ func TestListAssets(t *testing.T) {
 		t.Errorf("Expected 3 assets, got %d", len(listResp.Assets))
 	}
 }
+
+func TestTenantIsolation(t *testing.T) {
+	s := newServer()
+	north := auth.ContextWithTenant(context.Background(), "north-campus")
+	south := auth.ContextWithTenant(context.Background(), "south-campus")
+
+	resp, err := s.RegisterAsset(north, &pb.RegisterAssetRequest{Name: "Chiller-1", Type: "chillwater"})
+	if err != nil {
+		t.Fatalf("RegisterAsset failed: %v", err)
+	}
+	if resp.Asset.Tenant != "north-campus" {
+		t.Errorf("Expected tenant north-campus, got %q", resp.Asset.Tenant)
+	}
+	s.RegisterAsset(south, &pb.RegisterAssetRequest{Name: "Boiler-1", Type: "steam"})
+
+	if getResp, _ := s.GetAsset(south, &pb.GetAssetRequest{Id: resp.Asset.Id}); getResp.Found {
+		t.Error("Expected another tenant's asset not to be found")
+	}
+	if getResp, _ := s.GetAsset(north, &pb.GetAssetRequest{Id: resp.Asset.Id}); !getResp.Found {
+		t.Error("Expected own asset to be found")
+	}
+
+	listResp, _ := s.ListAssets(north, &pb.ListAssetsRequest{})
+	if len(listResp.Assets) != 1 || listResp.Assets[0].Name != "Chiller-1" {
+		t.Errorf("Expected only north-campus assets, got %v", listResp.Assets)
+	}
+
+	// Service credentials see every tenant but must name one to register
+	service := auth.ContextWithTenant(context.Background(), auth.AnyTenant)
+	if listResp, _ := s.ListAssets(service, &pb.ListAssetsRequest{}); len(listResp.Assets) != 2 {
+		t.Errorf("Expected 2 assets across tenants, got %d", len(listResp.Assets))
+	}
+	if _, err := s.RegisterAsset(service, &pb.RegisterAssetRequest{Name: "Orphan"}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Expected InvalidArgument without a tenant, got %v", err)
+	}
+}
+
+func TestAssetQuota(t *testing.T) {
+	s := newServer()
+	s.maxAssetsPerTenant = 2
+	north := auth.ContextWithTenant(context.Background(), "north-campus")
+	south := auth.ContextWithTenant(context.Background(), "south-campus")
+
+	for i := 0; i < 2; i++ {
+		if _, err := s.RegisterAsset(north, &pb.RegisterAssetRequest{Name: "Meter"}); err != nil {
+			t.Fatalf("RegisterAsset %d failed: %v", i+1, err)
+		}
+	}
+	if _, err := s.RegisterAsset(north, &pb.RegisterAssetRequest{Name: "Meter"}); status.Code(err) != codes.ResourceExhausted {
+		t.Errorf("Expected ResourceExhausted over quota, got %v", err)
+	}
+	if _, err := s.RegisterAsset(south, &pb.RegisterAssetRequest{Name: "Meter"}); err != nil {
+		t.Errorf("Expected other tenants to have their own quota, got %v", err)
+	}
+
+	rec := httptest.NewRecorder()
+	metrics.Handler(s.registry).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	if !strings.Contains(rec.Body.String(), `asset_registry_quota_rejections_total{tenant="north-campus"} 1`) {
+		t.Errorf("Expected a quota rejection for north-campus, got:\n%s", rec.Body.String())
+	}
+}
+
+func TestMetricsEndpointReportsAssets(t *testing.T) {
+	s := newServer()
+	for i := 0; i < 2; i++ {
+		s.RegisterAsset(context.Background(), &pb.RegisterAssetRequest{Name: "Sensor", Type: "sensor"})
+	}
+
+	rec := httptest.NewRecorder()
+	metrics.Handler(s.registry).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+	if !strings.Contains(rec.Body.String(), "\nasset_registry_assets 2\n") {
+		t.Errorf("Expected asset_registry_assets 2 in exposition, got:\n%s", rec.Body.String())
+	}
+}
+
+func TestUpdateAsset(t *testing.T) {
+	s := newServer()
+	north := auth.ContextWithTenant(context.Background(), "north-campus")
+	south := auth.ContextWithTenant(context.Background(), "south-campus")
+	registered, _ := s.RegisterAsset(north, &pb.RegisterAssetRequest{
+		Name:     "AHU-1",
+		Type:     "electric",
+		Metadata: map[string]string{"building": "library"},
+	})
+
+	resp, err := s.UpdateAsset(north, &pb.UpdateAssetRequest{Id: registered.Asset.Id, Type: "chillwater"})
+	if err != nil {
+		t.Fatalf("UpdateAsset failed: %v", err)
+	}
+	if resp.Asset.Type != "chillwater" || resp.Asset.Name != "AHU-1" || resp.Asset.Metadata["building"] != "library" {
+		t.Errorf("Expected only the type to change, got %v", resp.Asset)
+	}
+	if registered.Asset.Type != "electric" {
+		t.Error("Expected assets already returned not to be modified")
+	}
+
+	if _, err := s.UpdateAsset(south, &pb.UpdateAssetRequest{Id: registered.Asset.Id, Name: "Hijacked"}); status.Code(err) != codes.NotFound {
+		t.Errorf("Expected NotFound for another tenant's asset, got %v", err)
+	}
+	if _, err := s.UpdateAsset(north, &pb.UpdateAssetRequest{Id: "nonexistent"}); status.Code(err) != codes.NotFound {
+		t.Errorf("Expected NotFound, got %v", err)
+	}
+}
+
+func TestDeleteAsset(t *testing.T) {
+	s := newServer()
+	s.maxAssetsPerTenant = 1
+	north := auth.ContextWithTenant(context.Background(), "north-campus")
+	registered, _ := s.RegisterAsset(north, &pb.RegisterAssetRequest{Name: "Meter-1"})
+
+	if _, err := s.DeleteAsset(auth.ContextWithTenant(context.Background(), "south-campus"), &pb.DeleteAssetRequest{Id: registered.Asset.Id}); status.Code(err) != codes.NotFound {
+		t.Errorf("Expected NotFound for another tenant's asset, got %v", err)
+	}
+	resp, err := s.DeleteAsset(north, &pb.DeleteAssetRequest{Id: registered.Asset.Id})
+	if err != nil {
+		t.Fatalf("DeleteAsset failed: %v", err)
+	}
+	if resp.Asset.Name != "Meter-1" {
+		t.Errorf("Expected the deleted asset, got %v", resp.Asset)
+	}
+	if getResp, _ := s.GetAsset(north, &pb.GetAssetRequest{Id: registered.Asset.Id}); getResp.Found {
+		t.Error("Expected the deleted asset not to be found")
+	}
+
+	// Deleting frees quota
+	if _, err := s.RegisterAsset(north, &pb.RegisterAssetRequest{Name: "Meter-2"}); err != nil {
+		t.Errorf("Expected registration within quota after a deletion, got %v", err)
+	}
+}
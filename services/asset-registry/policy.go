@@ -0,0 +1,27 @@
+package main
+
+import (
+	pb "github.com/sairamkiran9/asset-telemetry-monitor/gen/go/proto/asset"
+	"github.com/sairamkiran9/asset-telemetry-monitor/internal/auth"
+)
+
+// policy lists the roles allowed to call each RPC of the asset registry.
+// Of the RPCs naming an asset, only GetAsset is open to credentials scoped
+// to that asset.
+var policy = auth.Policy{
+	pb.AssetRegistry_RegisterAsset_FullMethodName:    {Roles: []auth.Role{auth.RoleOperator}},
+	pb.AssetRegistry_GetAsset_FullMethodName:         {Roles: []auth.Role{auth.RoleViewer}, AssetField: "id"},
+	pb.AssetRegistry_ListAssets_FullMethodName:       {Roles: []auth.Role{auth.RoleViewer}},
+	pb.AssetRegistry_UpdateAsset_FullMethodName:      {Roles: []auth.Role{auth.RoleOperator}},
+	pb.AssetRegistry_DeleteAsset_FullMethodName:      {Roles: []auth.Role{auth.RoleOperator}},
+	pb.AssetRegistry_WatchAssets_FullMethodName:      {Roles: []auth.Role{auth.RoleViewer}},
+	pb.AssetRegistry_SetParent_FullMethodName:        {Roles: []auth.Role{auth.RoleOperator}},
+	pb.AssetRegistry_ConnectAssets_FullMethodName:    {Roles: []auth.Role{auth.RoleOperator}},
+	pb.AssetRegistry_DisconnectAssets_FullMethodName: {Roles: []auth.Role{auth.RoleOperator}},
+	pb.AssetRegistry_GetSubtree_FullMethodName:       {Roles: []auth.Role{auth.RoleViewer}},
+	pb.AssetRegistry_GetAncestors_FullMethodName:     {Roles: []auth.Role{auth.RoleViewer}},
+	pb.AssetRegistry_GetConnected_FullMethodName:     {Roles: []auth.Role{auth.RoleViewer}},
+	pb.AssetRegistry_ListAssetTypes_FullMethodName:   {Roles: []auth.Role{auth.RoleViewer}},
+	pb.AssetRegistry_ImportAssets_FullMethodName:     {Roles: []auth.Role{auth.RoleOperator}},
+	pb.AssetRegistry_ExportAssets_FullMethodName:     {Roles: []auth.Role{auth.RoleViewer}},
+}
@@ -0,0 +1,417 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/raft"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/sairamkiran9/asset-telemetry-monitor/gen/go/proto/asset"
+	"github.com/sairamkiran9/asset-telemetry-monitor/internal/auth"
+	"github.com/sairamkiran9/asset-telemetry-monitor/internal/certs"
+)
+
+// testNode is a registry node of a cluster running in the test process, with
+// Raft over in-memory transports and gRPC on localhost.
+type testNode struct {
+	*server
+	id   string
+	addr string
+	grpc *grpc.Server
+}
+
+func startRegistryCluster(t *testing.T, n int, maxStaleness time.Duration) []*testNode {
+	t.Helper()
+	nodes := make([]*testNode, n)
+	transports := make([]*raft.InmemTransport, n)
+	listeners := make([]net.Listener, n)
+	var servers []raft.Server
+	for i := range nodes {
+		lis, err := net.Listen("tcp", "127.0.0.1:0")
+		if err != nil {
+			t.Fatalf("Failed to listen: %v", err)
+		}
+		listeners[i] = lis
+		id := fmt.Sprintf("registry-%d", i+1)
+		_, transports[i] = raft.NewInmemTransport(raft.ServerAddress(id))
+		servers = append(servers, raft.Server{ID: raft.ServerID(id), Address: transports[i].LocalAddr()})
+		nodes[i] = &testNode{server: newServer(), id: id, addr: lis.Addr().String(), grpc: grpc.NewServer()}
+	}
+	for i := range transports {
+		for j := range transports {
+			if i != j {
+				transports[i].Connect(transports[j].LocalAddr(), transports[j])
+			}
+		}
+	}
+
+	for i, node := range nodes {
+		conf := raft.DefaultConfig()
+		conf.LocalID = raft.ServerID(node.id)
+		conf.HeartbeatTimeout = 50 * time.Millisecond
+		conf.ElectionTimeout = 50 * time.Millisecond
+		conf.LeaderLeaseTimeout = 50 * time.Millisecond
+		conf.CommitTimeout = 5 * time.Millisecond
+		conf.LogLevel = "ERROR"
+
+		store := raft.NewInmemStore()
+		r, err := startReplication(conf, node.server, node.addr, maxStaleness,
+			[]grpc.DialOption{grpc.WithTransportCredentials(insecure.NewCredentials())},
+			store, store, raft.NewInmemSnapshotStore(), transports[i], servers)
+		if err != nil {
+			t.Fatalf("Failed to start node %s: %v", node.id, err)
+		}
+		node.repl = r
+		t.Cleanup(func() { r.close(context.Background()) })
+
+		pb.RegisterAssetRegistryServer(node.grpc, node.server)
+		go node.grpc.Serve(listeners[i])
+		t.Cleanup(node.grpc.Stop)
+	}
+	return nodes
+}
+
+// waitForLeader returns the leader once every running node knows its address.
+func waitForLeader(t *testing.T, nodes []*testNode) *testNode {
+	t.Helper()
+	deadline := time.Now().Add(10 * time.Second)
+	for time.Now().Before(deadline) {
+		for _, node := range nodes {
+			if !node.repl.isLeader() {
+				continue
+			}
+			known := true
+			for _, other := range nodes {
+				known = known && other.nodeAddr(node.id) == node.addr
+			}
+			if known {
+				return node
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatal("Expected a leader to be elected")
+	return nil
+}
+
+func waitForAsset(t *testing.T, node *testNode, assetID string) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		node.mu.RLock()
+		_, exists := node.assets[assetID]
+		node.mu.RUnlock()
+		if exists {
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatalf("Expected %s to replicate to %s", assetID, node.id)
+}
+
+func dialNode(t *testing.T, node *testNode) pb.AssetRegistryClient {
+	t.Helper()
+	conn, err := grpc.NewClient(node.addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("Failed to dial %s: %v", node.id, err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return pb.NewAssetRegistryClient(conn)
+}
+
+func followers(nodes []*testNode, leader *testNode) []*testNode {
+	var result []*testNode
+	for _, node := range nodes {
+		if node != leader {
+			result = append(result, node)
+		}
+	}
+	return result
+}
+
+func TestReplicatedRegistry(t *testing.T) {
+	nodes := startRegistryCluster(t, 3, 0)
+	leader := waitForLeader(t, nodes)
+	follower := followers(nodes, leader)[0]
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	// Followers forward writes to the leader
+	resp, err := dialNode(t, follower).RegisterAsset(ctx, &pb.RegisterAssetRequest{Name: "Chiller-1", Type: "chillwater"})
+	if err != nil {
+		t.Fatalf("RegisterAsset via follower failed: %v", err)
+	}
+	if resp.Asset.Id != "asset-1" {
+		t.Errorf("Expected asset-1, got %s", resp.Asset.Id)
+	}
+	for _, node := range nodes {
+		waitForAsset(t, node, resp.Asset.Id)
+	}
+	if getResp, err := dialNode(t, follower).GetAsset(ctx, &pb.GetAssetRequest{Id: resp.Asset.Id}); err != nil || !getResp.Found {
+		t.Errorf("Expected follower to find the asset through the leader, got %v, %v", getResp, err)
+	}
+
+	// The remaining nodes elect a new leader and carry on numbering
+	leader.grpc.Stop()
+	if err := leader.repl.close(context.Background()); err != nil {
+		t.Fatalf("Failed to stop leader: %v", err)
+	}
+	remaining := followers(nodes, leader)
+	newLeader := waitForLeader(t, remaining)
+
+	resp, err = dialNode(t, followers(remaining, newLeader)[0]).RegisterAsset(ctx, &pb.RegisterAssetRequest{Name: "Boiler-1", Type: "steam"})
+	if err != nil {
+		t.Fatalf("RegisterAsset after failover failed: %v", err)
+	}
+	if resp.Asset.Id != "asset-2" {
+		t.Errorf("Expected asset-2 after failover, got %s", resp.Asset.Id)
+	}
+	listResp, err := dialNode(t, newLeader).ListAssets(ctx, &pb.ListAssetsRequest{})
+	if err != nil || len(listResp.Assets) != 2 {
+		t.Errorf("Expected 2 assets after failover, got %v, %v", listResp, err)
+	}
+}
+
+func TestReplicatedChanges(t *testing.T) {
+	nodes := startRegistryCluster(t, 3, 0)
+	leader := waitForLeader(t, nodes)
+	follower := dialNode(t, followers(nodes, leader)[0])
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	for _, name := range []string{"Feeder-1", "Panel-1"} {
+		if _, err := follower.RegisterAsset(ctx, &pb.RegisterAssetRequest{Name: name, Type: "electric"}); err != nil {
+			t.Fatalf("RegisterAsset failed: %v", err)
+		}
+	}
+	if _, err := follower.UpdateAsset(ctx, &pb.UpdateAssetRequest{Id: "asset-1", Description: "Main feeder"}); err != nil {
+		t.Fatalf("UpdateAsset via follower failed: %v", err)
+	}
+	if _, err := follower.DeleteAsset(ctx, &pb.DeleteAssetRequest{Id: "asset-2"}); err != nil {
+		t.Fatalf("DeleteAsset via follower failed: %v", err)
+	}
+
+	// Every node reaches the same state at the same version, so a watch can
+	// resume on any of them
+	for _, node := range nodes {
+		deadline := time.Now().Add(5 * time.Second)
+		for registryVersion(node.server) < 4 && time.Now().Before(deadline) {
+			time.Sleep(10 * time.Millisecond)
+		}
+		node.mu.RLock()
+		asset, deleted := node.assets["asset-1"], node.assets["asset-2"] == nil
+		node.mu.RUnlock()
+		if asset.GetDescription() != "Main feeder" || asset.GetResourceVersion() != 3 || !deleted {
+			t.Errorf("Expected %s to have applied every change, got %v (asset-2 deleted: %v)", node.id, asset, deleted)
+		}
+
+		w := startWatch(t, node.server, ctx, 2)
+		expectEvent(t, w.next(t), pb.AssetEventType_UPDATED, "asset-1", 3)
+		expectEvent(t, w.next(t), pb.AssetEventType_DELETED, "asset-2", 4)
+	}
+}
+
+func registryVersion(s *server) uint64 {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.version
+}
+
+func TestFollowerReadsWithinStaleness(t *testing.T) {
+	nodes := startRegistryCluster(t, 3, time.Minute)
+	leader := waitForLeader(t, nodes)
+	follower := followers(nodes, leader)[0]
+	north := auth.ContextWithTenant(context.Background(), "north-campus")
+
+	resp, err := leader.RegisterAsset(north, &pb.RegisterAssetRequest{Name: "Meter-1"})
+	if err != nil {
+		t.Fatalf("RegisterAsset failed: %v", err)
+	}
+	waitForAsset(t, follower, resp.Asset.Id)
+
+	// With the leader unreachable over gRPC, the follower answers itself
+	leader.grpc.Stop()
+	getResp, err := follower.GetAsset(north, &pb.GetAssetRequest{Id: resp.Asset.Id})
+	if err != nil || !getResp.Found || getResp.Asset.Tenant != "north-campus" {
+		t.Errorf("Expected follower to serve the read, got %v, %v", getResp, err)
+	}
+
+	// Writes still need the leader
+	if _, err := follower.RegisterAsset(north, &pb.RegisterAssetRequest{Name: "Meter-2"}); status.Code(err) != codes.Unavailable {
+		t.Errorf("Expected Unavailable without a reachable leader, got %v", err)
+	}
+}
+
+// snapshotBuffer is a raft.SnapshotSink writing to memory.
+type snapshotBuffer struct {
+	bytes.Buffer
+}
+
+func (b *snapshotBuffer) ID() string    { return "test" }
+func (b *snapshotBuffer) Cancel() error { return nil }
+func (b *snapshotBuffer) Close() error  { return nil }
+
+func TestSnapshotRestore(t *testing.T) {
+	s := newServer()
+	north := auth.ContextWithTenant(context.Background(), "north-campus")
+	for i := 0; i < 3; i++ {
+		s.RegisterAsset(north, &pb.RegisterAssetRequest{Name: "Meter", Metadata: map[string]string{"floor": "2"}})
+	}
+	s.SetParent(north, &pb.SetParentRequest{AssetId: "asset-2", ParentId: "asset-1"})
+	s.ConnectAssets(north, &pb.ConnectAssetsRequest{FromId: "asset-1", ToId: "asset-3", Type: pb.ConnectionType_POWER})
+	s.setNodeAddr("registry-1", "registry-1:50051")
+
+	snap, err := (&registryFSM{s: s}).Snapshot()
+	if err != nil {
+		t.Fatalf("Snapshot failed: %v", err)
+	}
+	var sink snapshotBuffer
+	if err := snap.Persist(&sink); err != nil {
+		t.Fatalf("Persist failed: %v", err)
+	}
+
+	restored := newServer()
+	if err := (&registryFSM{s: restored}).Restore(&readCloser{&sink}); err != nil {
+		t.Fatalf("Restore failed: %v", err)
+	}
+	if len(restored.assets) != 3 || restored.tenantAssets["north-campus"] != 3 {
+		t.Errorf("Expected 3 north-campus assets, got %d (%d counted)", len(restored.assets), restored.tenantAssets["north-campus"])
+	}
+	if restored.assets["asset-2"].Metadata["floor"] != "2" {
+		t.Errorf("Expected metadata to survive, got %v", restored.assets["asset-2"])
+	}
+	if restored.nodeAddr("registry-1") != "registry-1:50051" {
+		t.Error("Expected node addresses to survive")
+	}
+	if restored.version != 5 {
+		t.Errorf("Expected resource version 5 after restore, got %d", restored.version)
+	}
+	if children := restored.topology.childrenOf("asset-1"); len(children) != 1 || len(restored.topology.incoming["asset-3"]) != 1 {
+		t.Errorf("Expected the hierarchy and connections to be indexed after restore, got children %v", children)
+	}
+
+	// Numbering carries on from the snapshot
+	resp, _ := restored.RegisterAsset(north, &pb.RegisterAssetRequest{Name: "Meter"})
+	if resp.Asset.Id != "asset-4" {
+		t.Errorf("Expected asset-4 after restore, got %s", resp.Asset.Id)
+	}
+}
+
+type readCloser struct {
+	*snapshotBuffer
+}
+
+func (r *readCloser) Close() error { return nil }
+
+func TestReplicationConfigValidate(t *testing.T) {
+	valid := ReplicationConfig{
+		NodeID:        "registry-1",
+		BindAddr:      ":50061",
+		AdvertiseAddr: "registry-1:50051",
+		Peers:         []string{"registry-1=registry-1:50061", "registry-2=registry-2:50061", "registry-3=registry-3:50061"},
+	}
+	tests := []struct {
+		name   string
+		modify func(*ReplicationConfig)
+		valid  bool
+	}{
+		{"valid", func(c *ReplicationConfig) {}, true},
+		{"disabled", func(c *ReplicationConfig) { *c = defaultReplicationConfig() }, true},
+		{"self not a peer", func(c *ReplicationConfig) { c.NodeID = "registry-4" }, false},
+		{"malformed peer", func(c *ReplicationConfig) { c.Peers = append(c.Peers, "registry-4") }, false},
+		{"duplicate peer", func(c *ReplicationConfig) { c.Peers = append(c.Peers, "registry-1=other:50061") }, false},
+		{"no advertise address", func(c *ReplicationConfig) { c.AdvertiseAddr = "" }, false},
+		{"negative staleness", func(c *ReplicationConfig) { c.MaxStaleness = -time.Second }, false},
+	}
+
+	for _, tt := range tests {
+		cfg := valid
+		cfg.Peers = append([]string(nil), valid.Peers...)
+		tt.modify(&cfg)
+		if err := cfg.Validate(); (err == nil) != tt.valid {
+			t.Errorf("%s: expected valid=%v, got %v", tt.name, tt.valid, err)
+		}
+	}
+}
+
+// registryCerts issues a certificate for name and loads it with mTLS.
+func registryCerts(t *testing.T, ca *certs.CA, name string) *certs.Reloader {
+	t.Helper()
+	certPEM, keyPEM, err := ca.Issue(name, []string{name, "localhost", "127.0.0.1"}, time.Hour)
+	if err != nil {
+		t.Fatalf("Issue failed: %v", err)
+	}
+	dir := t.TempDir()
+	cfg := certs.DefaultConfig()
+	cfg.CertFile = filepath.Join(dir, "cert.pem")
+	cfg.KeyFile = filepath.Join(dir, "key.pem")
+	cfg.CAFile = filepath.Join(dir, "ca.pem")
+	cfg.ClientAuth = true
+	for path, data := range map[string][]byte{cfg.CertFile: certPEM, cfg.KeyFile: keyPEM, cfg.CAFile: ca.CertPEM()} {
+		if err := os.WriteFile(path, data, 0600); err != nil {
+			t.Fatalf("Failed to write %s: %v", path, err)
+		}
+	}
+	r, err := certs.New(cfg)
+	if err != nil {
+		t.Fatalf("Failed to load certificates: %v", err)
+	}
+	return r
+}
+
+func TestRaftTransportRequiresReplicaCertificates(t *testing.T) {
+	cfg := ReplicationConfig{NodeID: "registry-1", BindAddr: "127.0.0.1:0", AdvertiseAddr: "127.0.0.1:50051", Peers: []string{"registry-1=127.0.0.1:50061"}}
+	if _, err := newReplication(cfg, newServer(), nil, nil); err == nil {
+		t.Fatal("Expected replication without mTLS to be refused")
+	}
+
+	ca, err := certs.NewCA("test CA", time.Hour)
+	if err != nil {
+		t.Fatalf("NewCA failed: %v", err)
+	}
+	newTransport := func(name string) *raft.NetworkTransport {
+		transport, err := newTLSTransport("127.0.0.1:0", nil, registryCerts(t, ca, name))
+		if err != nil {
+			t.Fatalf("Failed to start transport: %v", err)
+		}
+		t.Cleanup(func() { transport.Close() })
+		return transport
+	}
+	node := newTransport("asset-registry")
+	go func() {
+		for rpc := range node.Consumer() {
+			rpc.Respond(&raft.RequestVoteResponse{Granted: true}, nil)
+		}
+	}()
+
+	tests := []struct {
+		name  string
+		cert  string
+		valid bool
+	}{
+		{"registry node", "asset-registry", true},
+		{"other service", "alerting", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var resp raft.RequestVoteResponse
+			err := newTransport(tt.cert).RequestVote("registry-1", node.LocalAddr(), &raft.RequestVoteRequest{}, &resp)
+			if (err == nil) != tt.valid {
+				t.Errorf("Expected success=%v, got %v", tt.valid, err)
+			}
+			if tt.valid && !resp.Granted {
+				t.Error("Expected the vote to reach the node")
+			}
+		})
+	}
+}
@@ -0,0 +1,434 @@
+package main
+
+import (
+	"context"
+	"log"
+	"slices"
+	"sort"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	pb "github.com/sairamkiran9/asset-telemetry-monitor/gen/go/proto/asset"
+	"github.com/sairamkiran9/asset-telemetry-monitor/internal/auth"
+	"github.com/sairamkiran9/asset-telemetry-monitor/internal/tracing"
+)
+
+// topology indexes the reverse of what assets record about themselves: the
+// children of each asset and the connections into it.
+type topology struct {
+	children map[string]map[string]struct{}
+	incoming map[string][]*pb.Connection
+}
+
+func newTopology() *topology {
+	return &topology{
+		children: make(map[string]map[string]struct{}),
+		incoming: make(map[string][]*pb.Connection),
+	}
+}
+
+// replace updates the index for an asset changing from old to new; either
+// may be nil for assets being added or removed.
+func (t *topology) replace(old, new *pb.Asset) {
+	if old != nil {
+		if old.ParentId != "" {
+			delete(t.children[old.ParentId], old.Id)
+			if len(t.children[old.ParentId]) == 0 {
+				delete(t.children, old.ParentId)
+			}
+		}
+		for _, conn := range old.Connections {
+			t.incoming[conn.ToId] = slices.DeleteFunc(t.incoming[conn.ToId], func(c *pb.Connection) bool { return c == conn })
+			if len(t.incoming[conn.ToId]) == 0 {
+				delete(t.incoming, conn.ToId)
+			}
+		}
+	}
+	if new != nil {
+		if new.ParentId != "" {
+			if t.children[new.ParentId] == nil {
+				t.children[new.ParentId] = make(map[string]struct{})
+			}
+			t.children[new.ParentId][new.Id] = struct{}{}
+		}
+		for _, conn := range new.Connections {
+			t.incoming[conn.ToId] = append(t.incoming[conn.ToId], conn)
+		}
+	}
+}
+
+// childrenOf returns an asset's children's IDs in order.
+func (t *topology) childrenOf(id string) []string {
+	ids := make([]string, 0, len(t.children[id]))
+	for child := range t.children[id] {
+		ids = append(ids, child)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// link is a change to the hierarchy or the connections, made by a caller
+// acting for Tenant. Connections go from AssetID to ToID.
+type link struct {
+	AssetID  string            `json:"asset_id"`
+	ParentID string            `json:"parent_id,omitempty"`
+	ToID     string            `json:"to_id,omitempty"`
+	Type     pb.ConnectionType `json:"type,omitempty"`
+	Tenant   string            `json:"tenant"`
+}
+
+// linked looks up the other end of a link, which must belong to the same
+// tenant as asset. The caller holds s.mu.
+func (s *server) linked(asset *pb.Asset, id, tenant string) (*pb.Asset, error) {
+	other, err := s.lookup(assetChange{ID: id, Tenant: tenant})
+	if err != nil {
+		return nil, err
+	}
+	if other.Tenant != asset.Tenant {
+		return nil, status.Errorf(codes.FailedPrecondition, "asset %s belongs to another tenant than %s", id, asset.Id)
+	}
+	return other, nil
+}
+
+// store replaces an asset with its changed copy and records the change. The
+// caller holds s.mu.
+func (s *server) store(old, asset *pb.Asset) {
+	s.assets[asset.Id] = asset
+	s.topology.replace(old, asset)
+	s.recordChange(pb.AssetEventType_UPDATED, asset)
+}
+
+func (s *server) setParent(l link) (*pb.Asset, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	current, err := s.lookup(assetChange{ID: l.AssetID, Tenant: l.Tenant})
+	if err != nil {
+		return nil, err
+	}
+	if l.ParentID != "" {
+		parent, err := s.linked(current, l.ParentID, l.Tenant)
+		if err != nil {
+			return nil, err
+		}
+		for ancestor := parent; ancestor != nil; ancestor = s.assets[ancestor.ParentId] {
+			if ancestor.Id == current.Id {
+				return nil, status.Errorf(codes.FailedPrecondition, "asset %s can't be a descendant of itself", current.Id)
+			}
+		}
+	}
+	if current.ParentId == l.ParentID {
+		return current, nil
+	}
+
+	asset := proto.Clone(current).(*pb.Asset)
+	asset.ParentId = l.ParentID
+	s.store(current, asset)
+	return asset, nil
+}
+
+func (s *server) connect(l link) (*pb.Asset, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	current, err := s.lookup(assetChange{ID: l.AssetID, Tenant: l.Tenant})
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.linked(current, l.ToID, l.Tenant); err != nil {
+		return nil, err
+	}
+	for _, conn := range current.Connections {
+		if conn.ToId == l.ToID && conn.Type == l.Type {
+			return current, nil
+		}
+	}
+	// Supply of each kind flows one way
+	if l.ToID == current.Id || s.reaches(l.ToID, current.Id, l.Type) {
+		return nil, status.Errorf(codes.FailedPrecondition, "connecting %s to %s would make a %v loop", current.Id, l.ToID, l.Type)
+	}
+
+	asset := proto.Clone(current).(*pb.Asset)
+	asset.Connections = append(asset.Connections, &pb.Connection{FromId: asset.Id, ToId: l.ToID, Type: l.Type})
+	s.store(current, asset)
+	return asset, nil
+}
+
+// reaches reports whether to is downstream of from over connections of type
+// connType. The caller holds s.mu.
+func (s *server) reaches(from, to string, connType pb.ConnectionType) bool {
+	visited := map[string]bool{from: true}
+	queue := []string{from}
+	for len(queue) > 0 {
+		id := queue[0]
+		queue = queue[1:]
+		for _, conn := range s.assets[id].GetConnections() {
+			if conn.Type != connType || visited[conn.ToId] {
+				continue
+			}
+			if conn.ToId == to {
+				return true
+			}
+			visited[conn.ToId] = true
+			queue = append(queue, conn.ToId)
+		}
+	}
+	return false
+}
+
+func (s *server) disconnect(l link) (*pb.Asset, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	current, err := s.lookup(assetChange{ID: l.AssetID, Tenant: l.Tenant})
+	if err != nil {
+		return nil, err
+	}
+	i := slices.IndexFunc(current.Connections, func(c *pb.Connection) bool { return c.ToId == l.ToID && c.Type == l.Type })
+	if i < 0 {
+		return nil, status.Errorf(codes.NotFound, "asset %s has no %v connection to %s", current.Id, l.Type, l.ToID)
+	}
+
+	asset := proto.Clone(current).(*pb.Asset)
+	asset.Connections = slices.Delete(asset.Connections, i, i+1)
+	s.store(current, asset)
+	return asset, nil
+}
+
+// checkUnlinked fails for assets that still have children or connections,
+// which deleting them would leave dangling. The caller holds s.mu.
+func (s *server) checkUnlinked(asset *pb.Asset) error {
+	if len(s.topology.children[asset.Id]) > 0 {
+		return status.Errorf(codes.FailedPrecondition, "asset %s has children; move or delete them first", asset.Id)
+	}
+	if len(asset.Connections) > 0 || len(s.topology.incoming[asset.Id]) > 0 {
+		return status.Errorf(codes.FailedPrecondition, "asset %s is connected to other assets; disconnect it first", asset.Id)
+	}
+	return nil
+}
+
+// applyLink makes a hierarchy or connection change here, or through the
+// replicated log.
+func (s *server) applyLink(ctx context.Context, op string, l link) (*pb.Asset, error) {
+	tracing.SpanFromContext(ctx).SetAttributes(tracing.AssetID(l.AssetID))
+	if s.repl != nil {
+		return s.repl.apply(command{Op: op, Link: &l})
+	}
+	return s.changeLink(op, l)
+}
+
+// changeLink makes the change to the hierarchy or connections op names.
+func (s *server) changeLink(op string, l link) (*pb.Asset, error) {
+	switch op {
+	case opSetParent:
+		return s.setParent(l)
+	case opConnect:
+		return s.connect(l)
+	default:
+		return s.disconnect(l)
+	}
+}
+
+func (s *server) SetParent(ctx context.Context, req *pb.SetParentRequest) (*pb.SetParentResponse, error) {
+	if req.AssetId == "" {
+		return nil, status.Error(codes.InvalidArgument, "asset ID is required")
+	}
+	leader, leaderCtx, err := s.repl.route(ctx, true)
+	if err != nil {
+		return nil, err
+	}
+	if leader != nil {
+		return leader.SetParent(leaderCtx, req)
+	}
+
+	asset, err := s.applyLink(ctx, opSetParent, link{AssetID: req.AssetId, ParentID: req.ParentId, Tenant: auth.TenantFromContext(ctx)})
+	if err != nil {
+		return nil, err
+	}
+	log.Printf("Set parent of asset %s to %q", asset.Id, asset.ParentId)
+	return &pb.SetParentResponse{Asset: asset}, nil
+}
+
+func (s *server) ConnectAssets(ctx context.Context, req *pb.ConnectAssetsRequest) (*pb.ConnectAssetsResponse, error) {
+	if err := validateConnection(req.FromId, req.ToId, req.Type); err != nil {
+		return nil, err
+	}
+	leader, leaderCtx, err := s.repl.route(ctx, true)
+	if err != nil {
+		return nil, err
+	}
+	if leader != nil {
+		return leader.ConnectAssets(leaderCtx, req)
+	}
+
+	asset, err := s.applyLink(ctx, opConnect, link{AssetID: req.FromId, ToID: req.ToId, Type: req.Type, Tenant: auth.TenantFromContext(ctx)})
+	if err != nil {
+		return nil, err
+	}
+	log.Printf("Connected asset %s to %s (%v)", req.FromId, req.ToId, req.Type)
+	return &pb.ConnectAssetsResponse{Asset: asset}, nil
+}
+
+func (s *server) DisconnectAssets(ctx context.Context, req *pb.DisconnectAssetsRequest) (*pb.DisconnectAssetsResponse, error) {
+	if err := validateConnection(req.FromId, req.ToId, req.Type); err != nil {
+		return nil, err
+	}
+	leader, leaderCtx, err := s.repl.route(ctx, true)
+	if err != nil {
+		return nil, err
+	}
+	if leader != nil {
+		return leader.DisconnectAssets(leaderCtx, req)
+	}
+
+	asset, err := s.applyLink(ctx, opDisconnect, link{AssetID: req.FromId, ToID: req.ToId, Type: req.Type, Tenant: auth.TenantFromContext(ctx)})
+	if err != nil {
+		return nil, err
+	}
+	log.Printf("Disconnected asset %s from %s (%v)", req.FromId, req.ToId, req.Type)
+	return &pb.DisconnectAssetsResponse{Asset: asset}, nil
+}
+
+func validateConnection(from, to string, connType pb.ConnectionType) error {
+	if from == "" || to == "" {
+		return status.Error(codes.InvalidArgument, "from_id and to_id are required")
+	}
+	if connType == pb.ConnectionType_CONNECTION_UNKNOWN {
+		return status.Error(codes.InvalidArgument, "connection type is required")
+	}
+	if _, known := pb.ConnectionType_name[int32(connType)]; !known {
+		return status.Errorf(codes.InvalidArgument, "unknown connection type %d", connType)
+	}
+	return nil
+}
+
+func (s *server) GetSubtree(ctx context.Context, req *pb.GetSubtreeRequest) (*pb.GetSubtreeResponse, error) {
+	leader, leaderCtx, err := s.routeQuery(ctx, req.AssetId)
+	if err != nil {
+		return nil, err
+	}
+	if leader != nil {
+		return leader.GetSubtree(leaderCtx, req)
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	root, err := s.lookup(assetChange{ID: req.AssetId, Tenant: auth.TenantFromContext(ctx)})
+	if err != nil {
+		return nil, err
+	}
+
+	var assets []*pb.Asset
+	var walk func(asset *pb.Asset, depth int32)
+	walk = func(asset *pb.Asset, depth int32) {
+		assets = append(assets, asset)
+		if req.MaxDepth > 0 && depth == req.MaxDepth {
+			return
+		}
+		for _, id := range s.topology.childrenOf(asset.Id) {
+			walk(s.assets[id], depth+1)
+		}
+	}
+	walk(root, 0)
+	return &pb.GetSubtreeResponse{Assets: assets}, nil
+}
+
+func (s *server) GetAncestors(ctx context.Context, req *pb.GetAncestorsRequest) (*pb.GetAncestorsResponse, error) {
+	leader, leaderCtx, err := s.routeQuery(ctx, req.AssetId)
+	if err != nil {
+		return nil, err
+	}
+	if leader != nil {
+		return leader.GetAncestors(leaderCtx, req)
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	asset, err := s.lookup(assetChange{ID: req.AssetId, Tenant: auth.TenantFromContext(ctx)})
+	if err != nil {
+		return nil, err
+	}
+
+	var ancestors []*pb.Asset
+	for parent := s.assets[asset.ParentId]; parent != nil; parent = s.assets[parent.ParentId] {
+		ancestors = append(ancestors, parent)
+	}
+	return &pb.GetAncestorsResponse{Ancestors: ancestors}, nil
+}
+
+// GetConnected walks the connections breadth-first, so assets come out
+// nearest first.
+func (s *server) GetConnected(ctx context.Context, req *pb.GetConnectedRequest) (*pb.GetConnectedResponse, error) {
+	leader, leaderCtx, err := s.routeQuery(ctx, req.AssetId)
+	if err != nil {
+		return nil, err
+	}
+	if leader != nil {
+		return leader.GetConnected(leaderCtx, req)
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	root, err := s.lookup(assetChange{ID: req.AssetId, Tenant: auth.TenantFromContext(ctx)})
+	if err != nil {
+		return nil, err
+	}
+
+	follow := func(conn *pb.Connection) bool {
+		return len(req.ConnectionTypes) == 0 || slices.Contains(req.ConnectionTypes, conn.Type)
+	}
+	resp := &pb.GetConnectedResponse{}
+	visited := map[string]bool{root.Id: true}
+	followed := make(map[*pb.Connection]bool)
+	level := []string{root.Id}
+	for depth := int32(0); len(level) > 0 && (req.MaxDepth == 0 || depth < req.MaxDepth); depth++ {
+		var next []string
+		for _, id := range level {
+			var conns []*pb.Connection
+			if req.Direction != pb.Direction_UPSTREAM {
+				conns = append(conns, s.assets[id].Connections...)
+			}
+			if req.Direction != pb.Direction_DOWNSTREAM {
+				conns = append(conns, s.topology.incoming[id]...)
+			}
+			for _, conn := range conns {
+				if !follow(conn) {
+					continue
+				}
+				other := conn.ToId
+				if other == id {
+					other = conn.FromId
+				}
+				// Connections between assets already found still count, but
+				// following both ways meets them from both ends
+				if !followed[conn] {
+					followed[conn] = true
+					resp.Connections = append(resp.Connections, conn)
+				}
+				if visited[other] {
+					continue
+				}
+				visited[other] = true
+				next = append(next, other)
+				if req.AssetType == "" || s.assets[other].Type == req.AssetType {
+					resp.Assets = append(resp.Assets, s.assets[other])
+				}
+			}
+		}
+		level = next
+	}
+	return resp, nil
+}
+
+// routeQuery validates a hierarchy or connection query and picks where it's
+// served. Everything linked to an asset belongs to its tenant, so checking
+// the caller may see the asset queried is enough.
+func (s *server) routeQuery(ctx context.Context, id string) (pb.AssetRegistryClient, context.Context, error) {
+	if id == "" {
+		return nil, nil, status.Error(codes.InvalidArgument, "asset ID is required")
+	}
+	tracing.SpanFromContext(ctx).SetAttributes(tracing.AssetID(id))
+	return s.repl.route(ctx, false)
+}
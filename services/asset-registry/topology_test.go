@@ -0,0 +1,237 @@
+package main
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/sairamkiran9/asset-telemetry-monitor/gen/go/proto/asset"
+	"github.com/sairamkiran9/asset-telemetry-monitor/internal/auth"
+)
+
+// registerAll registers an asset of each type in order, so they get IDs
+// asset-1, asset-2 and so on.
+func registerAll(t *testing.T, s *server, ctx context.Context, types ...string) {
+	t.Helper()
+	for _, assetType := range types {
+		if _, err := s.RegisterAsset(ctx, &pb.RegisterAssetRequest{Name: assetType, Type: assetType}); err != nil {
+			t.Fatalf("RegisterAsset failed: %v", err)
+		}
+	}
+}
+
+func assetIDs(assets []*pb.Asset) []string {
+	ids := make([]string, len(assets))
+	for i, asset := range assets {
+		ids[i] = asset.Id
+	}
+	return ids
+}
+
+func TestHierarchy(t *testing.T) {
+	s := newServer()
+	ctx := context.Background()
+	// asset-1 site, asset-2 building, asset-3 floor, asset-4 meter
+	registerAll(t, s, ctx, "site", "building", "floor")
+	resp, err := s.RegisterAsset(ctx, &pb.RegisterAssetRequest{Name: "Meter", Type: "electric", ParentId: "asset-3"})
+	if err != nil {
+		t.Fatalf("RegisterAsset with a parent failed: %v", err)
+	}
+	if resp.Asset.ParentId != "asset-3" {
+		t.Errorf("Expected parent asset-3, got %q", resp.Asset.ParentId)
+	}
+	for _, req := range []*pb.SetParentRequest{
+		{AssetId: "asset-2", ParentId: "asset-1"},
+		{AssetId: "asset-3", ParentId: "asset-2"},
+	} {
+		if _, err := s.SetParent(ctx, req); err != nil {
+			t.Fatalf("SetParent failed: %v", err)
+		}
+	}
+
+	subtree, err := s.GetSubtree(ctx, &pb.GetSubtreeRequest{AssetId: "asset-1"})
+	if err != nil {
+		t.Fatalf("GetSubtree failed: %v", err)
+	}
+	if got := assetIDs(subtree.Assets); !slices.Equal(got, []string{"asset-1", "asset-2", "asset-3", "asset-4"}) {
+		t.Errorf("Expected the whole site, got %v", got)
+	}
+	subtree, _ = s.GetSubtree(ctx, &pb.GetSubtreeRequest{AssetId: "asset-1", MaxDepth: 1})
+	if got := assetIDs(subtree.Assets); !slices.Equal(got, []string{"asset-1", "asset-2"}) {
+		t.Errorf("Expected the site and its building, got %v", got)
+	}
+
+	ancestors, err := s.GetAncestors(ctx, &pb.GetAncestorsRequest{AssetId: "asset-4"})
+	if err != nil {
+		t.Fatalf("GetAncestors failed: %v", err)
+	}
+	if got := assetIDs(ancestors.Ancestors); !slices.Equal(got, []string{"asset-3", "asset-2", "asset-1"}) {
+		t.Errorf("Expected ancestors nearest first, got %v", got)
+	}
+
+	tests := []struct {
+		name string
+		req  *pb.SetParentRequest
+		code codes.Code
+	}{
+		{"own parent", &pb.SetParentRequest{AssetId: "asset-1", ParentId: "asset-1"}, codes.FailedPrecondition},
+		{"under a descendant", &pb.SetParentRequest{AssetId: "asset-1", ParentId: "asset-4"}, codes.FailedPrecondition},
+		{"missing parent", &pb.SetParentRequest{AssetId: "asset-4", ParentId: "asset-99"}, codes.NotFound},
+		{"missing asset ID", &pb.SetParentRequest{ParentId: "asset-1"}, codes.InvalidArgument},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := s.SetParent(ctx, tt.req); status.Code(err) != tt.code {
+				t.Errorf("Expected %v, got %v", tt.code, err)
+			}
+		})
+	}
+
+	// Moving the meter up a level and then out of the hierarchy
+	s.SetParent(ctx, &pb.SetParentRequest{AssetId: "asset-4", ParentId: "asset-2"})
+	subtree, _ = s.GetSubtree(ctx, &pb.GetSubtreeRequest{AssetId: "asset-2"})
+	if got := assetIDs(subtree.Assets); !slices.Equal(got, []string{"asset-2", "asset-3", "asset-4"}) {
+		t.Errorf("Expected the meter moved under the building, got %v", got)
+	}
+	s.SetParent(ctx, &pb.SetParentRequest{AssetId: "asset-4"})
+	if ancestors, _ := s.GetAncestors(ctx, &pb.GetAncestorsRequest{AssetId: "asset-4"}); len(ancestors.Ancestors) != 0 {
+		t.Errorf("Expected no ancestors once detached, got %v", assetIDs(ancestors.Ancestors))
+	}
+}
+
+func TestConnections(t *testing.T) {
+	s := newServer()
+	ctx := context.Background()
+	// A feeder powers a panel, which powers a chiller and a pump; the chiller
+	// supplies chilled water to a building
+	registerAll(t, s, ctx, "electric", "electric", "chillwater", "electric", "building")
+	for _, req := range []*pb.ConnectAssetsRequest{
+		{FromId: "asset-1", ToId: "asset-2", Type: pb.ConnectionType_POWER},
+		{FromId: "asset-2", ToId: "asset-3", Type: pb.ConnectionType_POWER},
+		{FromId: "asset-2", ToId: "asset-4", Type: pb.ConnectionType_POWER},
+		{FromId: "asset-3", ToId: "asset-5", Type: pb.ConnectionType_CHILLED_WATER},
+	} {
+		if _, err := s.ConnectAssets(ctx, req); err != nil {
+			t.Fatalf("ConnectAssets failed: %v", err)
+		}
+	}
+
+	power := []pb.ConnectionType{pb.ConnectionType_POWER}
+	tests := []struct {
+		name  string
+		req   *pb.GetConnectedRequest
+		want  []string
+		conns int
+	}{
+		{"everything downstream", &pb.GetConnectedRequest{AssetId: "asset-1"}, []string{"asset-2", "asset-3", "asset-4", "asset-5"}, 4},
+		{"power downstream", &pb.GetConnectedRequest{AssetId: "asset-1", ConnectionTypes: power}, []string{"asset-2", "asset-3", "asset-4"}, 3},
+		{"electric assets downstream of the feeder", &pb.GetConnectedRequest{AssetId: "asset-1", ConnectionTypes: power, AssetType: "electric"}, []string{"asset-2", "asset-4"}, 3},
+		{"one hop", &pb.GetConnectedRequest{AssetId: "asset-1", MaxDepth: 1}, []string{"asset-2"}, 1},
+		{"upstream", &pb.GetConnectedRequest{AssetId: "asset-5", Direction: pb.Direction_UPSTREAM}, []string{"asset-3", "asset-2", "asset-1"}, 3},
+		{"both ways", &pb.GetConnectedRequest{AssetId: "asset-3", Direction: pb.Direction_BOTH, ConnectionTypes: power}, []string{"asset-2", "asset-4", "asset-1"}, 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := s.GetConnected(ctx, tt.req)
+			if err != nil {
+				t.Fatalf("GetConnected failed: %v", err)
+			}
+			if got := assetIDs(resp.Assets); !slices.Equal(got, tt.want) {
+				t.Errorf("Expected %v, got %v", tt.want, got)
+			}
+			if len(resp.Connections) != tt.conns {
+				t.Errorf("Expected %d connections, got %v", tt.conns, resp.Connections)
+			}
+		})
+	}
+}
+
+func TestConnectValidation(t *testing.T) {
+	s := newServer()
+	ctx := context.Background()
+	registerAll(t, s, ctx, "electric", "electric", "electric")
+	s.ConnectAssets(ctx, &pb.ConnectAssetsRequest{FromId: "asset-1", ToId: "asset-2", Type: pb.ConnectionType_POWER})
+	s.ConnectAssets(ctx, &pb.ConnectAssetsRequest{FromId: "asset-2", ToId: "asset-3", Type: pb.ConnectionType_POWER})
+
+	tests := []struct {
+		name string
+		req  *pb.ConnectAssetsRequest
+		code codes.Code
+	}{
+		{"power loop", &pb.ConnectAssetsRequest{FromId: "asset-3", ToId: "asset-1", Type: pb.ConnectionType_POWER}, codes.FailedPrecondition},
+		{"to itself", &pb.ConnectAssetsRequest{FromId: "asset-1", ToId: "asset-1", Type: pb.ConnectionType_GAS}, codes.FailedPrecondition},
+		{"missing type", &pb.ConnectAssetsRequest{FromId: "asset-1", ToId: "asset-3"}, codes.InvalidArgument},
+		{"unknown type", &pb.ConnectAssetsRequest{FromId: "asset-1", ToId: "asset-3", Type: 99}, codes.InvalidArgument},
+		{"missing asset", &pb.ConnectAssetsRequest{FromId: "asset-1", ToId: "asset-99", Type: pb.ConnectionType_POWER}, codes.NotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := s.ConnectAssets(ctx, tt.req); status.Code(err) != tt.code {
+				t.Errorf("Expected %v, got %v", tt.code, err)
+			}
+		})
+	}
+
+	// Another kind of supply may run the other way
+	if _, err := s.ConnectAssets(ctx, &pb.ConnectAssetsRequest{FromId: "asset-3", ToId: "asset-1", Type: pb.ConnectionType_HOT_WATER}); err != nil {
+		t.Errorf("Expected a hot water connection back to be allowed, got %v", err)
+	}
+	// Connecting twice changes nothing
+	resp, err := s.ConnectAssets(ctx, &pb.ConnectAssetsRequest{FromId: "asset-1", ToId: "asset-2", Type: pb.ConnectionType_POWER})
+	if err != nil || len(resp.Asset.Connections) != 1 {
+		t.Errorf("Expected a repeated connection to be ignored, got %v (%v)", resp.GetAsset(), err)
+	}
+
+	// Deleting needs the asset unlinked first
+	if _, err := s.DeleteAsset(ctx, &pb.DeleteAssetRequest{Id: "asset-2"}); status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("Expected deleting a connected asset to fail, got %v", err)
+	}
+	for _, req := range []*pb.DisconnectAssetsRequest{
+		{FromId: "asset-1", ToId: "asset-2", Type: pb.ConnectionType_POWER},
+		{FromId: "asset-2", ToId: "asset-3", Type: pb.ConnectionType_POWER},
+	} {
+		if _, err := s.DisconnectAssets(ctx, req); err != nil {
+			t.Fatalf("DisconnectAssets failed: %v", err)
+		}
+	}
+	if _, err := s.DisconnectAssets(ctx, &pb.DisconnectAssetsRequest{FromId: "asset-1", ToId: "asset-2", Type: pb.ConnectionType_POWER}); status.Code(err) != codes.NotFound {
+		t.Errorf("Expected NotFound for a missing connection, got %v", err)
+	}
+	if _, err := s.DeleteAsset(ctx, &pb.DeleteAssetRequest{Id: "asset-2"}); err != nil {
+		t.Errorf("Expected the disconnected asset to be deleted, got %v", err)
+	}
+}
+
+func TestTopologyTenants(t *testing.T) {
+	s := newServer()
+	north := auth.ContextWithTenant(context.Background(), "north-campus")
+	south := auth.ContextWithTenant(context.Background(), "south-campus")
+	registerAll(t, s, north, "site", "electric")
+	registerAll(t, s, south, "electric")
+	s.SetParent(north, &pb.SetParentRequest{AssetId: "asset-2", ParentId: "asset-1"})
+
+	// Other tenants' assets look missing
+	if _, err := s.SetParent(south, &pb.SetParentRequest{AssetId: "asset-3", ParentId: "asset-1"}); status.Code(err) != codes.NotFound {
+		t.Errorf("Expected NotFound for another tenant's parent, got %v", err)
+	}
+	if _, err := s.GetSubtree(south, &pb.GetSubtreeRequest{AssetId: "asset-1"}); status.Code(err) != codes.NotFound {
+		t.Errorf("Expected NotFound for another tenant's subtree, got %v", err)
+	}
+	if _, err := s.RegisterAsset(south, &pb.RegisterAssetRequest{Name: "Meter", ParentId: "asset-1"}); status.Code(err) != codes.NotFound {
+		t.Errorf("Expected NotFound registering under another tenant's asset, got %v", err)
+	}
+
+	// Even service credentials can't link across tenants
+	service := auth.ContextWithTenant(context.Background(), auth.AnyTenant)
+	if _, err := s.ConnectAssets(service, &pb.ConnectAssetsRequest{FromId: "asset-2", ToId: "asset-3", Type: pb.ConnectionType_POWER}); status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("Expected FailedPrecondition connecting across tenants, got %v", err)
+	}
+
+	// Parents can't be deleted from under their children
+	if _, err := s.DeleteAsset(north, &pb.DeleteAssetRequest{Id: "asset-1"}); status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("Expected deleting a parent to fail, got %v", err)
+	}
+}
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"context"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/sairamkiran9/asset-telemetry-monitor/gen/go/proto/asset"
+	"github.com/sairamkiran9/asset-telemetry-monitor/internal/assettypes"
+)
+
+// checkType validates an asset's metadata against the schema of its type,
+// returning the type's canonical name. Assets without a type have no schema.
+// Checks happen before changes are replicated, so the log only holds valid
+// changes and replays them even if the types later change.
+func (s *server) checkType(typeName string, metadata map[string]string) (string, error) {
+	if typeName == "" {
+		return "", nil
+	}
+	t, found := s.types.Lookup(typeName)
+	if !found {
+		return "", status.Errorf(codes.InvalidArgument, "unknown asset type %q; known types are %s", typeName, strings.Join(s.types.Names(), ", "))
+	}
+	if err := assettypes.Validate(t, metadata); err != nil {
+		return "", status.Errorf(codes.InvalidArgument, "invalid %s asset: %v", t.Name, err)
+	}
+	return t.Name, nil
+}
+
+// checkUpdate validates what an asset would become after change, and pins
+// the change to the asset's current version so a concurrent change can't
+// slip past the check.
+func (s *server) checkUpdate(change *assetChange) error {
+	if change.Type == "" && len(change.Metadata) == 0 {
+		return nil
+	}
+	s.mu.RLock()
+	current, err := s.lookup(*change)
+	s.mu.RUnlock()
+	if err != nil {
+		return err
+	}
+
+	typeName, metadata := current.Type, current.Metadata
+	if change.Type != "" {
+		typeName = change.Type
+	}
+	if len(change.Metadata) > 0 {
+		metadata = change.Metadata
+	}
+	canonical, err := s.checkType(typeName, metadata)
+	if err != nil {
+		return err
+	}
+	if change.Type != "" {
+		change.Type = canonical
+	}
+	change.Version = current.ResourceVersion
+	return nil
+}
+
+// ListAssetTypes is served by whichever node receives it; every node should
+// load the same asset types.
+func (s *server) ListAssetTypes(ctx context.Context, req *pb.ListAssetTypesRequest) (*pb.ListAssetTypesResponse, error) {
+	return &pb.ListAssetTypesResponse{Types: s.types.Types()}, nil
+}
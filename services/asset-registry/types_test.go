@@ -0,0 +1,107 @@
+package main
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/sairamkiran9/asset-telemetry-monitor/gen/go/proto/asset"
+	"github.com/sairamkiran9/asset-telemetry-monitor/internal/assettypes"
+	"github.com/sairamkiran9/asset-telemetry-monitor/internal/auth"
+)
+
+func TestRegisterAssetTypes(t *testing.T) {
+	s := newServer()
+	ctx := context.Background()
+
+	tests := []struct {
+		name     string
+		req      *pb.RegisterAssetRequest
+		code     codes.Code
+		wantType string
+	}{
+		{"canonical", &pb.RegisterAssetRequest{Name: "Feeder", Type: "electric"}, codes.OK, "electric"},
+		{"other case", &pb.RegisterAssetRequest{Name: "Feeder", Type: "Electric"}, codes.OK, "electric"},
+		{"alias", &pb.RegisterAssetRequest{Name: "Chiller", Type: "chilled-water"}, codes.OK, "chillwater"},
+		{"untyped", &pb.RegisterAssetRequest{Name: "Thing"}, codes.OK, ""},
+		{"valid metadata", &pb.RegisterAssetRequest{Name: "Feeder", Type: "electric", Metadata: map[string]string{"rated_kw": "750", "phases": "3"}}, codes.OK, "electric"},
+		{"unknown type", &pb.RegisterAssetRequest{Name: "Boiler", Type: "boiler"}, codes.InvalidArgument, ""},
+		{"bad number", &pb.RegisterAssetRequest{Name: "Feeder", Type: "electric", Metadata: map[string]string{"rated_kw": "750kW"}}, codes.InvalidArgument, ""},
+		{"bad enum", &pb.RegisterAssetRequest{Name: "Feeder", Type: "electric", Metadata: map[string]string{"phases": "2"}}, codes.InvalidArgument, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := s.RegisterAsset(ctx, tt.req)
+			if status.Code(err) != tt.code {
+				t.Fatalf("Expected %v, got %v", tt.code, err)
+			}
+			if err == nil && resp.Asset.Type != tt.wantType {
+				t.Errorf("Expected type %q, got %q", tt.wantType, resp.Asset.Type)
+			}
+		})
+	}
+}
+
+func TestUpdateAssetTypes(t *testing.T) {
+	s := newServer()
+	ctx := context.Background()
+	s.RegisterAsset(ctx, &pb.RegisterAssetRequest{Name: "Feeder", Type: "electric", Metadata: map[string]string{"phases": "3"}})
+
+	// Metadata is checked against the type the asset will have
+	if _, err := s.UpdateAsset(ctx, &pb.UpdateAssetRequest{Id: "asset-1", Metadata: map[string]string{"phases": "two"}}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Expected invalid metadata to be rejected, got %v", err)
+	}
+	if _, err := s.UpdateAsset(ctx, &pb.UpdateAssetRequest{Id: "asset-1", Type: "boiler"}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Expected an unknown type to be rejected, got %v", err)
+	}
+	resp, err := s.UpdateAsset(ctx, &pb.UpdateAssetRequest{Id: "asset-1", Type: "CHW"})
+	if err != nil {
+		t.Fatalf("UpdateAsset failed: %v", err)
+	}
+	if resp.Asset.Type != "chillwater" || resp.Asset.Metadata["phases"] != "3" {
+		t.Errorf("Expected the canonical type and unchanged metadata, got %v", resp.Asset)
+	}
+
+	// A change checked against an older version of the asset isn't applied
+	change := assetChange{ID: "asset-1", Metadata: map[string]string{"rated_tons": "500"}, Tenant: auth.TenantFromContext(ctx)}
+	if err := s.checkUpdate(&change); err != nil {
+		t.Fatalf("checkUpdate failed: %v", err)
+	}
+	s.UpdateAsset(ctx, &pb.UpdateAssetRequest{Id: "asset-1", Type: "electric"})
+	if _, err := s.update(change); status.Code(err) != codes.Aborted {
+		t.Errorf("Expected a stale change to be aborted, got %v", err)
+	}
+}
+
+func TestListAssetTypes(t *testing.T) {
+	s := newServer()
+	path := filepath.Join(t.TempDir(), "types.json")
+	os.WriteFile(path, []byte(`{"types": [{"name": "boiler", "fields": [{"name": "rated_kw", "kind": "NUMBER", "unit": "kW", "required": true}]}]}`), 0o600)
+	var err error
+	if s.types, err = assettypes.Load(path); err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+
+	resp, err := s.ListAssetTypes(context.Background(), &pb.ListAssetTypesRequest{})
+	if err != nil {
+		t.Fatalf("ListAssetTypes failed: %v", err)
+	}
+	names := make(map[string]bool)
+	for _, assetType := range resp.Types {
+		names[assetType.Name] = true
+	}
+	if !names["boiler"] || !names["electric"] {
+		t.Errorf("Expected the boiler and built-in types, got %v", names)
+	}
+
+	if _, err := s.RegisterAsset(context.Background(), &pb.RegisterAssetRequest{Name: "Boiler-1", Type: "boiler"}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Expected a boiler without its rated_kw to be rejected, got %v", err)
+	}
+	if _, err := s.RegisterAsset(context.Background(), &pb.RegisterAssetRequest{Name: "Boiler-1", Type: "boiler", Metadata: map[string]string{"rated_kw": "1200"}}); err != nil {
+		t.Errorf("Expected a boiler to be registered, got %v", err)
+	}
+}
@@ -0,0 +1,194 @@
+package main
+
+import (
+	"log"
+	"sync"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/sairamkiran9/asset-telemetry-monitor/gen/go/proto/asset"
+	"github.com/sairamkiran9/asset-telemetry-monitor/internal/auth"
+)
+
+const (
+	defaultWatchHistory = 1000
+
+	// watchBuffer is how many events a watcher may fall behind before it's
+	// dropped and has to resume.
+	watchBuffer = 256
+)
+
+// assetEvents keeps the most recent asset changes, so watchers can resume
+// after a disconnect, and fans new ones out to the open watches. The registry
+// records changes while holding its lock, so events arrive in version order.
+type assetEvents struct {
+	mu       sync.Mutex
+	history  []*pb.AssetEvent // Oldest first, at most limit long
+	limit    int
+	floor    uint64 // Every change after this version is in history
+	watchers map[*watcher]struct{}
+}
+
+// watcher is an open WatchAssets call's queue of events.
+type watcher struct {
+	events chan *pb.AssetEvent
+	done   chan struct{} // Closed when the watcher is dropped
+	err    error         // Why it was dropped
+}
+
+func newAssetEvents(limit int) *assetEvents {
+	return &assetEvents{limit: limit, watchers: make(map[*watcher]struct{})}
+}
+
+// record adds a change to the history and queues it for every watcher.
+// Watchers too far behind to take it are dropped.
+func (e *assetEvents) record(event *pb.AssetEvent) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.limit > 0 {
+		if len(e.history) == e.limit {
+			e.floor = e.history[0].ResourceVersion
+			// Appending copies the remaining events once capacity runs out
+			e.history = e.history[1:]
+		}
+		e.history = append(e.history, event)
+	} else {
+		e.floor = event.ResourceVersion
+	}
+
+	for w := range e.watchers {
+		select {
+		case w.events <- event:
+		default:
+			e.drop(w, status.Errorf(codes.ResourceExhausted, "watcher fell more than %d events behind; resume from its last version", watchBuffer))
+		}
+	}
+}
+
+// since returns the changes after version. It fails when some of them are no
+// longer kept, or when version is ahead of current, the registry's version,
+// as it is after the registry restarts without its data.
+func (e *assetEvents) since(version, current uint64) ([]*pb.AssetEvent, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if version < e.floor {
+		return nil, status.Errorf(codes.OutOfRange, "resource version %d is too old; watch from 0 to list every asset", version)
+	}
+	if version > current {
+		return nil, status.Errorf(codes.OutOfRange, "resource version %d is ahead of the registry's %d; watch from 0 to list every asset", version, current)
+	}
+	var events []*pb.AssetEvent
+	for _, event := range e.history {
+		if event.ResourceVersion > version {
+			events = append(events, event)
+		}
+	}
+	return events, nil
+}
+
+func (e *assetEvents) watch() *watcher {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	w := &watcher{events: make(chan *pb.AssetEvent, watchBuffer), done: make(chan struct{})}
+	e.watchers[w] = struct{}{}
+	return w
+}
+
+func (e *assetEvents) unwatch(w *watcher) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	delete(e.watchers, w)
+}
+
+// reset forgets the history, which no longer leads up to version, and drops
+// every watcher. Replicated registries reset when they restore a snapshot.
+func (e *assetEvents) reset(version uint64) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.history = nil
+	e.floor = version
+	for w := range e.watchers {
+		e.drop(w, status.Error(codes.Unavailable, "asset registry state was replaced; resume from the last version"))
+	}
+}
+
+func (e *assetEvents) watching() int {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return len(e.watchers)
+}
+
+// drop ends a watcher with err. The caller holds e.mu.
+func (e *assetEvents) drop(w *watcher, err error) {
+	w.err = err
+	close(w.done)
+	delete(e.watchers, w)
+}
+
+// WatchAssets is served by whichever node receives it, even a stale
+// follower: every node applies the same changes at the same versions, so a
+// watch sees them in order, if late, and can resume anywhere.
+func (s *server) WatchAssets(req *pb.WatchAssetsRequest, stream pb.AssetRegistry_WatchAssetsServer) error {
+	ctx := stream.Context()
+
+	// Subscribe and collect what the caller missed under the lock changes are
+	// made under, so nothing falls in between
+	s.mu.RLock()
+	version := s.version
+	var (
+		initial []*pb.AssetEvent
+		err     error
+	)
+	if req.ResourceVersion == 0 {
+		for _, asset := range s.assets {
+			initial = append(initial, &pb.AssetEvent{Type: pb.AssetEventType_CREATED, Asset: asset, ResourceVersion: asset.ResourceVersion})
+		}
+	} else {
+		initial, err = s.events.since(req.ResourceVersion, version)
+	}
+	var w *watcher
+	if err == nil {
+		w = s.events.watch()
+	}
+	s.mu.RUnlock()
+	if err != nil {
+		return err
+	}
+	defer s.events.unwatch(w)
+
+	for _, event := range initial {
+		if err := sendEvent(stream, event); err != nil {
+			return err
+		}
+	}
+	if err := stream.Send(&pb.AssetEvent{Type: pb.AssetEventType_SYNCED, ResourceVersion: version}); err != nil {
+		return err
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-w.done:
+			log.Printf("Dropped asset watcher: %v", w.err)
+			return w.err
+		case event := <-w.events:
+			if err := sendEvent(stream, event); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// sendEvent sends event unless it's about another tenant's asset.
+func sendEvent(stream pb.AssetRegistry_WatchAssetsServer, event *pb.AssetEvent) error {
+	if !auth.CanAccessTenant(stream.Context(), event.Asset.GetTenant()) {
+		return nil
+	}
+	return stream.Send(event)
+}
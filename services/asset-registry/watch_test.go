@@ -0,0 +1,184 @@
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/sairamkiran9/asset-telemetry-monitor/gen/go/proto/asset"
+	"github.com/sairamkiran9/asset-telemetry-monitor/internal/auth"
+)
+
+// watchStream is a WatchAssets call running in the background.
+type watchStream struct {
+	grpc.ServerStream
+	ctx    context.Context
+	events chan *pb.AssetEvent
+	err    chan error
+}
+
+func (w *watchStream) Context() context.Context {
+	return w.ctx
+}
+
+func (w *watchStream) Send(event *pb.AssetEvent) error {
+	w.events <- event
+	return nil
+}
+
+func startWatch(t *testing.T, s *server, ctx context.Context, version uint64) *watchStream {
+	t.Helper()
+	ctx, cancel := context.WithCancel(ctx)
+	t.Cleanup(cancel)
+	w := &watchStream{ctx: ctx, events: make(chan *pb.AssetEvent, 100), err: make(chan error, 1)}
+	go func() { w.err <- s.WatchAssets(&pb.WatchAssetsRequest{ResourceVersion: version}, w) }()
+	return w
+}
+
+func (w *watchStream) next(t *testing.T) *pb.AssetEvent {
+	t.Helper()
+	select {
+	case event := <-w.events:
+		return event
+	case err := <-w.err:
+		t.Fatalf("Expected an event, watch ended with %v", err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("Timed out waiting for an event")
+	}
+	return nil
+}
+
+func expectEvent(t *testing.T, event *pb.AssetEvent, eventType pb.AssetEventType, assetID string, version uint64) {
+	t.Helper()
+	if event.Type != eventType || event.Asset.GetId() != assetID || event.ResourceVersion != version {
+		t.Errorf("Expected %v of %q at version %d, got %v of %q at version %d",
+			eventType, assetID, version, event.Type, event.Asset.GetId(), event.ResourceVersion)
+	}
+}
+
+func TestWatchAssets(t *testing.T) {
+	s := newServer()
+	ctx := context.Background()
+	s.RegisterAsset(ctx, &pb.RegisterAssetRequest{Name: "Feeder-1", Type: "electric"})
+
+	w := startWatch(t, s, ctx, 0)
+	expectEvent(t, w.next(t), pb.AssetEventType_CREATED, "asset-1", 1)
+	expectEvent(t, w.next(t), pb.AssetEventType_SYNCED, "", 1)
+
+	s.RegisterAsset(ctx, &pb.RegisterAssetRequest{Name: "Chiller-1", Type: "chillwater"})
+	expectEvent(t, w.next(t), pb.AssetEventType_CREATED, "asset-2", 2)
+
+	if _, err := s.UpdateAsset(ctx, &pb.UpdateAssetRequest{Id: "asset-2", Description: "Plant 1 chiller"}); err != nil {
+		t.Fatalf("UpdateAsset failed: %v", err)
+	}
+	event := w.next(t)
+	expectEvent(t, event, pb.AssetEventType_UPDATED, "asset-2", 3)
+	if event.Asset.Description != "Plant 1 chiller" || event.Asset.ResourceVersion != 3 {
+		t.Errorf("Expected the updated asset at version 3, got %v", event.Asset)
+	}
+
+	if _, err := s.DeleteAsset(ctx, &pb.DeleteAssetRequest{Id: "asset-1"}); err != nil {
+		t.Fatalf("DeleteAsset failed: %v", err)
+	}
+	expectEvent(t, w.next(t), pb.AssetEventType_DELETED, "asset-1", 4)
+
+	listResp, _ := s.ListAssets(ctx, &pb.ListAssetsRequest{})
+	if listResp.ResourceVersion != 4 {
+		t.Errorf("Expected ListAssets at version 4, got %d", listResp.ResourceVersion)
+	}
+}
+
+func TestWatchAssetsResume(t *testing.T) {
+	s := newServer()
+	ctx := context.Background()
+	for i := 0; i < 3; i++ {
+		s.RegisterAsset(ctx, &pb.RegisterAssetRequest{Name: "Meter"})
+	}
+	s.DeleteAsset(ctx, &pb.DeleteAssetRequest{Id: "asset-1"})
+
+	// Only the changes after version 2 are replayed
+	w := startWatch(t, s, ctx, 2)
+	expectEvent(t, w.next(t), pb.AssetEventType_CREATED, "asset-3", 3)
+	expectEvent(t, w.next(t), pb.AssetEventType_DELETED, "asset-1", 4)
+	expectEvent(t, w.next(t), pb.AssetEventType_SYNCED, "", 4)
+
+	// Up to date already
+	w = startWatch(t, s, ctx, 4)
+	expectEvent(t, w.next(t), pb.AssetEventType_SYNCED, "", 4)
+}
+
+func TestWatchAssetsResumeOutOfRange(t *testing.T) {
+	s := newServer()
+	s.events = newAssetEvents(2)
+	ctx := context.Background()
+	for i := 0; i < 4; i++ {
+		s.RegisterAsset(ctx, &pb.RegisterAssetRequest{Name: "Meter"})
+	}
+
+	tests := []struct {
+		name    string
+		version uint64
+	}{
+		{"changes no longer kept", 1},
+		{"ahead of the registry", 10},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := startWatch(t, s, ctx, tt.version)
+			select {
+			case err := <-w.err:
+				if status.Code(err) != codes.OutOfRange {
+					t.Errorf("Expected OutOfRange, got %v", err)
+				}
+			case <-time.After(5 * time.Second):
+				t.Fatal("Expected the watch to fail")
+			}
+		})
+	}
+
+	// The two most recent changes are still kept
+	w := startWatch(t, s, ctx, 2)
+	expectEvent(t, w.next(t), pb.AssetEventType_CREATED, "asset-3", 3)
+}
+
+func TestWatchAssetsTenants(t *testing.T) {
+	s := newServer()
+	north := auth.ContextWithTenant(context.Background(), "north-campus")
+	south := auth.ContextWithTenant(context.Background(), "south-campus")
+	s.RegisterAsset(north, &pb.RegisterAssetRequest{Name: "Chiller-1"})
+
+	w := startWatch(t, s, south, 0)
+	expectEvent(t, w.next(t), pb.AssetEventType_SYNCED, "", 1)
+	s.RegisterAsset(north, &pb.RegisterAssetRequest{Name: "Chiller-2"})
+	s.RegisterAsset(south, &pb.RegisterAssetRequest{Name: "Boiler-1"})
+	expectEvent(t, w.next(t), pb.AssetEventType_CREATED, "asset-3", 3)
+
+	// Service credentials see every tenant
+	w = startWatch(t, s, auth.ContextWithTenant(context.Background(), auth.AnyTenant), 1)
+	expectEvent(t, w.next(t), pb.AssetEventType_CREATED, "asset-2", 2)
+	expectEvent(t, w.next(t), pb.AssetEventType_CREATED, "asset-3", 3)
+}
+
+func TestWatcherDroppedWhenBehind(t *testing.T) {
+	events := newAssetEvents(defaultWatchHistory)
+	w := events.watch()
+	for i := 1; i <= watchBuffer+1; i++ {
+		events.record(&pb.AssetEvent{Type: pb.AssetEventType_CREATED, ResourceVersion: uint64(i)})
+	}
+
+	select {
+	case <-w.done:
+	default:
+		t.Fatal("Expected a watcher that fell behind to be dropped")
+	}
+	if status.Code(w.err) != codes.ResourceExhausted {
+		t.Errorf("Expected ResourceExhausted, got %v", w.err)
+	}
+	if events.watching() != 0 {
+		t.Errorf("Expected no watchers left, got %d", events.watching())
+	}
+}
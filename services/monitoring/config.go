@@ -0,0 +1,36 @@
+package main
+
+import (
+	"errors"
+
+	"github.com/sairamkiran9/asset-telemetry-monitor/internal/config"
+)
+
+// Config holds the monitoring service's settings.
+type Config struct {
+	config.Common
+	AssetRegistryAddr   string `config:"asset_registry_addr" env:"ASSET_REGISTRY_ADDR" usage:"asset registry address, or comma-separated addresses of its replicas"`
+	TelemetryAddr       string `config:"telemetry_addr" env:"TELEMETRY_ADDR" usage:"telemetry service address"`
+	AssetMonitoringAddr string `config:"asset_monitoring_addr" env:"ASSET_MONITORING_ADDR" usage:"asset monitoring service address"`
+	AlertingAddr        string `config:"alerting_addr" env:"ALERTING_ADDR" usage:"alerting service address"`
+}
+
+func defaultConfig() Config {
+	return Config{
+		Common:              config.DefaultCommon(":50053"),
+		AssetRegistryAddr:   "asset-registry:50051",
+		TelemetryAddr:       "telemetry:50052",
+		AssetMonitoringAddr: "asset-monitoring:50054",
+		AlertingAddr:        "alerting:50055",
+	}
+}
+
+func (c *Config) Validate() error {
+	return errors.Join(
+		c.Common.Validate(),
+		config.CheckAddrs("asset_registry_addr", c.AssetRegistryAddr),
+		config.CheckAddr("telemetry_addr", c.TelemetryAddr),
+		config.CheckAddr("asset_monitoring_addr", c.AssetMonitoringAddr),
+		config.CheckAddr("alerting_addr", c.AlertingAddr),
+	)
+}
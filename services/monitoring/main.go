@@ -2,32 +2,70 @@ package main
 
 import (
 	"context"
+	"fmt"
 	"log"
 	"net"
+	"os/signal"
+	"strings"
+	"sync"
+	"syscall"
 	"time"
 
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 	"google.golang.org/grpc/reflection"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
+	alertingpb "github.com/sairamkiran9/asset-telemetry-monitor/gen/go/proto/alerting"
 	assetpb "github.com/sairamkiran9/asset-telemetry-monitor/gen/go/proto/asset"
+	monitoringpb "github.com/sairamkiran9/asset-telemetry-monitor/gen/go/proto/asset_monitoring"
 	pb "github.com/sairamkiran9/asset-telemetry-monitor/gen/go/proto/monitoring"
 	telemetrypb "github.com/sairamkiran9/asset-telemetry-monitor/gen/go/proto/telemetry"
+	"github.com/sairamkiran9/asset-telemetry-monitor/internal/auth"
+	"github.com/sairamkiran9/asset-telemetry-monitor/internal/certs"
+	"github.com/sairamkiran9/asset-telemetry-monitor/internal/cluster"
+	"github.com/sairamkiran9/asset-telemetry-monitor/internal/config"
+	"github.com/sairamkiran9/asset-telemetry-monitor/internal/health"
+	"github.com/sairamkiran9/asset-telemetry-monitor/internal/interceptors"
+	"github.com/sairamkiran9/asset-telemetry-monitor/internal/metrics"
+	"github.com/sairamkiran9/asset-telemetry-monitor/internal/shutdown"
+	"github.com/sairamkiran9/asset-telemetry-monitor/internal/tracing"
 )
 
+// upstream is a service whose standard health check HealthCheck aggregates
+// and whose metrics GetMetrics streams.
+type upstream struct {
+	name    string // Name used in HealthCheck requests, details and metric labels
+	service string // Readiness service name registered by the upstream
+	health  healthpb.HealthClient
+	metrics pb.MetricsExporterClient
+}
+
 type server struct {
 	pb.UnimplementedMonitoringServiceServer
-	assetClient     assetpb.AssetRegistryClient
-	telemetryClient telemetrypb.TelemetryServiceClient
+	upstreams []upstream
+	registry  *metrics.Registry
 }
 
-func newServer(assetClient assetpb.AssetRegistryClient, telemetryClient telemetrypb.TelemetryServiceClient) *server {
+func newServer(upstreams ...upstream) *server {
+	registry := metrics.NewRegistry()
+	metrics.RegisterRuntime(registry)
+
 	return &server{
-		assetClient:     assetClient,
-		telemetryClient: telemetryClient,
+		upstreams: upstreams,
+		registry:  registry,
+	}
+}
+
+// newUpstream creates an upstream served over conn.
+func newUpstream(name, service string, conn *grpc.ClientConn) upstream {
+	return upstream{
+		name:    name,
+		service: service,
+		health:  healthpb.NewHealthClient(conn),
+		metrics: pb.NewMetricsExporterClient(conn),
 	}
 }
 
@@ -37,42 +75,51 @@ func (s *server) HealthCheck(ctx context.Context, req *pb.HealthCheckRequest) (*
 		serviceName = "all"
 	}
 
-	details := make(map[string]string)
-	healthStatus := pb.HealthStatus_HEALTHY
-	message := "Service is healthy"
-
-	// Check Asset Registry
-	if serviceName == "all" || serviceName == "asset-registry" {
-		checkCtx, cancel := context.WithTimeout(ctx, 2*time.Second)
-		defer cancel()
-
-		_, err := s.assetClient.ListAssets(checkCtx, &assetpb.ListAssetsRequest{})
-		if err != nil {
-			details["asset-registry"] = "unhealthy"
-			healthStatus = pb.HealthStatus_DEGRADED
-			message = "Some services are unavailable"
-		} else {
-			details["asset-registry"] = "healthy"
+	var targets []upstream
+	for _, u := range s.upstreams {
+		if serviceName == "all" || serviceName == u.name {
+			targets = append(targets, u)
 		}
 	}
+	if len(targets) == 0 {
+		return nil, status.Errorf(codes.NotFound, "unknown service %q", serviceName)
+	}
 
-	// Check Telemetry Service
-	if serviceName == "all" || serviceName == "telemetry" {
-		checkCtx, cancel := context.WithTimeout(ctx, 2*time.Second)
-		defer cancel()
+	// Query every upstream's readiness concurrently
+	results := make([]error, len(targets))
+	var wg sync.WaitGroup
+	for i, u := range targets {
+		wg.Add(1)
+		go func(i int, u upstream) {
+			defer wg.Done()
+			results[i] = checkUpstream(ctx, u)
+		}(i, u)
+	}
+	wg.Wait()
 
-		_, err := s.telemetryClient.GetTelemetryData(checkCtx, &telemetrypb.GetTelemetryDataRequest{
-			AssetId: "test",
-		})
-		if err != nil && status.Code(err) != codes.InvalidArgument {
-			details["telemetry"] = "unhealthy"
-			healthStatus = pb.HealthStatus_DEGRADED
-			message = "Some services are unavailable"
+	details := make(map[string]string)
+	var unavailable []string
+	for i, u := range targets {
+		if results[i] != nil {
+			log.Printf("Health check for %s failed: %v", u.name, results[i])
+			details[u.name] = "unhealthy"
+			unavailable = append(unavailable, u.name)
 		} else {
-			details["telemetry"] = "healthy"
+			details[u.name] = "healthy"
 		}
 	}
 
+	healthStatus := pb.HealthStatus_HEALTHY
+	message := "Service is healthy"
+	switch {
+	case len(unavailable) == len(targets):
+		healthStatus = pb.HealthStatus_UNHEALTHY
+		message = "Unavailable: " + strings.Join(unavailable, ", ")
+	case len(unavailable) > 0:
+		healthStatus = pb.HealthStatus_DEGRADED
+		message = "Some services are unavailable: " + strings.Join(unavailable, ", ")
+	}
+
 	return &pb.HealthCheckResponse{
 		ServiceName: serviceName,
 		Status:      healthStatus,
@@ -82,65 +129,103 @@ func (s *server) HealthCheck(ctx context.Context, req *pb.HealthCheckRequest) (*
 	}, nil
 }
 
-func (s *server) GetMetrics(req *pb.GetMetricsRequest, stream pb.MonitoringService_GetMetricsServer) error {
-	interval := req.IntervalSeconds
-	if interval == 0 {
-		interval = 5
-	}
-
-	ticker := time.NewTicker(time.Duration(interval) * time.Second)
-	defer ticker.Stop()
-
-	for i := 0; i < 3; i++ {
-		// Get asset count
-		resp, err := s.assetClient.ListAssets(context.Background(), &assetpb.ListAssetsRequest{})
-		if err == nil {
-			metric := &pb.MetricsResponse{
-				MetricName: "asset_count",
-				Value:      float64(len(resp.Assets)),
-				Timestamp:  timestamppb.Now(),
-				Labels:     map[string]string{"service": "asset-registry"},
-			}
-			if err := stream.Send(metric); err != nil {
-				return err
-			}
-		}
+// checkUpstream returns nil when the upstream reports its service as SERVING.
+func checkUpstream(ctx context.Context, u upstream) error {
+	checkCtx, cancel := context.WithTimeout(ctx, 2*time.Second)
+	defer cancel()
 
-		<-ticker.C
+	resp, err := u.health.Check(checkCtx, &healthpb.HealthCheckRequest{Service: u.service})
+	if err != nil {
+		return err
+	}
+	if resp.Status != healthpb.HealthCheckResponse_SERVING {
+		return fmt.Errorf("%s reports %s", u.service, resp.Status)
 	}
-
 	return nil
 }
 
 func main() {
-	// Connect to Asset Registry
-	assetConn, err := grpc.Dial("asset-registry:50051", grpc.WithTransportCredentials(insecure.NewCredentials()))
+	cfg := defaultConfig()
+	config.Load("monitoring", &cfg)
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+	drain := shutdown.New(cfg.ShutdownGracePeriod)
+
+	tracer, err := tracing.New("monitoring", cfg.Tracing)
+	if err != nil {
+		log.Fatalf("Failed to set up tracing: %v", err)
+	}
+
+	tlsCerts, err := certs.New(cfg.TLS)
+	if err != nil {
+		log.Fatalf("Failed to load TLS certificates: %v", err)
+	}
+	go tlsCerts.Run(ctx)
+
+	authenticator, err := auth.New(cfg.Auth, auth.CommonPolicy.With(policy))
+	if err != nil {
+		log.Fatalf("Failed to set up authentication: %v", err)
+	}
+
+	dialOpts := append(interceptors.DialOptions(interceptors.Options{Tracer: tracer, Token: cfg.Auth.Token}), grpc.WithTransportCredentials(tlsCerts.Credentials()))
+
+	// Connect to the services whose health is aggregated. Their availability
+	// is what HealthCheck reports, so it does not affect this service's own
+	// readiness.
+	assetConn, err := cluster.Dial(cfg.AssetRegistryAddr, dialOpts...)
 	if err != nil {
 		log.Fatalf("Failed to connect to asset registry: %v", err)
 	}
 	defer assetConn.Close()
 
-	// Connect to Telemetry Service
-	telemetryConn, err := grpc.Dial("telemetry:50052", grpc.WithTransportCredentials(insecure.NewCredentials()))
+	telemetryConn, err := grpc.Dial(cfg.TelemetryAddr, dialOpts...)
 	if err != nil {
 		log.Fatalf("Failed to connect to telemetry service: %v", err)
 	}
 	defer telemetryConn.Close()
 
-	assetClient := assetpb.NewAssetRegistryClient(assetConn)
-	telemetryClient := telemetrypb.NewTelemetryServiceClient(telemetryConn)
+	monitoringConn, err := grpc.Dial(cfg.AssetMonitoringAddr, dialOpts...)
+	if err != nil {
+		log.Fatalf("Failed to connect to asset monitoring service: %v", err)
+	}
+	defer monitoringConn.Close()
 
-	lis, err := net.Listen("tcp", ":50053")
+	alertingConn, err := grpc.Dial(cfg.AlertingAddr, dialOpts...)
+	if err != nil {
+		log.Fatalf("Failed to connect to alerting service: %v", err)
+	}
+	defer alertingConn.Close()
+
+	s := newServer(
+		newUpstream("asset-registry", assetpb.AssetRegistry_ServiceDesc.ServiceName, assetConn),
+		newUpstream("telemetry", telemetrypb.TelemetryService_ServiceDesc.ServiceName, telemetryConn),
+		newUpstream("asset-monitoring", monitoringpb.AssetMonitoringService_ServiceDesc.ServiceName, monitoringConn),
+		newUpstream("alerting", alertingpb.AlertingService_ServiceDesc.ServiceName, alertingConn),
+	)
+
+	checker := health.NewChecker(pb.MonitoringService_ServiceDesc.ServiceName)
+	go checker.Run(ctx, health.DefaultInterval)
+
+	lis, err := net.Listen("tcp", cfg.ListenAddr)
 	if err != nil {
 		log.Fatalf("Failed to listen: %v", err)
 	}
 
-	grpcServer := grpc.NewServer()
-	pb.RegisterMonitoringServiceServer(grpcServer, newServer(assetClient, telemetryClient))
+	grpcServer := grpc.NewServer(append(interceptors.ServerOptions(interceptors.Options{Registry: s.registry, Tracer: tracer, MaxTimeout: cfg.MaxRPCTimeout, Shutdown: drain, Auth: authenticator, TLS: tlsCerts}), grpc.Creds(tlsCerts.Credentials()))...)
+	pb.RegisterMonitoringServiceServer(grpcServer, s)
+	pb.RegisterMetricsExporterServer(grpcServer, metrics.NewExporter(s.registry))
+	checker.Register(grpcServer)
 	reflection.Register(grpcServer)
 
-	log.Println("Monitoring Service listening on :50053")
-	if err := grpcServer.Serve(lis); err != nil {
+	metricsServer := metrics.Serve(cfg.MetricsAddr, s.registry)
+
+	drain.OnShutdown(checker.Shutdown)
+	drain.AddCleanup("metrics endpoint", metricsServer.Shutdown)
+	drain.AddCleanup("tracing", tracer.Shutdown)
+
+	log.Printf("Monitoring Service listening on %s", cfg.ListenAddr)
+	if err := drain.Serve(ctx, grpcServer, lis); err != nil {
 		log.Fatalf("Failed to serve: %v", err)
 	}
 }
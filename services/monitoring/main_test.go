@@ -4,58 +4,47 @@ import (
 	"context"
 	"testing"
 
-	assetpb "github.com/sairamkiran9/asset-telemetry-monitor/gen/go/proto/asset"
 	pb "github.com/sairamkiran9/asset-telemetry-monitor/gen/go/proto/monitoring"
-	telemetrypb "github.com/sairamkiran9/asset-telemetry-monitor/gen/go/proto/telemetry"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
 )
 
-// Mock clients for testing
-type mockAssetClient struct {
+// Mock health client reporting a fixed serving status
+type mockHealthClient struct {
 	healthy bool
+	service string // Last service checked
 }
 
-func (m *mockAssetClient) RegisterAsset(ctx context.Context, req *assetpb.RegisterAssetRequest, opts ...grpc.CallOption) (*assetpb.RegisterAssetResponse, error) {
-	return nil, nil
-}
-
-func (m *mockAssetClient) GetAsset(ctx context.Context, req *assetpb.GetAssetRequest, opts ...grpc.CallOption) (*assetpb.GetAssetResponse, error) {
-	return nil, nil
-}
-
-func (m *mockAssetClient) ListAssets(ctx context.Context, req *assetpb.ListAssetsRequest, opts ...grpc.CallOption) (*assetpb.ListAssetsResponse, error) {
+func (m *mockHealthClient) Check(ctx context.Context, req *healthpb.HealthCheckRequest, opts ...grpc.CallOption) (*healthpb.HealthCheckResponse, error) {
+	m.service = req.Service
 	if !m.healthy {
-		return nil, context.DeadlineExceeded
+		return &healthpb.HealthCheckResponse{Status: healthpb.HealthCheckResponse_NOT_SERVING}, nil
 	}
-	return &assetpb.ListAssetsResponse{
-		Assets: []*assetpb.Asset{
-			{Id: "asset-1", Name: "Test Asset"},
-		},
-	}, nil
+	return &healthpb.HealthCheckResponse{Status: healthpb.HealthCheckResponse_SERVING}, nil
 }
 
-type mockTelemetryClient struct {
-	healthy bool
+func (m *mockHealthClient) List(ctx context.Context, req *healthpb.HealthListRequest, opts ...grpc.CallOption) (*healthpb.HealthListResponse, error) {
+	return nil, nil
 }
 
-func (m *mockTelemetryClient) SubmitTelemetry(ctx context.Context, req *telemetrypb.SubmitTelemetryRequest, opts ...grpc.CallOption) (*telemetrypb.SubmitTelemetryResponse, error) {
+func (m *mockHealthClient) Watch(ctx context.Context, req *healthpb.HealthCheckRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[healthpb.HealthCheckResponse], error) {
 	return nil, nil
 }
 
-func (m *mockTelemetryClient) GetTelemetryData(ctx context.Context, req *telemetrypb.GetTelemetryDataRequest, opts ...grpc.CallOption) (*telemetrypb.GetTelemetryDataResponse, error) {
-	if !m.healthy {
-		return nil, context.DeadlineExceeded
-	}
-	return &telemetrypb.GetTelemetryDataResponse{
-		Data: []*telemetrypb.TelemetryData{},
-	}, nil
+func newTestServer(assetHealth, telemetryHealth *mockHealthClient) *server {
+	return newServer(
+		upstream{name: "asset-registry", service: "asset.AssetRegistry", health: assetHealth, metrics: &mockExporterClient{}},
+		upstream{name: "telemetry", service: "telemetry.TelemetryService", health: telemetryHealth, metrics: &mockExporterClient{}},
+	)
 }
 
 func TestHealthCheckHealthy(t *testing.T) {
-	mockAsset := &mockAssetClient{healthy: true}
-	mockTelemetry := &mockTelemetryClient{healthy: true}
+	assetHealth := &mockHealthClient{healthy: true}
+	telemetryHealth := &mockHealthClient{healthy: true}
 
-	s := newServer(mockAsset, mockTelemetry)
+	s := newTestServer(assetHealth, telemetryHealth)
 
 	req := &pb.HealthCheckRequest{
 		ServiceName: "all",
@@ -77,13 +66,17 @@ func TestHealthCheckHealthy(t *testing.T) {
 	if resp.Details["telemetry"] != "healthy" {
 		t.Errorf("Expected telemetry to be healthy")
 	}
+
+	if assetHealth.service != "asset.AssetRegistry" {
+		t.Errorf("Expected readiness check for asset.AssetRegistry, got %q", assetHealth.service)
+	}
 }
 
 func TestHealthCheckDegraded(t *testing.T) {
-	mockAsset := &mockAssetClient{healthy: false}
-	mockTelemetry := &mockTelemetryClient{healthy: true}
+	assetHealth := &mockHealthClient{healthy: false}
+	telemetryHealth := &mockHealthClient{healthy: true}
 
-	s := newServer(mockAsset, mockTelemetry)
+	s := newTestServer(assetHealth, telemetryHealth)
 
 	req := &pb.HealthCheckRequest{
 		ServiceName: "all",
@@ -104,10 +97,10 @@ func TestHealthCheckDegraded(t *testing.T) {
 }
 
 func TestHealthCheckSpecificService(t *testing.T) {
-	mockAsset := &mockAssetClient{healthy: true}
-	mockTelemetry := &mockTelemetryClient{healthy: true}
+	assetHealth := &mockHealthClient{healthy: true}
+	telemetryHealth := &mockHealthClient{healthy: true}
 
-	s := newServer(mockAsset, mockTelemetry)
+	s := newTestServer(assetHealth, telemetryHealth)
 
 	req := &pb.HealthCheckRequest{
 		ServiceName: "asset-registry",
@@ -127,3 +120,25 @@ func TestHealthCheckSpecificService(t *testing.T) {
 		t.Error("Should not check telemetry service")
 	}
 }
+
+func TestHealthCheckUnhealthy(t *testing.T) {
+	s := newTestServer(&mockHealthClient{healthy: false}, &mockHealthClient{healthy: false})
+
+	resp, err := s.HealthCheck(context.Background(), &pb.HealthCheckRequest{})
+	if err != nil {
+		t.Fatalf("HealthCheck failed: %v", err)
+	}
+
+	if resp.Status != pb.HealthStatus_UNHEALTHY {
+		t.Errorf("Expected UNHEALTHY status, got %v", resp.Status)
+	}
+}
+
+func TestHealthCheckUnknownService(t *testing.T) {
+	s := newTestServer(&mockHealthClient{healthy: true}, &mockHealthClient{healthy: true})
+
+	_, err := s.HealthCheck(context.Background(), &pb.HealthCheckRequest{ServiceName: "billing"})
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("Expected NotFound error, got %v", err)
+	}
+}
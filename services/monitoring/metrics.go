@@ -0,0 +1,219 @@
+package main
+
+import (
+	"context"
+	"log"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	pb "github.com/sairamkiran9/asset-telemetry-monitor/gen/go/proto/monitoring"
+	"github.com/sairamkiran9/asset-telemetry-monitor/internal/metrics"
+)
+
+const defaultMetricsInterval = 5
+
+// GetMetrics streams the metrics of every service, including this one, each
+// interval until the client cancels. Every metric carries a "service" label,
+// and an "up" metric reports whether each service could be collected.
+// Counters (names ending in _total) are accompanied by a _per_second rate
+// computed between consecutive samples of the stream.
+func (s *server) GetMetrics(req *pb.GetMetricsRequest, stream pb.MonitoringService_GetMetricsServer) error {
+	if req.IntervalSeconds < 0 {
+		return status.Error(codes.InvalidArgument, "interval_seconds must not be negative")
+	}
+	for _, pattern := range req.MetricNames {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return status.Errorf(codes.InvalidArgument, "invalid metric name pattern %q", pattern)
+		}
+	}
+
+	interval := req.IntervalSeconds
+	if interval == 0 {
+		interval = defaultMetricsInterval
+	}
+
+	ticker := time.NewTicker(time.Duration(interval) * time.Second)
+	defer ticker.Stop()
+
+	ctx := stream.Context()
+	rates := newRateTracker()
+
+	for {
+		samples := s.collectMetrics(ctx)
+		samples = append(samples, rates.update(samples, time.Now())...)
+
+		for _, sample := range samples {
+			if !matchesAny(req.MetricNames, sample.MetricName) {
+				continue
+			}
+			if err := stream.Send(sample); err != nil {
+				return err
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// collectMetrics gathers this service's registry and exports every upstream's
+// concurrently.
+func (s *server) collectMetrics(ctx context.Context) []*pb.MetricsResponse {
+	results := make([][]*pb.MetricsResponse, len(s.upstreams))
+	var wg sync.WaitGroup
+	for i, u := range s.upstreams {
+		wg.Add(1)
+		go func(i int, u upstream) {
+			defer wg.Done()
+			results[i] = exportUpstream(ctx, u)
+		}(i, u)
+	}
+
+	now := timestamppb.Now()
+	samples := []*pb.MetricsResponse{upSample("monitoring", true, now)}
+	for _, p := range metrics.Flatten(s.registry.Gather()) {
+		samples = append(samples, &pb.MetricsResponse{
+			MetricName: p.Name,
+			Value:      p.Value,
+			Timestamp:  now,
+			Labels:     withService(p.Labels, "monitoring"),
+		})
+	}
+
+	wg.Wait()
+	for _, result := range results {
+		samples = append(samples, result...)
+	}
+	return samples
+}
+
+// exportUpstream fetches an upstream's metrics and labels them with its name.
+func exportUpstream(ctx context.Context, u upstream) []*pb.MetricsResponse {
+	exportCtx, cancel := context.WithTimeout(ctx, 2*time.Second)
+	defer cancel()
+
+	resp, err := u.metrics.ExportMetrics(exportCtx, &pb.ExportMetricsRequest{})
+	if err != nil {
+		if ctx.Err() == nil {
+			log.Printf("Failed to collect metrics from %s: %v", u.name, err)
+		}
+		return []*pb.MetricsResponse{upSample(u.name, false, timestamppb.Now())}
+	}
+
+	samples := []*pb.MetricsResponse{upSample(u.name, true, timestamppb.Now())}
+	for _, sample := range resp.Metrics {
+		sample.Labels = withService(sample.Labels, u.name)
+		samples = append(samples, sample)
+	}
+	return samples
+}
+
+func upSample(service string, up bool, at *timestamppb.Timestamp) *pb.MetricsResponse {
+	value := 0.0
+	if up {
+		value = 1
+	}
+	return &pb.MetricsResponse{
+		MetricName: "up",
+		Value:      value,
+		Timestamp:  at,
+		Labels:     map[string]string{"service": service},
+	}
+}
+
+func withService(labels map[string]string, service string) map[string]string {
+	out := make(map[string]string, len(labels)+1)
+	for k, v := range labels {
+		out[k] = v
+	}
+	out["service"] = service
+	return out
+}
+
+// matchesAny reports whether name matches one of the glob patterns. No
+// patterns match everything.
+func matchesAny(patterns []string, name string) bool {
+	if len(patterns) == 0 {
+		return true
+	}
+	for _, pattern := range patterns {
+		if matched, _ := path.Match(pattern, name); matched {
+			return true
+		}
+	}
+	return false
+}
+
+type counterSample struct {
+	value float64
+	at    time.Time
+}
+
+// rateTracker derives per-second rates from successive counter samples.
+type rateTracker struct {
+	previous map[string]counterSample
+}
+
+func newRateTracker() *rateTracker {
+	return &rateTracker{previous: make(map[string]counterSample)}
+}
+
+// update records counter samples and returns a rate for each counter seen in
+// the previous update. A counter that went backwards was reset, so its rate is
+// computed from zero.
+func (r *rateTracker) update(samples []*pb.MetricsResponse, now time.Time) []*pb.MetricsResponse {
+	var rates []*pb.MetricsResponse
+	current := make(map[string]counterSample)
+
+	for _, sample := range samples {
+		if !strings.HasSuffix(sample.MetricName, "_total") {
+			continue
+		}
+		key := seriesKey(sample.MetricName, sample.Labels)
+		current[key] = counterSample{value: sample.Value, at: now}
+
+		prev, ok := r.previous[key]
+		elapsed := now.Sub(prev.at).Seconds()
+		if !ok || elapsed <= 0 {
+			continue
+		}
+		delta := sample.Value - prev.value
+		if delta < 0 {
+			delta = sample.Value
+		}
+		rates = append(rates, &pb.MetricsResponse{
+			MetricName: strings.TrimSuffix(sample.MetricName, "_total") + "_per_second",
+			Value:      delta / elapsed,
+			Timestamp:  sample.Timestamp,
+			Labels:     sample.Labels,
+		})
+	}
+
+	r.previous = current
+	return rates
+}
+
+func seriesKey(name string, labels map[string]string) string {
+	keys := make([]string, 0, len(labels))
+	for k := range labels {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var b strings.Builder
+	b.WriteString(name)
+	for _, k := range keys {
+		b.WriteString("\xff" + k + "=" + labels[k])
+	}
+	return b.String()
+}
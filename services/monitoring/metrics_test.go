@@ -0,0 +1,180 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	pb "github.com/sairamkiran9/asset-telemetry-monitor/gen/go/proto/monitoring"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Mock metrics exporter returning a counter that grows on every export
+type mockExporterClient struct {
+	mu       sync.Mutex
+	requests float64
+	down     bool
+}
+
+func (m *mockExporterClient) ExportMetrics(ctx context.Context, req *pb.ExportMetricsRequest, opts ...grpc.CallOption) (*pb.ExportMetricsResponse, error) {
+	if m.down {
+		return nil, errors.New("connection refused")
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.requests += 10
+	return &pb.ExportMetricsResponse{
+		Metrics: []*pb.MetricsResponse{
+			{MetricName: "grpc_server_handled_total", Value: m.requests, Labels: map[string]string{"grpc_method": "ListAssets"}},
+			{MetricName: "go_memstats_alloc_bytes", Value: 1 << 20, Labels: map[string]string{}},
+		},
+	}, nil
+}
+
+// Mock GetMetrics stream that cancels itself after a number of sends
+type mockMetricsStream struct {
+	grpc.ServerStream
+	ctx    context.Context
+	cancel context.CancelFunc
+	limit  int
+
+	mu      sync.Mutex
+	samples []*pb.MetricsResponse
+}
+
+func newMockMetricsStream(limit int) *mockMetricsStream {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	return &mockMetricsStream{ctx: ctx, cancel: cancel, limit: limit}
+}
+
+func (m *mockMetricsStream) Context() context.Context {
+	return m.ctx
+}
+
+func (m *mockMetricsStream) Send(sample *pb.MetricsResponse) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.samples = append(m.samples, sample)
+	if len(m.samples) >= m.limit {
+		m.cancel()
+	}
+	return nil
+}
+
+func (m *mockMetricsStream) names() map[string]int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	names := make(map[string]int)
+	for _, sample := range m.samples {
+		names[sample.MetricName]++
+	}
+	return names
+}
+
+func TestGetMetricsStreamsUntilCancelled(t *testing.T) {
+	s := newServer(upstream{name: "asset-registry", metrics: &mockExporterClient{}})
+
+	// Three rounds of one counter and one rate (after the first round)
+	stream := newMockMetricsStream(5)
+	defer stream.cancel()
+
+	err := s.GetMetrics(&pb.GetMetricsRequest{
+		MetricNames:     []string{"grpc_server_handled_*"},
+		IntervalSeconds: 1,
+	}, stream)
+	if err != nil {
+		t.Fatalf("GetMetrics failed: %v", err)
+	}
+
+	names := stream.names()
+	if names["grpc_server_handled_total"] != 3 {
+		t.Errorf("Expected 3 counter samples across rounds, got %v", names)
+	}
+	if names["grpc_server_handled_per_second"] != 2 {
+		t.Errorf("Expected 2 rate samples, got %v", names)
+	}
+	if names["go_memstats_alloc_bytes"] != 0 {
+		t.Errorf("Expected filtered metrics to be excluded, got %v", names)
+	}
+
+	for _, sample := range stream.samples {
+		if sample.Labels["service"] != "asset-registry" {
+			t.Errorf("Expected service label asset-registry, got %v", sample.Labels)
+		}
+		if sample.MetricName == "grpc_server_handled_per_second" && (sample.Value < 5 || sample.Value > 15) {
+			t.Errorf("Expected a rate of about 10/s, got %f", sample.Value)
+		}
+	}
+}
+
+func TestCollectMetricsReportsUp(t *testing.T) {
+	s := newServer(
+		upstream{name: "asset-registry", metrics: &mockExporterClient{}},
+		upstream{name: "telemetry", metrics: &mockExporterClient{down: true}},
+	)
+
+	up := make(map[string]float64)
+	sawRuntime := false
+	for _, sample := range s.collectMetrics(context.Background()) {
+		if sample.MetricName == "up" {
+			up[sample.Labels["service"]] = sample.Value
+		}
+		if sample.MetricName == "go_goroutines" && sample.Labels["service"] == "monitoring" {
+			sawRuntime = true
+		}
+	}
+
+	if up["asset-registry"] != 1 || up["telemetry"] != 0 || up["monitoring"] != 1 {
+		t.Errorf("Unexpected up values %v", up)
+	}
+	if !sawRuntime {
+		t.Error("Expected the monitoring service's own runtime metrics")
+	}
+}
+
+func TestGetMetricsValidation(t *testing.T) {
+	s := newServer()
+
+	tests := []struct {
+		name string
+		req  *pb.GetMetricsRequest
+	}{
+		{"Negative interval", &pb.GetMetricsRequest{IntervalSeconds: -1}},
+		{"Bad pattern", &pb.GetMetricsRequest{MetricNames: []string{"grpc_["}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stream := newMockMetricsStream(1)
+			defer stream.cancel()
+			err := s.GetMetrics(tt.req, stream)
+			if status.Code(err) != codes.InvalidArgument {
+				t.Errorf("Expected InvalidArgument error, got %v", err)
+			}
+		})
+	}
+}
+
+func TestMatchesAny(t *testing.T) {
+	tests := []struct {
+		patterns []string
+		name     string
+		expected bool
+	}{
+		{nil, "anything", true},
+		{[]string{"up"}, "up", true},
+		{[]string{"grpc_*"}, "grpc_server_handled_total", true},
+		{[]string{"go_memstats_*_bytes"}, "go_memstats_sys_bytes", true},
+		{[]string{"grpc_*", "up"}, "go_goroutines", false},
+	}
+
+	for _, tt := range tests {
+		if got := matchesAny(tt.patterns, tt.name); got != tt.expected {
+			t.Errorf("matchesAny(%v, %q) = %v, want %v", tt.patterns, tt.name, got, tt.expected)
+		}
+	}
+}
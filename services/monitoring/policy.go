@@ -0,0 +1,13 @@
+package main
+
+import (
+	pb "github.com/sairamkiran9/asset-telemetry-monitor/gen/go/proto/monitoring"
+	"github.com/sairamkiran9/asset-telemetry-monitor/internal/auth"
+)
+
+// policy lists the roles allowed to call each RPC of the monitoring service.
+// None names an asset, so credentials scoped to assets can't call them.
+var policy = auth.Policy{
+	pb.MonitoringService_HealthCheck_FullMethodName: {Roles: []auth.Role{auth.RoleViewer}},
+	pb.MonitoringService_GetMetrics_FullMethodName:  {Roles: []auth.Role{auth.RoleViewer}},
+}
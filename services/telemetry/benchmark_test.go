@@ -3,6 +3,7 @@ package main
 import (
 	"context"
 	"fmt"
+	"sync/atomic"
 	"testing"
 
 	assetpb "github.com/sairamkiran9/asset-telemetry-monitor/gen/go/proto/asset"
@@ -129,3 +130,75 @@ func BenchmarkSubmitTelemetryAllocs(b *testing.B) {
 		_, _ = s.SubmitTelemetry(ctx, req)
 	}
 }
+
+// The benchmarks below measure how the store scales with cores; compare
+// ns/op across go test -bench Parallel -cpu 1,2,4,8. With each goroutine on
+// its own asset, ns/op should fall close to 1/GOMAXPROCS.
+
+func parallelAssets(n int) *mockAssetClient {
+	assets := make(map[string]*assetpb.Asset, n)
+	for i := 0; i < n; i++ {
+		assetID := fmt.Sprintf("asset-%d", i)
+		assets[assetID] = &assetpb.Asset{Id: assetID, Name: "Test Asset"}
+	}
+	return &mockAssetClient{assets: assets}
+}
+
+func BenchmarkSubmitTelemetryParallelAssets(b *testing.B) {
+	s := newServer(parallelAssets(1024))
+	ctx := context.Background()
+	var goroutines atomic.Int64
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	b.RunParallel(func(p *testing.PB) {
+		assetID := fmt.Sprintf("asset-%d", goroutines.Add(1)%1024)
+		for p.Next() {
+			_, _ = s.SubmitTelemetry(ctx, &pb.SubmitTelemetryRequest{
+				AssetId:    assetID,
+				MetricName: "power",
+				Value:      42,
+				Unit:       "kW",
+			})
+		}
+	})
+}
+
+func BenchmarkStoreAppendParallel(b *testing.B) {
+	st := newTelemetryStore()
+	var goroutines atomic.Int64
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	b.RunParallel(func(p *testing.PB) {
+		assetID := fmt.Sprintf("asset-%d", goroutines.Add(1))
+		for p.Next() {
+			st.append(&pb.TelemetryData{Id: st.nextID(), AssetId: assetID, MetricName: "temperature", Value: 21})
+		}
+	})
+}
+
+func BenchmarkSubmitTelemetryParallelWithReaders(b *testing.B) {
+	s := newServer(parallelAssets(1024))
+	ctx := context.Background()
+
+	// A hot asset that half the goroutines keep reading
+	for i := 0; i < 100; i++ {
+		s.SubmitTelemetry(ctx, &pb.SubmitTelemetryRequest{AssetId: "asset-0", MetricName: "temperature", Value: float64(i)})
+	}
+	var goroutines atomic.Int64
+
+	b.ResetTimer()
+	b.RunParallel(func(p *testing.PB) {
+		n := goroutines.Add(1)
+		assetID := fmt.Sprintf("asset-%d", 1+n%1023)
+		reader := n%2 == 0
+		for p.Next() {
+			if reader {
+				_, _ = s.GetTelemetryData(ctx, &pb.GetTelemetryDataRequest{AssetId: "asset-0"})
+				continue
+			}
+			_, _ = s.SubmitTelemetry(ctx, &pb.SubmitTelemetryRequest{AssetId: assetID, MetricName: "temperature", Value: 21})
+		}
+	})
+}
@@ -0,0 +1,52 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/sairamkiran9/asset-telemetry-monitor/internal/config"
+)
+
+// Config holds the telemetry service's settings.
+type Config struct {
+	config.Common
+	AssetRegistryAddr string  `config:"asset_registry_addr" env:"ASSET_REGISTRY_ADDR" usage:"asset registry address, or comma-separated addresses of its replicas"`
+	ClientIngestRate  float64 `config:"client_ingest_rate" env:"CLIENT_INGEST_RATE" usage:"telemetry points per second each client may submit; 0 is unlimited"`
+	ClientIngestBurst int     `config:"client_ingest_burst" env:"CLIENT_INGEST_BURST" usage:"points a client may submit at once above client_ingest_rate"`
+	AssetIngestRate   float64 `config:"asset_ingest_rate" env:"ASSET_INGEST_RATE" usage:"telemetry points per second accepted for each asset; 0 is unlimited"`
+	AssetIngestBurst  int     `config:"asset_ingest_burst" env:"ASSET_INGEST_BURST" usage:"points accepted at once for an asset above asset_ingest_rate"`
+	TenantIngestRate  float64 `config:"tenant_ingest_rate" env:"TENANT_INGEST_RATE" usage:"telemetry points per second each tenant may submit; 0 is unlimited"`
+	TenantIngestBurst int     `config:"tenant_ingest_burst" env:"TENANT_INGEST_BURST" usage:"points a tenant may submit at once above tenant_ingest_rate"`
+}
+
+func defaultConfig() Config {
+	return Config{
+		Common:            config.DefaultCommon(":50052"),
+		AssetRegistryAddr: "asset-registry:50051",
+		ClientIngestBurst: 50,
+		AssetIngestBurst:  10,
+		TenantIngestBurst: 100,
+	}
+}
+
+func (c *Config) Validate() error {
+	return errors.Join(
+		c.Common.Validate(),
+		config.CheckAddrs("asset_registry_addr", c.AssetRegistryAddr),
+		checkRate("client_ingest", c.ClientIngestRate, c.ClientIngestBurst),
+		checkRate("asset_ingest", c.AssetIngestRate, c.AssetIngestBurst),
+		checkRate("tenant_ingest", c.TenantIngestRate, c.TenantIngestBurst),
+	)
+}
+
+// checkRate validates the <prefix>_rate and <prefix>_burst settings of a
+// rate limit.
+func checkRate(prefix string, rate float64, burst int) error {
+	if rate < 0 {
+		return fmt.Errorf("%s_rate must not be negative, got %g", prefix, rate)
+	}
+	if rate > 0 && burst < 1 {
+		return fmt.Errorf("%s_burst must be at least 1, got %d", prefix, burst)
+	}
+	return nil
+}
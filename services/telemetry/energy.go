@@ -0,0 +1,98 @@
+package main
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	pb "github.com/sairamkiran9/asset-telemetry-monitor/gen/go/proto/telemetry"
+	"github.com/sairamkiran9/asset-telemetry-monitor/internal/auth"
+	"github.com/sairamkiran9/asset-telemetry-monitor/internal/energy"
+)
+
+// Metric names that feed the energy accumulator of an electric asset.
+const (
+	metricPower          = "power"           // Instantaneous power, kW (or W)
+	metricEnergyRegister = "energy_register" // Cumulative meter register, kWh (or Wh)
+
+	// Tag carrying the value at which a physical register wraps to zero.
+	tagRegisterCapacity = "register_capacity"
+)
+
+// recordEnergy feeds power and register metrics into the asset's accumulator.
+// Callers must hold the asset's shard lock.
+func (a *assetSeries) recordEnergy(data *pb.TelemetryData) {
+	if data.MetricName != metricPower && data.MetricName != metricEnergyRegister {
+		return
+	}
+
+	if a.energy == nil {
+		a.energy = energy.NewAccumulator(energy.DefaultMaxGap, time.UTC)
+	}
+	acc := a.energy
+
+	at := data.Timestamp.AsTime()
+	value := data.Value
+	if strings.EqualFold(data.Unit, "w") || strings.EqualFold(data.Unit, "wh") {
+		value /= 1000
+	}
+
+	switch data.MetricName {
+	case metricPower:
+		acc.AddPower(at, value)
+	case metricEnergyRegister:
+		capacity, _ := strconv.ParseFloat(data.Tags[tagRegisterCapacity], 64)
+		if strings.EqualFold(data.Unit, "wh") {
+			capacity /= 1000
+		}
+		acc.AddRegister(at, value, capacity)
+	}
+}
+
+func (s *server) GetEnergyIntervals(ctx context.Context, req *pb.GetEnergyIntervalsRequest) (*pb.GetEnergyIntervalsResponse, error) {
+	if req.AssetId == "" {
+		return nil, status.Error(codes.InvalidArgument, "asset_id is required")
+	}
+
+	acc, tenant := s.store.energy(req.AssetId)
+	if acc == nil || !auth.CanAccessTenant(ctx, tenant) {
+		return &pb.GetEnergyIntervalsResponse{}, nil
+	}
+
+	var start, end time.Time
+	if req.StartTime != nil {
+		start = req.StartTime.AsTime()
+	}
+	if req.EndTime != nil {
+		end = req.EndTime.AsTime()
+	}
+
+	resp := &pb.GetEnergyIntervalsResponse{
+		TotalEnergyKwh: acc.Total(),
+	}
+	for _, iv := range acc.Intervals(start, end) {
+		resp.Intervals = append(resp.Intervals, &pb.EnergyInterval{
+			StartTime: timestamppb.New(iv.Start),
+			EndTime:   timestamppb.New(iv.End()),
+			EnergyKwh: iv.Energy,
+			DemandKw:  iv.Demand(),
+			Complete:  iv.Complete(),
+		})
+	}
+	for _, period := range acc.BillingPeriods(start, end) {
+		resp.BillingPeriods = append(resp.BillingPeriods, &pb.BillingPeriodDemand{
+			StartTime:         timestamppb.New(period.Start),
+			EndTime:           timestamppb.New(period.End),
+			EnergyKwh:         period.Energy,
+			PeakDemandKw:      period.PeakDemand,
+			PeakIntervalStart: timestamppb.New(period.PeakInterval),
+		})
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,86 @@
+package main
+
+import (
+	"context"
+	"math"
+	"testing"
+	"time"
+
+	assetpb "github.com/sairamkiran9/asset-telemetry-monitor/gen/go/proto/asset"
+	pb "github.com/sairamkiran9/asset-telemetry-monitor/gen/go/proto/telemetry"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestGetEnergyIntervalsFromPower(t *testing.T) {
+	s := newServer(&mockAssetClient{assets: map[string]*assetpb.Asset{}})
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	for i := 0; i <= 15; i++ {
+		s.store.append(&pb.TelemetryData{
+			AssetId:    "asset-1",
+			MetricName: "power",
+			Value:      120000,
+			Unit:       "W",
+			Timestamp:  timestamppb.New(start.Add(time.Duration(i) * time.Minute)),
+		})
+	}
+
+	resp, err := s.GetEnergyIntervals(context.Background(), &pb.GetEnergyIntervalsRequest{AssetId: "asset-1"})
+	if err != nil {
+		t.Fatalf("GetEnergyIntervals failed: %v", err)
+	}
+
+	if len(resp.Intervals) != 1 {
+		t.Fatalf("Expected 1 interval, got %d", len(resp.Intervals))
+	}
+
+	interval := resp.Intervals[0]
+	if math.Abs(interval.EnergyKwh-30) > 1e-9 {
+		t.Errorf("Expected 30 kWh, got %f", interval.EnergyKwh)
+	}
+	if math.Abs(interval.DemandKw-120) > 1e-9 {
+		t.Errorf("Expected demand=120 kW, got %f", interval.DemandKw)
+	}
+	if !interval.Complete {
+		t.Error("Expected interval to be complete")
+	}
+
+	if len(resp.BillingPeriods) != 1 || math.Abs(resp.BillingPeriods[0].PeakDemandKw-120) > 1e-9 {
+		t.Errorf("Expected one billing period with peak demand 120 kW, got %v", resp.BillingPeriods)
+	}
+}
+
+func TestGetEnergyIntervalsFromRegisterRollover(t *testing.T) {
+	s := newServer(&mockAssetClient{assets: map[string]*assetpb.Asset{}})
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	readings := []float64{9990, 9998, 6}
+	for i, reading := range readings {
+		s.store.append(&pb.TelemetryData{
+			AssetId:    "asset-1",
+			MetricName: "energy_register",
+			Value:      reading,
+			Unit:       "kWh",
+			Timestamp:  timestamppb.New(start.Add(time.Duration(i) * 15 * time.Minute)),
+			Tags:       map[string]string{"register_capacity": "10000"},
+		})
+	}
+
+	resp, err := s.GetEnergyIntervals(context.Background(), &pb.GetEnergyIntervalsRequest{AssetId: "asset-1"})
+	if err != nil {
+		t.Fatalf("GetEnergyIntervals failed: %v", err)
+	}
+
+	if math.Abs(resp.TotalEnergyKwh-16) > 1e-9 {
+		t.Errorf("Expected 16 kWh across the rollover, got %f", resp.TotalEnergyKwh)
+	}
+}
+
+func TestGetEnergyIntervalsMissingAssetID(t *testing.T) {
+	s := newServer(&mockAssetClient{assets: map[string]*assetpb.Asset{}})
+
+	_, err := s.GetEnergyIntervals(context.Background(), &pb.GetEnergyIntervalsRequest{})
+	if err == nil {
+		t.Fatal("Expected error for missing asset_id")
+	}
+}
@@ -2,36 +2,76 @@ package main
 
 import (
 	"context"
-	"fmt"
 	"log"
 	"net"
-	"sync"
+	"os/signal"
+	"syscall"
 	"time"
 
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/credentials/insecure"
 	"google.golang.org/grpc/reflection"
 	"google.golang.org/grpc/status"
-	"google.golang.org/protobuf/types/known/timestamppb"
 
 	assetpb "github.com/sairamkiran9/asset-telemetry-monitor/gen/go/proto/asset"
+	monitoringpb "github.com/sairamkiran9/asset-telemetry-monitor/gen/go/proto/monitoring"
 	pb "github.com/sairamkiran9/asset-telemetry-monitor/gen/go/proto/telemetry"
+	"github.com/sairamkiran9/asset-telemetry-monitor/internal/assetcache"
+	"github.com/sairamkiran9/asset-telemetry-monitor/internal/auth"
+	"github.com/sairamkiran9/asset-telemetry-monitor/internal/certs"
+	"github.com/sairamkiran9/asset-telemetry-monitor/internal/cluster"
+	"github.com/sairamkiran9/asset-telemetry-monitor/internal/config"
+	"github.com/sairamkiran9/asset-telemetry-monitor/internal/health"
+	"github.com/sairamkiran9/asset-telemetry-monitor/internal/interceptors"
+	"github.com/sairamkiran9/asset-telemetry-monitor/internal/metrics"
+	"github.com/sairamkiran9/asset-telemetry-monitor/internal/ratelimit"
+	"github.com/sairamkiran9/asset-telemetry-monitor/internal/shutdown"
+	"github.com/sairamkiran9/asset-telemetry-monitor/internal/tracing"
 )
 
+// maxClockSkew is how far ahead of the service's clock submitted timestamps
+// may be.
+const maxClockSkew = 5 * time.Minute
+
 type server struct {
 	pb.UnimplementedTelemetryServiceServer
-	mu            sync.RWMutex
-	telemetryData map[string][]*pb.TelemetryData
-	assetClient   assetpb.AssetRegistryClient
-	idCounter     int
+	store       *telemetryStore
+	assetClient assetpb.AssetRegistryClient
+	assets      *assetcache.Cache // Saves a registry call per submission; nil always asks
+
+	// Ingestion rate limits per client, asset and tenant; nil is unlimited
+	clientIngest *ratelimit.Limiter
+	assetIngest  *ratelimit.Limiter
+	tenantIngest *ratelimit.Limiter
+
+	registry        *metrics.Registry
+	throttled       *metrics.CounterVec
+	quotaRejections *metrics.CounterVec
 }
 
 func newServer(assetClient assetpb.AssetRegistryClient) *server {
-	return &server{
-		telemetryData: make(map[string][]*pb.TelemetryData),
-		assetClient:   assetClient,
+	registry := metrics.NewRegistry()
+	metrics.RegisterRuntime(registry)
+
+	s := &server{
+		store:           newTelemetryStore(),
+		assetClient:     assetClient,
+		registry:        registry,
+		throttled:       registry.Counter("telemetry_throttled_requests_total", "Submissions rejected by an ingestion rate limit.", "limit"),
+		quotaRejections: registry.Counter("telemetry_quota_rejections_total", "Submissions rejected because the tenant exceeded its ingestion rate.", "tenant"),
 	}
+
+	// Counted per shard rather than in shared gauges, which every writer
+	// would contend on
+	registry.GaugeFunc("telemetry_points_stored", "Telemetry data points held in memory.", func() float64 {
+		points, _ := s.store.counts()
+		return float64(points)
+	})
+	registry.GaugeFunc("telemetry_series", "Distinct asset and metric name pairs with stored data.", func() float64 {
+		_, series := s.store.counts()
+		return float64(series)
+	})
+	return s
 }
 
 func (s *server) SubmitTelemetry(ctx context.Context, req *pb.SubmitTelemetryRequest) (*pb.SubmitTelemetryResponse, error) {
@@ -41,36 +81,60 @@ func (s *server) SubmitTelemetry(ctx context.Context, req *pb.SubmitTelemetryReq
 	if req.MetricName == "" {
 		return nil, status.Error(codes.InvalidArgument, "metric_name is required")
 	}
+	if req.Timestamp != nil {
+		if err := req.Timestamp.CheckValid(); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid timestamp: %v", err)
+		}
+		// A point from the future would hold back the asset's energy
+		// accumulator until the clock caught up
+		if req.Timestamp.AsTime().After(time.Now().Add(maxClockSkew)) {
+			return nil, status.Errorf(codes.InvalidArgument, "timestamp is more than %v in the future", maxClockSkew)
+		}
+	}
 
-	// Validate asset exists
-	assetResp, err := s.assetClient.GetAsset(ctx, &assetpb.GetAssetRequest{
-		Id: req.AssetId,
-	})
+	// Throttle floods before they cost a registry lookup
+	if err := s.throttle(ctx, s.clientIngest, "client", ratelimit.ClientID(ctx)); err != nil {
+		return nil, err
+	}
+
+	// Validate asset exists. Points belong to the asset's tenant.
+	asset, found, err := s.lookupAsset(ctx, req.AssetId)
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to validate asset: %v", err)
 	}
-	if !assetResp.Found {
+	if !found || !auth.CanAccessTenant(ctx, asset.GetTenant()) {
 		return nil, status.Errorf(codes.NotFound, "asset %s not found", req.AssetId)
 	}
+	if isRollup(asset) {
+		return nil, status.Errorf(codes.FailedPrecondition, "asset %s is a roll-up computed from its children", req.AssetId)
+	}
+	// Only submissions the asset's tenant could make draw on its bucket
+	if err := s.throttle(ctx, s.assetIngest, "asset", req.AssetId); err != nil {
+		return nil, err
+	}
+	tenant := asset.GetTenant()
+	if tenant == "" {
+		tenant = auth.DefaultTenant
+	}
 
-	s.mu.Lock()
-	defer s.mu.Unlock()
-
-	s.idCounter++
-	telemetryID := fmt.Sprintf("telemetry-%d", s.idCounter)
+	if err := s.throttle(ctx, s.tenantIngest, "tenant", tenant); err != nil {
+		s.quotaRejections.WithLabelValues(tenant).Inc()
+		return nil, err
+	}
 
+	// The access log records each submission; logging every point here too
+	// would serialize writers on the logger
 	data := &pb.TelemetryData{
-		Id:         telemetryID,
+		Id:         s.store.nextID(),
 		AssetId:    req.AssetId,
 		MetricName: req.MetricName,
 		Value:      req.Value,
 		Unit:       req.Unit,
-		Timestamp:  timestamppb.New(time.Now()),
+		Timestamp:  req.Timestamp,
 		Tags:       req.Tags,
+		Tenant:     tenant,
 	}
-
-	s.telemetryData[req.AssetId] = append(s.telemetryData[req.AssetId], data)
-	log.Printf("Submitted telemetry for asset %s: %s = %.2f %s", req.AssetId, req.MetricName, req.Value, req.Unit)
+	s.store.append(data)
 
 	return &pb.SubmitTelemetryResponse{
 		Data:    data,
@@ -79,41 +143,127 @@ func (s *server) SubmitTelemetry(ctx context.Context, req *pb.SubmitTelemetryReq
 	}, nil
 }
 
+// lookupAsset returns the asset with id from the cache, or else from the
+// registry, which only finds the caller's own assets. Assets registered
+// moments ago may not have reached the cache yet.
+func (s *server) lookupAsset(ctx context.Context, id string) (*assetpb.Asset, bool, error) {
+	if asset, found := s.assets.Get(id); found {
+		return asset, true, nil
+	}
+	resp, err := s.assetClient.GetAsset(ctx, &assetpb.GetAssetRequest{Id: id})
+	if err != nil {
+		return nil, false, err
+	}
+	return resp.Asset, resp.Found, nil
+}
+
+// throttle takes a token from limiter's bucket for key, rejecting the call with
+// a retry-after hint when it's empty.
+func (s *server) throttle(ctx context.Context, limiter *ratelimit.Limiter, limit, key string) error {
+	ok, retryAfter := limiter.Allow(key)
+	if ok {
+		return nil
+	}
+	s.throttled.WithLabelValues(limit).Inc()
+	return ratelimit.Exhausted(ctx, retryAfter, "%s %s exceeded its ingestion rate, retry in %v", limit, key, retryAfter.Round(time.Millisecond))
+}
+
 func (s *server) GetTelemetryData(ctx context.Context, req *pb.GetTelemetryDataRequest) (*pb.GetTelemetryDataResponse, error) {
 	if req.AssetId == "" {
 		return nil, status.Error(codes.InvalidArgument, "asset_id is required")
 	}
 
-	s.mu.RLock()
-	defer s.mu.RUnlock()
-
-	data := s.telemetryData[req.AssetId]
+	// Other tenants' assets read as having no data
+	data, tenant := s.store.points(req.AssetId)
+	if data == nil {
+		// Roll-ups store nothing; they're computed from their children's
+		// points when read, so late points count as soon as they're stored
+		asset, found, err := s.lookupAsset(ctx, req.AssetId)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to look up asset: %v", err)
+		}
+		if !found || !isRollup(asset) || !auth.CanAccessTenant(ctx, asset.Tenant) {
+			return &pb.GetTelemetryDataResponse{}, nil
+		}
+		data, err = s.series(ctx, asset, make(map[string]bool))
+		if err != nil {
+			return nil, err
+		}
+		return &pb.GetTelemetryDataResponse{Data: data}, nil
+	}
+	if !auth.CanAccessTenant(ctx, tenant) {
+		return &pb.GetTelemetryDataResponse{}, nil
+	}
 	return &pb.GetTelemetryDataResponse{
 		Data: data,
 	}, nil
 }
 
 func main() {
+	cfg := defaultConfig()
+	config.Load("telemetry", &cfg)
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+	drain := shutdown.New(cfg.ShutdownGracePeriod)
+
+	tracer, err := tracing.New("telemetry", cfg.Tracing)
+	if err != nil {
+		log.Fatalf("Failed to set up tracing: %v", err)
+	}
+
+	tlsCerts, err := certs.New(cfg.TLS)
+	if err != nil {
+		log.Fatalf("Failed to load TLS certificates: %v", err)
+	}
+	go tlsCerts.Run(ctx)
+
+	authenticator, err := auth.New(cfg.Auth, auth.CommonPolicy.With(policy))
+	if err != nil {
+		log.Fatalf("Failed to set up authentication: %v", err)
+	}
+
+	dialOpts := append(interceptors.DialOptions(interceptors.Options{Tracer: tracer, Token: cfg.Auth.Token}), grpc.WithTransportCredentials(tlsCerts.Credentials()))
+
 	// Connect to Asset Registry
-	assetConn, err := grpc.Dial("asset-registry:50051", grpc.WithTransportCredentials(insecure.NewCredentials()))
+	assetConn, err := cluster.Dial(cfg.AssetRegistryAddr, dialOpts...)
 	if err != nil {
 		log.Fatalf("Failed to connect to asset registry: %v", err)
 	}
 	defer assetConn.Close()
 
 	assetClient := assetpb.NewAssetRegistryClient(assetConn)
+	s := newServer(assetClient)
+	s.assets = assetcache.New(assetClient)
+	go s.assets.Run(ctx)
+	s.clientIngest = ratelimit.New(cfg.ClientIngestRate, cfg.ClientIngestBurst)
+	s.assetIngest = ratelimit.New(cfg.AssetIngestRate, cfg.AssetIngestBurst)
+	s.tenantIngest = ratelimit.New(cfg.TenantIngestRate, cfg.TenantIngestBurst)
+
+	checker := health.NewChecker(pb.TelemetryService_ServiceDesc.ServiceName)
+	checker.AddLivenessCheck("telemetry-store", s.store.liveness)
+	checker.AddReadinessCheck("asset-registry", health.ConnReady(assetConn))
+	go checker.Run(ctx, health.DefaultInterval)
 
-	lis, err := net.Listen("tcp", ":50052")
+	lis, err := net.Listen("tcp", cfg.ListenAddr)
 	if err != nil {
 		log.Fatalf("Failed to listen: %v", err)
 	}
 
-	grpcServer := grpc.NewServer()
-	pb.RegisterTelemetryServiceServer(grpcServer, newServer(assetClient))
+	grpcServer := grpc.NewServer(append(interceptors.ServerOptions(interceptors.Options{Registry: s.registry, Tracer: tracer, MaxTimeout: cfg.MaxRPCTimeout, Shutdown: drain, Auth: authenticator, TLS: tlsCerts}), grpc.Creds(tlsCerts.Credentials()))...)
+	pb.RegisterTelemetryServiceServer(grpcServer, s)
+	monitoringpb.RegisterMetricsExporterServer(grpcServer, metrics.NewExporter(s.registry))
+	checker.Register(grpcServer)
 	reflection.Register(grpcServer)
 
-	log.Println("Telemetry Service listening on :50052")
-	if err := grpcServer.Serve(lis); err != nil {
+	metricsServer := metrics.Serve(cfg.MetricsAddr, s.registry)
+
+	drain.OnShutdown(checker.Shutdown)
+	drain.AddCleanup("metrics endpoint", metricsServer.Shutdown)
+	drain.AddCleanup("tracing", tracer.Shutdown)
+
+	log.Printf("Telemetry Service listening on %s", cfg.ListenAddr)
+	if err := drain.Serve(ctx, grpcServer, lis); err != nil {
 		log.Fatalf("Failed to serve: %v", err)
 	}
 }
@@ -2,12 +2,19 @@ package main
 
 import (
 	"context"
+	"sort"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	assetpb "github.com/sairamkiran9/asset-telemetry-monitor/gen/go/proto/asset"
 	pb "github.com/sairamkiran9/asset-telemetry-monitor/gen/go/proto/telemetry"
+	"github.com/sairamkiran9/asset-telemetry-monitor/internal/assetcache"
+	"github.com/sairamkiran9/asset-telemetry-monitor/internal/auth"
+	"github.com/sairamkiran9/asset-telemetry-monitor/internal/ratelimit"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 )
 
@@ -32,6 +39,66 @@ func (m *mockAssetClient) ListAssets(ctx context.Context, req *assetpb.ListAsset
 	return nil, nil
 }
 
+func (m *mockAssetClient) UpdateAsset(ctx context.Context, req *assetpb.UpdateAssetRequest, opts ...grpc.CallOption) (*assetpb.UpdateAssetResponse, error) {
+	return nil, nil
+}
+
+func (m *mockAssetClient) DeleteAsset(ctx context.Context, req *assetpb.DeleteAssetRequest, opts ...grpc.CallOption) (*assetpb.DeleteAssetResponse, error) {
+	return nil, nil
+}
+
+func (m *mockAssetClient) WatchAssets(ctx context.Context, req *assetpb.WatchAssetsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[assetpb.AssetEvent], error) {
+	return nil, status.Error(codes.Unimplemented, "not implemented")
+}
+
+func (m *mockAssetClient) SetParent(ctx context.Context, req *assetpb.SetParentRequest, opts ...grpc.CallOption) (*assetpb.SetParentResponse, error) {
+	return nil, nil
+}
+
+func (m *mockAssetClient) ConnectAssets(ctx context.Context, req *assetpb.ConnectAssetsRequest, opts ...grpc.CallOption) (*assetpb.ConnectAssetsResponse, error) {
+	return nil, nil
+}
+
+func (m *mockAssetClient) DisconnectAssets(ctx context.Context, req *assetpb.DisconnectAssetsRequest, opts ...grpc.CallOption) (*assetpb.DisconnectAssetsResponse, error) {
+	return nil, nil
+}
+
+// GetSubtree only goes one level down, which is all roll-ups ask for
+func (m *mockAssetClient) GetSubtree(ctx context.Context, req *assetpb.GetSubtreeRequest, opts ...grpc.CallOption) (*assetpb.GetSubtreeResponse, error) {
+	root, found := m.assets[req.AssetId]
+	if !found {
+		return nil, status.Errorf(codes.NotFound, "asset %s not found", req.AssetId)
+	}
+	resp := &assetpb.GetSubtreeResponse{Assets: []*assetpb.Asset{root}}
+	for _, asset := range m.assets {
+		if asset.ParentId == req.AssetId {
+			resp.Assets = append(resp.Assets, asset)
+		}
+	}
+	sort.Slice(resp.Assets[1:], func(i, j int) bool { return resp.Assets[i+1].Id < resp.Assets[j+1].Id })
+	return resp, nil
+}
+
+func (m *mockAssetClient) GetAncestors(ctx context.Context, req *assetpb.GetAncestorsRequest, opts ...grpc.CallOption) (*assetpb.GetAncestorsResponse, error) {
+	return nil, nil
+}
+
+func (m *mockAssetClient) GetConnected(ctx context.Context, req *assetpb.GetConnectedRequest, opts ...grpc.CallOption) (*assetpb.GetConnectedResponse, error) {
+	return nil, nil
+}
+
+func (m *mockAssetClient) ListAssetTypes(ctx context.Context, req *assetpb.ListAssetTypesRequest, opts ...grpc.CallOption) (*assetpb.ListAssetTypesResponse, error) {
+	return nil, nil
+}
+
+func (m *mockAssetClient) ImportAssets(ctx context.Context, req *assetpb.ImportAssetsRequest, opts ...grpc.CallOption) (*assetpb.ImportAssetsResponse, error) {
+	return nil, nil
+}
+
+func (m *mockAssetClient) ExportAssets(ctx context.Context, req *assetpb.ExportAssetsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[assetpb.Asset], error) {
+	return nil, status.Error(codes.Unimplemented, "not implemented")
+}
+
 func TestSubmitTelemetry(t *testing.T) {
 	mockClient := &mockAssetClient{
 		assets: map[string]*assetpb.Asset{
@@ -64,6 +131,10 @@ func TestSubmitTelemetry(t *testing.T) {
 	if resp.Data.Value != 23.5 {
 		t.Errorf("Expected value=23.5, got %v", resp.Data.Value)
 	}
+
+	if points, series := s.store.counts(); points != 1 || series != 1 {
+		t.Errorf("Expected telemetry_points_stored=1 and telemetry_series=1, got %d and %d", points, series)
+	}
 }
 
 func TestSubmitTelemetryAssetNotFound(t *testing.T) {
@@ -95,6 +166,82 @@ func TestSubmitTelemetryAssetNotFound(t *testing.T) {
 	}
 }
 
+// watchedAssetClient lists the mock's assets to watchers and counts lookups.
+type watchedAssetClient struct {
+	*mockAssetClient
+	lookups atomic.Int32
+}
+
+func (m *watchedAssetClient) GetAsset(ctx context.Context, req *assetpb.GetAssetRequest, opts ...grpc.CallOption) (*assetpb.GetAssetResponse, error) {
+	m.lookups.Add(1)
+	return m.mockAssetClient.GetAsset(ctx, req, opts...)
+}
+
+func (m *watchedAssetClient) WatchAssets(ctx context.Context, req *assetpb.WatchAssetsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[assetpb.AssetEvent], error) {
+	events := make(chan *assetpb.AssetEvent, len(m.assets)+1)
+	for _, asset := range m.assets {
+		events <- &assetpb.AssetEvent{Type: assetpb.AssetEventType_CREATED, Asset: asset}
+	}
+	events <- &assetpb.AssetEvent{Type: assetpb.AssetEventType_SYNCED}
+	return &listStream{ctx: ctx, events: events}, nil
+}
+
+type listStream struct {
+	grpc.ClientStream
+	ctx    context.Context
+	events chan *assetpb.AssetEvent
+}
+
+func (s *listStream) Recv() (*assetpb.AssetEvent, error) {
+	select {
+	case event := <-s.events:
+		return event, nil
+	case <-s.ctx.Done():
+		return nil, s.ctx.Err()
+	}
+}
+
+func TestSubmitTelemetryUsesAssetCache(t *testing.T) {
+	client := &watchedAssetClient{mockAssetClient: &mockAssetClient{
+		assets: map[string]*assetpb.Asset{
+			"asset-1": {Id: "asset-1", Name: "Meter-1", Tenant: "north-campus"},
+		},
+	}}
+	s := newServer(client)
+	s.assets = assetcache.New(client)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go s.assets.Run(ctx)
+	for deadline := time.Now().Add(5 * time.Second); !s.assets.Synced(); time.Sleep(time.Millisecond) {
+		if time.Now().After(deadline) {
+			t.Fatal("Timed out waiting for the asset cache")
+		}
+	}
+	north := auth.ContextWithTenant(context.Background(), "north-campus")
+
+	if _, err := s.SubmitTelemetry(north, &pb.SubmitTelemetryRequest{AssetId: "asset-1", MetricName: "power", Value: 1}); err != nil {
+		t.Fatalf("SubmitTelemetry failed: %v", err)
+	}
+	if client.lookups.Load() != 0 {
+		t.Errorf("Expected cached assets to need no registry lookup, got %d", client.lookups.Load())
+	}
+
+	// Cached assets are still isolated by tenant
+	south := auth.ContextWithTenant(context.Background(), "south-campus")
+	if _, err := s.SubmitTelemetry(south, &pb.SubmitTelemetryRequest{AssetId: "asset-1", MetricName: "power", Value: 1}); status.Code(err) != codes.NotFound {
+		t.Errorf("Expected NotFound for another tenant's asset, got %v", err)
+	}
+
+	// Assets the cache hasn't seen yet are looked up
+	client.assets["asset-2"] = &assetpb.Asset{Id: "asset-2", Name: "Meter-2", Tenant: "north-campus"}
+	if _, err := s.SubmitTelemetry(north, &pb.SubmitTelemetryRequest{AssetId: "asset-2", MetricName: "power", Value: 1}); err != nil {
+		t.Errorf("Expected an asset missing from the cache to be looked up, got %v", err)
+	}
+	if client.lookups.Load() != 1 {
+		t.Errorf("Expected 1 registry lookup, got %d", client.lookups.Load())
+	}
+}
+
 func TestGetTelemetryData(t *testing.T) {
 	mockClient := &mockAssetClient{
 		assets: map[string]*assetpb.Asset{
@@ -131,3 +278,162 @@ func TestGetTelemetryData(t *testing.T) {
 		t.Errorf("Expected value=23.5, got %v", resp.Data[0].Value)
 	}
 }
+
+func TestTelemetryTenantIsolation(t *testing.T) {
+	mockClient := &mockAssetClient{
+		assets: map[string]*assetpb.Asset{
+			"asset-1": {Id: "asset-1", Name: "Chiller", Tenant: "north-campus"},
+		},
+	}
+	s := newServer(mockClient)
+	north := auth.ContextWithTenant(context.Background(), "north-campus")
+	south := auth.ContextWithTenant(context.Background(), "south-campus")
+
+	submitReq := &pb.SubmitTelemetryRequest{AssetId: "asset-1", MetricName: "power", Value: 100, Unit: "kw"}
+	if _, err := s.SubmitTelemetry(south, submitReq); status.Code(err) != codes.NotFound {
+		t.Errorf("Expected NotFound for another tenant's asset, got %v", err)
+	}
+
+	// Service credentials submit on behalf of the asset's tenant
+	resp, err := s.SubmitTelemetry(auth.ContextWithTenant(context.Background(), auth.AnyTenant), submitReq)
+	if err != nil {
+		t.Fatalf("SubmitTelemetry failed: %v", err)
+	}
+	if resp.Data.Tenant != "north-campus" {
+		t.Errorf("Expected tenant north-campus, got %q", resp.Data.Tenant)
+	}
+
+	if dataResp, _ := s.GetTelemetryData(south, &pb.GetTelemetryDataRequest{AssetId: "asset-1"}); len(dataResp.Data) != 0 {
+		t.Errorf("Expected no data for another tenant, got %d records", len(dataResp.Data))
+	}
+	if dataResp, _ := s.GetTelemetryData(north, &pb.GetTelemetryDataRequest{AssetId: "asset-1"}); len(dataResp.Data) != 1 {
+		t.Errorf("Expected 1 record for the owning tenant, got %d", len(dataResp.Data))
+	}
+	if energyResp, _ := s.GetEnergyIntervals(south, &pb.GetEnergyIntervalsRequest{AssetId: "asset-1"}); energyResp.TotalEnergyKwh != 0 || len(energyResp.Intervals) != 0 {
+		t.Errorf("Expected no energy data for another tenant, got %v", energyResp)
+	}
+}
+
+func TestIngestionQuota(t *testing.T) {
+	mockClient := &mockAssetClient{
+		assets: map[string]*assetpb.Asset{
+			"asset-1": {Id: "asset-1", Tenant: "north-campus"},
+			"asset-2": {Id: "asset-2", Tenant: "south-campus"},
+		},
+	}
+	s := newServer(mockClient)
+	s.tenantIngest = ratelimit.New(1, 2)
+	north := auth.ContextWithTenant(context.Background(), "north-campus")
+
+	submitReq := &pb.SubmitTelemetryRequest{AssetId: "asset-1", MetricName: "temperature", Value: 7}
+	for i := 0; i < 2; i++ {
+		if _, err := s.SubmitTelemetry(north, submitReq); err != nil {
+			t.Fatalf("SubmitTelemetry %d failed: %v", i+1, err)
+		}
+	}
+	if _, err := s.SubmitTelemetry(north, submitReq); status.Code(err) != codes.ResourceExhausted {
+		t.Errorf("Expected ResourceExhausted over the ingestion rate, got %v", err)
+	}
+
+	south := auth.ContextWithTenant(context.Background(), "south-campus")
+	if _, err := s.SubmitTelemetry(south, &pb.SubmitTelemetryRequest{AssetId: "asset-2", MetricName: "temperature"}); err != nil {
+		t.Errorf("Expected other tenants to have their own quota, got %v", err)
+	}
+	if got := s.quotaRejections.WithLabelValues("north-campus").Value(); got != 1 {
+		t.Errorf("Expected 1 quota rejection for north-campus, got %v", got)
+	}
+}
+
+// trailerStream records the trailer set by a handler.
+type trailerStream struct {
+	grpc.ServerTransportStream
+	trailer metadata.MD
+}
+
+func (s *trailerStream) SetTrailer(md metadata.MD) error {
+	s.trailer = metadata.Join(s.trailer, md)
+	return nil
+}
+
+func TestSubmitTelemetryRateLimits(t *testing.T) {
+	mockClient := &mockAssetClient{
+		assets: map[string]*assetpb.Asset{
+			"asset-1": {Id: "asset-1"},
+			"asset-2": {Id: "asset-2"},
+		},
+	}
+	s := newServer(mockClient)
+	s.clientIngest = ratelimit.New(1, 3)
+	s.assetIngest = ratelimit.New(1, 2)
+
+	gateway := auth.ContextWithPrincipal(context.Background(), &auth.Principal{Subject: "gateway-7"})
+	other := auth.ContextWithPrincipal(context.Background(), &auth.Principal{Subject: "gateway-8"})
+	submit := func(ctx context.Context, assetID string) (*trailerStream, error) {
+		stream := &trailerStream{}
+		_, err := s.SubmitTelemetry(grpc.NewContextWithServerTransportStream(ctx, stream), &pb.SubmitTelemetryRequest{
+			AssetId: assetID, MetricName: "power", Value: 1,
+		})
+		return stream, err
+	}
+
+	for i := 0; i < 2; i++ {
+		if _, err := submit(gateway, "asset-1"); err != nil {
+			t.Fatalf("Submission %d failed: %v", i+1, err)
+		}
+	}
+
+	// The asset's bucket is empty, but the gateway may still use other assets
+	stream, err := submit(other, "asset-1")
+	if status.Code(err) != codes.ResourceExhausted {
+		t.Fatalf("Expected ResourceExhausted for a flooded asset, got %v", err)
+	}
+	if got := stream.trailer.Get("retry-after"); len(got) != 1 || got[0] != "1" {
+		t.Errorf("Expected retry-after trailer of 1 second, got %v", got)
+	}
+	if _, err := submit(gateway, "asset-2"); err != nil {
+		t.Fatalf("Expected other assets to be accepted, got %v", err)
+	}
+
+	// The gateway has used its burst of 3
+	if _, err := submit(gateway, "asset-2"); status.Code(err) != codes.ResourceExhausted {
+		t.Errorf("Expected ResourceExhausted for a flooding client, got %v", err)
+	}
+	if _, err := submit(other, "asset-2"); err != nil {
+		t.Errorf("Expected other clients to be unaffected, got %v", err)
+	}
+
+	if got := s.throttled.WithLabelValues("asset").Value(); got != 1 {
+		t.Errorf("Expected 1 asset throttle, got %v", got)
+	}
+	if got := s.throttled.WithLabelValues("client").Value(); got != 1 {
+		t.Errorf("Expected 1 client throttle, got %v", got)
+	}
+}
+
+func TestSubmitTelemetryRateLimitsTenants(t *testing.T) {
+	mockClient := &mockAssetClient{
+		assets: map[string]*assetpb.Asset{
+			"asset-1": {Id: "asset-1", Tenant: "north-campus"},
+		},
+	}
+	s := newServer(mockClient)
+	s.assetIngest = ratelimit.New(1, 2)
+	north := auth.ContextWithTenant(context.Background(), "north-campus")
+	south := auth.ContextWithTenant(context.Background(), "south-campus")
+	submitReq := &pb.SubmitTelemetryRequest{AssetId: "asset-1", MetricName: "power", Value: 1}
+
+	// Another tenant's submissions are rejected without touching the bucket
+	for i := 0; i < 5; i++ {
+		if _, err := s.SubmitTelemetry(south, submitReq); status.Code(err) != codes.NotFound {
+			t.Fatalf("Expected NotFound for another tenant's asset, got %v", err)
+		}
+	}
+	for i := 0; i < 2; i++ {
+		if _, err := s.SubmitTelemetry(north, submitReq); err != nil {
+			t.Fatalf("Expected the owning tenant's submission %d to be accepted, got %v", i+1, err)
+		}
+	}
+	if got := s.throttled.WithLabelValues("asset").Value(); got != 0 {
+		t.Errorf("Expected no asset throttles, got %v", got)
+	}
+}
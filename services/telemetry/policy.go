@@ -0,0 +1,14 @@
+package main
+
+import (
+	pb "github.com/sairamkiran9/asset-telemetry-monitor/gen/go/proto/telemetry"
+	"github.com/sairamkiran9/asset-telemetry-monitor/internal/auth"
+)
+
+// policy lists the roles allowed to call each RPC of the telemetry service.
+// RPCs naming an asset are open to credentials scoped to that asset.
+var policy = auth.Policy{
+	pb.TelemetryService_SubmitTelemetry_FullMethodName:    {Roles: []auth.Role{auth.RoleOperator, auth.RoleIngestDevice}, AssetField: "asset_id"},
+	pb.TelemetryService_GetTelemetryData_FullMethodName:   {Roles: []auth.Role{auth.RoleViewer}, AssetField: "asset_id"},
+	pb.TelemetryService_GetEnergyIntervals_FullMethodName: {Roles: []auth.Role{auth.RoleViewer}, AssetField: "asset_id"},
+}
@@ -0,0 +1,231 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	assetpb "github.com/sairamkiran9/asset-telemetry-monitor/gen/go/proto/asset"
+	pb "github.com/sairamkiran9/asset-telemetry-monitor/gen/go/proto/telemetry"
+)
+
+// Asset metadata defining a roll-up: a virtual asset whose telemetry is
+// computed from its children's.
+const (
+	metaRollup         = "rollup"          // How children combine: sum, weighted_average or difference
+	metaRollupInterval = "rollup_interval" // Width of the buckets points are averaged over
+	metaRollupWeight   = "rollup_weight"   // A child's weight in a weighted_average
+	metaRollupFrom     = "rollup_from"     // The meter a difference subtracts the children from
+
+	defaultRollupInterval = time.Minute
+)
+
+// Roll-up methods.
+const (
+	rollupSum             = "sum"
+	rollupWeightedAverage = "weighted_average"
+	rollupDifference      = "difference"
+)
+
+// Tags on computed points.
+const (
+	tagRollup       = "rollup"
+	tagRollupInputs = "rollup_inputs" // Assets with data in the point's bucket
+)
+
+type rollup struct {
+	method   string
+	interval time.Duration
+	from     string
+}
+
+// isRollup reports whether the asset's telemetry is computed rather than
+// submitted.
+func isRollup(asset *assetpb.Asset) bool {
+	return asset.GetMetadata()[metaRollup] != ""
+}
+
+func parseRollup(asset *assetpb.Asset) (*rollup, error) {
+	r := &rollup{
+		method:   asset.Metadata[metaRollup],
+		interval: defaultRollupInterval,
+		from:     asset.Metadata[metaRollupFrom],
+	}
+	switch r.method {
+	case rollupSum, rollupWeightedAverage:
+	case rollupDifference:
+		if r.from == "" {
+			return nil, fmt.Errorf("%s roll-up needs %s", rollupDifference, metaRollupFrom)
+		}
+	default:
+		return nil, fmt.Errorf("unknown %s %q; use %s, %s or %s", metaRollup, r.method, rollupSum, rollupWeightedAverage, rollupDifference)
+	}
+	if interval, set := asset.Metadata[metaRollupInterval]; set {
+		d, err := time.ParseDuration(interval)
+		if err != nil || d <= 0 {
+			return nil, fmt.Errorf("%s must be a positive duration, got %q", metaRollupInterval, interval)
+		}
+		r.interval = d
+	}
+	return r, nil
+}
+
+// bucket is one metric's points within one roll-up interval.
+type bucket struct {
+	metric string
+	start  int64 // Unix nanoseconds
+}
+
+// total accumulates the inputs to a bucket.
+type total struct {
+	value  float64
+	weight float64
+	inputs int
+}
+
+// series returns an asset's points, computing them if it's a roll-up.
+// visited holds the roll-ups being computed, which can't include themselves.
+func (s *server) series(ctx context.Context, asset *assetpb.Asset, visited map[string]bool) ([]*pb.TelemetryData, error) {
+	if !isRollup(asset) {
+		points, _ := s.store.points(asset.Id)
+		return points, nil
+	}
+	if visited[asset.Id] {
+		return nil, status.Errorf(codes.FailedPrecondition, "roll-up of asset %s includes itself", asset.Id)
+	}
+	visited[asset.Id] = true
+	defer delete(visited, asset.Id)
+
+	r, err := parseRollup(asset)
+	if err != nil {
+		return nil, status.Errorf(codes.FailedPrecondition, "asset %s has an invalid roll-up: %v", asset.Id, err)
+	}
+	children, err := s.children(ctx, asset.Id)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list children of asset %s: %v", asset.Id, err)
+	}
+
+	units := make(map[string]string)
+	totals := make(map[bucket]*total)
+	for _, child := range children {
+		if child.Id == r.from {
+			continue
+		}
+		weight := 1.0
+		if w, set := child.Metadata[metaRollupWeight]; set && r.method == rollupWeightedAverage {
+			if weight, err = strconv.ParseFloat(w, 64); err != nil || weight < 0 {
+				return nil, status.Errorf(codes.FailedPrecondition, "asset %s has an invalid %s %q", child.Id, metaRollupWeight, w)
+			}
+		}
+		points, err := s.series(ctx, child, visited)
+		if err != nil {
+			return nil, err
+		}
+		for b, mean := range bucketMeans(points, r.interval, units) {
+			t := totals[b]
+			if t == nil {
+				t = &total{}
+				totals[b] = t
+			}
+			t.value += weight * mean
+			t.weight += weight
+			t.inputs++
+		}
+	}
+
+	if r.method == rollupDifference {
+		// Only intervals the main meter reported have a difference
+		from, found, err := s.lookupAsset(ctx, r.from)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to look up asset %s: %v", r.from, err)
+		}
+		if !found || from.Tenant != asset.Tenant {
+			return nil, status.Errorf(codes.FailedPrecondition, "asset %s subtracts from missing asset %s", asset.Id, r.from)
+		}
+		points, err := s.series(ctx, from, visited)
+		if err != nil {
+			return nil, err
+		}
+		subtracted := totals
+		totals = make(map[bucket]*total)
+		for b, mean := range bucketMeans(points, r.interval, units) {
+			t := &total{value: mean, weight: 1, inputs: 1}
+			if sub := subtracted[b]; sub != nil {
+				t.value -= sub.value
+				t.inputs += sub.inputs
+			}
+			totals[b] = t
+		}
+	}
+
+	data := make([]*pb.TelemetryData, 0, len(totals))
+	for b, t := range totals {
+		value := t.value
+		if r.method == rollupWeightedAverage {
+			if t.weight == 0 {
+				continue
+			}
+			value /= t.weight
+		}
+		data = append(data, &pb.TelemetryData{
+			Id:         fmt.Sprintf("rollup-%s-%s-%d", asset.Id, b.metric, b.start),
+			AssetId:    asset.Id,
+			MetricName: b.metric,
+			Value:      value,
+			Unit:       units[b.metric],
+			Timestamp:  timestamppb.New(time.Unix(0, b.start)),
+			Tags:       map[string]string{tagRollup: r.method, tagRollupInputs: strconv.Itoa(t.inputs)},
+			Tenant:     asset.Tenant,
+		})
+	}
+	sort.Slice(data, func(i, j int) bool {
+		ti, tj := data[i].Timestamp.AsTime(), data[j].Timestamp.AsTime()
+		if !ti.Equal(tj) {
+			return ti.Before(tj)
+		}
+		return data[i].MetricName < data[j].MetricName
+	})
+	return data, nil
+}
+
+// bucketMeans averages an input's points per metric and interval, noting
+// the first unit seen for each metric.
+func bucketMeans(points []*pb.TelemetryData, interval time.Duration, units map[string]string) map[bucket]float64 {
+	sums := make(map[bucket]float64)
+	counts := make(map[bucket]int)
+	for _, p := range points {
+		b := bucket{metric: p.MetricName, start: p.Timestamp.AsTime().Truncate(interval).UnixNano()}
+		sums[b] += p.Value
+		counts[b]++
+		if _, seen := units[p.MetricName]; !seen {
+			units[p.MetricName] = p.Unit
+		}
+	}
+	for b, n := range counts {
+		sums[b] /= float64(n)
+	}
+	return sums
+}
+
+// children returns the assets directly under id, from the cache once it has
+// synced and otherwise from the registry.
+func (s *server) children(ctx context.Context, id string) ([]*assetpb.Asset, error) {
+	if s.assets.Synced() {
+		return s.assets.Children(id), nil
+	}
+	resp, err := s.assetClient.GetSubtree(ctx, &assetpb.GetSubtreeRequest{AssetId: id, MaxDepth: 1})
+	if err != nil {
+		return nil, err
+	}
+	if len(resp.GetAssets()) == 0 {
+		return nil, nil
+	}
+	// The subtree starts with the asset itself
+	return resp.Assets[1:], nil
+}
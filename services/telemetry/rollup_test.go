@@ -0,0 +1,179 @@
+package main
+
+import (
+	"context"
+	"math"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	assetpb "github.com/sairamkiran9/asset-telemetry-monitor/gen/go/proto/asset"
+	pb "github.com/sairamkiran9/asset-telemetry-monitor/gen/go/proto/telemetry"
+	"github.com/sairamkiran9/asset-telemetry-monitor/internal/auth"
+)
+
+var rollupStart = time.Date(2024, 1, 1, 8, 0, 0, 0, time.UTC)
+
+// submitAt submits a point measured minutes after rollupStart.
+func submitAt(t *testing.T, s *server, assetID, metric string, minutes float64, value float64) {
+	t.Helper()
+	_, err := s.SubmitTelemetry(context.Background(), &pb.SubmitTelemetryRequest{
+		AssetId:    assetID,
+		MetricName: metric,
+		Value:      value,
+		Unit:       "kW",
+		Timestamp:  timestamppb.New(rollupStart.Add(time.Duration(minutes * float64(time.Minute)))),
+	})
+	if err != nil {
+		t.Fatalf("SubmitTelemetry failed: %v", err)
+	}
+}
+
+// expectSeries checks a roll-up's values, one per minute from rollupStart.
+func expectSeries(t *testing.T, s *server, assetID string, want ...float64) []*pb.TelemetryData {
+	t.Helper()
+	resp, err := s.GetTelemetryData(context.Background(), &pb.GetTelemetryDataRequest{AssetId: assetID})
+	if err != nil {
+		t.Fatalf("GetTelemetryData failed: %v", err)
+	}
+	if len(resp.Data) != len(want) {
+		t.Fatalf("Expected %d points, got %v", len(want), resp.Data)
+	}
+	for i, data := range resp.Data {
+		at := rollupStart.Add(time.Duration(i) * time.Minute)
+		if math.Abs(data.Value-want[i]) > 1e-9 || !data.Timestamp.AsTime().Equal(at) {
+			t.Errorf("Expected %g at %v, got %g at %v", want[i], at, data.Value, data.Timestamp.AsTime())
+		}
+	}
+	return resp.Data
+}
+
+func TestRollupSum(t *testing.T) {
+	s := newServer(&mockAssetClient{assets: map[string]*assetpb.Asset{
+		"building": {Id: "building", Metadata: map[string]string{metaRollup: rollupSum}},
+		"meter-1":  {Id: "meter-1", ParentId: "building"},
+		"meter-2":  {Id: "meter-2", ParentId: "building"},
+	}})
+
+	// Points within a minute are averaged before they're summed
+	submitAt(t, s, "meter-1", "power", 0, 100)
+	submitAt(t, s, "meter-1", "power", 0.5, 120)
+	submitAt(t, s, "meter-2", "power", 0.2, 50)
+	submitAt(t, s, "meter-1", "power", 1, 90)
+
+	data := expectSeries(t, s, "building", 160, 90)
+	if data[0].Tags[tagRollupInputs] != "2" || data[1].Tags[tagRollupInputs] != "1" || data[0].Unit != "kW" {
+		t.Errorf("Expected the first minute from 2 meters and the second from 1, got %v", data)
+	}
+
+	// A late point is counted the next time the roll-up is read
+	submitAt(t, s, "meter-2", "power", 1.5, 40)
+	expectSeries(t, s, "building", 160, 130)
+
+	if _, err := s.SubmitTelemetry(context.Background(), &pb.SubmitTelemetryRequest{AssetId: "building", MetricName: "power"}); status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("Expected submitting to a roll-up to fail, got %v", err)
+	}
+}
+
+func TestRollupWeightedAverage(t *testing.T) {
+	s := newServer(&mockAssetClient{assets: map[string]*assetpb.Asset{
+		"floor":  {Id: "floor", Metadata: map[string]string{metaRollup: rollupWeightedAverage, metaRollupInterval: "5m"}},
+		"zone-1": {Id: "zone-1", ParentId: "floor", Metadata: map[string]string{metaRollupWeight: "300"}},
+		"zone-2": {Id: "zone-2", ParentId: "floor", Metadata: map[string]string{metaRollupWeight: "100"}},
+	}})
+	submitAt(t, s, "zone-1", "temperature", 1, 20)
+	submitAt(t, s, "zone-2", "temperature", 4, 24)
+
+	expectSeries(t, s, "floor", 21)
+}
+
+func TestRollupDifference(t *testing.T) {
+	// Common-area load is the main meter less the tenant submeters, and the
+	// site sums roll-ups like any other children
+	s := newServer(&mockAssetClient{assets: map[string]*assetpb.Asset{
+		"site":        {Id: "site", Metadata: map[string]string{metaRollup: rollupSum}},
+		"main":        {Id: "main", ParentId: "site"},
+		"common-area": {Id: "common-area", ParentId: "site", Metadata: map[string]string{metaRollup: rollupDifference, metaRollupFrom: "main"}},
+		"tenant-a":    {Id: "tenant-a", ParentId: "common-area"},
+		"tenant-b":    {Id: "tenant-b", ParentId: "common-area"},
+	}})
+	submitAt(t, s, "main", "power", 0, 500)
+	submitAt(t, s, "tenant-a", "power", 0, 200)
+	submitAt(t, s, "tenant-b", "power", 0, 100)
+	submitAt(t, s, "main", "power", 1, 450)
+	// Submeter readings without a main meter reading have no difference
+	submitAt(t, s, "tenant-a", "power", 2, 150)
+
+	expectSeries(t, s, "common-area", 200, 450)
+	expectSeries(t, s, "site", 700, 900)
+}
+
+func TestRollupErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		assets map[string]*assetpb.Asset
+	}{
+		{"unknown method", map[string]*assetpb.Asset{
+			"rollup": {Id: "rollup", Metadata: map[string]string{metaRollup: "median"}},
+		}},
+		{"bad interval", map[string]*assetpb.Asset{
+			"rollup": {Id: "rollup", Metadata: map[string]string{metaRollup: rollupSum, metaRollupInterval: "-1m"}},
+		}},
+		{"difference without a main meter", map[string]*assetpb.Asset{
+			"rollup": {Id: "rollup", Metadata: map[string]string{metaRollup: rollupDifference, metaRollupFrom: "missing"}},
+		}},
+		{"bad weight", map[string]*assetpb.Asset{
+			"rollup": {Id: "rollup", Metadata: map[string]string{metaRollup: rollupWeightedAverage}},
+			"zone":   {Id: "zone", ParentId: "rollup", Metadata: map[string]string{metaRollupWeight: "lots"}},
+		}},
+		{"subtracting from itself", map[string]*assetpb.Asset{
+			"rollup": {Id: "rollup", Metadata: map[string]string{metaRollup: rollupDifference, metaRollupFrom: "rollup"}},
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newServer(&mockAssetClient{assets: tt.assets})
+			_, err := s.GetTelemetryData(context.Background(), &pb.GetTelemetryDataRequest{AssetId: "rollup"})
+			if status.Code(err) != codes.FailedPrecondition {
+				t.Errorf("Expected FailedPrecondition, got %v", err)
+			}
+		})
+	}
+}
+
+func TestRollupTenantIsolation(t *testing.T) {
+	s := newServer(&mockAssetClient{assets: map[string]*assetpb.Asset{
+		"building": {Id: "building", Tenant: "north-campus", Metadata: map[string]string{metaRollup: rollupSum}},
+		"meter-1":  {Id: "meter-1", Tenant: "north-campus", ParentId: "building"},
+	}})
+	north := auth.ContextWithTenant(context.Background(), "north-campus")
+	if _, err := s.SubmitTelemetry(north, &pb.SubmitTelemetryRequest{AssetId: "meter-1", MetricName: "power", Value: 100}); err != nil {
+		t.Fatalf("SubmitTelemetry failed: %v", err)
+	}
+
+	resp, err := s.GetTelemetryData(north, &pb.GetTelemetryDataRequest{AssetId: "building"})
+	if err != nil || len(resp.Data) != 1 || resp.Data[0].Tenant != "north-campus" {
+		t.Errorf("Expected the tenant's own roll-up, got %v (%v)", resp.GetData(), err)
+	}
+	south := auth.ContextWithTenant(context.Background(), "south-campus")
+	resp, err = s.GetTelemetryData(south, &pb.GetTelemetryDataRequest{AssetId: "building"})
+	if err != nil || len(resp.Data) != 0 {
+		t.Errorf("Expected no data for another tenant's roll-up, got %v (%v)", resp.GetData(), err)
+	}
+}
+
+func TestSubmitTelemetryTimestamp(t *testing.T) {
+	s := newServer(&mockAssetClient{assets: map[string]*assetpb.Asset{"asset-1": {Id: "asset-1"}}})
+
+	_, err := s.SubmitTelemetry(context.Background(), &pb.SubmitTelemetryRequest{
+		AssetId:    "asset-1",
+		MetricName: "power",
+		Timestamp:  timestamppb.New(time.Now().Add(time.Hour)),
+	})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Expected a timestamp an hour ahead to be rejected, got %v", err)
+	}
+}
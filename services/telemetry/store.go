@@ -0,0 +1,154 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+	"sync"
+	"sync/atomic"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	pb "github.com/sairamkiran9/asset-telemetry-monitor/gen/go/proto/telemetry"
+	"github.com/sairamkiran9/asset-telemetry-monitor/internal/energy"
+	"github.com/sairamkiran9/asset-telemetry-monitor/internal/health"
+)
+
+// storeShards is the number of lock stripes in the telemetry store. It's a
+// power of two so an asset's shard is its hash masked.
+const storeShards = 64
+
+// telemetryStore holds telemetry per asset, hash-sharded by asset ID. Writers
+// only lock their asset's shard, so submissions for different assets rarely
+// contend and readers of one asset don't block writers of assets elsewhere.
+type telemetryStore struct {
+	shards [storeShards]storeShard
+	lastID atomic.Uint64
+}
+
+type storeShard struct {
+	mu     sync.RWMutex
+	assets map[string]*assetSeries
+	points int // Points held, for the points gauge
+	series int // Distinct asset/metric pairs, for the series gauge
+
+	// Keeps neighbouring shards' locks on separate 64-byte cache lines
+	_ [16]byte
+}
+
+// assetSeries is everything stored for one asset.
+type assetSeries struct {
+	tenant  string
+	points  []*pb.TelemetryData
+	metrics map[string]bool     // Metric names with points
+	energy  *energy.Accumulator // Created by the first power or register point
+}
+
+func newTelemetryStore() *telemetryStore {
+	st := &telemetryStore{}
+	for i := range st.shards {
+		st.shards[i].assets = make(map[string]*assetSeries)
+	}
+	return st
+}
+
+// shard picks the asset's shard by its FNV-1a hash.
+func (st *telemetryStore) shard(assetID string) *storeShard {
+	h := uint32(2166136261)
+	for i := 0; i < len(assetID); i++ {
+		h ^= uint32(assetID[i])
+		h *= 16777619
+	}
+	return &st.shards[h&(storeShards-1)]
+}
+
+// nextID returns a unique point ID without taking any lock.
+func (st *telemetryStore) nextID() string {
+	return "telemetry-" + strconv.FormatUint(st.lastID.Add(1), 10)
+}
+
+// append stores a point of tenant's asset and feeds its energy accumulator.
+// Points without a timestamp are stamped under the shard lock, so they land
+// after the asset's earlier points. Late points are inserted in time order;
+// the energy accumulator ignores them.
+func (st *telemetryStore) append(data *pb.TelemetryData) {
+	sh := st.shard(data.AssetId)
+	sh.mu.Lock()
+	defer sh.mu.Unlock()
+
+	a, exists := sh.assets[data.AssetId]
+	if !exists {
+		a = &assetSeries{tenant: data.Tenant, metrics: make(map[string]bool)}
+		sh.assets[data.AssetId] = a
+	}
+	if data.Timestamp == nil {
+		data.Timestamp = timestamppb.Now()
+	}
+
+	at := data.Timestamp.AsTime()
+	if n := len(a.points); n == 0 || !at.Before(a.points[n-1].Timestamp.AsTime()) {
+		a.points = append(a.points, data)
+	} else {
+		// Readers may hold the current slice, so late points go into a copy
+		i := sort.Search(n, func(i int) bool { return a.points[i].Timestamp.AsTime().After(at) })
+		a.points = slices.Concat(a.points[:i], []*pb.TelemetryData{data}, a.points[i:])
+	}
+	sh.points++
+	if !a.metrics[data.MetricName] {
+		a.metrics[data.MetricName] = true
+		sh.series++
+	}
+	a.recordEnergy(data)
+}
+
+// points returns the asset's points and tenant. Points are never modified
+// once stored, so the slice stays valid after the lock is released.
+func (st *telemetryStore) points(assetID string) ([]*pb.TelemetryData, string) {
+	sh := st.shard(assetID)
+	sh.mu.RLock()
+	defer sh.mu.RUnlock()
+
+	a, exists := sh.assets[assetID]
+	if !exists {
+		return nil, ""
+	}
+	return a.points[:len(a.points):len(a.points)], a.tenant
+}
+
+// energy returns the asset's energy accumulator, if it has one, and tenant.
+func (st *telemetryStore) energy(assetID string) (*energy.Accumulator, string) {
+	sh := st.shard(assetID)
+	sh.mu.RLock()
+	defer sh.mu.RUnlock()
+
+	a, exists := sh.assets[assetID]
+	if !exists {
+		return nil, ""
+	}
+	return a.energy, a.tenant
+}
+
+// counts sums the points and series held by every shard.
+func (st *telemetryStore) counts() (points, series int) {
+	for i := range st.shards {
+		sh := &st.shards[i]
+		sh.mu.RLock()
+		points += sh.points
+		series += sh.series
+		sh.mu.RUnlock()
+	}
+	return points, series
+}
+
+// liveness fails while any shard's lock can't be acquired, e.g. because a
+// writer is stuck holding it.
+func (st *telemetryStore) liveness(ctx context.Context) error {
+	for i := range st.shards {
+		if err := health.LockAcquirable(&st.shards[i].mu)(ctx); err != nil {
+			return fmt.Errorf("shard %d: %w", i, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,99 @@
+package main
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+	"unsafe"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	pb "github.com/sairamkiran9/asset-telemetry-monitor/gen/go/proto/telemetry"
+)
+
+func TestStoreConcurrentAppends(t *testing.T) {
+	st := newTelemetryStore()
+	const writers, perWriter = 8, 200
+
+	var wg sync.WaitGroup
+	for w := 0; w < writers; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for i := 0; i < perWriter; i++ {
+				st.append(&pb.TelemetryData{
+					Id:         st.nextID(),
+					AssetId:    fmt.Sprintf("asset-%d", w%4), // Two writers per asset
+					MetricName: "temperature",
+					Value:      float64(i),
+				})
+			}
+		}(w)
+	}
+	wg.Wait()
+
+	ids := make(map[string]bool)
+	for a := 0; a < 4; a++ {
+		points, _ := st.points(fmt.Sprintf("asset-%d", a))
+		if len(points) != 2*perWriter {
+			t.Errorf("Expected %d points for asset-%d, got %d", 2*perWriter, a, len(points))
+		}
+		for i, p := range points {
+			ids[p.Id] = true
+			if i > 0 && p.Timestamp.AsTime().Before(points[i-1].Timestamp.AsTime()) {
+				t.Errorf("Expected asset-%d points in time order", a)
+				break
+			}
+		}
+	}
+	if len(ids) != writers*perWriter {
+		t.Errorf("Expected %d unique IDs, got %d", writers*perWriter, len(ids))
+	}
+	if points, series := st.counts(); points != writers*perWriter || series != 4 {
+		t.Errorf("Expected %d points in 4 series, got %d in %d", writers*perWriter, points, series)
+	}
+}
+
+func TestStorePointsAreASnapshot(t *testing.T) {
+	st := newTelemetryStore()
+	st.append(&pb.TelemetryData{Id: st.nextID(), AssetId: "asset-1", MetricName: "power"})
+
+	points, _ := st.points("asset-1")
+	st.append(&pb.TelemetryData{Id: st.nextID(), AssetId: "asset-1", MetricName: "power"})
+
+	if len(points) != 1 {
+		t.Errorf("Expected earlier read to keep 1 point, got %d", len(points))
+	}
+	if points, _ := st.points("asset-2"); points != nil {
+		t.Errorf("Expected no points for an unknown asset, got %v", points)
+	}
+}
+
+func TestStoreLatePoints(t *testing.T) {
+	st := newTelemetryStore()
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	for _, minute := range []int{0, 2} {
+		st.append(&pb.TelemetryData{AssetId: "asset-1", MetricName: "power", Timestamp: timestamppb.New(start.Add(time.Duration(minute) * time.Minute))})
+	}
+	before, _ := st.points("asset-1")
+
+	st.append(&pb.TelemetryData{Id: "late", AssetId: "asset-1", MetricName: "power", Timestamp: timestamppb.New(start.Add(time.Minute))})
+
+	points, _ := st.points("asset-1")
+	if len(points) != 3 || points[1].Id != "late" {
+		t.Errorf("Expected the late point between the others, got %v", points)
+	}
+	if len(before) != 2 || before[1].Timestamp.AsTime() != start.Add(2*time.Minute) {
+		t.Errorf("Expected an earlier read to be unchanged, got %v", before)
+	}
+}
+
+func TestStoreShardSize(t *testing.T) {
+	if unsafe.Sizeof(uintptr(0)) != 8 {
+		t.Skip("Padding is sized for 64-bit platforms")
+	}
+	if size := unsafe.Sizeof(storeShard{}); size != 64 {
+		t.Errorf("Expected shards to fill one 64-byte cache line, got %d bytes", size)
+	}
+}